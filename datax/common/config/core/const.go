@@ -31,6 +31,9 @@ var (
 	DataxCoreTransportChannelFlowcontrolinterval      = "core.transport.channel.flowControlInterval"
 	DataxCoreTransportExchangerBuffersize             = "core.transport.exchanger.bufferSize"
 	DataxCoreTransportRecordClass                     = "core.transport.record.class"
+	DataxCoreTransportChannelSpillByte                = "core.transport.channel.spill.byte"
+	DataxCoreTransportChannelSpillDir                 = "core.transport.channel.spill.dir"
+	DataxCoreTransportChannelVerify                   = "core.transport.channel.verify"
 	DataxCoreStatisticsCollectorPluginTaskclass       = "core.statistics.collector.plugin.taskClass"
 	DataxCoreStatisticsCollectorPluginMaxdirtynum     = "core.statistics.collector.plugin.maxDirtyNumber"
 	DataxJobContentReaderName                         = "job.content.0.reader.name"
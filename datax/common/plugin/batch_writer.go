@@ -0,0 +1,107 @@
+package plugin
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//BatchOptions 批量写入选项
+type BatchOptions struct {
+	BatchSize    int           //每批记录的最大数量
+	BatchTimeout time.Duration //每批记录的最长等待时间，必须大于0
+}
+
+//BatchWrite 从记录接收器receiver中接收记录，每条记录先通过onRecord处理后再加入批次，
+//记录数达到BatchSize或者每隔BatchTimeout通过write写入一批记录，写入成功后释放这些记录，
+//收到终止记录后写入剩余的记录，onRecord为空时不处理记录，
+//ctx取消或者收到终止记录时正常返回，接收记录，处理记录以及写入失败时会报错
+func BatchWrite(ctx context.Context, receiver RecordReceiver, opts BatchOptions,
+	onRecord func(element.Record) error, write func([]element.Record) error) (err error) {
+	recordChan := make(chan element.Record)
+	var rerr error
+	afterCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			wg.Done()
+			close(recordChan)
+		}()
+		for {
+			select {
+			case <-afterCtx.Done():
+				return
+			default:
+			}
+			var record element.Record
+			record, rerr = receiver.GetFromReader()
+			if rerr != nil && rerr != ErrEmpty {
+				return
+			}
+
+			if rerr != ErrEmpty {
+				select {
+				case <-afterCtx.Done():
+					return
+				case recordChan <- record:
+				}
+			}
+		}
+	}()
+
+	var records []element.Record
+	flush := func() error {
+		if len(records) == 0 {
+			return nil
+		}
+		if err := write(records); err != nil {
+			return err
+		}
+		ReleaseRecords(receiver, records...)
+		records = nil
+		return nil
+	}
+	ticker := time.NewTicker(opts.BatchTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case record, ok := <-recordChan:
+			if !ok {
+				err = rerr
+				//收到终止记录后写入剩余的记录
+				if err == ErrTerminate {
+					err = flush()
+				}
+				goto End
+			}
+			if onRecord != nil {
+				if err = onRecord(record); err != nil {
+					goto End
+				}
+			}
+			records = append(records, record)
+			if len(records) >= opts.BatchSize {
+				if err = flush(); err != nil {
+					goto End
+				}
+			}
+		case <-ticker.C:
+			if err = flush(); err != nil {
+				goto End
+			}
+		}
+	}
+End:
+	cancel()
+	wg.Wait()
+	switch {
+	case ctx.Err() != nil:
+		return nil
+	case err == ErrTerminate:
+		return nil
+	}
+	return
+}
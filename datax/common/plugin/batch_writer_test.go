@@ -0,0 +1,147 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//mockBatchReceiver 依次返回n条记录，之后返回错误err
+type mockBatchReceiver struct {
+	mockRecordReleaser
+
+	n   int
+	i   int
+	err error
+}
+
+func (m *mockBatchReceiver) GetFromReader() (element.Record, error) {
+	if m.i >= m.n {
+		if m.err == nil {
+			return nil, ErrEmpty
+		}
+		return nil, m.err
+	}
+	m.i++
+	return element.NewDefaultRecord(), nil
+}
+
+func TestBatchWrite(t *testing.T) {
+	tests := []struct {
+		name        string
+		receiver    *mockBatchReceiver
+		opts        BatchOptions
+		onRecordErr error
+		writeErr    error
+		wantBatches []int
+		wantRelease int
+		wantErr     bool
+	}{
+		{
+			name: "1",
+			receiver: &mockBatchReceiver{
+				n:   5,
+				err: ErrTerminate,
+			},
+			opts: BatchOptions{
+				BatchSize:    2,
+				BatchTimeout: time.Hour,
+			},
+			wantBatches: []int{2, 2, 1},
+			wantRelease: 5,
+		},
+		{
+			name: "2",
+			receiver: &mockBatchReceiver{
+				n:   3,
+				err: errors.New("mock error"),
+			},
+			opts: BatchOptions{
+				BatchSize:    2,
+				BatchTimeout: time.Hour,
+			},
+			wantBatches: []int{2},
+			wantRelease: 2,
+			wantErr:     true,
+		},
+		{
+			name: "3",
+			receiver: &mockBatchReceiver{
+				n:   3,
+				err: ErrTerminate,
+			},
+			opts: BatchOptions{
+				BatchSize:    2,
+				BatchTimeout: time.Hour,
+			},
+			writeErr: errors.New("mock error"),
+			wantErr:  true,
+		},
+		{
+			name: "4",
+			receiver: &mockBatchReceiver{
+				n:   3,
+				err: ErrTerminate,
+			},
+			opts: BatchOptions{
+				BatchSize:    2,
+				BatchTimeout: time.Hour,
+			},
+			onRecordErr: errors.New("mock error"),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var batches []int
+			err := BatchWrite(context.Background(), tt.receiver, tt.opts,
+				func(element.Record) error {
+					return tt.onRecordErr
+				},
+				func(records []element.Record) error {
+					if tt.writeErr != nil {
+						return tt.writeErr
+					}
+					batches = append(batches, len(records))
+					return nil
+				})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BatchWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(batches, tt.wantBatches) {
+				t.Errorf("BatchWrite() batches = %v, want %v", batches, tt.wantBatches)
+			}
+			if len(tt.receiver.records) != tt.wantRelease {
+				t.Errorf("BatchWrite() release = %v, want %v", len(tt.receiver.records), tt.wantRelease)
+			}
+		})
+	}
+}
+
+func TestBatchWrite_Timeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	receiver := &mockBatchReceiver{
+		n: 3,
+	}
+	//没有收到终止记录，只能通过超时写入记录
+	total := 0
+	if err := BatchWrite(ctx, receiver, BatchOptions{
+		BatchSize:    10,
+		BatchTimeout: time.Millisecond,
+	}, nil, func(records []element.Record) error {
+		if total += len(records); total == 3 {
+			cancel()
+		}
+		return nil
+	}); err != nil {
+		t.Errorf("BatchWrite() error = %v", err)
+	}
+	if total != 3 {
+		t.Errorf("BatchWrite() total = %v, want 3", total)
+	}
+}
@@ -1,9 +1,17 @@
 package plugin
 
 import (
+	"errors"
+
 	"github.com/Breeze0806/go-etl/element"
 )
 
+//记录接收器返回的错误枚举
+var (
+	ErrTerminate = errors.New("reader is terminated") //读取器已终止，不会再有记录
+	ErrEmpty     = errors.New("chan is empty")        //暂时没有记录
+)
+
 //RecordReceiver 记录接收器
 type RecordReceiver interface {
 	GetFromReader() (element.Record, error) //从reader中读取记录
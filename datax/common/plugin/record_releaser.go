@@ -0,0 +1,17 @@
+package plugin
+
+import (
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//RecordReleaser 记录释放器，写入器用完记录后可以通过它复用记录
+type RecordReleaser interface {
+	ReleaseRecords(records ...element.Record) //释放记录，释放后不能再使用这些记录
+}
+
+//ReleaseRecords 当记录接收器receiver实现了记录释放器时释放记录records，否则为空操作
+func ReleaseRecords(receiver RecordReceiver, records ...element.Record) {
+	if r, ok := receiver.(RecordReleaser); ok {
+		r.ReleaseRecords(records...)
+	}
+}
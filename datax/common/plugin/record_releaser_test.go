@@ -0,0 +1,37 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockRecordReceiver struct{}
+
+func (m *mockRecordReceiver) GetFromReader() (element.Record, error) {
+	return nil, nil
+}
+
+func (m *mockRecordReceiver) Shutdown() error {
+	return nil
+}
+
+type mockRecordReleaser struct {
+	mockRecordReceiver
+
+	records []element.Record
+}
+
+func (m *mockRecordReleaser) ReleaseRecords(records ...element.Record) {
+	m.records = append(m.records, records...)
+}
+
+func TestReleaseRecords(t *testing.T) {
+	releaser := &mockRecordReleaser{}
+	ReleaseRecords(releaser, element.NewDefaultRecord(), element.NewDefaultRecord())
+	if len(releaser.records) != 2 {
+		t.Errorf("ReleaseRecords() len(records) = %v, want 2", len(releaser.records))
+	}
+
+	ReleaseRecords(&mockRecordReceiver{}, element.NewDefaultRecord())
+}
@@ -0,0 +1,31 @@
+package plugin
+
+import (
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//SchemaSender 模式发送器，是记录发送器的可选功能，读取器在发送记录前通过它发送记录模式
+type SchemaSender interface {
+	SendSchema(schema *element.Schema) error //发送记录模式
+}
+
+//SchemaReceiver 模式接收器，是记录接收器的可选功能，写入器收到记录后通过它获取记录模式
+type SchemaReceiver interface {
+	Schema() *element.Schema //获取记录模式，读取器没有发送时为空
+}
+
+//SendSchema 当记录发送器sender实现了模式发送器时发送记录模式schema，否则为空操作
+func SendSchema(sender RecordSender, schema *element.Schema) error {
+	if s, ok := sender.(SchemaSender); ok {
+		return s.SendSchema(schema)
+	}
+	return nil
+}
+
+//ReceiveSchema 当记录接收器receiver实现了模式接收器时获取记录模式，否则返回空
+func ReceiveSchema(receiver RecordReceiver) *element.Schema {
+	if r, ok := receiver.(SchemaReceiver); ok {
+		return r.Schema()
+	}
+	return nil
+}
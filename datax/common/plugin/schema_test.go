@@ -0,0 +1,89 @@
+package plugin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockRecordSender struct{}
+
+func (m *mockRecordSender) CreateRecord() (element.Record, error) {
+	return element.NewDefaultRecord(), nil
+}
+
+func (m *mockRecordSender) SendWriter(record element.Record) error {
+	return nil
+}
+
+func (m *mockRecordSender) Flush() error {
+	return nil
+}
+
+func (m *mockRecordSender) Terminate() error {
+	return nil
+}
+
+func (m *mockRecordSender) Shutdown() error {
+	return nil
+}
+
+type mockSchemaExchanger struct {
+	mockRecordSender
+	mockRecordReceiver
+
+	schema *element.Schema
+	err    error
+}
+
+func (m *mockSchemaExchanger) Shutdown() error {
+	return nil
+}
+
+func (m *mockSchemaExchanger) SendSchema(schema *element.Schema) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.schema = schema
+	return nil
+}
+
+func (m *mockSchemaExchanger) Schema() *element.Schema {
+	return m.schema
+}
+
+func TestSendSchema(t *testing.T) {
+	schema, _ := element.NewSchema([]element.ColumnSchema{
+		{
+			Name: "a",
+			Type: element.TypeString,
+		},
+	})
+
+	e := &mockSchemaExchanger{}
+	if err := SendSchema(e, schema); err != nil {
+		t.Errorf("SendSchema() error = %v", err)
+		return
+	}
+	if got := ReceiveSchema(e); got != schema {
+		t.Errorf("ReceiveSchema() = %v, want %v", got, schema)
+		return
+	}
+
+	e = &mockSchemaExchanger{
+		err: errors.New("mock error"),
+	}
+	if err := SendSchema(e, schema); err == nil {
+		t.Errorf("SendSchema() error = %v, wantErr true", err)
+		return
+	}
+
+	if err := SendSchema(&mockRecordSender{}, schema); err != nil {
+		t.Errorf("SendSchema() error = %v", err)
+		return
+	}
+	if got := ReceiveSchema(&mockRecordReceiver{}); got != nil {
+		t.Errorf("ReceiveSchema() = %v, want nil", got)
+	}
+}
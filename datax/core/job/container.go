@@ -18,9 +18,18 @@ import (
 	"github.com/Breeze0806/go-etl/datax/core"
 	statplugin "github.com/Breeze0806/go-etl/datax/core/statistics/container/plugin"
 	"github.com/Breeze0806/go-etl/datax/core/taskgroup"
+	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/schedule"
 )
 
+//通道记录校验模式，通过core.transport.channel.verify配置，为空时不校验，
+//只比较转化后发送到通道的记录和写入器从通道收到的记录，用于发现记录在通道中的丢失，重复和损坏，
+//不会读取源端和目的端的数据，不能代替对源端和目的端数据的校验
+const (
+	VerifyModeWarn = "warn" //通道记录的校验和不一致时打印错误日志
+	VerifyModeFail = "fail" //通道记录的校验和不一致时工作失败
+)
+
 //Container 工作容器环境，所有的工作都在本容器环境中执行
 type Container struct {
 	ctx context.Context
@@ -41,6 +50,7 @@ type Container struct {
 	errorLimit   util.ErrorRecordChecker
 	taskSchduler *schedule.TaskSchduler
 	wg           sync.WaitGroup
+	verify       string //通道记录校验模式
 }
 
 //NewContainer 通过上下文ctx和JSON配置conf生成工作容器环境
@@ -121,6 +131,13 @@ func (c *Container) destroy() (err error) {
 //当配置文件读取器和写入器的名字和参数不存在的情况下会报错
 //另外，读取器和写入器工作初始化失败也会导致报错
 func (c *Container) init() (err error) {
+	c.verify = c.Config().GetStringOrDefaullt(coreconst.DataxCoreTransportChannelVerify, "")
+	switch c.verify {
+	case "", VerifyModeWarn, VerifyModeFail:
+	default:
+		return fmt.Errorf("verify mode(%v) does not exist", c.verify)
+	}
+
 	c.readerPluginName, err = c.Config().GetString(coreconst.DataxJobContentReaderName)
 	if err != nil {
 		return
@@ -240,12 +257,14 @@ func (c *Container) schedule() (err error) {
 	c.taskSchduler = schedule.NewTaskSchduler(int(c.Config().GetInt64OrDefaullt(
 		coreconst.DataxCoreContainerJobMaxWorkerNumber, 4)), len(tasksConfigs))
 	defer c.taskSchduler.Stop()
+	var taskGroups []*taskgroup.Container
 	for i := range tasksConfigs {
 		var taskGroup *taskgroup.Container
 		taskGroup, err = taskgroup.NewContainer(c.ctx, tasksConfigs[i])
 		if err != nil {
 			goto End
 		}
+		taskGroups = append(taskGroups, taskGroup)
 		c.wg.Add(1)
 		var errChan <-chan error
 		errChan, err = c.taskSchduler.Push(taskGroup)
@@ -264,8 +283,31 @@ func (c *Container) schedule() (err error) {
 	}
 End:
 	c.wg.Wait()
+	if err != nil || c.verify == "" || c.ctx.Err() != nil {
+		return
+	}
 
-	return
+	var sent, received element.Checksum
+	for _, v := range taskGroups {
+		s, r := v.Checksums()
+		sent.Merge(s)
+		received.Merge(r)
+	}
+	return c.verifyChecksums(sent, received)
+}
+
+//verifyChecksums 比较发送到通道的记录的校验和sent和写入器从通道收到的记录的校验和received，
+//不一致时，校验模式为fail时会报错，为warn时打印错误日志
+func (c *Container) verifyChecksums(sent, received element.Checksum) error {
+	log.Infof("DataX jobContainer %v channel sent checksum: %v received checksum: %v", c.jobID, sent, received)
+	if sent == received {
+		return nil
+	}
+	if c.verify == VerifyModeFail {
+		return fmt.Errorf("channel checksum mismatch. sent: %v received: %v", sent, received)
+	}
+	log.Errorf("DataX jobContainer %v channel checksum mismatch. sent: %v received: %v", c.jobID, sent, received)
+	return nil
 }
 
 //post 后置通知
@@ -10,6 +10,7 @@ import (
 	"github.com/Breeze0806/go-etl/config"
 	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
 	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
+	"github.com/Breeze0806/go-etl/element"
 )
 
 func TestNewContainer(t *testing.T) {
@@ -695,6 +696,42 @@ func TestContainer_init(t *testing.T) {
 									"name": "mockErr",
 									"parameter" : {
 
+									}
+								}
+							}
+						]
+					}
+				}`)),
+			wantErr: true,
+		},
+		{
+			name: "10",
+			c: testContainer(testJSONFromString(`{
+					"core" : {
+						"container": {
+							"job":{
+								"id": 1
+							}
+						},
+						"transport":{
+							"channel":{
+								"verify":"mock"
+							}
+						}
+					},
+					"job":{
+						"content":[
+							{
+								"reader":{
+									"name": "mock",
+									"parameter" : {
+
+									}
+								},
+								"writer":{
+									"name": "mock",
+									"parameter" : {
+
 									}
 								}
 							}
@@ -2408,6 +2445,57 @@ func TestContainer_schedule(t *testing.T) {
 	}
 }
 
+func TestContainer_verifyChecksums(t *testing.T) {
+	var one, two element.Checksum
+	one.Add(element.NewDefaultRecord())
+	two.Add(element.NewDefaultRecord())
+	two.Add(element.NewDefaultRecord())
+	tests := []struct {
+		name     string
+		verify   string
+		sent     element.Checksum
+		received element.Checksum
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			verify:   VerifyModeFail,
+			sent:     two,
+			received: two,
+		},
+		{
+			name:     "2",
+			verify:   VerifyModeFail,
+			sent:     two,
+			received: one,
+			wantErr:  true,
+		},
+		{
+			name:     "3",
+			verify:   VerifyModeWarn,
+			sent:     two,
+			received: one,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testContainer(testJSONFromString(`{
+				"core":{
+					"container": {
+						"job":{
+							"id": 1
+						}
+					}
+				}
+			}`))
+			c.verify = tt.verify
+			if err := c.verifyChecksums(tt.sent, tt.received); (err != nil) != tt.wantErr {
+				t.Errorf("Container.verifyChecksums() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_doAssign(t *testing.T) {
 	type args struct {
 		taskIDMap       map[string][]int
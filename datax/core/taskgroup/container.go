@@ -9,6 +9,7 @@ import (
 	"github.com/Breeze0806/go-etl/config"
 	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
 	"github.com/Breeze0806/go-etl/datax/core"
+	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/schedule"
 )
 
@@ -25,6 +26,10 @@ type Container struct {
 	sleepInterval time.Duration
 	retryInterval time.Duration
 	retryMaxCount int32
+
+	checksumMu       sync.Mutex
+	sentChecksum     element.Checksum //结束的任务中发送到通道的记录的校验和
+	receivedChecksum element.Checksum //结束的任务中写入器从通道收到的记录的校验和
 }
 
 //NewContainer 根据JSON配置conf创建任务组容器
@@ -65,6 +70,13 @@ func (c *Container) TaskGroupID() int64 {
 	return c.taskGroupID
 }
 
+//Checksums 结束的任务中发送到通道的记录和写入器从通道收到的记录的校验和，没有开启校验时为空
+func (c *Container) Checksums() (sent, received element.Checksum) {
+	c.checksumMu.Lock()
+	defer c.checksumMu.Unlock()
+	return c.sentChecksum, c.receivedChecksum
+}
+
 //Do 执行
 func (c *Container) Do() error {
 	return c.Start()
@@ -83,9 +95,34 @@ func (c *Container) Start() (err error) {
 	defer c.scheduler.Stop()
 	prefixKey := strconv.FormatInt(c.jobID, 10) + "-" + strconv.FormatInt(c.taskGroupID, 10)
 	log.Infof("datax job(%v) taskgruop(%v) manager config", c.jobID, c.taskGroupID)
+	recordClass := c.Config().GetStringOrDefaullt(coreconst.DataxCoreTransportRecordClass, "")
+	spillByte := c.Config().GetInt64OrDefaullt(coreconst.DataxCoreTransportChannelSpillByte, 0)
+	spillDir := c.Config().GetStringOrDefaullt(coreconst.DataxCoreTransportChannelSpillDir, "")
+	verify := c.Config().GetStringOrDefaullt(coreconst.DataxCoreTransportChannelVerify, "")
 	for i := range taskConfigs {
 		var taskExecer *taskExecer
 
+		if recordClass != "" {
+			//任务执行器通过任务配置获取记录类型
+			if err = taskConfigs[i].Set(coreconst.DataxCoreTransportRecordClass, recordClass); err != nil {
+				return err
+			}
+		}
+		if spillByte > 0 {
+			//任务执行器通过任务配置获取通道的溢出配置
+			if err = taskConfigs[i].Set(coreconst.DataxCoreTransportChannelSpillByte, spillByte); err != nil {
+				return err
+			}
+			if err = taskConfigs[i].Set(coreconst.DataxCoreTransportChannelSpillDir, spillDir); err != nil {
+				return err
+			}
+		}
+		if verify != "" {
+			//任务执行器通过任务配置获取是否计算记录校验和
+			if err = taskConfigs[i].Set(coreconst.DataxCoreTransportChannelVerify, verify); err != nil {
+				return err
+			}
+		}
 		taskExecer, err = newTaskExecer(c.ctx, taskConfigs[i], prefixKey, 0)
 		if err != nil {
 			return err
@@ -174,6 +211,7 @@ func (c *Container) startTaskExecer(te *taskExecer) (err error) {
 				c.tasks.removeRunAndPushRemain(te)
 			} else {
 				log.Debugf("datax job(%v) taskgruop(%v) task(%v) end", c.jobID, c.taskGroupID, te.Key())
+				c.mergeChecksums(te)
 				//从任务调度器移除
 				c.tasks.removeRun(te)
 			}
@@ -182,3 +220,19 @@ func (c *Container) startTaskExecer(te *taskExecer) (err error) {
 	}(te)
 	return
 }
+
+//mergeChecksums 合并结束的任务te的校验和，校验和不一致时打印错误日志
+func (c *Container) mergeChecksums(te *taskExecer) {
+	if !te.verify {
+		return
+	}
+	sent, received := te.Checksums()
+	if sent != received {
+		log.Errorf("datax job(%v) taskgruop(%v) task(%v) channel checksum mismatch. sent: %v received: %v",
+			c.jobID, c.taskGroupID, te.Key(), sent, received)
+	}
+	c.checksumMu.Lock()
+	c.sentChecksum.Merge(sent)
+	c.receivedChecksum.Merge(received)
+	c.checksumMu.Unlock()
+}
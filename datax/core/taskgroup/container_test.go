@@ -272,6 +272,10 @@ func TestContainer_TaskGroupId(t *testing.T) {
 }
 
 func TestContainer_Start(t *testing.T) {
+	resetLoader()
+	initLoader("mock", []error{
+		nil, nil, nil, nil, nil,
+	})
 	tests := []struct {
 		name    string
 		c       *Container
@@ -297,6 +301,82 @@ func TestContainer_Start(t *testing.T) {
 				}`)),
 			wantErr: true,
 		},
+		{
+			name: "2",
+			c: testContainer(context.Background(), testJSONFromString(`{
+					"core" : {
+						"container": {
+							"job":{
+								"id": 30000000,
+								"sleepInterval":100
+							},
+							"taskGroup":{
+								"id": 30000001,
+								"failover":{
+									"retryIntervalInMsec":0
+								}
+							}
+						},
+						"transport":{
+							"record":{
+								"class":"index"
+							}
+						}
+					},
+					"job":{
+						"content":[
+							{
+								"taskId": 1,
+								"reader":{
+									"name":"mock"
+								},
+								"writer":{
+									"name":"mock"
+								}
+							}
+						]
+					}
+				}`)),
+			wantErr: false,
+		},
+		{
+			name: "3",
+			c: testContainer(context.Background(), testJSONFromString(`{
+					"core" : {
+						"container": {
+							"job":{
+								"id": 30000000,
+								"sleepInterval":100
+							},
+							"taskGroup":{
+								"id": 30000001,
+								"failover":{
+									"retryIntervalInMsec":0
+								}
+							}
+						},
+						"transport":{
+							"record":{
+								"class":"mock"
+							}
+						}
+					},
+					"job":{
+						"content":[
+							{
+								"taskId": 1,
+								"reader":{
+									"name":"mock"
+								},
+								"writer":{
+									"name":"mock"
+								}
+							}
+						]
+					}
+				}`)),
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -421,3 +501,46 @@ func TestContainer_startTaskExecer(t *testing.T) {
 		t.Errorf("Container.startTaskExecer() error = %v, wantErr true", err)
 	}
 }
+
+func TestContainer_Checksums(t *testing.T) {
+	resetLoader()
+	loader.RegisterReader("record", &mockRecordReader{})
+	loader.RegisterWriter("record", &mockRecordWriter{receive: -1})
+	content := testJSONFromString(`{
+		"core" : {
+			"container": {
+				"job":{
+					"id": 1,
+					"sleepInterval":100
+				},
+				"taskGroup":{
+					"id": 1
+				}
+			},
+			"transport":{
+				"channel":{
+					"verify":"fail"
+				}
+			}
+		}
+	}`)
+	for i := 0; i < 3; i++ {
+		content.SetRawString(coreconst.DataxJobContent+fmt.Sprintf(".%d", i), fmt.Sprintf(`{
+			"taskId": %d,
+			"reader":{
+				"name":"record"
+			},
+			"writer":{
+				"name":"record"
+			}
+		}`, i))
+	}
+	c, _ := NewContainer(context.Background(), content)
+	if err := c.Do(); err != nil {
+		t.Fatalf("Do error: %v", err)
+	}
+	sent, received := c.Checksums()
+	if sent.Count() != 30 || sent != received {
+		t.Errorf("Checksums() sent = %v received = %v, want equal with count 30", sent, received)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
 	"github.com/Breeze0806/go-etl/datax/common/spi/reader"
 	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
 )
 
 type mockPlugin struct {
@@ -197,6 +199,73 @@ func (m *mockWriter) Task() writer.Task {
 	return newMockWriterTask(m.errs)
 }
 
+type mockRecordReaderTask struct {
+	*mockReaderTask
+}
+
+func (m *mockRecordReaderTask) StartRead(ctx context.Context, sender plugin.RecordSender) error {
+	for i := 0; i < 10; i++ {
+		r, err := sender.CreateRecord()
+		if err != nil {
+			return err
+		}
+		r.Add(element.NewDefaultColumn(element.NewInt64ColumnValue(int64(i)), "i", 8))
+		if err = sender.SendWriter(r); err != nil {
+			return err
+		}
+	}
+	return sender.Terminate()
+}
+
+type mockRecordWriterTask struct {
+	*mockWriterTask
+	receive int //接收的记录数，小于0时接收所有记录
+}
+
+func (m *mockRecordWriterTask) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) error {
+	for n := 0; m.receive < 0 || n < m.receive; n++ {
+		r, err := receiver.GetFromReader()
+		switch err {
+		case nil:
+		case exchange.ErrEmpty:
+			continue
+		case exchange.ErrTerminate:
+			return nil
+		default:
+			return err
+		}
+		plugin.ReleaseRecords(receiver, r)
+	}
+	return nil
+}
+
+type mockRecordReader struct{}
+
+func (m *mockRecordReader) Job() reader.Job {
+	return &mockReaderJob{}
+}
+
+func (m *mockRecordReader) Task() reader.Task {
+	return &mockRecordReaderTask{
+		mockReaderTask: newMockReaderTask(make([]error, 5)),
+	}
+}
+
+type mockRecordWriter struct {
+	receive int
+}
+
+func (m *mockRecordWriter) Job() writer.Job {
+	return &mockWriterJob{}
+}
+
+func (m *mockRecordWriter) Task() writer.Task {
+	return &mockRecordWriterTask{
+		mockWriterTask: newMockWriterTask(make([]error, 5)),
+		receive:        m.receive,
+	}
+}
+
 func testJSONFromString(s string) *config.JSON {
 	j, err := config.NewJSONFromString(s)
 	if err != nil {
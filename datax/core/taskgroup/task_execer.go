@@ -14,6 +14,8 @@ import (
 	"github.com/Breeze0806/go-etl/datax/core/taskgroup/runner"
 	"github.com/Breeze0806/go-etl/datax/core/transport/channel"
 	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/datax/transform"
+	"github.com/Breeze0806/go-etl/element"
 	"go.uber.org/atomic"
 )
 
@@ -21,9 +23,11 @@ type taskExecer struct {
 	taskConf     *config.JSON //任务JSON配置
 	taskID       int64        //任务编号
 	ctx          context.Context
-	channel      *channel.Channel //记录通道
-	writerRunner runner.Runner    //写入运行器
-	readerRunner runner.Runner    //执行运行器
+	channel      *channel.Channel          //记录通道
+	exchanger    *exchange.RecordExchanger //记录交换器
+	verify       bool                      //是否计算记录校验和
+	writerRunner runner.Runner             //写入运行器
+	readerRunner runner.Runner             //执行运行器
 	wg           sync.WaitGroup
 	errors       chan error
 	//todo: taskCommunication没用
@@ -37,8 +41,8 @@ type taskExecer struct {
 }
 
 //newTaskExecer 根据上下文ctx，任务配置taskConf，前缀关键字prefixKey
-//执行次数attemptCount生成任务执行器，当taskID不存在，工作器名字配置以及
-//对应写入器和读取器不存在时会报错
+//执行次数attemptCount生成任务执行器，当taskID不存在，工作器名字配置，
+//对应写入器和读取器以及记录类型不存在时会报错
 func newTaskExecer(ctx context.Context, taskConf *config.JSON,
 	prefixKey string, attemptCount int) (t *taskExecer, err error) {
 	t = &taskExecer{
@@ -47,7 +51,15 @@ func newTaskExecer(ctx context.Context, taskConf *config.JSON,
 		ctx:          ctx,
 		attemptCount: atomic.NewInt32(int32(attemptCount)),
 	}
-	t.channel, err = channel.NewChannel()
+	//配置了溢出字节数时，通道中的记录超过该内存大小后会写入临时段文件
+	if spillByte := taskConf.GetInt64OrDefaullt(coreconst.DataxCoreTransportChannelSpillByte, 0); spillByte > 0 {
+		t.channel, err = channel.NewChannelWithSpill(element.SpillConfig{
+			Dir:        taskConf.GetStringOrDefaullt(coreconst.DataxCoreTransportChannelSpillDir, ""),
+			MemoryByte: spillByte,
+		})
+	} else {
+		t.channel, err = channel.NewChannel()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -67,8 +79,13 @@ func newTaskExecer(ctx context.Context, taskConf *config.JSON,
 	if !ok {
 		return nil, fmt.Errorf("reader task name (%v) does not exist", name)
 	}
-	exchanger := exchange.NewRecordExchangerWithoutTransformer(t.channel)
-	t.readerRunner = runner.NewReader(readTask, exchanger, t.key)
+	t.exchanger, err = exchange.NewRecordExchangerWithRecordClass(t.channel, &transform.NilTransformer{},
+		taskConf.GetStringOrDefaullt(coreconst.DataxCoreTransportRecordClass, ""))
+	if err != nil {
+		return nil, err
+	}
+	t.verify = taskConf.GetStringOrDefaullt(coreconst.DataxCoreTransportChannelVerify, "") != ""
+	t.readerRunner = runner.NewReader(readTask, t.exchanger, t.key)
 
 	name, err = taskConf.GetString(coreconst.JobWriterName)
 	if err != nil {
@@ -79,7 +96,7 @@ func newTaskExecer(ctx context.Context, taskConf *config.JSON,
 	if !ok {
 		return nil, fmt.Errorf("writer task name (%v) does not exist", name)
 	}
-	t.writerRunner = runner.NewWriter(writeTask, exchanger, t.key)
+	t.writerRunner = runner.NewWriter(writeTask, t.exchanger, t.key)
 
 	return
 }
@@ -128,11 +145,19 @@ func (t *taskExecer) Do() error {
 		t.attemptCount.Inc()
 		log.Debugf("taskExecer %v end to do", t.key)
 	}()
+	//重试时重新计算记录校验和
+	if t.verify {
+		t.exchanger.EnableChecksum()
+	}
 	//执行读取写入运行器
 	t.Start()
 	log.Debugf("taskExecer %v do wait runner stop", t.key)
 	//等待读取写入运行器
 	t.wg.Wait()
+	//删除通道溢出的段文件
+	if err := t.channel.Destroy(); err != nil {
+		log.Errorf("taskExecer %v destroy channel fail, err: %v", t.key, err)
+	}
 	var errs []error
 	log.Debugf("taskExecer %v do wait runner err chan", t.key)
 	//监听错误通道器获取错误
@@ -159,6 +184,11 @@ ErrorLoop:
 	return nil
 }
 
+//Checksums 最近一次执行时发送到通道的记录和写入器从通道收到的记录的校验和，没有开启校验时为空
+func (t *taskExecer) Checksums() (sent, received element.Checksum) {
+	return t.exchanger.SentChecksum(), t.exchanger.ReceivedChecksum()
+}
+
 //Key 关键之
 func (t *taskExecer) Key() string {
 	return t.key
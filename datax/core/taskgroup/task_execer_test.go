@@ -151,6 +151,83 @@ func Test_newTaskExecer(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "7",
+			args: args{
+				ctx: context.Background(),
+				taskConf: testJSONFromString(`{
+						"taskId":7,
+						"reader":{
+							"name":"mock"
+						},
+						"writer":{
+							"name":"mock"
+						},
+						"core":{
+							"transport":{
+								"record":{
+									"class":"index"
+								}
+							}
+						}
+					}`),
+				prefixKey:    "mock",
+				attemptCount: 0,
+			},
+			wantErr: false,
+		},
+		{
+			name: "8",
+			args: args{
+				ctx: context.Background(),
+				taskConf: testJSONFromString(`{
+						"taskId":8,
+						"reader":{
+							"name":"mock"
+						},
+						"writer":{
+							"name":"mock"
+						},
+						"core":{
+							"transport":{
+								"record":{
+									"class":"mock"
+								}
+							}
+						}
+					}`),
+				prefixKey:    "mock",
+				attemptCount: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "9",
+			args: args{
+				ctx: context.Background(),
+				taskConf: testJSONFromString(`{
+						"taskId":9,
+						"reader":{
+							"name":"mock"
+						},
+						"writer":{
+							"name":"mock"
+						},
+						"core":{
+							"transport":{
+								"channel":{
+									"spill":{
+										"byte":1048576
+									}
+								}
+							}
+						}
+					}`),
+				prefixKey:    "mock",
+				attemptCount: 0,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -217,3 +294,78 @@ func Test_taskExecer_Do(t *testing.T) {
 		})
 	}
 }
+
+func Test_taskExecer_Checksums(t *testing.T) {
+	resetLoader()
+	loader.RegisterReader("record", &mockRecordReader{})
+	loader.RegisterWriter("record", &mockRecordWriter{receive: -1})
+	loader.RegisterWriter("receive5", &mockRecordWriter{receive: 5})
+	tests := []struct {
+		name      string
+		writer    string
+		verify    string
+		wantCount int64
+		wantEqual bool
+	}{
+		{
+			name:      "1",
+			writer:    "record",
+			verify:    "fail",
+			wantCount: 10,
+			wantEqual: true,
+		},
+		{
+			name:      "2",
+			writer:    "receive5",
+			verify:    "fail",
+			wantCount: 10,
+			wantEqual: false,
+		},
+		{
+			name:      "3",
+			writer:    "record",
+			verify:    "warn",
+			wantCount: 10,
+			wantEqual: true,
+		},
+		{
+			name:      "4",
+			writer:    "record",
+			wantCount: 0,
+			wantEqual: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			te := testTaskExecer(context.Background(), testJSONFromString(fmt.Sprintf(`{
+				"taskId":1,
+				"core":{
+					"transport":{
+						"channel":{
+							"verify":%q
+						}
+					}
+				},
+				"reader":{
+					"name":"record"
+				},
+				"writer":{
+					"name":%q
+				}
+			}`, tt.verify, tt.writer)), `mock`, 0)
+			//重试时重新计算
+			for i := 0; i < 2; i++ {
+				if err := te.Do(); err != nil {
+					t.Fatalf("taskExecer.Do() error = %v", err)
+				}
+			}
+			sent, received := te.Checksums()
+			if sent.Count() != tt.wantCount {
+				t.Errorf("taskExecer.Checksums() sent = %v, want count %v", sent, tt.wantCount)
+			}
+			if (sent == received) != tt.wantEqual {
+				t.Errorf("taskExecer.Checksums() sent = %v received = %v, wantEqual %v", sent, received, tt.wantEqual)
+			}
+		})
+	}
+}
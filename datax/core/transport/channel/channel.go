@@ -1,10 +1,25 @@
 package channel
 
-import "github.com/Breeze0806/go-etl/element"
+import (
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//recordChan 记录通道，由element.RecordChan和element.SpillRecordChan实现
+type recordChan interface {
+	Close()
+	Buffered() int
+	PushBack(element.Record) int
+	PopFront() (element.Record, bool)
+	PushBackAll(func() (element.Record, error)) error
+	PopFrontAll(func(element.Record) error) error
+}
 
 //Channel 通道
 type Channel struct {
-	records *element.RecordChan
+	records recordChan
+	spill   *element.SpillRecordChan //溢出记录通道，没有配置溢出时为空
 }
 
 //NewChannel 创建通道
@@ -14,6 +29,19 @@ func NewChannel() (*Channel, error) {
 	}, nil
 }
 
+//NewChannelWithSpill 根据溢出配置conf创建溢出通道，内存中记录的内存大小超过
+//conf.MemoryByte时，记录会写入临时段文件，conf.MemoryByte不为正数时会报错
+func NewChannelWithSpill(conf element.SpillConfig) (*Channel, error) {
+	if conf.MemoryByte <= 0 {
+		return nil, fmt.Errorf("spill memory byte(%v) should be positive", conf.MemoryByte)
+	}
+	spill := element.NewSpillRecordChan(conf)
+	return &Channel{
+		records: spill,
+		spill:   spill,
+	}, nil
+}
+
 //Size 通道记录大小
 func (c *Channel) Size() int {
 	return c.records.Buffered()
@@ -53,3 +81,19 @@ func (c *Channel) Close() {
 func (c *Channel) PushTerminate() int {
 	return c.Push(element.GetTerminateRecord())
 }
+
+//Err 溢出通道读写段文件的错误，出错后通道弹出时没有值
+func (c *Channel) Err() error {
+	if c.spill == nil {
+		return nil
+	}
+	return c.spill.Err()
+}
+
+//Destroy 销毁，删除溢出通道的所有段文件
+func (c *Channel) Destroy() error {
+	if c.spill == nil {
+		return nil
+	}
+	return c.spill.Destroy()
+}
@@ -2,6 +2,7 @@ package channel
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/Breeze0806/go-etl/element"
@@ -48,3 +49,95 @@ func TestChannel_PushAllPopAll(t *testing.T) {
 		t.Errorf("PopAll() = %v want nil", err)
 	}
 }
+
+func TestNewChannelWithSpill(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    element.SpillConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: element.SpillConfig{
+				Dir:        t.TempDir(),
+				MemoryByte: 1,
+			},
+		},
+		{
+			name:    "2",
+			conf:    element.SpillConfig{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewChannelWithSpill(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewChannelWithSpill() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChannel_Spill(t *testing.T) {
+	ch, err := NewChannelWithSpill(element.SpillConfig{
+		Dir:        t.TempDir(),
+		MemoryByte: 1,
+	})
+	if err != nil {
+		t.Fatalf("NewChannelWithSpill() error = %v", err)
+	}
+	defer ch.Destroy()
+	for i := 0; i < 10; i++ {
+		r := element.NewDefaultRecord()
+		r.Add(element.NewDefaultColumn(element.NewInt64ColumnValue(int64(i)), "i", 8))
+		ch.Push(r)
+	}
+	ch.PushTerminate()
+	if n := ch.Size(); n != 11 {
+		t.Errorf("Size() = %v want 11", n)
+	}
+	for i := 0; i < 10; i++ {
+		r, ok := ch.Pop()
+		if !ok {
+			t.Fatalf("Pop() = %v want true", ok)
+		}
+		c, _ := r.GetByName("i")
+		if v, _ := c.AsInt64(); v != int64(i) {
+			t.Errorf("Pop() = %v want %v", v, i)
+		}
+	}
+	if r, _ := ch.Pop(); r != element.GetTerminateRecord() {
+		t.Errorf("Pop() = %v want terminate record", r)
+	}
+	if err := ch.Err(); err != nil {
+		t.Errorf("Err() = %v want nil", err)
+	}
+	if err := ch.Destroy(); err != nil {
+		t.Errorf("Destroy() = %v want nil", err)
+	}
+}
+
+func TestChannel_Err(t *testing.T) {
+	ch, _ := NewChannel()
+	defer ch.Close()
+	if err := ch.Err(); err != nil {
+		t.Errorf("Err() = %v want nil", err)
+	}
+	if err := ch.Destroy(); err != nil {
+		t.Errorf("Destroy() = %v want nil", err)
+	}
+
+	ch, _ = NewChannelWithSpill(element.SpillConfig{
+		Dir:        filepath.Join(t.TempDir(), "not_exist"),
+		MemoryByte: 1,
+	})
+	for i := 0; i < 2; i++ {
+		r := element.NewDefaultRecord()
+		r.Add(element.NewDefaultColumn(element.NewInt64ColumnValue(int64(i)), "i", 8))
+		ch.Push(r)
+	}
+	if err := ch.Err(); err == nil {
+		t.Errorf("Err() = %v want not nil", err)
+	}
+}
@@ -2,7 +2,10 @@ package exchange
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
 	"github.com/Breeze0806/go-etl/datax/core/transport/channel"
 	"github.com/Breeze0806/go-etl/datax/transform"
 	"github.com/Breeze0806/go-etl/element"
@@ -10,16 +13,31 @@ import (
 
 //错误枚举
 var (
-	ErrTerminate = errors.New("reader is terminated")
-	ErrEmpty     = errors.New("chan is empty")
+	ErrTerminate = plugin.ErrTerminate //与plugin.ErrTerminate相同
+	ErrEmpty     = plugin.ErrEmpty     //与plugin.ErrEmpty相同
 	ErrShutdown  = errors.New("exchange is shutdowned")
 )
 
+//记录类型，通过core.transport.record.class配置
+const (
+	RecordClassDefault = "default" //默认记录，列通过映射存储
+	RecordClassIndex   = "index"   //索引记录，列通过数组存储，并通过记录池复用
+)
+
 //RecordExchanger 记录交换器
 type RecordExchanger struct {
 	tran       transform.Transformer
 	ch         *channel.Channel
+	pool       *element.RecordPool //记录池，记录类型为index时不为空
 	isShutdown bool
+
+	schemaMu sync.RWMutex
+	schema   *element.Schema //写入器收到的记录模式
+
+	checksumMu sync.Mutex
+	checksum   bool             //是否计算通道记录校验和
+	sent       element.Checksum //转化后发送到通道的记录的校验和
+	received   element.Checksum //写入器从通道收到的记录的校验和
 }
 
 //NewRecordExchangerWithoutTransformer 生成不带转化器的记录交换器
@@ -35,14 +53,32 @@ func NewRecordExchanger(ch *channel.Channel, tran transform.Transformer) *Record
 	}
 }
 
+//NewRecordExchangerWithRecordClass 根据通道ch，转化器tran和记录类型recordClass生成的记录交换器，
+//recordClass为空时使用默认记录，记录类型不存在时会报错
+func NewRecordExchangerWithRecordClass(ch *channel.Channel, tran transform.Transformer,
+	recordClass string) (*RecordExchanger, error) {
+	r := NewRecordExchanger(ch, tran)
+	switch recordClass {
+	case "", RecordClassDefault:
+	case RecordClassIndex:
+		r.pool = element.NewRecordPool()
+	default:
+		return nil, fmt.Errorf("record class(%v) does not exist", recordClass)
+	}
+	return r, nil
+}
+
 //GetFromReader 从Reader中获取记录
-//当交换器关闭，通道为空或者收到终止消息也会报错
+//当交换器关闭，通道为空，通道读写段文件失败或者收到终止消息也会报错
 func (r *RecordExchanger) GetFromReader() (element.Record, error) {
 	if r.isShutdown {
 		return nil, ErrShutdown
 	}
 	record, ok := r.ch.Pop()
 	if !ok {
+		if err := r.ch.Err(); err != nil {
+			return nil, err
+		}
 		return nil, ErrEmpty
 	}
 
@@ -50,6 +86,13 @@ func (r *RecordExchanger) GetFromReader() (element.Record, error) {
 	case *element.TerminateRecord:
 		return nil, ErrTerminate
 	default:
+		//写入器可能会在写入前转化记录，所以在收到时计算校验和
+		if r.checksumEnabled() {
+			h := element.RecordHash(record)
+			r.checksumMu.Lock()
+			r.received.AddHash(h)
+			r.checksumMu.Unlock()
+		}
 		return record, nil
 	}
 }
@@ -60,11 +103,84 @@ func (r *RecordExchanger) Shutdown() error {
 	return nil
 }
 
-//CreateRecord 创建记录
+//CreateRecord 创建记录，记录类型为index时从记录池中取出索引记录
 func (r *RecordExchanger) CreateRecord() (element.Record, error) {
+	if r.pool != nil {
+		return r.pool.Get(), nil
+	}
 	return element.NewDefaultRecord(), nil
 }
 
+//ReleaseRecords 写入器用完记录records后将其放回记录池，记录类型不为index时为空操作
+func (r *RecordExchanger) ReleaseRecords(records ...element.Record) {
+	if r.pool == nil {
+		return
+	}
+	for _, v := range records {
+		r.pool.Put(v)
+	}
+}
+
+//EnableChecksum 开启并重置通道记录校验和，每次执行任务前调用，
+//转化后发送到通道的记录以及写入器从通道收到的记录会分别计入校验和，
+//只能发现记录在通道中的丢失，重复和损坏，不检查读取器读取和写入器写入的数据
+func (r *RecordExchanger) EnableChecksum() {
+	r.checksumMu.Lock()
+	r.checksum = true
+	r.sent = element.Checksum{}
+	r.received = element.Checksum{}
+	r.checksumMu.Unlock()
+}
+
+func (r *RecordExchanger) checksumEnabled() bool {
+	r.checksumMu.Lock()
+	defer r.checksumMu.Unlock()
+	return r.checksum
+}
+
+//SentChecksum 转化后发送到通道的记录的校验和
+func (r *RecordExchanger) SentChecksum() element.Checksum {
+	r.checksumMu.Lock()
+	defer r.checksumMu.Unlock()
+	return r.sent
+}
+
+//ReceivedChecksum 写入器从通道收到的记录的校验和
+func (r *RecordExchanger) ReceivedChecksum() element.Checksum {
+	r.checksumMu.Lock()
+	defer r.checksumMu.Unlock()
+	return r.received
+}
+
+//SendSchema 发送读取器的记录模式schema，只有转化器实现了模式转化器时，写入器才能收到转化后的记录模式，
+//记录类型为index时，之后创建的记录会使用读取器记录模式的列名索引，当转化失败或者交换器已关闭时就会报错
+func (r *RecordExchanger) SendSchema(schema *element.Schema) (err error) {
+	if r.isShutdown {
+		return ErrShutdown
+	}
+	if r.pool != nil {
+		r.pool.SetColumnIndex(schema.ColumnIndex())
+	}
+
+	var newSchema *element.Schema
+	if st, ok := r.tran.(transform.SchemaTransformer); ok {
+		if newSchema, err = st.TransformSchema(schema); err != nil {
+			return
+		}
+	}
+	r.schemaMu.Lock()
+	r.schema = newSchema
+	r.schemaMu.Unlock()
+	return
+}
+
+//Schema 获取写入器收到的记录模式，读取器没有发送或者转化器不支持转化记录模式时为空
+func (r *RecordExchanger) Schema() *element.Schema {
+	r.schemaMu.RLock()
+	defer r.schemaMu.RUnlock()
+	return r.schema
+}
+
 //SendWriter 向写入器写入记录recode,其中还会通过转化器的转化
 //当转化失败或者通道已关闭时就会报错
 func (r *RecordExchanger) SendWriter(record element.Record) (err error) {
@@ -73,6 +189,12 @@ func (r *RecordExchanger) SendWriter(record element.Record) (err error) {
 	}
 	var newRecord element.Record
 	if newRecord, err = r.tran.DoTransform(record); err == nil {
+		if r.checksumEnabled() {
+			h := element.RecordHash(newRecord)
+			r.checksumMu.Lock()
+			r.sent.AddHash(h)
+			r.checksumMu.Unlock()
+		}
 		r.ch.Push(newRecord)
 	}
 	return
@@ -1,10 +1,14 @@
 package exchange
 
 import (
+	"errors"
+	"path/filepath"
+	"reflect"
 	"sync"
 	"testing"
 
 	"github.com/Breeze0806/go-etl/datax/core/transport/channel"
+	"github.com/Breeze0806/go-etl/datax/transform"
 	"github.com/Breeze0806/go-etl/element"
 )
 
@@ -82,3 +86,279 @@ func TestRecordExchanger(t *testing.T) {
 		t.Errorf("GetFromReader() err = %v  want %v", err, ErrTerminate)
 	}
 }
+
+func TestRecordExchanger_GetFromReaderSpillErr(t *testing.T) {
+	ch, _ := channel.NewChannelWithSpill(element.SpillConfig{
+		Dir:        filepath.Join(t.TempDir(), "not_exist"),
+		MemoryByte: 1,
+	})
+	defer ch.Destroy()
+	re := NewRecordExchangerWithoutTransformer(ch)
+	defer re.Shutdown()
+
+	for i := 0; i < 2; i++ {
+		r := element.NewDefaultRecord()
+		r.Add(element.NewDefaultColumn(element.NewInt64ColumnValue(int64(i)), "i", 8))
+		re.SendWriter(r)
+	}
+	if _, err := re.GetFromReader(); err == nil || err == ErrEmpty {
+		t.Errorf("GetFromReader() err = %v want spill error", err)
+	}
+}
+
+func TestNewRecordExchangerWithRecordClass(t *testing.T) {
+	ch, _ := channel.NewChannel()
+	defer ch.Close()
+	tests := []struct {
+		name        string
+		recordClass string
+		wantRecord  element.Record
+		wantErr     bool
+	}{
+		{
+			name:       "1",
+			wantRecord: element.NewDefaultRecord(),
+		},
+		{
+			name:        "2",
+			recordClass: RecordClassDefault,
+			wantRecord:  element.NewDefaultRecord(),
+		},
+		{
+			name:        "3",
+			recordClass: RecordClassIndex,
+			wantRecord:  element.NewIndexRecord(nil),
+		},
+		{
+			name:        "4",
+			recordClass: "mock",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := NewRecordExchangerWithRecordClass(ch, &transform.NilTransformer{}, tt.recordClass)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRecordExchangerWithRecordClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, _ := re.CreateRecord()
+			if !reflect.DeepEqual(got, tt.wantRecord) {
+				t.Errorf("CreateRecord() = %#v, want %#v", got, tt.wantRecord)
+			}
+		})
+	}
+}
+
+func TestRecordExchanger_ReleaseRecords(t *testing.T) {
+	ch, _ := channel.NewChannel()
+	defer ch.Close()
+	re, _ := NewRecordExchangerWithRecordClass(ch, &transform.NilTransformer{}, RecordClassIndex)
+	defer re.Shutdown()
+
+	r, _ := re.CreateRecord()
+	r.Add(element.NewDefaultColumn(element.NewStringColumnValue("x"), "a", 1))
+	if err := re.SendWriter(r); err != nil {
+		t.Errorf("SendWriter() error = %v", err)
+		return
+	}
+	got, err := re.GetFromReader()
+	if err != nil {
+		t.Errorf("GetFromReader() error = %v", err)
+		return
+	}
+	re.ReleaseRecords(got, element.GetTerminateRecord())
+
+	r, _ = re.CreateRecord()
+	index := r.(*element.IndexRecord).ColumnIndex()
+	if index == nil || index.Len() != 1 || index.Name(0) != "a" {
+		t.Errorf("CreateRecord() ColumnIndex() = %v, want [a]", index)
+	}
+
+	re = NewRecordExchangerWithoutTransformer(ch)
+	re.ReleaseRecords(element.NewDefaultRecord())
+}
+
+func testChecksumRecord(i int) element.Record {
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewInt64ColumnValue(int64(i)), "i", 8))
+	return r
+}
+
+func TestRecordExchanger_Checksum(t *testing.T) {
+	ch, _ := channel.NewChannel()
+	defer ch.Close()
+	re := NewRecordExchanger(ch, &mockRenameTransformer{})
+	defer re.Shutdown()
+
+	//未开启时不计算校验和
+	re.SendWriter(testChecksumRecord(0))
+	re.GetFromReader()
+	if got := re.SentChecksum(); got.Count() != 0 {
+		t.Errorf("SentChecksum() = %v, want empty", got)
+	}
+
+	re.EnableChecksum()
+	for i := 0; i < 3; i++ {
+		if err := re.SendWriter(testChecksumRecord(i)); err != nil {
+			t.Fatalf("SendWriter() error = %v", err)
+		}
+	}
+	//转化后计算校验和
+	sent := re.SentChecksum()
+	var want element.Checksum
+	for i := 0; i < 3; i++ {
+		want.Add(testChecksumRecord(i))
+	}
+	if sent.Count() != 3 || sent == want {
+		t.Errorf("SentChecksum() = %v, want transformed records", sent)
+	}
+
+	for i := 0; i < 3; i++ {
+		r, err := re.GetFromReader()
+		if err != nil {
+			t.Fatalf("GetFromReader() error = %v", err)
+		}
+		//写入器收到后转化记录不影响校验和
+		if err = r.Set(0, element.NewDefaultColumn(element.NewStringColumnValue("x"), "j", 0)); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		if i == 1 {
+			if got := re.ReceivedChecksum(); got.Count() != 2 || got == sent {
+				t.Errorf("ReceivedChecksum() = %v, want 2 records", got)
+			}
+		}
+	}
+	if got := re.ReceivedChecksum(); got != sent {
+		t.Errorf("ReceivedChecksum() = %v, want %v", got, sent)
+	}
+
+	//重新开启时重置
+	re.EnableChecksum()
+	if got := re.SentChecksum(); got.Count() != 0 {
+		t.Errorf("SentChecksum() = %v, want empty", got)
+	}
+	if got := re.ReceivedChecksum(); got.Count() != 0 {
+		t.Errorf("ReceivedChecksum() = %v, want empty", got)
+	}
+}
+
+//mockRenameTransformer 将第一列重命名为j的转化器
+type mockRenameTransformer struct{}
+
+func (m *mockRenameTransformer) DoTransform(record element.Record) (element.Record, error) {
+	c, err := record.GetByIndex(0)
+	if err != nil {
+		return nil, err
+	}
+	v, err := c.AsInt64()
+	if err != nil {
+		return nil, err
+	}
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewInt64ColumnValue(v), "j", 8))
+	return r, nil
+}
+
+type mockTransformer struct{}
+
+func (m *mockTransformer) DoTransform(record element.Record) (element.Record, error) {
+	return record, nil
+}
+
+type mockSchemaTransformer struct {
+	mockTransformer
+
+	err error
+}
+
+func (m *mockSchemaTransformer) TransformSchema(schema *element.Schema) (*element.Schema, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return element.NewSchema(append([]element.ColumnSchema{
+		{
+			Name: "id",
+			Type: element.TypeBigInt,
+		},
+	}, schema.Column(0)))
+}
+
+func TestRecordExchanger_SendSchema(t *testing.T) {
+	ch, _ := channel.NewChannel()
+	defer ch.Close()
+	schema, _ := element.NewSchema([]element.ColumnSchema{
+		{
+			Name: "a",
+			Type: element.TypeString,
+		},
+	})
+	tests := []struct {
+		name      string
+		tran      transform.Transformer
+		shutdown  bool
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			tran:      &transform.NilTransformer{},
+			wantNames: []string{"a"},
+		},
+		{
+			name:      "2",
+			tran:      &mockSchemaTransformer{},
+			wantNames: []string{"id", "a"},
+		},
+		{
+			name: "3",
+			tran: &mockTransformer{},
+		},
+		{
+			name: "4",
+			tran: &mockSchemaTransformer{
+				err: errors.New("mock error"),
+			},
+			wantErr: true,
+		},
+		{
+			name:     "5",
+			tran:     &transform.NilTransformer{},
+			shutdown: true,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, _ := NewRecordExchangerWithRecordClass(ch, tt.tran, RecordClassIndex)
+			if tt.shutdown {
+				re.Shutdown()
+			}
+			if err := re.SendSchema(schema); (err != nil) != tt.wantErr {
+				t.Errorf("SendSchema() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			r, _ := re.CreateRecord()
+			if got := r.(*element.IndexRecord).ColumnIndex(); got != schema.ColumnIndex() {
+				t.Errorf("CreateRecord() ColumnIndex() = %v, want %v", got, schema.ColumnIndex())
+			}
+
+			got := re.Schema()
+			if tt.wantNames == nil {
+				if got != nil {
+					t.Errorf("Schema() = %v, want nil", got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got.Names(), tt.wantNames) {
+				t.Errorf("Schema() = %v, want %v", got.Names(), tt.wantNames)
+			}
+		})
+	}
+}
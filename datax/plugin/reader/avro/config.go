@@ -0,0 +1,37 @@
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+type paramConfig struct {
+	streamfile.FileSystemConfig
+
+	Path   []string `json:"path"`   //文件路径，支持通配符，s3://<bucket>/<key>形式的路径从对象存储读取
+	Column []string `json:"column"` //读取的列名，嵌套记录中的列名以.连接，为空时读取所有列
+	Split  *split   `json:"split"`  //由Job.Split生成的切分配置
+}
+
+//split 切分，需要读取的文件
+type split struct {
+	Path string `json:"path"` //文件路径
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Path) == 0 {
+		return nil, fmt.Errorf("path is empty")
+	}
+	if err = c.FileSystemConfig.Validate(); err != nil {
+		return nil, err
+	}
+	return
+}
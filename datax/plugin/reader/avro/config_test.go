@@ -0,0 +1,62 @@
+package avro
+
+import (
+	"reflect"
+	"testing"
+
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    *paramConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"path":["a.avro"],"column":["a","b.c"],"split":{"path":"a.avro"}}`,
+			want: &paramConfig{
+				Path:   []string{"a.avro"},
+				Column: []string{"a", "b.c"},
+				Split:  &split{Path: "a.avro"},
+			},
+		},
+		{
+			name:    "2",
+			json:    `{"path":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"path":"a.avro"}`,
+			wantErr: true,
+		},
+		{
+			name: "4",
+			json: `{"path":["s3://bucket/a.avro"],"s3":{"region":"us-west-2"}}`,
+			want: &paramConfig{
+				FileSystemConfig: streamfile.FileSystemConfig{S3: &streamfile.S3Config{Region: "us-west-2"}},
+				Path:             []string{"s3://bucket/a.avro"},
+			},
+		},
+		{
+			name:    "5",
+			json:    `{"path":["s3://bucket/a.avro"],"s3":{"secretKey":"sk"}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newParamConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package avro
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+	streamavro "github.com/Breeze0806/go-etl/storage/stream/file/avro"
+)
+
+//Job 工作
+type Job struct {
+	*plugin.BaseJob
+
+	param     *paramConfig
+	fs        streamfile.FileSystem
+	filenames []string
+}
+
+//Init 初始化
+func (j *Job) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if j.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+	j.fs = streamfile.NewFileSystem(&j.param.FileSystemConfig)
+
+	if j.filenames, err = streamfile.Glob(j.fs, j.param.Path); err != nil {
+		return
+	}
+	return
+}
+
+//Destroy 销毁
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//Split 切分，每个文件一个任务
+func (j *Job) Split(ctx context.Context, number int) (confs []*config.JSON, err error) {
+	for _, filename := range j.filenames {
+		if err = j.checkColumns(filename); err != nil {
+			return nil, err
+		}
+
+		conf := j.PluginJobConf().CloneConfig()
+		if err = conf.Set(coreconst.DataxJobContentReaderParameter+".split", &split{Path: filename}); err != nil {
+			return nil, err
+		}
+		confs = append(confs, conf)
+	}
+	return
+}
+
+//checkColumns 检查文件filename中是否存在需要读取的列
+func (j *Job) checkColumns(filename string) (err error) {
+	var r *streamavro.Reader
+	if r, err = streamavro.NewReader(j.fs, filename, j.param.Column); err != nil {
+		return
+	}
+	return r.Close()
+}
@@ -0,0 +1,107 @@
+package avro
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+)
+
+func testJob(t *testing.T, param string) *Job {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	j.SetPluginJobConf(testJobConf(param))
+	if err := j.Init(context.TODO()); err != nil {
+		t.Fatalf("Job.Init() error = %v", err)
+	}
+	return j
+}
+
+func TestJob_Init(t *testing.T) {
+	dir := testTempDir(t)
+	testWriteAvro(t, dir, "a.avro", testManyRecords(1)...)
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.avro") + `"]}`),
+		},
+		{
+			name:    "2",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.csv") + `"]}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJobConf(`{"path":[]}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			jobConf: testJSONFromString(`{}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				BaseJob: plugin.NewBaseJob(),
+			}
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJob_Split(t *testing.T) {
+	dir := testTempDir(t)
+	a := testWriteAvro(t, dir, "a.avro", testManyRecords(1000)...)
+	b := testWriteAvro(t, dir, "b.avro", testManyRecords(1)...)
+
+	j := testJob(t, `{"path":["`+filepath.Join(dir, "*.avro")+`"],"column":["id"]}`)
+	confs, err := j.Split(context.TODO(), 1)
+	if err != nil {
+		t.Fatalf("Job.Split() error = %v", err)
+	}
+
+	var got []split
+	for _, conf := range confs {
+		var sc *config.JSON
+		if sc, err = conf.GetConfig(coreconst.DataxJobContentReaderParameter + ".split"); err != nil {
+			t.Fatal(err)
+		}
+		s := split{}
+		if err = json.Unmarshal([]byte(sc.String()), &s); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, s)
+	}
+	if want := []split{{Path: a}, {Path: b}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Job.Split() = %v, want %v", got, want)
+	}
+
+	j = testJob(t, `{"path":["`+a+`"],"column":["none"]}`)
+	if _, err = j.Split(context.TODO(), 1); err == nil {
+		t.Errorf("Job.Split() error = nil, wantErr true")
+	}
+}
+
+func TestJob_Destroy(t *testing.T) {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	if err := j.Destroy(context.TODO()); err != nil {
+		t.Errorf("Job.Destroy() error = %v", err)
+	}
+}
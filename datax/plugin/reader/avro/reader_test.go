@@ -0,0 +1,109 @@
+package avro
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testReader(filename string) *Reader {
+	reader, err := NewReader(filename)
+	if err != nil {
+		panic(err)
+	}
+	return reader
+}
+
+func testJSONFromFile(filename string) *config.JSON {
+	conf, err := config.NewJSONFromFile(filename)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func TestReader_Job(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *Reader
+		conf *config.JSON
+	}{
+		{
+			name: "1",
+			r:    testReader(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Job(); !reflect.DeepEqual(got.PluginConf(), tt.conf) {
+				t.Errorf("Reader.Job().PluginConf() = %v, want %v", got.PluginConf(), tt.conf)
+			}
+		})
+	}
+}
+
+func TestReader_Task(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *Reader
+		conf *config.JSON
+	}{
+		{
+			name: "1",
+			r:    testReader(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Task(); !reflect.DeepEqual(got.PluginConf(), tt.conf) {
+				t.Errorf("Reader.Task().PluginConf() = %v, want %v", got.PluginConf(), tt.conf)
+			}
+		})
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	type args struct {
+		filename string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantConf *config.JSON
+		wantErr  bool
+	}{
+		{
+			name: "1",
+			args: args{
+				filename: filepath.Join("resources", "plugin.json"),
+			},
+			wantConf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+		},
+		{
+			name: "2",
+			args: args{
+				filename: filepath.Join("tmpresources", "tmpplugin.json"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotR, err := NewReader(tt.args.filename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewReader() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(gotR.PluginConf(), tt.wantConf) {
+				t.Errorf("NewReader().PluginConf() = %v, want %v", gotR.PluginConf(), tt.wantConf)
+			}
+		})
+	}
+}
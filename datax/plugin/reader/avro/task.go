@@ -0,0 +1,95 @@
+package avro
+
+import (
+	"context"
+	"io"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+	streamavro "github.com/Breeze0806/go-etl/storage/stream/file/avro"
+)
+
+//Task 任务
+type Task struct {
+	*plugin.BaseTask
+
+	param *paramConfig
+	fs    streamfile.FileSystem
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+	t.fs = streamfile.NewFileSystem(&t.param.FileSystemConfig)
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//StartRead 开始读，在没有切分配置时读取所有匹配的文件
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	var filenames []string
+	if t.param.Split != nil {
+		filenames = []string{t.param.Split.Path}
+	} else if filenames, err = streamfile.Glob(t.fs, t.param.Path); err != nil {
+		return
+	}
+
+	for _, v := range filenames {
+		if err = t.readFile(ctx, v, sender); err != nil {
+			return
+		}
+	}
+	return sender.Terminate()
+}
+
+//readFile 读取文件filename中的记录并发往写入器
+func (t *Task) readFile(ctx context.Context, filename string, sender plugin.RecordSender) (err error) {
+	var r *streamavro.Reader
+	if r, err = streamavro.NewReader(t.fs, filename, t.param.Column); err != nil {
+		return
+	}
+	defer r.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var columns []element.Column
+		if columns, err = r.Read(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return
+		}
+
+		var record element.Record
+		if record, err = sender.CreateRecord(); err != nil {
+			return
+		}
+		for _, c := range columns {
+			if err = record.Add(c); err != nil {
+				return
+			}
+		}
+		if err = sender.SendWriter(record); err != nil {
+			return
+		}
+	}
+}
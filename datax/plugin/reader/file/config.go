@@ -0,0 +1,107 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/Breeze0806/go-etl/config"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+//Config 文件读取配置，可以内嵌在具体文件读取器的参数配置中
+type Config struct {
+	streamfile.FileSystemConfig
+
+	Path      []string            `json:"path"`      //文件路径，支持通配符，s3://<bucket>/<key>形式的路径从对象存储读取
+	Encoding  string              `json:"encoding"`  //文件编码，支持utf-8和gbk，默认为utf-8
+	Compress  streamfile.Compress `json:"compress"`  //压缩格式，支持gzip，bzip2，zip，默认不压缩
+	SplitSize int64               `json:"splitSize"` //未压缩文件的切分大小，单位字节，小于等于0时不切分
+	Split     *Split              `json:"split"`     //由Job.Split生成的切分配置
+
+	fs streamfile.FileSystem
+}
+
+//Split 切分，文件Path中的字节范围，字节范围为空时代表整个文件
+type Split struct {
+	Path string `json:"path"` //文件路径
+	streamfile.Range
+}
+
+//NewConfig 通过读取器参数配置conf获取文件读取配置，配置不合法时会报错
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.Validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//Validate 校验配置
+func (c *Config) Validate() (err error) {
+	if len(c.Path) == 0 {
+		return fmt.Errorf("path is empty")
+	}
+
+	if !streamfile.IsValidEncoding(c.Encoding) {
+		return fmt.Errorf("encoding(%v) is not supported", c.Encoding)
+	}
+
+	if !c.Compress.IsValid() {
+		return fmt.Errorf("compress(%v) is not supported", c.Compress)
+	}
+	return c.FileSystemConfig.Validate()
+}
+
+//FileSystem 获取文件系统，按照路径的前缀选择本地文件系统或者对象存储
+func (c *Config) FileSystem() streamfile.FileSystem {
+	if c.fs == nil {
+		c.fs = streamfile.NewFileSystem(&c.FileSystemConfig)
+	}
+	return c.fs
+}
+
+//Splits 获取需要读取的切分，没有切分配置时返回所有匹配的文件
+func (c *Config) Splits() (splits []Split, err error) {
+	if c.Split != nil {
+		return []Split{*c.Split}, nil
+	}
+
+	var filenames []string
+	if filenames, err = streamfile.Glob(c.FileSystem(), c.Path); err != nil {
+		return
+	}
+	for _, v := range filenames {
+		splits = append(splits, Split{Path: v})
+	}
+	return
+}
+
+//Open 打开切分s，返回解压并转化为utf-8编码的数据流
+func (c *Config) Open(s Split) (rc io.ReadCloser, err error) {
+	var fr io.ReadCloser
+	if s.Range == (streamfile.Range{}) {
+		fr, err = streamfile.Open(c.FileSystem(), s.Path, c.Compress)
+	} else {
+		fr, err = streamfile.NewRangeReader(c.FileSystem(), s.Path, s.Range)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open file(%v) err: %v", s.Path, err)
+	}
+
+	var r io.Reader
+	if r, err = streamfile.NewDecodeReader(fr, c.Encoding); err != nil {
+		fr.Close()
+		return nil, err
+	}
+	return &readCloser{Reader: r, Closer: fr}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,176 @@
+package file
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"path":["a.csv"],"encoding":"gbk","compress":"gzip","splitSize":10}`,
+		},
+		{
+			name:    "2",
+			json:    `{"path":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"path":["a.csv"],"encoding":"big5"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"path":["a.csv"],"compress":"lzo"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"path":"a.csv"}`,
+			wantErr: true,
+		},
+		{
+			name: "6",
+			json: `{"path":["s3://bucket/a.csv"],"s3":{"endpoint":"http://127.0.0.1:9000","accessKey":"ak","secretKey":"sk","pathStyle":true}}`,
+		},
+		{
+			name:    "7",
+			json:    `{"path":["s3://bucket/a.csv"],"s3":{"accessKey":"ak"}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Splits(t *testing.T) {
+	dir := testTempDir(t)
+	a := testWriteFile(t, dir, "a.csv", "")
+	b := testWriteFile(t, dir, "b.csv", "")
+	tests := []struct {
+		name    string
+		c       *Config
+		want    []Split
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    &Config{Path: []string{filepath.Join(dir, "*.csv")}},
+			want: []Split{{Path: a}, {Path: b}},
+		},
+		{
+			name: "2",
+			c: &Config{
+				Path:  []string{filepath.Join(dir, "*.csv")},
+				Split: &Split{Path: b, Range: streamfile.Range{Start: 1, End: 2}},
+			},
+			want: []Split{{Path: b, Range: streamfile.Range{Start: 1, End: 2}}},
+		},
+		{
+			name:    "3",
+			c:       &Config{Path: []string{filepath.Join(dir, "*.json")}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.Splits()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Config.Splits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Config.Splits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_Open(t *testing.T) {
+	dir := testTempDir(t)
+	a := testWriteFile(t, dir, "a.csv", "a\nb\nc\n")
+	g := filepath.Join(dir, "b.csv.gz")
+	f, err := os.Create(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gw := gzip.NewWriter(f)
+	gw.Write([]byte{0xd6, 0xd0, 0xce, 0xc4, '\n'})
+	gw.Close()
+	f.Close()
+
+	tests := []struct {
+		name    string
+		c       *Config
+		s       Split
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    &Config{},
+			s:    Split{Path: a},
+			want: "a\nb\nc\n",
+		},
+		{
+			name: "2",
+			c:    &Config{},
+			s:    Split{Path: a, Range: streamfile.Range{Start: 1, End: 4}},
+			want: "b\n",
+		},
+		{
+			name: "3",
+			c:    &Config{Compress: streamfile.CompressGzip, Encoding: "gbk"},
+			s:    Split{Path: g},
+			want: "中文\n",
+		},
+		{
+			name:    "4",
+			c:       &Config{},
+			s:       Split{Path: filepath.Join(dir, "not_exist.csv")},
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			c:       &Config{Encoding: "big5"},
+			s:       Split{Path: a},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, err := tt.c.Open(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Config.Open() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer rc.Close()
+			got, err := ioutil.ReadAll(rc)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Config.Open() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Package file 实现了按行读取文本文件的通用读取工作，供txtfilereader，jsonlreader等
+文件读取器使用
+
+读取工作会通过通配符匹配path中的所有文件，每个文件切分成一个任务，未压缩的文件在配置了
+splitSize时按照字节范围切分成多个任务，切分结果以split的形式写入读取器参数配置中：
+
+	{
+	    "path":["/data/*.csv"],
+	    "splitSize":67108864,
+	    "split":{
+	        "path":"/data/a.csv",
+	        "start":0,
+	        "end":67108864
+	    }
+	}
+
+读取任务通过Config.Splits获取需要读取的切分，并通过Config.Open打开切分后读取，
+每个切分都从完整的行开始，并以完整的行结束，由于切分只按照换行符对齐，
+字段中可能含有换行符的文件（如带引号的CSV）不能切分
+
+path也可以是s3://<bucket>/<key>形式的s3兼容对象存储路径，通配符只能出现在key中，
+对象存储的访问地址和凭证在s3中配置，没有配置凭证时从环境变量和共享凭证文件中获取：
+
+	{
+	    "path":["s3://bucket/data/*.csv"],
+	    "s3":{
+	        "endpoint":"http://127.0.0.1:9000",
+	        "region":"us-east-1",
+	        "accessKey":"",
+	        "secretKey":"",
+	        "pathStyle":true
+	    }
+	}
+*/
+package file
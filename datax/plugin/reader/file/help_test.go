@@ -0,0 +1,53 @@
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func testTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+func testWriteFile(t *testing.T, dir, name string, data string) string {
+	filename := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(filename, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+//testJobConf 生成读取器参数为param的工作配置
+func testJobConf(param string) *config.JSON {
+	return testJSONFromString(`{
+		"job":{
+			"content":[
+				{
+					"reader": {
+						"name":"txtfilereader",
+						"parameter":` + param + `
+					}
+				}
+			]
+		}
+	}`)
+}
@@ -0,0 +1,74 @@
+package file
+
+import (
+	"context"
+	"os"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+//Job 文件读取工作
+type Job struct {
+	*plugin.BaseJob
+
+	conf      *Config
+	filenames []string
+}
+
+//NewJob 创建文件读取工作
+func NewJob() *Job {
+	return &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+}
+
+//Init 初始化
+func (j *Job) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if j.conf, err = NewConfig(paramConf); err != nil {
+		return
+	}
+
+	if j.filenames, err = streamfile.Glob(j.conf.FileSystem(), j.conf.Path); err != nil {
+		return
+	}
+	return
+}
+
+//Destroy 销毁
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//Split 切分，每个文件一个任务，未压缩的文件在配置了splitSize时按照字节范围切分成多个任务
+func (j *Job) Split(ctx context.Context, number int) (confs []*config.JSON, err error) {
+	for _, filename := range j.filenames {
+		ranges := []streamfile.Range{{}}
+		if j.conf.Compress == streamfile.CompressNone && j.conf.SplitSize > 0 {
+			var fi os.FileInfo
+			if fi, err = j.conf.FileSystem().Stat(filename); err != nil {
+				return nil, err
+			}
+			ranges = streamfile.SplitRanges(fi.Size(), j.conf.SplitSize)
+		}
+
+		for _, rng := range ranges {
+			conf := j.PluginJobConf().CloneConfig()
+			if err = conf.Set(coreconst.DataxJobContentReaderParameter+".split", &Split{
+				Path:  filename,
+				Range: rng,
+			}); err != nil {
+				return nil, err
+			}
+			confs = append(confs, conf)
+		}
+	}
+	return
+}
@@ -0,0 +1,127 @@
+package file
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+)
+
+func TestJob_Init(t *testing.T) {
+	dir := testTempDir(t)
+	testWriteFile(t, dir, "a.csv", "1,2\n")
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.csv") + `"]}`),
+		},
+		{
+			name:    "2",
+			jobConf: testJSONFromString(`{}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJobConf(`{"path":[]}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.json") + `"]}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJob()
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJob_Split(t *testing.T) {
+	dir := testTempDir(t)
+	a := testWriteFile(t, dir, "a.csv", "1,2\n3,4\n5,6\n")
+	b := testWriteFile(t, dir, "b.csv", "1,2\n")
+	tests := []struct {
+		name    string
+		param   string
+		want    []Split
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":["` + filepath.Join(dir, "*.csv") + `"]}`,
+			want: []Split{
+				{Path: a},
+				{Path: b},
+			},
+		},
+		{
+			name:  "2",
+			param: `{"path":["` + filepath.Join(dir, "*.csv") + `"],"splitSize":5}`,
+			want: []Split{
+				{Path: a},
+				{Path: a},
+				{Path: a},
+				{Path: b},
+			},
+		},
+		{
+			name:  "3",
+			param: `{"path":["` + filepath.Join(dir, "*.csv") + `"],"splitSize":5,"compress":"gzip"}`,
+			want: []Split{
+				{Path: a},
+				{Path: b},
+			},
+		},
+	}
+	tests[1].want[0].Range.End = 5
+	tests[1].want[1].Range.Start, tests[1].want[1].Range.End = 5, 10
+	tests[1].want[2].Range.Start = 10
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJob()
+			j.SetPluginJobConf(testJobConf(tt.param))
+			if err := j.Init(context.TODO()); err != nil {
+				t.Fatalf("Job.Init() error = %v", err)
+			}
+			confs, err := j.Split(context.TODO(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Job.Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []Split
+			for _, conf := range confs {
+				paramConf, err := conf.GetConfig(coreconst.DataxJobContentReaderParameter)
+				if err != nil {
+					t.Fatalf("GetConfig() error = %v", err)
+				}
+				p, err := NewConfig(paramConf)
+				if err != nil {
+					t.Fatalf("NewConfig() error = %v", err)
+				}
+				got = append(got, *p.Split)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.Split() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_Destroy(t *testing.T) {
+	j := NewJob()
+	if err := j.Destroy(context.TODO()); err != nil {
+		t.Errorf("Job.Destroy() error = %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/tidwall/gjson"
+)
+
+//columnValue 将JSON值v按照列配置转化为列值，不存在的值和null会转化为对应类型的空值，
+//数字按照原始文本转化，整数和高精度实数不会损失精度
+func (c *columnConfig) columnValue(v gjson.Result) (element.ColumnValue, error) {
+	typ := c.columnType()
+	if !v.Exists() || v.Type == gjson.Null {
+		return element.NewNilColumnValue(typ), nil
+	}
+
+	switch typ {
+	case element.TypeBool:
+		switch v.Type {
+		case gjson.True, gjson.False:
+			return element.NewBoolColumnValue(v.Bool()), nil
+		case gjson.String:
+			b, err := strconv.ParseBool(v.Str)
+			if err != nil {
+				return nil, err
+			}
+			return element.NewBoolColumnValue(b), nil
+		}
+	case element.TypeBigInt:
+		switch v.Type {
+		case gjson.Number:
+			return element.NewBigIntColumnValueFromString(v.Raw)
+		case gjson.String:
+			return element.NewBigIntColumnValueFromString(v.Str)
+		}
+	case element.TypeDecimal:
+		switch v.Type {
+		case gjson.Number:
+			return element.NewDecimalColumnValueFromString(v.Raw)
+		case gjson.String:
+			return element.NewDecimalColumnValueFromString(v.Str)
+		}
+	case element.TypeString:
+		return element.NewStringColumnValue(text(v)), nil
+	case element.TypeBytes:
+		return element.NewBytesColumnValue([]byte(text(v))), nil
+	case element.TypeTime:
+		if v.Type == gjson.String {
+			layout := c.layout()
+			t, err := time.Parse(layout, v.Str)
+			if err != nil {
+				return nil, err
+			}
+			return element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(layout)), nil
+		}
+	}
+	return nil, fmt.Errorf("%v can not convert to %v", v.Raw, typ)
+}
+
+//text 获取JSON值v的文本，字符串为其内容，其他为原始的JSON文本
+func text(v gjson.Result) string {
+	if v.Type == gjson.String {
+		return v.Str
+	}
+	return v.Raw
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/tidwall/gjson"
+)
+
+func TestColumnConfig_columnValue(t *testing.T) {
+	json := `{"s":"abc","n":12345678901234567890123,"d":0.10000000000000000001,"b":true,
+		"bs":"false","ns":"42","ds":"1.5","t":"2021-02-03","o":{"x":[1,2]},"nil":null}`
+	tests := []struct {
+		name     string
+		c        *columnConfig
+		wantType element.ColumnType
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			c:        &columnConfig{Path: "s"},
+			wantType: element.TypeString,
+			want:     "abc",
+		},
+		{
+			name:     "2",
+			c:        &columnConfig{Path: "n", Type: element.TypeBigInt},
+			wantType: element.TypeBigInt,
+			want:     "12345678901234567890123",
+		},
+		{
+			name:     "3",
+			c:        &columnConfig{Path: "d", Type: element.TypeDecimal},
+			wantType: element.TypeDecimal,
+			want:     "0.10000000000000000001",
+		},
+		{
+			name:     "4",
+			c:        &columnConfig{Path: "b", Type: element.TypeBool},
+			wantType: element.TypeBool,
+			want:     "true",
+		},
+		{
+			name:     "5",
+			c:        &columnConfig{Path: "bs", Type: element.TypeBool},
+			wantType: element.TypeBool,
+			want:     "false",
+		},
+		{
+			name:     "6",
+			c:        &columnConfig{Path: "ns", Type: element.TypeBigInt},
+			wantType: element.TypeBigInt,
+			want:     "42",
+		},
+		{
+			name:     "7",
+			c:        &columnConfig{Path: "ds", Type: element.TypeDecimal},
+			wantType: element.TypeDecimal,
+			want:     "1.5",
+		},
+		{
+			name:     "8",
+			c:        &columnConfig{Path: "t", Type: element.TypeTime, Format: "2006-01-02"},
+			wantType: element.TypeTime,
+			want:     "2021-02-03T00:00:00Z",
+		},
+		{
+			name:     "9",
+			c:        &columnConfig{Path: "o"},
+			wantType: element.TypeString,
+			want:     `{"x":[1,2]}`,
+		},
+		{
+			name:     "10",
+			c:        &columnConfig{Path: "o.x.1", Type: element.TypeBytes},
+			wantType: element.TypeBytes,
+			want:     "2",
+		},
+		{
+			name:     "11",
+			c:        &columnConfig{Path: "nil", Type: element.TypeBigInt},
+			wantType: element.TypeBigInt,
+			want:     "<nil>",
+		},
+		{
+			name:     "12",
+			c:        &columnConfig{Path: "not.exist", Type: element.TypeTime},
+			wantType: element.TypeTime,
+			want:     "<nil>",
+		},
+		{
+			name:    "13",
+			c:       &columnConfig{Path: "d", Type: element.TypeBigInt},
+			wantErr: true,
+		},
+		{
+			name:    "14",
+			c:       &columnConfig{Path: "o", Type: element.TypeDecimal},
+			wantErr: true,
+		},
+		{
+			name:    "15",
+			c:       &columnConfig{Path: "s", Type: element.TypeBool},
+			wantErr: true,
+		},
+		{
+			name:    "16",
+			c:       &columnConfig{Path: "n", Type: element.TypeTime},
+			wantErr: true,
+		},
+		{
+			name:    "17",
+			c:       &columnConfig{Path: "s", Type: element.TypeTime},
+			wantErr: true,
+		},
+		{
+			name:    "18",
+			c:       &columnConfig{Path: "b", Type: element.TypeBigInt},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.columnValue(gjson.Get(json, tt.c.Path))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("columnConfig.columnValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Type() != tt.wantType {
+				t.Errorf("columnConfig.columnValue() type = %v, want %v", got.Type(), tt.wantType)
+			}
+			if got.String() != tt.want {
+				t.Errorf("columnConfig.columnValue() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//分页方式
+const (
+	PaginationNone   = ""       //不分页
+	PaginationPage   = "page"   //页码
+	PaginationOffset = "offset" //偏移量
+	PaginationCursor = "cursor" //游标
+	PaginationLink   = "link"   //响应头Link中rel="next"的地址
+)
+
+//分页参数的位置
+const (
+	InQuery = "query" //查询参数，默认的位置
+	InBody  = "body"  //JSON请求体
+)
+
+//默认值
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 3
+	defaultWait       = time.Second
+	defaultMaxWait    = 30 * time.Second
+)
+
+type paramConfig struct {
+	URL        string            `json:"url"`        //请求地址
+	Method     string            `json:"method"`     //请求方法，支持GET和POST，默认为GET
+	Header     map[string]string `json:"header"`     //请求头
+	Query      map[string]string `json:"query"`      //查询参数
+	Body       string            `json:"body"`       //请求体，分页参数放在请求体中时必须是JSON对象
+	DataPath   string            `json:"dataPath"`   //响应中对象数组的JSON路径，如data.items，为空时整个响应为对象数组
+	Column     []columnConfig    `json:"column"`     //列配置，路径相对于数组中的每个对象
+	Pagination pagination        `json:"pagination"` //分页配置
+	Retry      retryConfig       `json:"retry"`      //重试配置
+	Timeout    string            `json:"timeout"`    //单次请求的超时时间，使用go的时间间隔格式，默认为30s
+	RateLimit  float64           `json:"rateLimit"`  //每秒最多的请求数，小于等于0时不限制
+}
+
+type columnConfig struct {
+	Path   string             `json:"path"`   //JSON对象中的路径，如a.b.c，数组可以用序号访问，如a.0
+	Name   string             `json:"name"`   //列名，默认为path
+	Type   element.ColumnType `json:"type"`   //列类型，默认为字符串
+	Format string             `json:"format"` //时间格式，使用go的时间格式，默认为time.RFC3339Nano
+	Value  *string            `json:"value"`  //常量值，设置后不从JSON对象中读取
+}
+
+//pagination 分页配置，页码和偏移量分页在返回的对象数组为空或者记录数小于每页记录数时结束，
+//游标分页在下一页游标为空时结束，Link分页在没有下一页地址时结束
+type pagination struct {
+	Type        string `json:"type"`        //分页方式，支持page，offset，cursor，link，为空时不分页
+	In          string `json:"in"`          //分页参数的位置，支持query和body，默认为query
+	PageParam   string `json:"pageParam"`   //页码参数名，默认为page
+	StartPage   *int   `json:"startPage"`   //起始页码，默认为1
+	OffsetParam string `json:"offsetParam"` //偏移量参数名，默认为offset
+	SizeParam   string `json:"sizeParam"`   //每页记录数参数名，页码分页默认为size，偏移量分页默认为limit
+	Size        int    `json:"size"`        //每页记录数，大于0时作为参数发送
+	CursorParam string `json:"cursorParam"` //游标参数名，默认为cursor
+	CursorPath  string `json:"cursorPath"`  //响应中下一页游标的JSON路径
+	MaxPages    int    `json:"maxPages"`    //最多请求的页数，小于等于0时不限制
+}
+
+//retryConfig 重试配置，在网络错误，429和5xx时重试，等待时间从wait开始每次翻倍，
+//响应头中有Retry-After时按照其等待
+type retryConfig struct {
+	MaxRetries *int   `json:"maxRetries"` //最大重试次数，默认为3
+	Wait       string `json:"wait"`       //首次重试的等待时间，使用go的时间间隔格式，默认为1s
+	MaxWait    string `json:"maxWait"`    //最长的等待时间，使用go的时间间隔格式，默认为30s
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	var u *url.URL
+	if u, err = url.Parse(p.URL); err != nil {
+		return fmt.Errorf("url(%v) err: %v", p.URL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url(%v) is not http or https", p.URL)
+	}
+
+	switch p.method() {
+	case http.MethodGet, http.MethodPost:
+	default:
+		return fmt.Errorf("method(%v) is not supported", p.Method)
+	}
+
+	if len(p.Column) == 0 {
+		return fmt.Errorf("column is empty")
+	}
+
+	for i, v := range p.Column {
+		if v.Path == "" && v.Value == nil {
+			return fmt.Errorf("column(%v) path and value are both empty", i)
+		}
+		if v.Path == "" && v.Name == "" {
+			return fmt.Errorf("column(%v) name of constant value is empty", i)
+		}
+		switch v.Type {
+		case "", element.TypeBool, element.TypeBigInt, element.TypeDecimal,
+			element.TypeString, element.TypeBytes, element.TypeTime:
+		default:
+			return fmt.Errorf("column(%v) type(%v) is not supported", i, v.Type)
+		}
+	}
+
+	if err = p.Pagination.validate(); err != nil {
+		return
+	}
+	if p.Pagination.in() == InBody {
+		if _, err = p.body(); err != nil {
+			return
+		}
+	}
+
+	if _, err = p.timeout(); err != nil {
+		return
+	}
+	if _, _, _, err = p.Retry.options(); err != nil {
+		return
+	}
+	return
+}
+
+//method 获取请求方法，默认为GET
+func (p *paramConfig) method() string {
+	if p.Method == "" {
+		return http.MethodGet
+	}
+	return p.Method
+}
+
+//body 将请求体解析为JSON对象，请求体为空时返回空对象
+func (p *paramConfig) body() (body map[string]interface{}, err error) {
+	body = make(map[string]interface{})
+	if p.Body == "" {
+		return
+	}
+	if err = json.Unmarshal([]byte(p.Body), &body); err != nil {
+		return nil, fmt.Errorf("body(%v) is not json object: %v", p.Body, err)
+	}
+	return
+}
+
+//timeout 获取单次请求的超时时间，默认为30s
+func (p *paramConfig) timeout() (time.Duration, error) {
+	return parseDuration("timeout", p.Timeout, defaultTimeout)
+}
+
+//interval 获取两次请求之间的最小间隔
+func (p *paramConfig) interval() time.Duration {
+	if p.RateLimit <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / p.RateLimit)
+}
+
+func (p *pagination) validate() error {
+	switch p.Type {
+	case PaginationNone, PaginationPage, PaginationOffset, PaginationLink:
+	case PaginationCursor:
+		if p.CursorPath == "" {
+			return fmt.Errorf("cursorPath is empty")
+		}
+	default:
+		return fmt.Errorf("pagination type(%v) is not supported", p.Type)
+	}
+
+	switch p.In {
+	case "", InQuery, InBody:
+	default:
+		return fmt.Errorf("pagination in(%v) is not supported", p.In)
+	}
+	if p.Size < 0 {
+		return fmt.Errorf("pagination size(%v) is less than 0", p.Size)
+	}
+	return nil
+}
+
+//in 获取分页参数的位置，默认为query
+func (p *pagination) in() string {
+	if p.In == "" {
+		return InQuery
+	}
+	return p.In
+}
+
+//startPage 获取起始页码，默认为1
+func (p *pagination) startPage() int {
+	if p.StartPage == nil {
+		return 1
+	}
+	return *p.StartPage
+}
+
+//param 获取分页方式对应的参数名，默认为分页方式名
+func (p *pagination) param() string {
+	switch p.Type {
+	case PaginationPage:
+		return defaultString(p.PageParam, "page")
+	case PaginationOffset:
+		return defaultString(p.OffsetParam, "offset")
+	case PaginationCursor:
+		return defaultString(p.CursorParam, "cursor")
+	}
+	return ""
+}
+
+//sizeParam 获取每页记录数参数名，页码分页默认为size，偏移量分页默认为limit
+func (p *pagination) sizeParam() string {
+	if p.Type == PaginationOffset {
+		return defaultString(p.SizeParam, "limit")
+	}
+	return defaultString(p.SizeParam, "size")
+}
+
+//options 获取最大重试次数，首次重试的等待时间和最长的等待时间
+func (r *retryConfig) options() (maxRetries int, wait, maxWait time.Duration, err error) {
+	maxRetries = defaultMaxRetries
+	if r.MaxRetries != nil {
+		if maxRetries = *r.MaxRetries; maxRetries < 0 {
+			return 0, 0, 0, fmt.Errorf("maxRetries(%v) is less than 0", maxRetries)
+		}
+	}
+	if wait, err = parseDuration("wait", r.Wait, defaultWait); err != nil {
+		return
+	}
+	if maxWait, err = parseDuration("maxWait", r.MaxWait, defaultMaxWait); err != nil {
+		return
+	}
+	return
+}
+
+//name 获取列名，默认为path
+func (c *columnConfig) name() string {
+	if c.Name == "" {
+		return c.Path
+	}
+	return c.Name
+}
+
+//columnType 获取列类型，默认为字符串
+func (c *columnConfig) columnType() element.ColumnType {
+	if c.Type == "" {
+		return element.TypeString
+	}
+	return c.Type
+}
+
+//layout 获取时间格式，默认为time.RFC3339Nano
+func (c *columnConfig) layout() string {
+	if c.Format == "" {
+		return time.RFC3339Nano
+	}
+	return c.Format
+}
+
+//parseDuration 将名为name的时间间隔s转化为正的时间间隔，s为空时使用默认值def
+func parseDuration(name, s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%v(%v) err: %v", name, s, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%v(%v) is not positive", name, s)
+	}
+	return d, nil
+}
+
+//defaultString s为空时返回默认值def
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
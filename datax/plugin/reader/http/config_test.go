@@ -0,0 +1,179 @@
+package http
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"url":"http://localhost/a","method":"POST","body":"{\"a\":1}","column":[{"path":"a"}],` +
+				`"pagination":{"type":"cursor","in":"body","cursorPath":"next"},"retry":{"maxRetries":0,"wait":"1ms"},"timeout":"1s"}`,
+		},
+		{
+			name:    "2",
+			json:    `{"url":"ftp://localhost/a","column":[{"path":"a"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"url":"http://localhost/%zz","column":[{"path":"a"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"url":"http://localhost/a","method":"PUT","column":[{"path":"a"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"url":"http://localhost/a","column":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"url":"http://localhost/a","column":[{"name":"a"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"url":"http://localhost/a","column":[{"value":"a"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"url":"http://localhost/a","column":[{"path":"a","type":"mock"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			json:    `{"url":"http://localhost/a","column":[{"path":"a"}],"pagination":{"type":"mock"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			json:    `{"url":"http://localhost/a","column":[{"path":"a"}],"pagination":{"type":"cursor"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			json:    `{"url":"http://localhost/a","column":[{"path":"a"}],"pagination":{"type":"page","in":"header"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "12",
+			json:    `{"url":"http://localhost/a","column":[{"path":"a"}],"pagination":{"type":"page","size":-1}}`,
+			wantErr: true,
+		},
+		{
+			name:    "13",
+			json:    `{"url":"http://localhost/a","body":"[1]","column":[{"path":"a"}],"pagination":{"type":"page","in":"body"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "14",
+			json:    `{"url":"http://localhost/a","column":[{"path":"a"}],"timeout":"-1s"}`,
+			wantErr: true,
+		},
+		{
+			name:    "15",
+			json:    `{"url":"http://localhost/a","column":[{"path":"a"}],"retry":{"maxRetries":-1}}`,
+			wantErr: true,
+		},
+		{
+			name:    "16",
+			json:    `{"url":"http://localhost/a","column":[{"path":"a"}],"retry":{"wait":"1x"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "17",
+			json:    `{"url":"http://localhost/a","column":[{"path":"a"}],"retry":{"maxWait":"0s"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "18",
+			json:    `{"url":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_defaults(t *testing.T) {
+	p := &paramConfig{}
+	if got := p.method(); got != "GET" {
+		t.Errorf("paramConfig.method() = %v, want GET", got)
+	}
+	if got, _ := p.timeout(); got != defaultTimeout {
+		t.Errorf("paramConfig.timeout() = %v, want %v", got, defaultTimeout)
+	}
+	if got := p.interval(); got != 0 {
+		t.Errorf("paramConfig.interval() = %v, want 0", got)
+	}
+	p.RateLimit = 4
+	if got := p.interval(); got != 250*time.Millisecond {
+		t.Errorf("paramConfig.interval() = %v, want %v", got, 250*time.Millisecond)
+	}
+
+	maxRetries, wait, maxWait, _ := p.Retry.options()
+	if maxRetries != defaultMaxRetries || wait != defaultWait || maxWait != defaultMaxWait {
+		t.Errorf("retryConfig.options() = %v %v %v", maxRetries, wait, maxWait)
+	}
+}
+
+func TestPagination_params(t *testing.T) {
+	tests := []struct {
+		name          string
+		p             pagination
+		wantParam     string
+		wantSizeParam string
+	}{
+		{
+			name:          "1",
+			p:             pagination{Type: PaginationPage},
+			wantParam:     "page",
+			wantSizeParam: "size",
+		},
+		{
+			name:          "2",
+			p:             pagination{Type: PaginationOffset},
+			wantParam:     "offset",
+			wantSizeParam: "limit",
+		},
+		{
+			name:          "3",
+			p:             pagination{Type: PaginationCursor, CursorParam: "after", SizeParam: "n"},
+			wantParam:     "after",
+			wantSizeParam: "n",
+		},
+		{
+			name:          "4",
+			p:             pagination{Type: PaginationLink},
+			wantParam:     "",
+			wantSizeParam: "size",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.param(); got != tt.wantParam {
+				t.Errorf("pagination.param() = %v, want %v", got, tt.wantParam)
+			}
+			if got := tt.p.sizeParam(); got != tt.wantSizeParam {
+				t.Errorf("pagination.sizeParam() = %v, want %v", got, tt.wantSizeParam)
+			}
+		})
+	}
+}
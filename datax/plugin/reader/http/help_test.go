@@ -0,0 +1,78 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockSender struct {
+	createErr  error
+	sendErr    error
+	records    []element.Record
+	terminated bool
+}
+
+func (m *mockSender) CreateRecord() (element.Record, error) {
+	return element.NewDefaultRecord(), m.createErr
+}
+
+func (m *mockSender) SendWriter(record element.Record) error {
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *mockSender) Flush() error {
+	return nil
+}
+
+func (m *mockSender) Terminate() error {
+	m.terminated = true
+	return nil
+}
+
+func (m *mockSender) Shutdown() error {
+	return nil
+}
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+//testJobConf 生成读取器参数为param的工作配置
+func testJobConf(param string) *config.JSON {
+	return testJSONFromString(`{
+		"job":{
+			"content":[
+				{
+					"reader": {
+						"name":"httpreader",
+						"parameter":` + param + `
+					}
+				}
+			]
+		}
+	}`)
+}
+
+//testRecords 将记录records转化为字符串方便比较，每列为列名:列类型:列值，列之间用空格分隔
+func testRecords(records []element.Record) (s []string) {
+	for _, r := range records {
+		var cols []string
+		for i := 0; i < r.ColumnNumber(); i++ {
+			c, _ := r.GetByIndex(i)
+			cols = append(cols, fmt.Sprintf("%v:%v:%v", c.Name(), c.Type(), c.String()))
+		}
+		s = append(s, strings.Join(cols, " "))
+	}
+	return
+}
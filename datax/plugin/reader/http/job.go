@@ -0,0 +1,35 @@
+package http
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+)
+
+//Job 工作
+type Job struct {
+	*plugin.BaseJob
+}
+
+//Init 初始化
+func (j *Job) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	_, err = newParamConfig(paramConf)
+	return
+}
+
+//Destroy 销毁
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//Split 切分，分页需要按顺序请求，只生成一个任务
+func (j *Job) Split(ctx context.Context, number int) ([]*config.JSON, error) {
+	return []*config.JSON{j.PluginJobConf().CloneConfig()}, nil
+}
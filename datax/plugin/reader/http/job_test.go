@@ -0,0 +1,66 @@
+package http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+)
+
+func TestJob_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJobConf(`{"url":"http://localhost","column":[{"path":"a"}]}`),
+		},
+		{
+			name:    "2",
+			jobConf: testJobConf(`{"url":"http://localhost","column":[]}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJSONFromString(`{}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				BaseJob: plugin.NewBaseJob(),
+			}
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJob_Split(t *testing.T) {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	j.SetPluginJobConf(testJobConf(`{"url":"http://localhost","column":[{"path":"a"}]}`))
+	confs, err := j.Split(context.TODO(), 4)
+	if err != nil {
+		t.Fatalf("Job.Split() error = %v", err)
+	}
+	if len(confs) != 1 {
+		t.Errorf("Job.Split() = %v confs, want 1", len(confs))
+	}
+}
+
+func TestJob_Destroy(t *testing.T) {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	if err := j.Destroy(context.TODO()); err != nil {
+		t.Errorf("Job.Destroy() error = %v", err)
+	}
+}
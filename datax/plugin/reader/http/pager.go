@@ -0,0 +1,173 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+//pager 分页器，生成每一页的请求并根据响应判断是否还有下一页
+type pager struct {
+	param *paramConfig
+	conf  *pagination
+	base  *url.URL               //加上查询参数的请求地址
+	body  map[string]interface{} //分页参数放在请求体中时的请求体
+
+	pages  int    //已经请求的页数
+	page   int    //页码
+	offset int    //偏移量
+	cursor string //游标
+	next   string //Link分页的下一页地址
+}
+
+func newPager(param *paramConfig) (p *pager, err error) {
+	p = &pager{
+		param: param,
+		conf:  &param.Pagination,
+		page:  param.Pagination.startPage(),
+	}
+	if p.base, err = url.Parse(param.URL); err != nil {
+		return nil, err
+	}
+	q := p.base.Query()
+	for k, v := range param.Query {
+		q.Set(k, v)
+	}
+	p.base.RawQuery = q.Encode()
+
+	if p.conf.in() == InBody {
+		if p.body, err = param.body(); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+//request 获取当前页的请求地址和请求体
+func (p *pager) request() (u string, body []byte, err error) {
+	if p.next != "" {
+		return p.next, []byte(p.param.Body), nil
+	}
+
+	params := make(map[string]interface{})
+	switch p.conf.Type {
+	case PaginationPage:
+		params[p.conf.param()] = p.page
+	case PaginationOffset:
+		params[p.conf.param()] = p.offset
+	case PaginationCursor:
+		if p.cursor != "" {
+			params[p.conf.param()] = p.cursor
+		}
+	}
+	if len(params) > 0 && p.conf.Size > 0 {
+		params[p.conf.sizeParam()] = p.conf.Size
+	}
+
+	if p.conf.in() == InBody {
+		for k, v := range params {
+			p.body[k] = v
+		}
+		if body, err = json.Marshal(p.body); err != nil {
+			return
+		}
+		return p.base.String(), body, nil
+	}
+
+	next := *p.base
+	q := next.Query()
+	for k, v := range params {
+		q.Set(k, fmt.Sprint(v))
+	}
+	next.RawQuery = q.Encode()
+	return next.String(), []byte(p.param.Body), nil
+}
+
+//advance 根据当前页的响应resp和其中的记录数n翻页，没有下一页时返回false
+func (p *pager) advance(resp *response, n int) (more bool, err error) {
+	p.pages++
+	if p.conf.MaxPages > 0 && p.pages >= p.conf.MaxPages {
+		return false, nil
+	}
+
+	switch p.conf.Type {
+	case PaginationPage:
+		if p.lastPage(n) {
+			return false, nil
+		}
+		p.page++
+		return true, nil
+	case PaginationOffset:
+		if p.lastPage(n) {
+			return false, nil
+		}
+		p.offset += n
+		return true, nil
+	case PaginationCursor:
+		v := gjson.GetBytes(resp.body, p.conf.CursorPath)
+		cursor := text(v)
+		if !v.Exists() || v.Type == gjson.Null || cursor == "" {
+			return false, nil
+		}
+		if cursor == p.cursor {
+			return false, fmt.Errorf("cursor(%v) does not change", cursor)
+		}
+		p.cursor = cursor
+		return true, nil
+	case PaginationLink:
+		var next string
+		if next, err = nextLink(resp.url, resp.header); err != nil || next == "" {
+			return false, err
+		}
+		p.next = next
+		return true, nil
+	}
+	return false, nil
+}
+
+//lastPage 返回的记录数n为0或者小于每页记录数时为最后一页
+func (p *pager) lastPage(n int) bool {
+	return n == 0 || (p.conf.Size > 0 && n < p.conf.Size)
+}
+
+//nextLink 获取响应头Link中rel="next"的地址，相对地址按照请求地址u解析，没有时返回空字符串
+func nextLink(u string, header http.Header) (string, error) {
+	for _, link := range header.Values("Link") {
+		for _, v := range strings.Split(link, ",") {
+			parts := strings.Split(v, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+				if len(kv) != 2 || !strings.EqualFold(kv[0], "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(kv[1], `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return resolve(u, target[1:len(target)-1])
+					}
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+//resolve 按照地址base解析地址ref
+func resolve(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("link(%v) err: %v", ref, err)
+	}
+	return b.ResolveReference(r).String(), nil
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func testPager(t *testing.T, param string) *pager {
+	p, err := newParamConfig(testJSONFromString(param))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pg, err := newPager(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pg
+}
+
+func TestPager(t *testing.T) {
+	type page struct {
+		body   string
+		header http.Header
+		n      int
+	}
+	tests := []struct {
+		name      string
+		param     string
+		pages     []page
+		wantURLs  []string
+		wantBodys []string
+		wantErr   bool
+	}{
+		{
+			name:     "1",
+			param:    `{"url":"http://a/b?x=1","query":{"y":"2"},"column":[{"path":"a"}]}`,
+			pages:    []page{{n: 10}},
+			wantURLs: []string{"http://a/b?x=1&y=2"},
+		},
+		{
+			name:     "2",
+			param:    `{"url":"http://a/b","column":[{"path":"a"}],"pagination":{"type":"page","size":2}}`,
+			pages:    []page{{n: 2}, {n: 2}, {n: 1}},
+			wantURLs: []string{"http://a/b?page=1&size=2", "http://a/b?page=2&size=2", "http://a/b?page=3&size=2"},
+		},
+		{
+			name:     "3",
+			param:    `{"url":"http://a/b","column":[{"path":"a"}],"pagination":{"type":"page","startPage":0,"pageParam":"p"}}`,
+			pages:    []page{{n: 2}, {n: 0}},
+			wantURLs: []string{"http://a/b?p=0", "http://a/b?p=1"},
+		},
+		{
+			name:     "4",
+			param:    `{"url":"http://a/b","column":[{"path":"a"}],"pagination":{"type":"offset","size":2,"maxPages":2}}`,
+			pages:    []page{{n: 2}, {n: 2}},
+			wantURLs: []string{"http://a/b?limit=2&offset=0", "http://a/b?limit=2&offset=2"},
+		},
+		{
+			name: "5",
+			param: `{"url":"http://a/b","method":"POST","body":"{\"q\":\"x\"}","column":[{"path":"a"}],` +
+				`"pagination":{"type":"cursor","in":"body","cursorPath":"next"}}`,
+			pages:     []page{{body: `{"next":"c1"}`, n: 1}, {body: `{"next":null}`, n: 1}},
+			wantURLs:  []string{"http://a/b", "http://a/b"},
+			wantBodys: []string{`{"q":"x"}`, `{"cursor":"c1","q":"x"}`},
+		},
+		{
+			name:     "6",
+			param:    `{"url":"http://a/b","column":[{"path":"a"}],"pagination":{"type":"cursor","cursorPath":"next"}}`,
+			pages:    []page{{body: `{"next":"c1"}`, n: 1}, {body: `{"next":"c1"}`, n: 1}},
+			wantURLs: []string{"http://a/b", "http://a/b?cursor=c1"},
+			wantErr:  true,
+		},
+		{
+			name:  "7",
+			param: `{"url":"http://a/b?x=1","column":[{"path":"a"}],"pagination":{"type":"link"}}`,
+			pages: []page{
+				{header: http.Header{"Link": {`</b?x=1&page=2>; rel="next", </b?page=9>; rel="last"`}}, n: 1},
+				{header: http.Header{"Link": {`<http://c/d>; rel="prev next"`}}, n: 1},
+				{header: http.Header{"Link": {`<http://c/a>; rel="prev"`}}, n: 1},
+			},
+			wantURLs: []string{"http://a/b?x=1", "http://a/b?x=1&page=2", "http://c/d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testPager(t, tt.param)
+			var urls, bodys []string
+			for i, pg := range tt.pages {
+				u, body, err := p.request()
+				if err != nil {
+					t.Fatal(err)
+				}
+				urls = append(urls, u)
+				bodys = append(bodys, string(body))
+				more, err := p.advance(&response{url: u, header: pg.header, body: []byte(pg.body)}, pg.n)
+				if err != nil {
+					if !tt.wantErr {
+						t.Fatalf("pager.advance() error = %v", err)
+					}
+					break
+				}
+				if more != (i < len(tt.pages)-1) {
+					t.Fatalf("pager.advance() page %v more = %v", i, more)
+				}
+			}
+			if !reflect.DeepEqual(urls, tt.wantURLs) {
+				t.Errorf("pager.request() urls = %v, want %v", urls, tt.wantURLs)
+			}
+			if tt.wantBodys != nil && !reflect.DeepEqual(bodys, tt.wantBodys) {
+				t.Errorf("pager.request() bodys = %v, want %v", bodys, tt.wantBodys)
+			}
+		})
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			link: `<https://x/y?page=2>; rel=next`,
+			want: "https://x/y?page=2",
+		},
+		{
+			name: "2",
+			link: `https://x/y; rel="next"`,
+			want: "",
+		},
+		{
+			name: "3",
+			link: `<https://x/y>; title="next"`,
+			want: "",
+		},
+		{
+			name:    "4",
+			link:    `<%zz>; rel="next"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextLink("http://a/b", http.Header{"Link": {tt.link}})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nextLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("nextLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/tidwall/gjson"
+)
+
+//Task 任务
+type Task struct {
+	*plugin.BaseTask
+
+	param  *paramConfig
+	client *client
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.client, err = newClient(t.param); err != nil {
+		return
+	}
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//StartRead 开始读，依次请求每一页直到没有下一页
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	var p *pager
+	if p, err = newPager(t.param); err != nil {
+		return
+	}
+
+	for more := true; more; {
+		var u string
+		var body []byte
+		if u, body, err = p.request(); err != nil {
+			return
+		}
+
+		var resp *response
+		if resp, err = t.client.do(ctx, t.param.method(), u, body); err != nil {
+			return
+		}
+
+		var n int
+		if n, err = t.readPage(ctx, resp, sender); err != nil {
+			return fmt.Errorf("request(%v %v) err: %v", t.param.method(), u, err)
+		}
+
+		if more, err = p.advance(resp, n); err != nil {
+			return fmt.Errorf("request(%v %v) err: %v", t.param.method(), u, err)
+		}
+	}
+	return sender.Terminate()
+}
+
+//readPage 读取响应resp中的对象数组并发往写入器，返回对象的个数，对象数组不存在或者为null时当做空数组
+func (t *Task) readPage(ctx context.Context, resp *response, sender plugin.RecordSender) (n int, err error) {
+	if !gjson.ValidBytes(resp.body) {
+		return 0, fmt.Errorf("response is not valid json")
+	}
+
+	data := gjson.ParseBytes(resp.body)
+	if t.param.DataPath != "" {
+		data = data.Get(t.param.DataPath)
+	}
+	if !data.Exists() || data.Type == gjson.Null {
+		return 0, nil
+	}
+	if !data.IsArray() {
+		return 0, fmt.Errorf("dataPath(%v) is not array", t.param.DataPath)
+	}
+
+	data.ForEach(func(_, v gjson.Result) bool {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return false
+		default:
+		}
+
+		var record element.Record
+		if record, err = sender.CreateRecord(); err != nil {
+			return false
+		}
+		if err = t.fillRecord(record, v); err != nil {
+			err = fmt.Errorf("object(%v) err: %v", n, err)
+			return false
+		}
+		if err = sender.SendWriter(record); err != nil {
+			return false
+		}
+		n++
+		return true
+	})
+	return
+}
+
+//fillRecord 将JSON对象obj按照列配置转化为列并加入记录record
+func (t *Task) fillRecord(record element.Record, obj gjson.Result) (err error) {
+	for i, c := range t.param.Column {
+		var v gjson.Result
+		if c.Value != nil {
+			v = gjson.Result{Type: gjson.String, Str: *c.Value, Raw: *c.Value}
+		} else {
+			v = obj.Get(c.Path)
+		}
+
+		var cv element.ColumnValue
+		if cv, err = c.columnValue(v); err != nil {
+			return fmt.Errorf("column(%v) path(%v) err: %v", i, c.Path, err)
+		}
+
+		if err = record.Add(element.NewDefaultColumn(cv, c.name(), len(v.Raw))); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,226 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/tidwall/gjson"
+)
+
+func testTask(t *testing.T, param string) *Task {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+//testServer 生成有5个用户的REST接口，支持页码，偏移量，游标和Link分页
+func testServer() *httptest.Server {
+	users := []string{"a", "b", "c", "d", "e"}
+	items := func(from, to int) (s string) {
+		for i := from; i < to && i < len(users); i++ {
+			if s != "" {
+				s += ","
+			}
+			s += fmt.Sprintf(`{"id":%v,"user":{"name":"%v"}}`, i+1, users[i])
+		}
+		return "[" + s + "]"
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/page":
+			page, _ := strconv.Atoi(q.Get("page"))
+			size, _ := strconv.Atoi(q.Get("size"))
+			fmt.Fprintf(w, `{"data":{"items":%v}}`, items((page-1)*size, page*size))
+		case "/offset":
+			offset, _ := strconv.Atoi(q.Get("offset"))
+			fmt.Fprintf(w, `{"data":{"items":%v}}`, items(offset, offset+2))
+		case "/cursor":
+			body, _ := ioutil.ReadAll(r.Body)
+			cursor := int(gjson.GetBytes(body, "after").Int())
+			next := "null"
+			if cursor+2 < len(users) {
+				next = strconv.Itoa(cursor + 2)
+			}
+			fmt.Fprintf(w, `{"data":{"items":%v},"next":%v}`, items(cursor, cursor+2), next)
+		case "/link":
+			page, _ := strconv.Atoi(q.Get("page"))
+			if page < 2 {
+				w.Header().Set("Link", fmt.Sprintf(`</link?page=%v>; rel="next"`, page+1))
+			}
+			fmt.Fprint(w, items(page*2, page*2+2))
+		case "/object":
+			fmt.Fprint(w, `{"data":{"items":{"id":1}}}`)
+		case "/invalid":
+			fmt.Fprint(w, `{"data":`)
+		case "/empty":
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestTask_StartRead(t *testing.T) {
+	ts := testServer()
+	defer ts.Close()
+	column := `"column":[{"path":"id","type":"bigInt"},{"path":"user.name","name":"name"},{"name":"src","value":"api"}]`
+	all := []string{
+		"id:bigInt:1 name:string:a src:string:api",
+		"id:bigInt:2 name:string:b src:string:api",
+		"id:bigInt:3 name:string:c src:string:api",
+		"id:bigInt:4 name:string:d src:string:api",
+		"id:bigInt:5 name:string:e src:string:api",
+	}
+	tests := []struct {
+		name  string
+		param string
+		want  []string
+	}{
+		{
+			name:  "1",
+			param: `{"url":"` + ts.URL + `/page","dataPath":"data.items",` + column + `,"pagination":{"type":"page","size":2}}`,
+			want:  all,
+		},
+		{
+			name:  "2",
+			param: `{"url":"` + ts.URL + `/offset","dataPath":"data.items",` + column + `,"pagination":{"type":"offset"}}`,
+			want:  all,
+		},
+		{
+			name: "3",
+			param: `{"url":"` + ts.URL + `/cursor","method":"POST","dataPath":"data.items",` + column +
+				`,"pagination":{"type":"cursor","in":"body","cursorParam":"after","cursorPath":"next"}}`,
+			want: all,
+		},
+		{
+			name:  "4",
+			param: `{"url":"` + ts.URL + `/link",` + column + `,"pagination":{"type":"link"},"rateLimit":1000}`,
+			want:  all,
+		},
+		{
+			name:  "5",
+			param: `{"url":"` + ts.URL + `/page?page=1","query":{"size":"3"},"dataPath":"data.items",` + column + `}`,
+			want:  all[:3],
+		},
+		{
+			name:  "6",
+			param: `{"url":"` + ts.URL + `/empty","dataPath":"data.items",` + column + `}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &mockSender{}
+			if err := testTask(t, tt.param).StartRead(context.TODO(), sender); err != nil {
+				t.Fatalf("Task.StartRead() error = %v", err)
+			}
+			if !sender.terminated {
+				t.Errorf("Task.StartRead() terminated = %v", sender.terminated)
+			}
+			if got := testRecords(sender.records); len(got)+len(tt.want) != 0 && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartReadErr(t *testing.T) {
+	ts := testServer()
+	defer ts.Close()
+	errMock := errors.New("mock error")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	param := `{"url":"` + ts.URL + `/page?page=1&size=2","dataPath":"data.items","column":[{"path":"id","type":"bigInt"}]}`
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		param  string
+		sender *mockSender
+	}{
+		{
+			name:   "1",
+			ctx:    context.TODO(),
+			param:  param,
+			sender: &mockSender{createErr: errMock},
+		},
+		{
+			name:   "2",
+			ctx:    context.TODO(),
+			param:  param,
+			sender: &mockSender{sendErr: errMock},
+		},
+		{
+			name:   "3",
+			ctx:    canceled,
+			param:  param,
+			sender: &mockSender{},
+		},
+		{
+			name:   "4",
+			ctx:    context.TODO(),
+			param:  `{"url":"` + ts.URL + `/page?page=1&size=2","dataPath":"data.items","column":[{"path":"user.name","type":"bigInt"}]}`,
+			sender: &mockSender{},
+		},
+		{
+			name:   "5",
+			ctx:    context.TODO(),
+			param:  `{"url":"` + ts.URL + `/object","dataPath":"data.items","column":[{"path":"id"}]}`,
+			sender: &mockSender{},
+		},
+		{
+			name:   "6",
+			ctx:    context.TODO(),
+			param:  `{"url":"` + ts.URL + `/invalid","column":[{"path":"id"}]}`,
+			sender: &mockSender{},
+		},
+		{
+			name:   "7",
+			ctx:    context.TODO(),
+			param:  `{"url":"` + ts.URL + `/none","column":[{"path":"id"}]}`,
+			sender: &mockSender{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testTask(t, tt.param).StartRead(tt.ctx, tt.sender); err == nil {
+				t.Errorf("Task.StartRead() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func TestTask_Init(t *testing.T) {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+	task.SetPluginJobConf(testJobConf(`{"url":"http://localhost","column":[]}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package jsonl
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type paramConfig struct {
+	file.Config
+
+	Column []columnConfig `json:"column"` //列配置
+}
+
+type columnConfig struct {
+	Path   string             `json:"path"`   //JSON对象中的路径，如a.b.c，数组可以用序号访问，如a.0
+	Name   string             `json:"name"`   //列名，默认为path
+	Type   element.ColumnType `json:"type"`   //列类型，默认为字符串
+	Format string             `json:"format"` //时间格式，使用go的时间格式，默认为time.RFC3339Nano
+	Value  *string            `json:"value"`  //常量值，设置后不从JSON对象中读取
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	if err = p.Validate(); err != nil {
+		return
+	}
+
+	if len(p.Column) == 0 {
+		return fmt.Errorf("column is empty")
+	}
+
+	for i, v := range p.Column {
+		if v.Path == "" && v.Value == nil {
+			return fmt.Errorf("column(%v) path and value are both empty", i)
+		}
+		if v.Path == "" && v.Name == "" {
+			return fmt.Errorf("column(%v) name of constant value is empty", i)
+		}
+		switch v.Type {
+		case "", element.TypeBool, element.TypeBigInt, element.TypeDecimal,
+			element.TypeString, element.TypeBytes, element.TypeTime:
+		default:
+			return fmt.Errorf("column(%v) type(%v) is not supported", i, v.Type)
+		}
+	}
+	return
+}
+
+//name 获取列名，默认为path
+func (c *columnConfig) name() string {
+	if c.Name == "" {
+		return c.Path
+	}
+	return c.Name
+}
+
+//columnType 获取列类型，默认为字符串
+func (c *columnConfig) columnType() element.ColumnType {
+	if c.Type == "" {
+		return element.TypeString
+	}
+	return c.Type
+}
+
+//layout 获取时间格式，默认为time.RFC3339Nano
+func (c *columnConfig) layout() string {
+	if c.Format == "" {
+		return time.RFC3339Nano
+	}
+	return c.Format
+}
@@ -0,0 +1,82 @@
+package jsonl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"path":["a.jsonl"],"column":[{"path":"a.b","type":"bigInt"},{"name":"c","value":"1"}]}`,
+		},
+		{
+			name:    "2",
+			json:    `{"path":[],"column":[{"path":"a"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"path":["a.jsonl"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"path":["a.jsonl"],"column":[{"type":"string"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"path":["a.jsonl"],"column":[{"value":"1"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"path":["a.jsonl"],"column":[{"path":"a","type":"map"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"path":"a.jsonl"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestColumnConfig_default(t *testing.T) {
+	c := &columnConfig{Path: "a.b"}
+	if got := c.name(); got != "a.b" {
+		t.Errorf("columnConfig.name() = %v, want %v", got, "a.b")
+	}
+	if got := c.columnType(); got != element.TypeString {
+		t.Errorf("columnConfig.columnType() = %v, want %v", got, element.TypeString)
+	}
+	if got := c.layout(); got != time.RFC3339Nano {
+		t.Errorf("columnConfig.layout() = %v, want %v", got, time.RFC3339Nano)
+	}
+	c = &columnConfig{Path: "a.b", Name: "c", Type: element.TypeTime, Format: "2006"}
+	if got := c.name(); got != "c" {
+		t.Errorf("columnConfig.name() = %v, want %v", got, "c")
+	}
+	if got := c.columnType(); got != element.TypeTime {
+		t.Errorf("columnConfig.columnType() = %v, want %v", got, element.TypeTime)
+	}
+	if got := c.layout(); got != "2006" {
+		t.Errorf("columnConfig.layout() = %v, want %v", got, "2006")
+	}
+}
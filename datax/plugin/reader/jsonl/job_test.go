@@ -0,0 +1,46 @@
+package jsonl
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
+)
+
+func TestJob_Init(t *testing.T) {
+	dir := testTempDir(t)
+	testWriteFile(t, dir, "a.jsonl", "{}\n")
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.jsonl") + `"],"column":[{"path":"a"}]}`),
+		},
+		{
+			name:    "2",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.json") + `"],"column":[{"path":"a"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.jsonl") + `"]}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				Job: file.NewJob(),
+			}
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
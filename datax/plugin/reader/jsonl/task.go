@@ -0,0 +1,127 @@
+package jsonl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/tidwall/gjson"
+)
+
+//Task 任务
+type Task struct {
+	*plugin.BaseTask
+
+	param *paramConfig
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//StartRead 开始读，在没有切分配置时读取所有匹配的文件
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	var splits []file.Split
+	if splits, err = t.param.Splits(); err != nil {
+		return
+	}
+
+	for _, v := range splits {
+		if err = t.readSplit(ctx, v, sender); err != nil {
+			return
+		}
+	}
+	return sender.Terminate()
+}
+
+//readSplit 读取切分s中的记录并发往写入器，每行为一个JSON对象，空行会被跳过
+func (t *Task) readSplit(ctx context.Context, s file.Split, sender plugin.RecordSender) (err error) {
+	var rc io.ReadCloser
+	if rc, err = t.param.Open(s); err != nil {
+		return
+	}
+	defer rc.Close()
+
+	r := bufio.NewReader(rc)
+	var record element.Record
+	for lineNumber := 1; ; lineNumber++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var line []byte
+		line, err = r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("read file(%v) err: %v", s.Path, err)
+		}
+		eof := err == io.EOF
+		err = nil
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if !gjson.ValidBytes(line) {
+				return fmt.Errorf("file(%v) line(%v) is not valid json", s.Path, lineNumber)
+			}
+
+			if record, err = sender.CreateRecord(); err != nil {
+				return
+			}
+
+			if err = t.fillRecord(record, line); err != nil {
+				return fmt.Errorf("file(%v) line(%v) err: %v", s.Path, lineNumber, err)
+			}
+
+			if err = sender.SendWriter(record); err != nil {
+				return
+			}
+		}
+
+		if eof {
+			return nil
+		}
+	}
+}
+
+//fillRecord 将JSON对象line按照列配置转化为列并加入记录record
+func (t *Task) fillRecord(record element.Record, line []byte) (err error) {
+	for i, c := range t.param.Column {
+		var v gjson.Result
+		if c.Value != nil {
+			v = gjson.Result{Type: gjson.String, Str: *c.Value, Raw: *c.Value}
+		} else {
+			v = gjson.GetBytes(line, c.Path)
+		}
+
+		var cv element.ColumnValue
+		if cv, err = c.columnValue(v); err != nil {
+			return fmt.Errorf("column(%v) path(%v) err: %v", i, c.Path, err)
+		}
+
+		if err = record.Add(element.NewDefaultColumn(cv, c.name(), len(v.Raw))); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,195 @@
+package jsonl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+)
+
+func testGzip(t *testing.T, s string) string {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	return buf.String()
+}
+
+func testTask(t *testing.T, param string) *Task {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+func TestTask_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":["a.jsonl"],"column":[{"path":"a"}]}`,
+		},
+		{
+			name:    "2",
+			param:   `{"path":["a.jsonl"]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: plugin.NewBaseTask(),
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			if err := task.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartRead(t *testing.T) {
+	dir := testTempDir(t)
+	abs := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+	testWriteFile(t, dir, "a.jsonl", `{"id":12345678901234567890,"user":{"name":"a"},"tags":["x","y"]}`+"\n\n"+
+		`{"id":2,"user":{"name":null}}`)
+	testWriteFile(t, dir, "b.jsonl.gz", testGzip(t, `{"id":3,"user":{"name":"c"}}`+"\r\n"))
+	testWriteFile(t, dir, "c.jsonl", `{"id":1}`+"\n"+`{"id":`+"\n")
+	testWriteFile(t, dir, "d.jsonl", `{"id":"x"}`+"\n")
+	testWriteFile(t, dir, "e.jsonl", string([]byte{'{', '"', 'a', '"', ':', '"', 0xd6, 0xd0, 0xce, 0xc4, '"', '}', '\n'}))
+	column := `"column":[{"path":"id","type":"bigInt"},{"path":"user.name","name":"name"},{"path":"tags.1","name":"tag"},{"name":"k","value":"v"}]`
+	tests := []struct {
+		name    string
+		param   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":["` + abs("a.jsonl") + `"],` + column + `}`,
+			want: []string{
+				"id:bigInt:12345678901234567890 name:string:a tag:string:y k:string:v",
+				"id:bigInt:2 name:string:<nil> tag:string:<nil> k:string:v",
+			},
+		},
+		{
+			name:  "2",
+			param: `{"path":["` + abs("b.jsonl.gz") + `"],"compress":"gzip",` + column + `}`,
+			want: []string{
+				"id:bigInt:3 name:string:c tag:string:<nil> k:string:v",
+			},
+		},
+		{
+			name:    "3",
+			param:   `{"path":["` + abs("c.jsonl") + `"],` + column + `}`,
+			want:    []string{"id:bigInt:1 name:string:<nil> tag:string:<nil> k:string:v"},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			param:   `{"path":["` + abs("d.jsonl") + `"],` + column + `}`,
+			wantErr: true,
+		},
+		{
+			name:  "5",
+			param: `{"path":["` + abs("e.jsonl") + `"],"encoding":"gbk","column":[{"path":"a"}]}`,
+			want:  []string{"a:string:中文"},
+		},
+		{
+			name:    "6",
+			param:   `{"path":["` + abs("a.jsonl") + `"],"compress":"bzip2",` + column + `}`,
+			wantErr: true,
+		},
+		{
+			name:  "7",
+			param: `{"path":["` + abs("c.jsonl") + `"],"split":{"path":"` + abs("a.jsonl") + `","start":2,"end":0},` + column + `}`,
+			want: []string{
+				"id:bigInt:2 name:string:<nil> tag:string:<nil> k:string:v",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &mockSender{}
+			err := testTask(t, tt.param).StartRead(context.TODO(), sender)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Task.StartRead() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if sender.terminated == tt.wantErr {
+				t.Errorf("Task.StartRead() terminated = %v", sender.terminated)
+			}
+			if got := testRecords(sender.records); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartReadErr(t *testing.T) {
+	dir := testTempDir(t)
+	param := `{"path":["` + testWriteFile(t, dir, "a.jsonl", `{"a":1}`) + `"],"column":[{"path":"a"}]}`
+	errMock := errors.New("mock error")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		sender *mockSender
+	}{
+		{
+			name:   "1",
+			ctx:    context.TODO(),
+			sender: &mockSender{createErr: errMock},
+		},
+		{
+			name:   "2",
+			ctx:    context.TODO(),
+			sender: &mockSender{sendErr: errMock},
+		},
+		{
+			name:   "3",
+			ctx:    canceled,
+			sender: &mockSender{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testTask(t, param).StartRead(tt.ctx, tt.sender); err == nil {
+				t.Errorf("Task.StartRead() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
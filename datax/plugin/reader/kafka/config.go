@@ -0,0 +1,156 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	"github.com/Breeze0806/go/time2"
+	"github.com/Shopify/sarama"
+)
+
+var defaultIdleTimeout = 5 * time.Second
+
+//起始偏移量
+const (
+	OffsetEarliest  = "earliest"  //分区中最早的偏移量，默认的起始偏移量
+	OffsetCommitted = "committed" //消费组已提交的偏移量，没有提交过时为最早的偏移量
+)
+
+type paramConfig struct {
+	kafka.Config
+
+	Topic          string            `json:"topic"`          //主题
+	Partitions     []int32           `json:"partitions"`     //读取的分区，为空时读取主题的所有分区
+	Group          string            `json:"group"`          //消费组，设置后在Job.Post中提交读取到的偏移量
+	StartOffset    string            `json:"startOffset"`    //起始偏移量，支持earliest，committed和具体的偏移量，默认为earliest
+	StartTime      string            `json:"startTime"`      //起始时间，使用RFC3339格式，设置后从该时间之后的第一条消息开始读取，优先于startOffset
+	EndTime        string            `json:"endTime"`        //结束时间，使用RFC3339格式，设置后读取到该时间之前的最后一条消息
+	Format         kafka.Format      `json:"format"`         //消息值的格式，支持json，csv和avro，默认为json
+	Column         []kafka.Column    `json:"column"`         //从消息值中读取的列定义
+	KeyColumn      string            `json:"keyColumn"`      //消息键的列名，设置后消息键作为字符串列加入记录的末尾
+	FieldDelimiter string            `json:"fieldDelimiter"` //CSV格式的字段分隔符，默认为逗号
+	NullFormat     string            `json:"nullFormat"`     //CSV格式中空值的文本，默认为空字符串
+	RecordName     string            `json:"recordName"`     //avro格式的记录名，默认为Record
+	IdleTimeout    time2.Duration    `json:"idleTimeout"`    //没有新消息时的最长等待时间，超时后分区的高水位已达到结束偏移量时结束读取，默认为5s
+	Ranges         []*partitionRange `json:"ranges"`         //由Job.Split生成的分区偏移量范围
+	TaskID         *int              `json:"taskID"`         //由Job.Split生成的任务ID
+}
+
+//partitionRange 分区偏移量范围，读取偏移量在[Start, End)中的消息
+type partitionRange struct {
+	Partition int32 `json:"partition"` //分区
+	Start     int64 `json:"start"`     //起始偏移量
+	End       int64 `json:"end"`       //结束偏移量，不包含
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	if err = p.Config.Validate(); err != nil {
+		return
+	}
+	if p.Topic == "" {
+		return fmt.Errorf("topic is empty")
+	}
+	if len(p.Column) == 0 {
+		return fmt.Errorf("column is empty")
+	}
+	if !p.Format.IsValid() {
+		return fmt.Errorf("format(%v) is not supported", p.Format)
+	}
+	var opts kafka.Options
+	if opts, err = p.options(); err != nil {
+		return
+	}
+	if _, err = kafka.NewCodec(p.Format, p.Column, opts); err != nil {
+		return
+	}
+	if _, err = p.startOffset(); err != nil {
+		return
+	}
+	if p.StartOffset == OffsetCommitted && p.Group == "" {
+		return fmt.Errorf("group is empty when startOffset is %v", OffsetCommitted)
+	}
+	if _, err = parseTime(p.StartTime); err != nil {
+		return fmt.Errorf("startTime(%v) err: %v", p.StartTime, err)
+	}
+	if _, err = parseTime(p.EndTime); err != nil {
+		return fmt.Errorf("endTime(%v) err: %v", p.EndTime, err)
+	}
+	if p.IdleTimeout.Duration < 0 {
+		return fmt.Errorf("idleTimeout(%v) is less than 0", p.IdleTimeout.Duration)
+	}
+	return
+}
+
+//options 获取编解码选项
+func (p *paramConfig) options() (opts kafka.Options, err error) {
+	opts = kafka.Options{
+		NullFormat: p.NullFormat,
+		Name:       p.RecordName,
+	}
+	if p.FieldDelimiter != "" {
+		if utf8.RuneCountInString(p.FieldDelimiter) != 1 {
+			return opts, fmt.Errorf("fieldDelimiter(%v) is not a character", p.FieldDelimiter)
+		}
+		opts.Delimiter, _ = utf8.DecodeRuneInString(p.FieldDelimiter)
+	}
+	return
+}
+
+//startOffset 解析具体的起始偏移量，earliest和committed时返回-1
+func (p *paramConfig) startOffset() (int64, error) {
+	switch p.StartOffset {
+	case "", OffsetEarliest, OffsetCommitted:
+		return -1, nil
+	}
+	offset, err := strconv.ParseInt(p.StartOffset, 10, 64)
+	if err != nil || offset < 0 {
+		return -1, fmt.Errorf("startOffset(%v) is not supported", p.StartOffset)
+	}
+	return offset, nil
+}
+
+func (p *paramConfig) getIdleTimeout() time.Duration {
+	if p.IdleTimeout.Duration == 0 {
+		return defaultIdleTimeout
+	}
+	return p.IdleTimeout.Duration
+}
+
+//saramaConfig 生成客户端和消费者的配置，消费者返回读取中的错误
+func (p *paramConfig) saramaConfig() (conf *sarama.Config, err error) {
+	if conf, err = p.Config.SaramaConfig(); err != nil {
+		return
+	}
+	conf.Consumer.Return.Errors = true
+	return
+}
+
+//parseTime 解析RFC3339格式的时间s，s为空时返回零值
+func parseTime(s string) (t time.Time, err error) {
+	if s == "" {
+		return
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+//timestamp 获取时间t的毫秒时间戳
+func timestamp(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,161 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}],"format":"csv","fieldDelimiter":"|",` +
+				`"startOffset":"committed","group":"group","startTime":"2021-01-02T03:04:05Z","endTime":"2021-01-02T03:04:05.123+08:00"}`,
+		},
+		{
+			name:    "2",
+			json:    `{"topic":"test","column":[{"name":"id"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"brokers":["127.0.0.1:9092"],"column":[{"name":"id"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}],"format":"xml"}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}],"fieldDelimiter":"||"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id","type":"unknown"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}],"startOffset":"latest"}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}],"startOffset":"-1"}`,
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}],"startOffset":"committed"}`,
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}],"startTime":"2021-01-02"}`,
+			wantErr: true,
+		},
+		{
+			name:    "12",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}],"endTime":"2021-01-02"}`,
+			wantErr: true,
+		},
+		{
+			name:    "13",
+			json:    `{"brokers":1}`,
+			wantErr: true,
+		},
+		{
+			name:    "14",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}],"idleTimeout":"-1s"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_startOffset(t *testing.T) {
+	tests := []struct {
+		name        string
+		startOffset string
+		want        int64
+	}{
+		{name: "1", startOffset: "", want: -1},
+		{name: "2", startOffset: OffsetEarliest, want: -1},
+		{name: "3", startOffset: OffsetCommitted, want: -1},
+		{name: "4", startOffset: "0", want: 0},
+		{name: "5", startOffset: "100", want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &paramConfig{StartOffset: tt.startOffset}
+			if got, err := p.startOffset(); err != nil || got != tt.want {
+				t.Errorf("paramConfig.startOffset() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParamConfig_saramaConfig(t *testing.T) {
+	p, err := newParamConfig(testJSONFromString(`{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err := p.saramaConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !conf.Consumer.Return.Errors {
+		t.Errorf("paramConfig.saramaConfig() Consumer.Return.Errors = false, want true")
+	}
+
+	if _, err = (&paramConfig{}).saramaConfig(); err != nil {
+		t.Errorf("paramConfig.saramaConfig() error = %v", err)
+	}
+}
+
+func TestParamConfig_getIdleTimeout(t *testing.T) {
+	p := &paramConfig{}
+	if got := p.getIdleTimeout(); got != defaultIdleTimeout {
+		t.Errorf("paramConfig.getIdleTimeout() = %v, want %v", got, defaultIdleTimeout)
+	}
+
+	p.IdleTimeout.Duration = time.Minute
+	if got := p.getIdleTimeout(); got != time.Minute {
+		t.Errorf("paramConfig.getIdleTimeout() = %v, want %v", got, time.Minute)
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	tm, err := parseTime("1970-01-01T00:00:01.5+00:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := timestamp(tm); got != 1500 {
+		t.Errorf("timestamp() = %v, want 1500", got)
+	}
+	if tm, err = parseTime(""); err != nil || !tm.IsZero() {
+		t.Errorf("parseTime() = %v, %v, want zero", tm, err)
+	}
+	if got := timestamp(time.Unix(2, 0)); got != 2000 {
+		t.Errorf("timestamp() = %v, want 2000", got)
+	}
+}
@@ -0,0 +1,152 @@
+package kafka
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Shopify/sarama"
+)
+
+type mockSender struct {
+	createErr  error
+	sendErr    error
+	records    []element.Record
+	terminated bool
+}
+
+func (m *mockSender) CreateRecord() (element.Record, error) {
+	return element.NewDefaultRecord(), m.createErr
+}
+
+func (m *mockSender) SendWriter(record element.Record) error {
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *mockSender) Flush() error {
+	return nil
+}
+
+func (m *mockSender) Terminate() error {
+	m.terminated = true
+	return nil
+}
+
+func (m *mockSender) Shutdown() error {
+	return nil
+}
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+//testJobConf 生成读取器参数为param的工作配置
+func testJobConf(param string) *config.JSON {
+	return testJSONFromString(`{
+		"job":{
+			"content":[
+				{
+					"reader": {
+						"name":"kafkareader",
+						"parameter":` + param + `
+					}
+				}
+			]
+		}
+	}`)
+}
+
+//testRecords 将记录records转化为字符串方便比较，每列为列名:列类型:列值，列之间用空格分隔
+func testRecords(records []element.Record) (s []string) {
+	for _, r := range records {
+		var cols []string
+		for i := 0; i < r.ColumnNumber(); i++ {
+			c, _ := r.GetByIndex(i)
+			cols = append(cols, fmt.Sprintf("%v:%v:%v", c.Name(), c.Type(), c.String()))
+		}
+		s = append(s, strings.Join(cols, " "))
+	}
+	return
+}
+
+//testMessage 分区中的测试消息
+type testMessage struct {
+	partition int32
+	key       string
+	value     string
+}
+
+//testMessages 测试主题test中的消息，分区0的偏移量为0到2，分区1的偏移量为0到1
+var testMessages = []testMessage{
+	{partition: 0, key: "a", value: `{"id":1,"name":"a"}`},
+	{partition: 0, value: `{"id":2,"name":null}`},
+	{partition: 0, key: "c", value: `{"id":3,"name":"c"}`},
+	{partition: 1, key: "d", value: `{"id":4,"name":"d"}`},
+	{partition: 1, key: "e", value: `{"id":5,"name":"e"}`},
+}
+
+//testBroker 生成包含主题test的模拟broker，分区0在时间1000的偏移量为1，在时间2000的偏移量为2，
+//消费组group在分区0上提交的偏移量为committed，handlers会覆盖默认的处理
+func testBroker(t *testing.T, committed int64, handlers map[string]sarama.MockResponse) *sarama.MockBroker {
+	broker := sarama.NewMockBroker(t, 1)
+
+	fetch := &sarama.FetchResponse{Version: 4}
+	offsets := map[int32]int64{}
+	for _, m := range testMessages {
+		var key sarama.Encoder
+		if m.key != "" {
+			key = sarama.StringEncoder(m.key)
+		}
+		fetch.AddRecord("test", m.partition, key, sarama.StringEncoder(m.value), offsets[m.partition])
+		offsets[m.partition]++
+	}
+	for p, offset := range offsets {
+		fetch.GetBlock("test", p).HighWaterMarkOffset = offset
+	}
+
+	m := map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("test", 0, broker.BrokerID()).
+			SetLeader("test", 1, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).
+			SetOffset("test", 0, sarama.OffsetOldest, 0).
+			SetOffset("test", 0, sarama.OffsetNewest, 3).
+			SetOffset("test", 0, 1000, 1).
+			SetOffset("test", 0, 2000, 2).
+			SetOffset("test", 1, sarama.OffsetOldest, 0).
+			SetOffset("test", 1, sarama.OffsetNewest, 2).
+			SetOffset("test", 1, 1000, -1).
+			SetOffset("test", 1, 2000, -1),
+		"FetchRequest": sarama.NewMockWrapper(fetch),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, "group", broker),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset("group", "test", 0, committed, "", sarama.ErrNoError).
+			SetOffset("group", "test", 1, -1, "", sarama.ErrNoError),
+		"OffsetCommitRequest": sarama.NewMockOffsetCommitResponse(t),
+	}
+	for k, v := range handlers {
+		m[k] = v
+	}
+	broker.SetHandlerByMap(m)
+	return broker
+}
+
+//testRanges 将分区偏移量范围ranges转化为值方便输出
+func testRanges(ranges []*partitionRange) (s []partitionRange) {
+	for _, v := range ranges {
+		s = append(s, *v)
+	}
+	return
+}
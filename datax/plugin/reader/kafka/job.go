@@ -0,0 +1,246 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Shopify/sarama"
+)
+
+//Job 工作
+type Job struct {
+	*plugin.BaseJob
+
+	param  *paramConfig
+	client sarama.Client
+	ranges []*partitionRange
+}
+
+//Init 初始化，在工作开始时确定每个分区的偏移量范围，结束偏移量不会随着新消息的写入而改变
+func (j *Job) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if j.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	var conf *sarama.Config
+	if conf, err = j.param.saramaConfig(); err != nil {
+		return
+	}
+
+	if j.client, err = sarama.NewClient(j.param.Brokers, conf); err != nil {
+		return fmt.Errorf("brokers(%v) err: %v", j.param.Brokers, err)
+	}
+
+	if j.ranges, err = j.partitionRanges(); err != nil {
+		return fmt.Errorf("topic(%v) err: %v", j.param.Topic, err)
+	}
+	return
+}
+
+//Destroy 销毁
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	if j.client != nil {
+		err = j.client.Close()
+		j.client = nil
+	}
+	return
+}
+
+//Split 切分，将非空的分区偏移量范围依次分配给至多number个任务，至少生成一个任务
+func (j *Job) Split(ctx context.Context, number int) (confs []*config.JSON, err error) {
+	var ranges []*partitionRange
+	for _, v := range j.ranges {
+		if v.Start < v.End {
+			ranges = append(ranges, v)
+		}
+	}
+
+	if number > len(ranges) {
+		number = len(ranges)
+	}
+	if number <= 0 {
+		number = 1
+	}
+
+	splits := make([][]*partitionRange, number)
+	for i, v := range ranges {
+		splits[i%number] = append(splits[i%number], v)
+	}
+
+	for i, v := range splits {
+		if v == nil {
+			v = []*partitionRange{}
+		}
+		conf := j.PluginJobConf().CloneConfig()
+		if err = conf.Set(coreconst.DataxJobContentReaderParameter+".ranges", v); err != nil {
+			return nil, err
+		}
+		if err = conf.Set(coreconst.DataxJobContentReaderParameter+".taskID", i); err != nil {
+			return nil, err
+		}
+		confs = append(confs, conf)
+	}
+	return
+}
+
+//Post 后置通知，设置消费组时将每个分区的结束偏移量提交到消费组
+func (j *Job) Post(ctx context.Context) (err error) {
+	if j.param.Group == "" || len(j.ranges) == 0 {
+		return
+	}
+
+	var coordinator *sarama.Broker
+	if coordinator, err = j.client.Coordinator(j.param.Group); err != nil {
+		return fmt.Errorf("group(%v) err: %v", j.param.Group, err)
+	}
+
+	req := &sarama.OffsetCommitRequest{
+		Version:                 1,
+		ConsumerGroup:           j.param.Group,
+		ConsumerGroupGeneration: sarama.GroupGenerationUndefined,
+	}
+	for _, v := range j.ranges {
+		req.AddBlock(j.param.Topic, v.Partition, v.End, sarama.ReceiveTime, "")
+	}
+
+	var resp *sarama.OffsetCommitResponse
+	if resp, err = coordinator.CommitOffset(req); err != nil {
+		return fmt.Errorf("group(%v) err: %v", j.param.Group, err)
+	}
+	for _, v := range j.ranges {
+		kerr, ok := resp.Errors[j.param.Topic][v.Partition]
+		if !ok {
+			return fmt.Errorf("group(%v) partition(%v) err: no response", j.param.Group, v.Partition)
+		}
+		if kerr != sarama.ErrNoError {
+			return fmt.Errorf("group(%v) partition(%v) err: %v", j.param.Group, v.Partition, kerr)
+		}
+	}
+	log.Infof("group(%v) commits offsets of topic(%v)", j.param.Group, j.param.Topic)
+	return
+}
+
+//partitionRanges 获取需要读取的每个分区的偏移量范围
+func (j *Job) partitionRanges() (ranges []*partitionRange, err error) {
+	partitions := j.param.Partitions
+	if len(partitions) == 0 {
+		if partitions, err = j.client.Partitions(j.param.Topic); err != nil {
+			return
+		}
+	}
+
+	var committed map[int32]int64
+	if j.param.StartTime == "" && j.param.StartOffset == OffsetCommitted {
+		if committed, err = j.committedOffsets(partitions); err != nil {
+			return
+		}
+	}
+
+	for _, p := range partitions {
+		var r *partitionRange
+		if r, err = j.partitionRange(p, committed); err != nil {
+			return nil, fmt.Errorf("partition(%v) err: %v", p, err)
+		}
+		ranges = append(ranges, r)
+	}
+	return
+}
+
+//partitionRange 获取分区p的偏移量范围，起始偏移量不小于最早的偏移量并且不大于结束偏移量，
+//committed为消费组已提交的偏移量
+func (j *Job) partitionRange(p int32, committed map[int32]int64) (r *partitionRange, err error) {
+	var oldest, newest int64
+	if oldest, err = j.client.GetOffset(j.param.Topic, p, sarama.OffsetOldest); err != nil {
+		return
+	}
+	if newest, err = j.client.GetOffset(j.param.Topic, p, sarama.OffsetNewest); err != nil {
+		return
+	}
+
+	r = &partitionRange{
+		Partition: p,
+		Start:     oldest,
+		End:       newest,
+	}
+
+	endTime, _ := parseTime(j.param.EndTime)
+	if !endTime.IsZero() {
+		var offset int64
+		if offset, err = j.client.GetOffset(j.param.Topic, p, timestamp(endTime)); err != nil {
+			return
+		}
+		if offset >= 0 && offset < r.End {
+			r.End = offset
+		}
+	}
+
+	startTime, _ := parseTime(j.param.StartTime)
+	start, _ := j.param.startOffset()
+	switch {
+	case !startTime.IsZero():
+		if r.Start, err = j.client.GetOffset(j.param.Topic, p, timestamp(startTime)); err != nil {
+			return
+		}
+		if r.Start < 0 {
+			r.Start = newest
+		}
+	case j.param.StartOffset == OffsetCommitted:
+		if offset, ok := committed[p]; ok {
+			r.Start = offset
+		}
+	case start >= 0:
+		r.Start = start
+	}
+
+	if r.Start < oldest {
+		r.Start = oldest
+	}
+	if r.Start > r.End {
+		r.Start = r.End
+	}
+	return
+}
+
+//committedOffsets 获取消费组在分区partitions上已提交的偏移量，没有提交过的分区不在结果中
+func (j *Job) committedOffsets(partitions []int32) (offsets map[int32]int64, err error) {
+	var coordinator *sarama.Broker
+	if coordinator, err = j.client.Coordinator(j.param.Group); err != nil {
+		return nil, fmt.Errorf("group(%v) err: %v", j.param.Group, err)
+	}
+
+	req := &sarama.OffsetFetchRequest{
+		Version:       1,
+		ConsumerGroup: j.param.Group,
+	}
+	for _, p := range partitions {
+		req.AddPartition(j.param.Topic, p)
+	}
+
+	var resp *sarama.OffsetFetchResponse
+	if resp, err = coordinator.FetchOffset(req); err != nil {
+		return nil, fmt.Errorf("group(%v) err: %v", j.param.Group, err)
+	}
+
+	offsets = make(map[int32]int64)
+	for _, p := range partitions {
+		block := resp.GetBlock(j.param.Topic, p)
+		if block == nil {
+			continue
+		}
+		if block.Err != sarama.ErrNoError {
+			return nil, fmt.Errorf("group(%v) partition(%v) err: %v", j.param.Group, p, block.Err)
+		}
+		if block.Offset >= 0 {
+			offsets[p] = block.Offset
+		}
+	}
+	return
+}
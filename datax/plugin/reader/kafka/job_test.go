@@ -0,0 +1,277 @@
+package kafka
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Shopify/sarama"
+)
+
+//testParam 生成连接模拟broker的读取器参数，other为其他参数
+func testParam(broker *sarama.MockBroker, other string) string {
+	param := `{"brokers":["` + broker.Addr() + `"],"topic":"test","column":[{"name":"id","type":"bigInt"},{"name":"name"}]`
+	if other != "" {
+		param += "," + other
+	}
+	return param + "}"
+}
+
+//testJob 生成初始化后的工作
+func testJob(t *testing.T, param string) *Job {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	j.SetPluginJobConf(testJobConf(param))
+	if err := j.Init(context.TODO()); err != nil {
+		t.Fatalf("Job.Init() error = %v", err)
+	}
+	t.Cleanup(func() {
+		j.Destroy(context.TODO())
+	})
+	return j
+}
+
+func TestJob_Init(t *testing.T) {
+	broker := testBroker(t, 1, nil)
+	defer broker.Close()
+
+	tests := []struct {
+		name  string
+		other string
+		want  []*partitionRange
+	}{
+		{
+			name: "1",
+			want: []*partitionRange{{Partition: 0, Start: 0, End: 3}, {Partition: 1, Start: 0, End: 2}},
+		},
+		{
+			name:  "2",
+			other: `"startOffset":"2","partitions":[0]`,
+			want:  []*partitionRange{{Partition: 0, Start: 2, End: 3}},
+		},
+		{
+			name:  "3",
+			other: `"startOffset":"10"`,
+			want:  []*partitionRange{{Partition: 0, Start: 3, End: 3}, {Partition: 1, Start: 2, End: 2}},
+		},
+		{
+			name:  "4",
+			other: `"startOffset":"committed","group":"group"`,
+			want:  []*partitionRange{{Partition: 0, Start: 1, End: 3}, {Partition: 1, Start: 0, End: 2}},
+		},
+		{
+			name:  "5",
+			other: `"startTime":"1970-01-01T00:00:01Z","endTime":"1970-01-01T00:00:02Z"`,
+			want:  []*partitionRange{{Partition: 0, Start: 1, End: 2}, {Partition: 1, Start: 2, End: 2}},
+		},
+		{
+			name:  "6",
+			other: `"startOffset":"earliest","endTime":"1970-01-01T00:00:01Z"`,
+			want:  []*partitionRange{{Partition: 0, Start: 0, End: 1}, {Partition: 1, Start: 0, End: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := testJob(t, testParam(broker, tt.other))
+			if !reflect.DeepEqual(j.ranges, tt.want) {
+				t.Errorf("Job.Init() ranges = %v, want %v", testRanges(j.ranges), testRanges(tt.want))
+			}
+		})
+	}
+}
+
+func TestJob_InitErr(t *testing.T) {
+	broker := testBroker(t, 1, map[string]sarama.MockResponse{
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset("group", "test", 0, 0, "", sarama.ErrNotCoordinatorForConsumer),
+	})
+	defer broker.Close()
+	closed := sarama.NewMockBroker(t, 2)
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+	}{
+		{
+			name:    "1",
+			jobConf: testJSONFromString(`{}`),
+		},
+		{
+			name:    "2",
+			jobConf: testJobConf(`{"brokers":["` + broker.Addr() + `"],"topic":"test"}`),
+		},
+		{
+			name:    "3",
+			jobConf: testJobConf(`{"brokers":["` + closed.Addr() + `"],"topic":"test","column":[{"name":"id"}],"version":"0.8.2.0"}`),
+		},
+		{
+			name:    "4",
+			jobConf: testJobConf(testParam(broker, `"partitions":[2]`)),
+		},
+		{
+			name:    "5",
+			jobConf: testJobConf(testParam(broker, `"startOffset":"committed","group":"group"`)),
+		},
+		{
+			name:    "6",
+			jobConf: testJobConf(testParam(broker, `"startOffset":"committed","group":"none"`)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				BaseJob: plugin.NewBaseJob(),
+			}
+			j.SetPluginJobConf(tt.jobConf)
+			defer j.Destroy(context.TODO())
+			if err := j.Init(context.TODO()); err == nil {
+				t.Errorf("Job.Init() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func TestJob_Split(t *testing.T) {
+	broker := testBroker(t, 1, nil)
+	defer broker.Close()
+
+	tests := []struct {
+		name   string
+		other  string
+		number int
+		want   [][]*partitionRange
+	}{
+		{
+			name:   "1",
+			number: 4,
+			want:   [][]*partitionRange{{{Partition: 0, Start: 0, End: 3}}, {{Partition: 1, Start: 0, End: 2}}},
+		},
+		{
+			name:   "2",
+			number: 1,
+			want:   [][]*partitionRange{{{Partition: 0, Start: 0, End: 3}, {Partition: 1, Start: 0, End: 2}}},
+		},
+		{
+			name:   "3",
+			other:  `"startTime":"1970-01-01T00:00:01Z"`,
+			number: 4,
+			want:   [][]*partitionRange{{{Partition: 0, Start: 1, End: 3}}},
+		},
+		{
+			name:   "4",
+			other:  `"startOffset":"10"`,
+			number: 4,
+			want:   [][]*partitionRange{{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := testJob(t, testParam(broker, tt.other))
+			confs, err := j.Split(context.TODO(), tt.number)
+			if err != nil {
+				t.Fatalf("Job.Split() error = %v", err)
+			}
+			var got [][]*partitionRange
+			for i, conf := range confs {
+				paramConf, err := conf.GetConfig(coreconst.DataxJobContentReaderParameter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				p, err := newParamConfig(paramConf)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if p.TaskID == nil || *p.TaskID != i {
+					t.Errorf("Job.Split() taskID = %v, want %v", p.TaskID, i)
+				}
+				got = append(got, p.Ranges)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.Split() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_Post(t *testing.T) {
+	tests := []struct {
+		name    string
+		other   string
+		commit  sarama.MockResponse
+		wantErr bool
+	}{
+		{
+			name: "1",
+		},
+		{
+			name:  "2",
+			other: `"group":"group"`,
+		},
+		{
+			name:    "3",
+			other:   `"group":"group"`,
+			commit:  sarama.NewMockOffsetCommitResponse(t).SetError("group", "test", 1, sarama.ErrOffsetMetadataTooLarge),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			other:   `"group":"none"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handlers map[string]sarama.MockResponse
+			if tt.commit != nil {
+				handlers = map[string]sarama.MockResponse{"OffsetCommitRequest": tt.commit}
+			}
+			broker := testBroker(t, 1, handlers)
+			defer broker.Close()
+			j := testJob(t, testParam(broker, tt.other))
+			if err := j.Post(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Post() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+//TestJob_PostCommit 测试提交的偏移量为工作开始时的结束偏移量
+func TestJob_PostCommit(t *testing.T) {
+	broker := testBroker(t, 1, nil)
+	defer broker.Close()
+	j := testJob(t, testParam(broker, `"group":"group","endTime":"1970-01-01T00:00:02Z"`))
+	if err := j.Post(context.TODO()); err != nil {
+		t.Fatalf("Job.Post() error = %v", err)
+	}
+
+	want := map[int32]int64{0: 2, 1: 2}
+	got := map[int32]int64{}
+	for _, v := range broker.History() {
+		req, ok := v.Request.(*sarama.OffsetCommitRequest)
+		if !ok {
+			continue
+		}
+		for p := range want {
+			if offset, _, err := req.Offset("test", p); err == nil {
+				got[p] = offset
+			}
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Job.Post() commits %v, want %v", got, want)
+	}
+}
+
+func TestJob_Destroy(t *testing.T) {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	if err := j.Destroy(context.TODO()); err != nil {
+		t.Errorf("Job.Destroy() error = %v", err)
+	}
+}
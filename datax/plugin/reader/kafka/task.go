@@ -0,0 +1,156 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	"github.com/Shopify/sarama"
+)
+
+//Task 任务
+type Task struct {
+	*plugin.BaseTask
+
+	param *paramConfig
+	codec kafka.Codec
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.param.TaskID != nil {
+		t.SetTaskID(*t.param.TaskID)
+	}
+
+	var opts kafka.Options
+	if opts, err = t.param.options(); err != nil {
+		return
+	}
+	if t.codec, err = kafka.NewCodec(t.param.Format, t.param.Column, opts); err != nil {
+		return
+	}
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//StartRead 开始读，依次读取每个分区偏移量范围中的消息
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	var ranges []*partitionRange
+	for _, v := range t.param.Ranges {
+		if v.Start < v.End {
+			ranges = append(ranges, v)
+		}
+	}
+
+	if len(ranges) > 0 {
+		var conf *sarama.Config
+		if conf, err = t.param.saramaConfig(); err != nil {
+			return
+		}
+
+		var consumer sarama.Consumer
+		if consumer, err = sarama.NewConsumer(t.param.Brokers, conf); err != nil {
+			return fmt.Errorf("brokers(%v) err: %v", t.param.Brokers, err)
+		}
+		defer consumer.Close()
+
+		for _, v := range ranges {
+			if err = t.readRange(ctx, consumer, v, sender); err != nil {
+				return fmt.Errorf("topic(%v) partition(%v) err: %v", t.param.Topic, v.Partition, err)
+			}
+		}
+	}
+	return sender.Terminate()
+}
+
+//readRange 读取分区偏移量范围r中的消息并发往写入器，读取到结束偏移量之前的消息时结束，
+//结束偏移量之前的偏移量可能是事务标记或者已被压缩，此时不会收到对应的消息，
+//所以超过idleTimeout没有新消息并且分区的高水位已达到结束偏移量时也结束
+func (t *Task) readRange(ctx context.Context, consumer sarama.Consumer, r *partitionRange, sender plugin.RecordSender) (err error) {
+	var pc sarama.PartitionConsumer
+	if pc, err = consumer.ConsumePartition(t.param.Topic, r.Partition, r.Start); err != nil {
+		return
+	}
+	defer pc.Close()
+
+	idle := time.NewTimer(t.param.getIdleTimeout())
+	defer idle.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-pc.Errors():
+			return e.Err
+		case <-idle.C:
+			if pc.HighWaterMarkOffset() >= r.End {
+				return
+			}
+			idle.Reset(t.param.getIdleTimeout())
+		case msg := <-pc.Messages():
+			if msg.Offset >= r.End {
+				return
+			}
+			if err = t.send(msg, sender); err != nil {
+				return fmt.Errorf("offset(%v) err: %v", msg.Offset, err)
+			}
+			if msg.Offset >= r.End-1 {
+				return
+			}
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(t.param.getIdleTimeout())
+		}
+	}
+}
+
+//send 将消息msg转化为记录并发往写入器，设置消息键的列名时消息键作为字符串列加入记录的末尾
+func (t *Task) send(msg *sarama.ConsumerMessage, sender plugin.RecordSender) (err error) {
+	var columns []element.Column
+	if columns, err = t.codec.Decode(msg.Value); err != nil {
+		return
+	}
+
+	if t.param.KeyColumn != "" {
+		cv := element.NewNilStringColumnValue()
+		if msg.Key != nil {
+			cv = element.NewStringColumnValue(string(msg.Key))
+		}
+		columns = append(columns, element.NewDefaultColumn(cv, t.param.KeyColumn, len(msg.Key)))
+	}
+
+	var record element.Record
+	if record, err = sender.CreateRecord(); err != nil {
+		return
+	}
+	for _, c := range columns {
+		if err = record.Add(c); err != nil {
+			return
+		}
+	}
+	return sender.SendWriter(record)
+}
@@ -0,0 +1,254 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Shopify/sarama"
+)
+
+//testTask 生成使用配置conf并初始化后的任务
+func testTask(t *testing.T, conf *config.JSON) *Task {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(conf)
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+//testSplit 初始化工作并切分成number个任务的配置
+func testSplit(t *testing.T, param string, number int) []*config.JSON {
+	confs, err := testJob(t, param).Split(context.TODO(), number)
+	if err != nil {
+		t.Fatalf("Job.Split() error = %v", err)
+	}
+	return confs
+}
+
+func TestTask_Init(t *testing.T) {
+	tests := []struct {
+		name       string
+		jobConf    *config.JSON
+		wantTaskID int
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			jobConf:    testJobConf(`{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}],"taskID":3}`),
+			wantTaskID: 3,
+		},
+		{
+			name:    "2",
+			jobConf: testJobConf(`{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"id"}],"fieldDelimiter":"||"}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJSONFromString(`{}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: plugin.NewBaseTask(),
+			}
+			task.SetPluginJobConf(tt.jobConf)
+			err := task.Init(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && task.TaskID() != tt.wantTaskID {
+				t.Errorf("Task.TaskID() = %v, want %v", task.TaskID(), tt.wantTaskID)
+			}
+		})
+	}
+}
+
+func TestTask_StartRead(t *testing.T) {
+	broker := testBroker(t, 1, nil)
+	defer broker.Close()
+
+	tests := []struct {
+		name   string
+		other  string
+		number int
+		want   [][]string
+	}{
+		{
+			name:   "1",
+			number: 2,
+			want: [][]string{
+				{"id:bigInt:1 name:string:a", "id:bigInt:2 name:string:<nil>", "id:bigInt:3 name:string:c"},
+				{"id:bigInt:4 name:string:d", "id:bigInt:5 name:string:e"},
+			},
+		},
+		{
+			name:   "2",
+			other:  `"keyColumn":"key","startTime":"1970-01-01T00:00:01Z","endTime":"1970-01-01T00:00:02Z"`,
+			number: 2,
+			want:   [][]string{{"id:bigInt:2 name:string:<nil> key:string:<nil>"}},
+		},
+		{
+			name:   "3",
+			other:  `"keyColumn":"key","startOffset":"committed","group":"group"`,
+			number: 1,
+			want: [][]string{{
+				"id:bigInt:2 name:string:<nil> key:string:<nil>", "id:bigInt:3 name:string:c key:string:c",
+				"id:bigInt:4 name:string:d key:string:d", "id:bigInt:5 name:string:e key:string:e",
+			}},
+		},
+		{
+			name:   "4",
+			other:  `"startOffset":"10"`,
+			number: 2,
+			want:   [][]string{nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			for _, conf := range testSplit(t, testParam(broker, tt.other), tt.number) {
+				sender := &mockSender{}
+				if err := testTask(t, conf).StartRead(context.TODO(), sender); err != nil {
+					t.Fatalf("Task.StartRead() error = %v", err)
+				}
+				if !sender.terminated {
+					t.Errorf("Task.StartRead() does not terminate")
+				}
+				got = append(got, testRecords(sender.records))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartReadErr(t *testing.T) {
+	errMock := errors.New("mock error")
+	invalid := &sarama.FetchResponse{Version: 4}
+	invalid.AddRecord("test", 0, nil, sarama.StringEncoder(`{"id":"a"}`), 0)
+	invalid.GetBlock("test", 0).HighWaterMarkOffset = 1
+	broken := &sarama.FetchResponse{Version: 4}
+	broken.AddError("test", 0, sarama.ErrOffsetOutOfRange)
+
+	tests := []struct {
+		name   string
+		fetch  sarama.MockResponse
+		sender *mockSender
+	}{
+		{
+			name:   "1",
+			sender: &mockSender{createErr: errMock},
+		},
+		{
+			name:   "2",
+			sender: &mockSender{sendErr: errMock},
+		},
+		{
+			name:   "3",
+			fetch:  sarama.NewMockWrapper(invalid),
+			sender: &mockSender{},
+		},
+		{
+			name:   "4",
+			fetch:  sarama.NewMockWrapper(broken),
+			sender: &mockSender{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handlers map[string]sarama.MockResponse
+			if tt.fetch != nil {
+				handlers = map[string]sarama.MockResponse{"FetchRequest": tt.fetch}
+			}
+			broker := testBroker(t, 1, handlers)
+			defer broker.Close()
+			confs := testSplit(t, testParam(broker, `"partitions":[0]`), 1)
+			if err := testTask(t, confs[0]).StartRead(context.TODO(), tt.sender); err == nil {
+				t.Errorf("Task.StartRead() error = nil, wantErr true")
+			}
+		})
+	}
+
+	broker := testBroker(t, 1, nil)
+	defer broker.Close()
+	confs := testSplit(t, testParam(broker, `"partitions":[0]`), 1)
+	closed := sarama.NewMockBroker(t, 2)
+	closed.Close()
+	if err := confs[0].Set("job.content.0.reader.parameter.brokers", []string{closed.Addr()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := testTask(t, confs[0]).StartRead(context.TODO(), &mockSender{}); err == nil {
+		t.Errorf("Task.StartRead() error = nil, wantErr true")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	confs = testSplit(t, testParam(broker, `"partitions":[0]`), 1)
+	if err := testTask(t, confs[0]).StartRead(ctx, &mockSender{}); err == nil {
+		t.Errorf("Task.StartRead() error = nil, wantErr true")
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
+
+func TestTask_StartReadGap(t *testing.T) {
+	tests := []struct {
+		name    string
+		hwm     int64
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			hwm:  3,
+			want: []string{"id:bigInt:1 name:string:a", "id:bigInt:2 name:string:b"},
+		},
+		{
+			name:    "2",
+			hwm:     2,
+			want:    []string{"id:bigInt:1 name:string:a", "id:bigInt:2 name:string:b"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//分区0的结束偏移量为3，偏移量2是事务标记，不会收到对应的消息
+			fetch := &sarama.FetchResponse{Version: 4}
+			fetch.AddRecord("test", 0, nil, sarama.StringEncoder(`{"id":1,"name":"a"}`), 0)
+			fetch.AddRecord("test", 0, nil, sarama.StringEncoder(`{"id":2,"name":"b"}`), 1)
+			fetch.GetBlock("test", 0).HighWaterMarkOffset = tt.hwm
+			broker := testBroker(t, 1, map[string]sarama.MockResponse{"FetchRequest": sarama.NewMockWrapper(fetch)})
+			defer broker.Close()
+
+			confs := testSplit(t, testParam(broker, `"partitions":[0],"idleTimeout":"50ms"`), 1)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			sender := &mockSender{}
+			err := testTask(t, confs[0]).StartRead(ctx, sender)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Task.StartRead() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := testRecords(sender.records); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package mongodb
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/storage/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type paramConfig struct {
+	mongodb.Config
+
+	Column     []string        `json:"column"`     //读取的字段名，嵌套的字段使用.分隔，如a.b
+	Filter     json.RawMessage `json:"filter"`     //查询条件，使用扩展JSON格式的文档，如{"status":"A"}
+	Projection json.RawMessage `json:"projection"` //投影，使用扩展JSON格式的文档，为空时返回完整的文档
+	BatchSize  int32           `json:"batchSize"`  //游标每批次返回的文档数，默认由服务器决定
+	Range      json.RawMessage `json:"range"`      //由Job.Split生成的_id范围，如{"$gte":1,"$lt":10}
+	TaskID     *int            `json:"taskID"`     //由Job.Split生成的任务ID
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	if err = p.Config.Validate(); err != nil {
+		return
+	}
+	if len(p.Column) == 0 {
+		return fmt.Errorf("column is empty")
+	}
+	for i, v := range p.Column {
+		if v == "" {
+			return fmt.Errorf("column(%v) is empty", i)
+		}
+	}
+	if _, err = p.filter(); err != nil {
+		return
+	}
+	if _, err = p.projection(); err != nil {
+		return
+	}
+	if p.BatchSize < 0 {
+		return fmt.Errorf("batchSize(%v) is less than 0", p.BatchSize)
+	}
+	return
+}
+
+//filter 获取查询条件，设置了_id范围时与查询条件同时满足
+func (p *paramConfig) filter() (filter bson.D, err error) {
+	if filter, err = parseDocument("filter", p.Filter); err != nil {
+		return
+	}
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	var r bson.D
+	if r, err = parseDocument("range", p.Range); err != nil {
+		return
+	}
+	if r == nil {
+		return
+	}
+	idRange := bson.D{{Key: "_id", Value: r}}
+	if len(filter) == 0 {
+		return idRange, nil
+	}
+	return bson.D{{Key: "$and", Value: bson.A{filter, idRange}}}, nil
+}
+
+//projection 获取投影，没有设置时为空
+func (p *paramConfig) projection() (bson.D, error) {
+	return parseDocument("projection", p.Projection)
+}
+
+//parseDocument 将名为name的扩展JSON格式的文档raw转化为文档，raw为空时返回空
+func parseDocument(name string, raw json.RawMessage) (doc bson.D, err error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	if err = bson.UnmarshalExtJSON(raw, false, &doc); err != nil {
+		return nil, fmt.Errorf("%v(%s) err: %v", name, raw, err)
+	}
+	return
+}
@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":["a","b.c"],"filter":{"a":{"$gt":1}},"projection":{"a":1},"batchSize":10}`,
+		},
+		{
+			name:    "2",
+			json:    `{"database":"db","collection":"c","column":["a"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":[""]}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":["a"],"filter":[1]}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":["a"],"projection":"a"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":["a"],"batchSize":-1}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":["a"],"range":{"$gte":{"$oid":"x"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			json:    `{"uri":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_filter(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{
+			name: "1",
+			json: `{}`,
+			want: `{}`,
+		},
+		{
+			name: "2",
+			json: `{"filter":null}`,
+			want: `{}`,
+		},
+		{
+			name: "3",
+			json: `{"filter":{"a":{"$date":"2021-01-02T00:00:00Z"}}}`,
+			want: `{"a":{"$date":{"$numberLong":"1609545600000"}}}`,
+		},
+		{
+			name: "4",
+			json: `{"filter":{},"range":{"$lt":{"$oid":"5f1d7e2a9b1e8a3c4d5e6f70"}}}`,
+			want: `{"_id":{"$lt":{"$oid":"5f1d7e2a9b1e8a3c4d5e6f70"}}}`,
+		},
+		{
+			name: "5",
+			json: `{"filter":{"a":"x"},"range":{"$gte":{"$numberInt":"1"}}}`,
+			want: `{"$and":[{"a":"x"},{"_id":{"$gte":{"$numberInt":"1"}}}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &paramConfig{}
+			if err := json.Unmarshal([]byte(tt.json), p); err != nil {
+				t.Fatal(err)
+			}
+			filter, err := p.filter()
+			if err != nil {
+				t.Fatalf("paramConfig.filter() error = %v", err)
+			}
+			got, err := bson.MarshalExtJSON(filter, true, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("paramConfig.filter() = %s, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package mongodb
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type mockSender struct {
+	createErr  error
+	sendErr    error
+	records    []element.Record
+	terminated bool
+}
+
+func (m *mockSender) CreateRecord() (element.Record, error) {
+	return element.NewDefaultRecord(), m.createErr
+}
+
+func (m *mockSender) SendWriter(record element.Record) error {
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *mockSender) Flush() error {
+	return nil
+}
+
+func (m *mockSender) Terminate() error {
+	m.terminated = true
+	return nil
+}
+
+func (m *mockSender) Shutdown() error {
+	return nil
+}
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+//testJobConf 生成读取器参数为param的工作配置
+func testJobConf(param string) *config.JSON {
+	return testJSONFromString(`{
+		"job":{
+			"content":[
+				{
+					"reader": {
+						"name":"mongodbreader",
+						"parameter":` + param + `
+					}
+				}
+			]
+		}
+	}`)
+}
+
+//testRecords 将记录records转化为字符串方便比较，每列为列名:列类型:列值，列之间用空格分隔
+func testRecords(records []element.Record) (s []string) {
+	for _, r := range records {
+		var cols []string
+		for i := 0; i < r.ColumnNumber(); i++ {
+			c, _ := r.GetByIndex(i)
+			cols = append(cols, fmt.Sprintf("%v:%v:%v", c.Name(), c.Type(), c.String()))
+		}
+		s = append(s, strings.Join(cols, " "))
+	}
+	return
+}
+
+//操作码
+const (
+	opReply = 1
+	opQuery = 2004
+	opMsg   = 2013
+)
+
+//testServer mongodb协议的本地替身，握手和结束会话由替身处理，
+//其他命令交由handler处理，handler返回空时回复{ok:1}
+type testServer struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	handler func(name string, cmd bson.Raw) interface{}
+}
+
+func newTestServer(handler func(name string, cmd bson.Raw) interface{}) *testServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	s := &testServer{
+		listener: l,
+		handler:  handler,
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+//URI 获取连接串
+func (s *testServer) URI() string {
+	return "mongodb://" + s.listener.Addr().String() + "/?connect=direct"
+}
+
+//Close 关闭
+func (s *testServer) Close() {
+	s.listener.Close()
+}
+
+func (s *testServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		header := make([]byte, 16)
+		if _, err := io.ReadFull(r, header); err != nil {
+			return
+		}
+		body := make([]byte, binary.LittleEndian.Uint32(header)-16)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return
+		}
+		requestID := binary.LittleEndian.Uint32(header[4:])
+
+		var reply []byte
+		switch binary.LittleEndian.Uint32(header[12:]) {
+		case opQuery:
+			//跳过flags和集合名
+			i := 4 + strings.IndexByte(string(body[4:]), 0) + 1 + 8
+			doc := s.do(bson.Raw(body[i:]))
+			reply = make([]byte, 20)
+			binary.LittleEndian.PutUint32(reply[16:], 1)
+			reply = append(reply, doc...)
+			reply = s.message(opReply, requestID, reply)
+		case opMsg:
+			doc := s.do(bson.Raw(body[5:]))
+			reply = s.message(opMsg, requestID, append(make([]byte, 5), doc...))
+		default:
+			return
+		}
+		if _, err := conn.Write(reply); err != nil {
+			return
+		}
+	}
+}
+
+//message 生成回复请求requestID的消息
+func (s *testServer) message(opCode, requestID uint32, body []byte) []byte {
+	header := make([]byte, 16)
+	binary.LittleEndian.PutUint32(header, uint32(len(body)+16))
+	binary.LittleEndian.PutUint32(header[8:], requestID)
+	binary.LittleEndian.PutUint32(header[12:], opCode)
+	return append(header, body...)
+}
+
+func (s *testServer) do(cmd bson.Raw) []byte {
+	var reply interface{}
+	name := cmd.Index(0).Key()
+	switch strings.ToLower(name) {
+	case "ismaster", "hello":
+		reply = bson.D{
+			{Key: "ismaster", Value: true},
+			{Key: "maxBsonObjectSize", Value: 16 * 1024 * 1024},
+			{Key: "maxMessageSizeBytes", Value: 48000000},
+			{Key: "maxWriteBatchSize", Value: 100000},
+			{Key: "minWireVersion", Value: 0},
+			{Key: "maxWireVersion", Value: 8},
+			{Key: "ok", Value: 1},
+		}
+	case "endsessions", "killcursors":
+	default:
+		s.mu.Lock()
+		reply = s.handler(name, cmd)
+		s.mu.Unlock()
+	}
+	if reply == nil {
+		reply = bson.D{{Key: "ok", Value: 1}}
+	}
+	b, err := bson.Marshal(reply)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+//testCursor 生成游标的回复，batch为firstBatch或者nextBatch
+func testCursor(batch string, id int64, docs ...interface{}) bson.D {
+	return bson.D{
+		{Key: "cursor", Value: bson.D{
+			{Key: batch, Value: append(bson.A{}, docs...)},
+			{Key: "id", Value: id},
+			{Key: "ns", Value: "db.c"},
+		}},
+		{Key: "ok", Value: 1},
+	}
+}
+
+//testError 生成命令失败的回复
+func testError(msg string) bson.D {
+	return bson.D{
+		{Key: "ok", Value: 0},
+		{Key: "errmsg", Value: msg},
+		{Key: "code", Value: 2},
+	}
+}
@@ -0,0 +1,124 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//Job 工作
+type Job struct {
+	*plugin.BaseJob
+
+	param  *paramConfig
+	client *mongo.Client
+}
+
+//Init 初始化
+func (j *Job) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if j.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if j.client, err = j.param.Connect(ctx); err != nil {
+		return
+	}
+	return
+}
+
+//Destroy 销毁
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	if j.client != nil {
+		err = j.client.Disconnect(ctx)
+		j.client = nil
+	}
+	return
+}
+
+//Split 切分，number大于1时将满足查询条件的文档按照_id切分成至多number个数量相近的范围，
+//每个任务读取一个范围，否则只生成一个读取所有文档的任务
+func (j *Job) Split(ctx context.Context, number int) (confs []*config.JSON, err error) {
+	var bounds []bson.RawValue
+	if number > 1 {
+		if bounds, err = j.bounds(ctx, number); err != nil {
+			return nil, fmt.Errorf("collection(%v) err: %v", j.param.Collection, err)
+		}
+	}
+
+	//n个边界切分出n+1个范围
+	for i := 0; i <= len(bounds); i++ {
+		conf := j.PluginJobConf().CloneConfig()
+		if len(bounds) > 0 {
+			r := bson.D{}
+			if i > 0 {
+				r = append(r, bson.E{Key: "$gte", Value: bounds[i-1]})
+			}
+			if i < len(bounds) {
+				r = append(r, bson.E{Key: "$lt", Value: bounds[i]})
+			}
+			var b []byte
+			if b, err = bson.MarshalExtJSON(r, true, false); err != nil {
+				return nil, err
+			}
+			if err = conf.SetRawBytes(coreconst.DataxJobContentReaderParameter+".range", b); err != nil {
+				return nil, err
+			}
+		}
+		if err = conf.Set(coreconst.DataxJobContentReaderParameter+".taskID", i); err != nil {
+			return nil, err
+		}
+		confs = append(confs, conf)
+	}
+	return
+}
+
+//bounds 通过$bucketAuto将满足查询条件的文档按照_id分成至多number个桶，
+//返回除第一个桶以外每个桶的最小_id作为范围的边界
+func (j *Job) bounds(ctx context.Context, number int) (bounds []bson.RawValue, err error) {
+	var filter bson.D
+	if filter, err = j.param.filter(); err != nil {
+		return
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$bucketAuto", Value: bson.D{
+			{Key: "groupBy", Value: "$_id"},
+			{Key: "buckets", Value: number},
+		}}},
+	}
+
+	var cursor *mongo.Cursor
+	if cursor, err = j.param.GetCollection(j.client).Aggregate(ctx, pipeline,
+		options.Aggregate().SetAllowDiskUse(true)); err != nil {
+		return
+	}
+	defer cursor.Close(ctx)
+
+	for first := true; cursor.Next(ctx); first = false {
+		min, lerr := cursor.Current.LookupErr("_id", "min")
+		if lerr != nil {
+			return nil, fmt.Errorf("bucket(%v) err: %v", cursor.Current, lerr)
+		}
+		if !first {
+			//Current只在下次调用Next前有效，需要复制
+			min.Value = append([]byte(nil), min.Value...)
+			bounds = append(bounds, min)
+		}
+	}
+	if err = cursor.Err(); err != nil {
+		return
+	}
+	log.Infof("mongodbreader collection(%v) is split into %v ranges", j.param.Collection, len(bounds)+1)
+	return
+}
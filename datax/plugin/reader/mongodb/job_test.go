@@ -0,0 +1,202 @@
+package mongodb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func testJob(t *testing.T, param string) *Job {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	j.SetPluginJobConf(testJobConf(param))
+	if err := j.Init(context.TODO()); err != nil {
+		t.Fatalf("Job.Init() error = %v", err)
+	}
+	t.Cleanup(func() {
+		j.Destroy(context.TODO())
+	})
+	return j
+}
+
+//testBucket 生成$bucketAuto的桶
+func testBucket(min, max interface{}, count int) bson.D {
+	return bson.D{
+		{Key: "_id", Value: bson.D{{Key: "min", Value: min}, {Key: "max", Value: max}}},
+		{Key: "count", Value: count},
+	}
+}
+
+func TestJob_Init(t *testing.T) {
+	ts := newTestServer(func(name string, cmd bson.Raw) interface{} {
+		return nil
+	})
+	defer ts.Close()
+
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJobConf(`{"uri":"` + ts.URI() + `","database":"db","collection":"c","column":["a"]}`),
+		},
+		{
+			name:    "2",
+			jobConf: testJobConf(`{"uri":"mongodb://127.0.0.1:1/?connect=direct","database":"db","collection":"c","column":["a"],"timeout":"100ms"}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJobConf(`{"uri":"` + ts.URI() + `","database":"db","collection":"c"}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			jobConf: testJSONFromString(`{}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				BaseJob: plugin.NewBaseJob(),
+			}
+			j.SetPluginJobConf(tt.jobConf)
+			defer j.Destroy(context.TODO())
+			if err := j.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJob_Split(t *testing.T) {
+	var pipeline bson.RawValue
+	ts := newTestServer(func(name string, cmd bson.Raw) interface{} {
+		if name != "aggregate" {
+			return nil
+		}
+		pipeline = cmd.Lookup("pipeline")
+		switch cmd.Lookup("aggregate").StringValue() {
+		case "empty":
+			return testCursor("firstBatch", 0)
+		case "one":
+			return testCursor("firstBatch", 0, testBucket(int32(1), int32(9), 9))
+		}
+		return testCursor("firstBatch", 0,
+			testBucket(int32(1), int32(4), 3),
+			testBucket(int32(4), int64(7), 3),
+			testBucket(int64(7), "a", 3))
+	})
+	defer ts.Close()
+
+	tests := []struct {
+		name       string
+		collection string
+		number     int
+		want       []string
+	}{
+		{
+			name:       "1",
+			collection: "c",
+			number:     1,
+			want:       []string{""},
+		},
+		{
+			name:       "2",
+			collection: "c",
+			number:     3,
+			want: []string{
+				`{"$lt":{"$numberInt":"4"}}`,
+				`{"$gte":{"$numberInt":"4"},"$lt":{"$numberLong":"7"}}`,
+				`{"$gte":{"$numberLong":"7"}}`,
+			},
+		},
+		{
+			name:       "3",
+			collection: "empty",
+			number:     3,
+			want:       []string{""},
+		},
+		{
+			name:       "4",
+			collection: "one",
+			number:     3,
+			want:       []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := testJob(t, `{"uri":"`+ts.URI()+`","database":"db","collection":"`+tt.collection+`","column":["a"],"filter":{"a":1}}`)
+			confs, err := j.Split(context.TODO(), tt.number)
+			if err != nil {
+				t.Fatalf("Job.Split() error = %v", err)
+			}
+			var got []string
+			for i, conf := range confs {
+				id, err := conf.GetInt64(coreconst.DataxJobContentReaderParameter + ".taskID")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if id != int64(i) {
+					t.Errorf("Job.Split() taskID = %v, want %v", id, i)
+				}
+				r, _ := conf.GetConfig(coreconst.DataxJobContentReaderParameter + ".range")
+				s := ""
+				if r != nil {
+					s = r.String()
+				}
+				got = append(got, s)
+
+				task := testTask(t, conf)
+				if _, err = task.param.filter(); err != nil {
+					t.Errorf("Job.Split() filter error = %v", err)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.Split() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	want := `[{"$match": {"a": {"$numberInt":"1"}}},{"$bucketAuto": {"groupBy": "$_id","buckets": {"$numberInt":"3"}}}]`
+	if pipeline.String() != want {
+		t.Errorf("Job.Split() pipeline = %v, want %v", pipeline, want)
+	}
+}
+
+func TestJob_SplitErr(t *testing.T) {
+	ts := newTestServer(func(name string, cmd bson.Raw) interface{} {
+		if name != "aggregate" {
+			return nil
+		}
+		if cmd.Lookup("aggregate").StringValue() == "fail" {
+			return testError("mock error")
+		}
+		return testCursor("firstBatch", 0, bson.D{{Key: "_id", Value: 1}}, bson.D{{Key: "_id", Value: 2}})
+	})
+	defer ts.Close()
+
+	for i, collection := range []string{"fail", "invalid"} {
+		j := testJob(t, `{"uri":"`+ts.URI()+`","database":"db","collection":"`+collection+`","column":["a"]}`)
+		if _, err := j.Split(context.TODO(), 2); err == nil {
+			t.Errorf("%v Job.Split() error = nil, wantErr true", i)
+		}
+	}
+}
+
+func TestJob_Destroy(t *testing.T) {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	if err := j.Destroy(context.TODO()); err != nil {
+		t.Errorf("Job.Destroy() error = %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//Task 任务
+type Task struct {
+	*plugin.BaseTask
+
+	param *paramConfig
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.param.TaskID != nil {
+		t.SetTaskID(*t.param.TaskID)
+	}
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//StartRead 开始读，读取满足查询条件和_id范围的文档，每个文档转化为一条记录
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	var filter, projection bson.D
+	if filter, err = t.param.filter(); err != nil {
+		return
+	}
+	if projection, err = t.param.projection(); err != nil {
+		return
+	}
+
+	var client *mongo.Client
+	if client, err = t.param.Connect(ctx); err != nil {
+		return
+	}
+	defer client.Disconnect(context.Background())
+
+	opts := options.Find()
+	if projection != nil {
+		opts.SetProjection(projection)
+	}
+	if t.param.BatchSize > 0 {
+		opts.SetBatchSize(t.param.BatchSize)
+	}
+
+	var cursor *mongo.Cursor
+	if cursor, err = t.param.GetCollection(client).Find(ctx, filter, opts); err != nil {
+		return fmt.Errorf("collection(%v) err: %v", t.param.Collection, err)
+	}
+	defer cursor.Close(context.Background())
+
+	for cursor.Next(ctx) {
+		if err = t.send(cursor.Current, sender); err != nil {
+			return fmt.Errorf("document(%v) err: %v", mongodb.Lookup(cursor.Current, "_id"), err)
+		}
+	}
+	if err = cursor.Err(); err != nil {
+		return fmt.Errorf("collection(%v) err: %v", t.param.Collection, err)
+	}
+	log.Debugf("mongodbreader task(%v) read end", t.TaskID())
+	return sender.Terminate()
+}
+
+//send 将文档doc中的字段按照列的顺序转化为记录并发往写入器，不存在的字段转化为空值
+func (t *Task) send(doc bson.Raw, sender plugin.RecordSender) (err error) {
+	var record element.Record
+	if record, err = sender.CreateRecord(); err != nil {
+		return
+	}
+	for _, name := range t.param.Column {
+		v := mongodb.Lookup(doc, name)
+		var cv element.ColumnValue
+		if cv, err = mongodb.NewColumnValue(v); err != nil {
+			return fmt.Errorf("field(%v) err: %v", name, err)
+		}
+		if err = record.Add(element.NewDefaultColumn(cv, name, len(v.Value))); err != nil {
+			return
+		}
+	}
+	return sender.SendWriter(record)
+}
@@ -0,0 +1,246 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testTask(t *testing.T, conf *config.JSON) *Task {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(conf)
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+//testDocs 测试文档
+func testDocs() []interface{} {
+	id, _ := primitive.ObjectIDFromHex("5f1d7e2a9b1e8a3c4d5e6f70")
+	d, _ := primitive.ParseDecimal128("12.345")
+	return []interface{}{
+		bson.D{
+			{Key: "_id", Value: id},
+			{Key: "name", Value: "a"},
+			{Key: "price", Value: d},
+			{Key: "created", Value: primitive.NewDateTimeFromTime(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC))},
+			{Key: "user", Value: bson.D{{Key: "age", Value: int32(18)}, {Key: "tags", Value: bson.A{"x", "y"}}}},
+		},
+		bson.D{
+			{Key: "_id", Value: int64(2)},
+			{Key: "name", Value: nil},
+			{Key: "price", Value: 1.5},
+		},
+	}
+}
+
+func TestTask_Init(t *testing.T) {
+	tests := []struct {
+		name       string
+		conf       *config.JSON
+		wantTaskID int
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			conf:       testJobConf(`{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":["_id"],"taskID":2}`),
+			wantTaskID: 2,
+		},
+		{
+			name: "2",
+			conf: testJobConf(`{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":["_id"]}`),
+		},
+		{
+			name:    "3",
+			conf:    testJobConf(`{"uri":"mongodb://127.0.0.1","database":"db","collection":"c"}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: plugin.NewBaseTask(),
+			}
+			task.SetPluginJobConf(tt.conf)
+			if err := task.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Fatalf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if task.TaskID() != tt.wantTaskID {
+				t.Errorf("Task.TaskID() = %v, want %v", task.TaskID(), tt.wantTaskID)
+			}
+		})
+	}
+}
+
+func TestTask_StartRead(t *testing.T) {
+	var find bson.Raw
+	ts := newTestServer(func(name string, cmd bson.Raw) interface{} {
+		docs := testDocs()
+		switch name {
+		case "find":
+			find = cmd
+			return testCursor("firstBatch", 1, docs[0])
+		case "getMore":
+			return testCursor("nextBatch", 0, docs[1])
+		}
+		return nil
+	})
+	defer ts.Close()
+
+	tests := []struct {
+		name           string
+		param          string
+		want           []string
+		wantFilter     string
+		wantProjection string
+		wantBatchSize  int32
+	}{
+		{
+			name:  "1",
+			param: `"column":["_id","name","price","created","user","user.age","none"]`,
+			want: []string{
+				"_id:string:5f1d7e2a9b1e8a3c4d5e6f70 name:string:a price:decimal:12.345 created:time:2021-01-02T03:04:05Z " +
+					`user:string:{"age":18,"tags":["x","y"]} user.age:bigInt:18 none:string:<nil>`,
+				"_id:bigInt:2 name:string:<nil> price:decimal:1.5 created:string:<nil> " +
+					"user:string:<nil> user.age:string:<nil> none:string:<nil>",
+			},
+			wantFilter: `{}`,
+		},
+		{
+			name:  "2",
+			param: `"column":["name"],"filter":{"status":"A"},"projection":{"name":1},"batchSize":1,"range":{"$gte":{"$numberLong":"1"},"$lt":{"$numberLong":"5"}}`,
+			want: []string{
+				"name:string:a",
+				"name:string:<nil>",
+			},
+			wantFilter:     `{"$and": [{"status": "A"},{"_id": {"$gte": {"$numberLong":"1"},"$lt": {"$numberLong":"5"}}}]}`,
+			wantProjection: `{"name": {"$numberInt":"1"}}`,
+			wantBatchSize:  1,
+		},
+		{
+			name:       "3",
+			param:      `"column":["name"],"range":{"$gte":{"$numberLong":"1"}}`,
+			want:       []string{"name:string:a", "name:string:<nil>"},
+			wantFilter: `{"_id": {"$gte": {"$numberLong":"1"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask(t, testJobConf(`{"uri":"`+ts.URI()+`","database":"db","collection":"c",`+tt.param+`}`))
+			sender := &mockSender{}
+			if err := task.StartRead(context.TODO(), sender); err != nil {
+				t.Fatalf("Task.StartRead() error = %v", err)
+			}
+			if !sender.terminated {
+				t.Errorf("Task.StartRead() not terminated")
+			}
+			if got := testRecords(sender.records); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+			if got := find.Lookup("filter").String(); got != tt.wantFilter {
+				t.Errorf("Task.StartRead() filter = %v, want %v", got, tt.wantFilter)
+			}
+			if v, err := find.LookupErr("projection"); (err == nil && v.String() != tt.wantProjection) ||
+				(err != nil && tt.wantProjection != "") {
+				t.Errorf("Task.StartRead() projection = %v, want %v", v, tt.wantProjection)
+			}
+			if v, _ := find.Lookup("batchSize").AsInt32OK(); v != tt.wantBatchSize {
+				t.Errorf("Task.StartRead() batchSize = %v, want %v", v, tt.wantBatchSize)
+			}
+		})
+	}
+}
+
+func TestTask_StartReadErr(t *testing.T) {
+	ts := newTestServer(func(name string, cmd bson.Raw) interface{} {
+		switch name {
+		case "find":
+			if cmd.Lookup("find").StringValue() == "fail" {
+				return testError("mock error")
+			}
+			return testCursor("firstBatch", 1, testDocs()...)
+		case "getMore":
+			return testError("mock error")
+		}
+		return nil
+	})
+	defer ts.Close()
+	nan := bson.D{{Key: "_id", Value: 1}, {Key: "a", Value: primitive.NewDecimal128(0x7c00000000000000, 0)}}
+	bad := newTestServer(func(name string, cmd bson.Raw) interface{} {
+		if name == "find" {
+			return testCursor("firstBatch", 0, nan)
+		}
+		return nil
+	})
+	defer bad.Close()
+
+	errMock := errors.New("mock error")
+	tests := []struct {
+		name   string
+		param  string
+		sender *mockSender
+	}{
+		{
+			name:   "1",
+			param:  `{"uri":"` + ts.URI() + `","database":"db","collection":"fail","column":["name"]}`,
+			sender: &mockSender{},
+		},
+		{
+			name:   "2",
+			param:  `{"uri":"` + ts.URI() + `","database":"db","collection":"c","column":["name"]}`,
+			sender: &mockSender{},
+		},
+		{
+			name:   "3",
+			param:  `{"uri":"` + ts.URI() + `","database":"db","collection":"c","column":["name"]}`,
+			sender: &mockSender{createErr: errMock},
+		},
+		{
+			name:   "4",
+			param:  `{"uri":"` + ts.URI() + `","database":"db","collection":"c","column":["name"]}`,
+			sender: &mockSender{sendErr: errMock},
+		},
+		{
+			name:   "5",
+			param:  `{"uri":"` + bad.URI() + `","database":"db","collection":"c","column":["a"]}`,
+			sender: &mockSender{},
+		},
+		{
+			name:   "6",
+			param:  `{"uri":"mongodb://127.0.0.1:1/?connect=direct","database":"db","collection":"c","column":["a"],"timeout":"100ms"}`,
+			sender: &mockSender{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask(t, testJobConf(tt.param))
+			if err := task.StartRead(context.TODO(), tt.sender); err == nil {
+				t.Errorf("Task.StartRead() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
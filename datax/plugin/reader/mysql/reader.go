@@ -3,11 +3,8 @@ package mysql
 import (
 	"path/filepath"
 
-	"github.com/Breeze0806/go-etl/config"
-	"github.com/Breeze0806/go-etl/datax/common/plugin"
 	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
-	"github.com/Breeze0806/go-etl/datax/common/spi/reader"
-	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/rdbm"
 )
 
 func init() {
@@ -15,7 +12,7 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	name, err := reader.pluginConf.GetString("name")
+	name, err := reader.PluginConf().GetString("name")
 	if err != nil {
 		panic(err)
 	}
@@ -25,41 +22,16 @@ func init() {
 	loader.RegisterReader(name, reader)
 }
 
-//Reader 读取器
+//Reader 读取器，使用插件配置中dialect为mysql的关系型数据库读取器
 type Reader struct {
-	pluginConf *config.JSON
+	*rdbm.Reader
 }
 
 //NewReader 创建读取器
 func NewReader(filename string) (r *Reader, err error) {
 	r = &Reader{}
-	r.pluginConf, err = config.NewJSONFromFile(filename)
-	if err != nil {
+	if r.Reader, err = rdbm.NewReader(filename); err != nil {
 		return nil, err
 	}
 	return
 }
-
-//Job 工作
-func (r *Reader) Job() reader.Job {
-	job := &Job{
-		BaseJob: plugin.NewBaseJob(),
-		newQuerier: func(name string, conf *config.JSON) (Querier, error) {
-			return database.Open(name, conf)
-		},
-	}
-	job.SetPluginConf(r.pluginConf)
-	return job
-}
-
-//Task 任务
-func (r *Reader) Task() reader.Task {
-	task := &Task{
-		BaseTask: plugin.NewBaseTask(),
-		newQuerier: func(name string, conf *config.JSON) (Querier, error) {
-			return database.Open(name, conf)
-		},
-	}
-	task.SetPluginConf(r.pluginConf)
-	return task
-}
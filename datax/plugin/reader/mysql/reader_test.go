@@ -6,9 +6,6 @@ import (
 	"testing"
 
 	"github.com/Breeze0806/go-etl/config"
-	"github.com/Breeze0806/go-etl/datax/common/plugin"
-	"github.com/Breeze0806/go-etl/datax/common/spi/reader"
-	"github.com/Breeze0806/go-etl/storage/database"
 )
 
 func testReader(filename string) *Reader {
@@ -19,30 +16,30 @@ func testReader(filename string) *Reader {
 	return reader
 }
 
+func testJSONFromFile(filename string) *config.JSON {
+	conf, err := config.NewJSONFromFile(filename)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
 func TestReader_Job(t *testing.T) {
 	tests := []struct {
 		name string
 		r    *Reader
-		want reader.Job
 		conf *config.JSON
 	}{
 		{
 			name: "1",
 			r:    testReader(filepath.Join("resources", "plugin.json")),
-			want: &Job{
-				BaseJob: plugin.NewBaseJob(),
-				newQuerier: func(name string, conf *config.JSON) (Querier, error) {
-					return database.Open(name, conf)
-				},
-			},
 			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.want.SetPluginConf(tt.conf)
-			if got := tt.r.Job(); !reflect.DeepEqual(got.PluginConf(), tt.want.PluginConf()) {
-				t.Errorf("Reader.Job() = %v, want %v", got, tt.want)
+			if got := tt.r.Job(); !reflect.DeepEqual(got.PluginConf(), tt.conf) {
+				t.Errorf("Reader.Job().PluginConf() = %v, want %v", got.PluginConf(), tt.conf)
 			}
 		})
 	}
@@ -52,26 +49,18 @@ func TestReader_Task(t *testing.T) {
 	tests := []struct {
 		name string
 		r    *Reader
-		want reader.Task
 		conf *config.JSON
 	}{
 		{
 			name: "1",
 			r:    testReader(filepath.Join("resources", "plugin.json")),
-			want: &Task{
-				BaseTask: plugin.NewBaseTask(),
-				newQuerier: func(name string, conf *config.JSON) (Querier, error) {
-					return database.Open(name, conf)
-				},
-			},
 			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.want.SetPluginConf(tt.conf)
-			if got := tt.r.Task(); !reflect.DeepEqual(got.PluginConf(), tt.want.PluginConf()) {
-				t.Errorf("Reader.Task() = %v, want %v", got, tt.want)
+			if got := tt.r.Task(); !reflect.DeepEqual(got.PluginConf(), tt.conf) {
+				t.Errorf("Reader.Task().PluginConf() = %v, want %v", got.PluginConf(), tt.conf)
 			}
 		})
 	}
@@ -82,17 +71,17 @@ func TestNewReader(t *testing.T) {
 		filename string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		wantR   *Reader
-		wantErr bool
+		name     string
+		args     args
+		wantConf *config.JSON
+		wantErr  bool
 	}{
 		{
 			name: "1",
 			args: args{
 				filename: filepath.Join("resources", "plugin.json"),
 			},
-			wantR: testReader(filepath.Join("resources", "plugin.json")),
+			wantConf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
 		},
 		{
 			name: "2",
@@ -109,8 +98,11 @@ func TestNewReader(t *testing.T) {
 				t.Errorf("NewReader() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(gotR, tt.wantR) {
-				t.Errorf("NewReader() = %v, want %v", gotR, tt.wantR)
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(gotR.PluginConf(), tt.wantConf) {
+				t.Errorf("NewReader().PluginConf() = %v, want %v", gotR.PluginConf(), tt.wantConf)
 			}
 		})
 	}
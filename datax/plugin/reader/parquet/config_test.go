@@ -0,0 +1,62 @@
+package parquet
+
+import (
+	"reflect"
+	"testing"
+
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    *paramConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"path":["a.parquet"],"column":["a","b.c"],"split":{"path":"a.parquet","rowGroups":[1]}}`,
+			want: &paramConfig{
+				Path:   []string{"a.parquet"},
+				Column: []string{"a", "b.c"},
+				Split:  &split{Path: "a.parquet", RowGroups: []int{1}},
+			},
+		},
+		{
+			name:    "2",
+			json:    `{"path":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"path":"a.parquet"}`,
+			wantErr: true,
+		},
+		{
+			name: "4",
+			json: `{"path":["s3://bucket/a.parquet"],"s3":{"region":"us-west-2"}}`,
+			want: &paramConfig{
+				FileSystemConfig: streamfile.FileSystemConfig{S3: &streamfile.S3Config{Region: "us-west-2"}},
+				Path:             []string{"s3://bucket/a.parquet"},
+			},
+		},
+		{
+			name:    "5",
+			json:    `{"path":["s3://bucket/a.parquet"],"s3":{"secretKey":"sk"}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newParamConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
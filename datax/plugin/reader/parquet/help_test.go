@@ -0,0 +1,138 @@
+package parquet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	streamparquet "github.com/Breeze0806/go-etl/storage/stream/file/parquet"
+)
+
+//testRecord 生成列为columns的记录
+func testRecord(columns ...element.Column) element.Record {
+	r := element.NewDefaultRecord()
+	for _, c := range columns {
+		r.Add(c)
+	}
+	return r
+}
+
+//testManyRecords 生成n条记录，记录足够多时写入的文件会有多个行组
+func testManyRecords(n int) (records []element.Record) {
+	for i := 0; i < n; i++ {
+		records = append(records, testRecord(
+			element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(int64(i)), "id", 0),
+			element.NewDefaultColumn(element.NewStringColumnValue(strings.Repeat("a", i%100)), "s", 0),
+		))
+	}
+	return
+}
+
+type mockSender struct {
+	createErr  error
+	sendErr    error
+	records    []element.Record
+	terminated bool
+}
+
+func (m *mockSender) CreateRecord() (element.Record, error) {
+	return element.NewDefaultRecord(), m.createErr
+}
+
+func (m *mockSender) SendWriter(record element.Record) error {
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *mockSender) Flush() error {
+	return nil
+}
+
+func (m *mockSender) Terminate() error {
+	m.terminated = true
+	return nil
+}
+
+func (m *mockSender) Shutdown() error {
+	return nil
+}
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func testTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+//testWriteParquet 向dir中的文件name写入记录records，记录足够多时会生成多个行组，返回文件路径
+func testWriteParquet(t *testing.T, dir, name string, records ...element.Record) string {
+	filename := filepath.Join(dir, name)
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w, err := streamparquet.NewWriter(f, nil, streamparquet.Options{RowGroupSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		if err = w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+//testJobConf 生成读取器参数为param的工作配置
+func testJobConf(param string) *config.JSON {
+	return testJSONFromString(`{
+		"job":{
+			"content":[
+				{
+					"reader": {
+						"name":"parquetreader",
+						"parameter":` + param + `
+					}
+				}
+			]
+		}
+	}`)
+}
+
+//testRecords 将记录records转化为字符串方便比较，每列为列名:列类型:列值，列之间用空格分隔
+func testRecords(records []element.Record) (s []string) {
+	for _, r := range records {
+		var cols []string
+		for i := 0; i < r.ColumnNumber(); i++ {
+			c, _ := r.GetByIndex(i)
+			cols = append(cols, fmt.Sprintf("%v:%v:%v", c.Name(), c.Type(), c.String()))
+		}
+		s = append(s, strings.Join(cols, " "))
+	}
+	return
+}
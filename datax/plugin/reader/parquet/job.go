@@ -0,0 +1,80 @@
+package parquet
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+	streamparquet "github.com/Breeze0806/go-etl/storage/stream/file/parquet"
+)
+
+//Job 工作
+type Job struct {
+	*plugin.BaseJob
+
+	param     *paramConfig
+	fs        streamfile.FileSystem
+	filenames []string
+}
+
+//Init 初始化
+func (j *Job) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if j.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+	j.fs = streamfile.NewFileSystem(&j.param.FileSystemConfig)
+
+	if j.filenames, err = streamfile.Glob(j.fs, j.param.Path); err != nil {
+		return
+	}
+	return
+}
+
+//Destroy 销毁
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//Split 切分，每个行组一个任务，没有行组的文件也会生成一个任务
+func (j *Job) Split(ctx context.Context, number int) (confs []*config.JSON, err error) {
+	for _, filename := range j.filenames {
+		var n int
+		if n, err = j.numRowGroups(filename); err != nil {
+			return nil, err
+		}
+
+		splits := []*split{{Path: filename, RowGroups: []int{}}}
+		if n > 0 {
+			splits = nil
+			for i := 0; i < n; i++ {
+				splits = append(splits, &split{Path: filename, RowGroups: []int{i}})
+			}
+		}
+
+		for _, s := range splits {
+			conf := j.PluginJobConf().CloneConfig()
+			if err = conf.Set(coreconst.DataxJobContentReaderParameter+".split", s); err != nil {
+				return nil, err
+			}
+			confs = append(confs, conf)
+		}
+	}
+	return
+}
+
+//numRowGroups 获取文件filename的行组数，同时检查需要读取的列
+func (j *Job) numRowGroups(filename string) (n int, err error) {
+	var r *streamparquet.Reader
+	if r, err = streamparquet.NewReader(j.fs, filename, j.param.Column); err != nil {
+		return
+	}
+	defer r.Close()
+	return r.NumRowGroups(), nil
+}
@@ -0,0 +1,102 @@
+package parquet
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+	streamparquet "github.com/Breeze0806/go-etl/storage/stream/file/parquet"
+)
+
+//Task 任务
+type Task struct {
+	*plugin.BaseTask
+
+	param *paramConfig
+	fs    streamfile.FileSystem
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+	t.fs = streamfile.NewFileSystem(&t.param.FileSystemConfig)
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//StartRead 开始读，在没有切分配置时读取所有匹配的文件的所有行组
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	var splits []*split
+	if t.param.Split != nil {
+		splits = []*split{t.param.Split}
+	} else {
+		var filenames []string
+		if filenames, err = streamfile.Glob(t.fs, t.param.Path); err != nil {
+			return
+		}
+		for _, v := range filenames {
+			splits = append(splits, &split{Path: v})
+		}
+	}
+
+	for _, v := range splits {
+		if err = t.readSplit(ctx, v, sender); err != nil {
+			return
+		}
+	}
+	return sender.Terminate()
+}
+
+//readSplit 读取切分s中的行组并发往写入器，切分中的行组为nil时读取所有行组
+func (t *Task) readSplit(ctx context.Context, s *split, sender plugin.RecordSender) (err error) {
+	var r *streamparquet.Reader
+	if r, err = streamparquet.NewReader(t.fs, s.Path, t.param.Column); err != nil {
+		return
+	}
+	defer r.Close()
+
+	rowGroups := s.RowGroups
+	if rowGroups == nil {
+		for i := 0; i < r.NumRowGroups(); i++ {
+			rowGroups = append(rowGroups, i)
+		}
+	}
+
+	for _, i := range rowGroups {
+		if err = r.ReadRowGroup(i, func(columns []element.Column) (err error) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			var record element.Record
+			if record, err = sender.CreateRecord(); err != nil {
+				return
+			}
+			for _, c := range columns {
+				if err = record.Add(c); err != nil {
+					return
+				}
+			}
+			return sender.SendWriter(record)
+		}); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,197 @@
+package parquet
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testTask(t *testing.T, param string) *Task {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+func TestTask_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":["a.parquet"]}`,
+		},
+		{
+			name:    "2",
+			param:   `{"path":[]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: plugin.NewBaseTask(),
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			if err := task.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartRead(t *testing.T) {
+	dir := testTempDir(t)
+	d, _ := element.NewDecimalColumnValueFromString("12345678901234567890.5")
+	a := testWriteParquet(t, dir, "a.parquet", testRecord(
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 6000, time.UTC)), "time", 0),
+		element.NewDefaultColumn(d, "decimal", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "s", 0),
+	))
+	b := testWriteParquet(t, dir, "b.parquet", testRecord(
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(2), "id", 0),
+		element.NewDefaultColumn(element.NewNilTimeColumnValue(), "time", 0),
+		element.NewDefaultColumn(element.NewNilDecimalColumnValue(), "decimal", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue("b"), "s", 0),
+	))
+	tests := []struct {
+		name    string
+		param   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":["` + filepath.Join(dir, "*.parquet") + `"]}`,
+			want: []string{
+				"id:bigInt:1 time:time:2021-01-02T03:04:05.000006Z decimal:decimal:12345678901234567890.5 s:string:<nil>",
+				"id:bigInt:2 time:time:<nil> decimal:decimal:<nil> s:string:b",
+			},
+		},
+		{
+			name:  "2",
+			param: `{"path":["` + a + `"],"column":["s","id"],"split":{"path":"` + b + `","rowGroups":[0]}}`,
+			want: []string{
+				"s:string:b id:bigInt:2",
+			},
+		},
+		{
+			name:  "3",
+			param: `{"path":["` + a + `"],"split":{"path":"` + b + `","rowGroups":[]}}`,
+		},
+		{
+			name:    "4",
+			param:   `{"path":["` + a + `"],"split":{"path":"` + b + `","rowGroups":[1]}}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			param:   `{"path":["` + filepath.Join(dir, "*.csv") + `"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			param:   `{"path":["` + a + `"],"column":["none"]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &mockSender{}
+			err := testTask(t, tt.param).StartRead(context.TODO(), sender)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Task.StartRead() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if sender.terminated == tt.wantErr {
+				t.Errorf("Task.StartRead() terminated = %v", sender.terminated)
+			}
+			if got := testRecords(sender.records); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartReadRowGroups(t *testing.T) {
+	dir := testTempDir(t)
+	param := `{"path":["` + testWriteParquet(t, dir, "a.parquet", testManyRecords(10000)...) + `"]}`
+	sender := &mockSender{}
+	if err := testTask(t, param).StartRead(context.TODO(), sender); err != nil {
+		t.Fatalf("Task.StartRead() error = %v", err)
+	}
+	if len(sender.records) != 10000 {
+		t.Fatalf("Task.StartRead() = %v records, want %v", len(sender.records), 10000)
+	}
+	for i, r := range sender.records {
+		c, _ := r.GetByIndex(0)
+		if c.String() != element.NewBigIntColumnValueFromInt64(int64(i)).String() {
+			t.Fatalf("Task.StartRead() record %v id = %v", i, c.String())
+		}
+	}
+}
+
+func TestTask_StartReadErr(t *testing.T) {
+	dir := testTempDir(t)
+	param := `{"path":["` + testWriteParquet(t, dir, "a.parquet", testManyRecords(1)...) + `"]}`
+	errMock := errors.New("mock error")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		sender *mockSender
+	}{
+		{
+			name:   "1",
+			ctx:    context.TODO(),
+			sender: &mockSender{createErr: errMock},
+		},
+		{
+			name:   "2",
+			ctx:    context.TODO(),
+			sender: &mockSender{sendErr: errMock},
+		},
+		{
+			name:   "3",
+			ctx:    canceled,
+			sender: &mockSender{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testTask(t, param).StartRead(tt.ctx, tt.sender); err == nil {
+				t.Errorf("Task.StartRead() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package rdbm
+
+import (
+	"encoding/json"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type paramConfig struct {
+	Dialect    string     `json:"dialect"`
+	Username   string     `json:"username"`
+	Password   string     `json:"password"`
+	Column     []string   `json:"column"`
+	Connection connConfig `json:"connection"`
+	Where      string     `json:"where"`
+
+	Time       element.TimeConfig            `json:"time"`       //时间配置，location为源数据库中没有时区的时间所在的时区
+	ColumnTime map[string]element.TimeConfig `json:"columnTime"` //列名对应的时间配置，会覆盖time中的配置
+}
+
+type connConfig struct {
+	URL   string      `json:"url"`
+	Table tableConfig `json:"table"`
+}
+
+type tableConfig struct {
+	Db   string `json:"db"`
+	Name string `json:"name"`
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+//dialectName 获取数据库方言名，优先使用工作配置中的dialect，
+//不存在时使用插件配置pluginConf中的dialect
+func (p *paramConfig) dialectName(pluginConf *config.JSON) (string, error) {
+	if p.Dialect != "" {
+		return p.Dialect, nil
+	}
+	return pluginConf.GetString("dialect")
+}
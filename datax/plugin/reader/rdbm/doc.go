@@ -0,0 +1,34 @@
+/*
+Package rdbm 实现了关系型数据库通用的读取工作和读取任务，所有通过
+database.RegisterDialect注册的数据库方言都可以使用
+
+数据库方言名优先取自工作配置参数中的dialect，不存在时取自插件配置中的dialect，
+因此具体数据库的读取器只需要在插件配置中声明dialect，例如mysqlreader：
+
+	{
+	    "name" : "mysqlreader",
+	    "developer":"Breeze0806",
+	    "dialect":"mysql",
+	    "description":"..."
+	}
+
+而通用的rdbmsreader则需要在工作配置参数中声明dialect
+
+读取任务可以通过time和columnTime配置时间列的时区location和转化为字符串时的格式layout，
+columnTime按照列名覆盖time中的配置，layout默认为RFC3339Nano：
+
+	"parameter":{
+	    "time":{
+	        "location":"Asia/Shanghai",
+	        "layout":"2006-01-02 15:04:05"
+	    },
+	    "columnTime":{
+	        "birthday":{
+	            "layout":"2006-01-02"
+	        }
+	    }
+	}
+
+读取时时间的时钟读数会被解释为location时区中的时间，适用于源数据库中没有时区的时间
+*/
+package rdbm
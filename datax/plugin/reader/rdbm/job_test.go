@@ -1,9 +1,8 @@
-package mysql
+package rdbm
 
 import (
 	"context"
 	"errors"
-	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -34,7 +33,7 @@ func TestJob_Init(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 			},
-			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromString(testPluginConf),
 			jobConf: testJSONFromString(`{
 				"job":{
 					"content":[
@@ -89,7 +88,7 @@ func TestJob_Init(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 			},
-			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromString(testPluginConf),
 			jobConf: testJSONFromString(`{
 				"job":{
 					"content":[
@@ -114,7 +113,7 @@ func TestJob_Init(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 			},
-			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromString(testPluginConf),
 			jobConf: testJSONFromString(`{
 				"job":{
 					"content":[
@@ -142,7 +141,7 @@ func TestJob_Init(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 			},
-			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromString(testPluginConf),
 			jobConf: testJSONFromString(`{
 				"job":{
 					"content":[
@@ -171,7 +170,7 @@ func TestJob_Init(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 			},
-			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromString(testPluginConf),
 			jobConf: testJSONFromString(`{
 				"job":{
 					"content":[
@@ -187,6 +186,36 @@ func TestJob_Init(t *testing.T) {
 			}`),
 			wantErr: true,
 		},
+		{
+			name: "7",
+			j: &Job{
+				BaseJob: plugin.NewBaseJob(),
+				newQuerier: func(name string, conf *config.JSON) (Querier, error) {
+					if name != "mysql" {
+						return nil, errors.New("mock dialect error")
+					}
+					return &mockQuerier{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(`{}`),
+			jobConf: testJSONFromString(`{
+				"job":{
+					"content":[
+						{
+							"reader": {
+								"name":"rdbmsreader",
+								"parameter":{
+									"dialect":"mysql"
+								}
+							}
+						}
+					]
+				}
+			}`),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
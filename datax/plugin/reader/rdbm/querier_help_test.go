@@ -1,4 +1,4 @@
-package mysql
+package rdbm
 
 import (
 	"context"
@@ -89,6 +89,7 @@ func (m *mockTable) AddField(bf *database.BaseField) {
 type mockQuerier struct {
 	queryErr error
 	fetchErr error
+	record   element.Record //FetchRecord返回的记录，为空时返回空记录
 }
 
 func (m *mockQuerier) Table(bt *database.BaseTable) database.Table {
@@ -108,6 +109,9 @@ func (m *mockQuerier) FetchRecord(ctx context.Context, param database.Parameter,
 	if err != nil {
 		return
 	}
+	if m.record != nil {
+		return handler.OnRecord(m.record)
+	}
 	return handler.OnRecord(element.NewDefaultRecord())
 }
 
@@ -123,13 +127,12 @@ func (m *mockQuerier) Close() error {
 	return nil
 }
 
-func testJSONFromFile(filename string) *config.JSON {
-	conf, err := config.NewJSONFromFile(filename)
-	if err != nil {
-		panic(err)
-	}
-	return conf
-}
+const testPluginConf = `{
+    "name" : "mysqlreader",
+    "developer":"Breeze0806",
+    "dialect":"mysql",
+    "description":"use github.com/go-sql-driver/mysql."
+}`
 
 func testJSONFromString(json string) *config.JSON {
 	conf, err := config.NewJSONFromString(json)
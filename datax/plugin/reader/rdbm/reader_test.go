@@ -0,0 +1,25 @@
+package rdbm
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReader(t *testing.T) {
+	r := &Reader{
+		pluginConf: testJSONFromString(testPluginConf),
+	}
+	if got := r.Job().PluginConf(); !reflect.DeepEqual(got, r.PluginConf()) {
+		t.Errorf("Reader.Job().PluginConf() = %v, want %v", got, r.PluginConf())
+	}
+	if got := r.Task().PluginConf(); !reflect.DeepEqual(got, r.PluginConf()) {
+		t.Errorf("Reader.Task().PluginConf() = %v, want %v", got, r.PluginConf())
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	if _, err := NewReader(filepath.Join("tmpresources", "tmpplugin.json")); err == nil {
+		t.Errorf("NewReader() error = %v, wantErr true", err)
+	}
+}
@@ -1,4 +1,4 @@
-package mysql
+package rdbm
 
 import (
 	"context"
@@ -17,15 +17,13 @@ type Task struct {
 
 	querier    Querier
 	param      *parameter
+	schema     *element.Schema        //记录模式，由表的字段生成
+	times      *element.TimeConverter //时间列转化器，没有时间配置时为空
 	newQuerier func(name string, conf *config.JSON) (Querier, error)
 }
 
 //Init 初始化
 func (t *Task) Init(ctx context.Context) (err error) {
-	var name string
-	if name, err = t.PluginConf().GetString("dialect"); err != nil {
-		return
-	}
 	var paramConf *config.JSON
 	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
 		return
@@ -36,6 +34,15 @@ func (t *Task) Init(ctx context.Context) (err error) {
 		return
 	}
 
+	var name string
+	if name, err = paramConfig.dialectName(t.PluginConf()); err != nil {
+		return
+	}
+
+	if t.times, err = element.NewSourceTimeConverter(paramConfig.Time, paramConfig.ColumnTime); err != nil {
+		return
+	}
+
 	var jobSettingConf *config.JSON
 	if jobSettingConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobSetting); err != nil {
 		jobSettingConf, _ = config.NewJSONFromString("{}")
@@ -70,6 +77,9 @@ func (t *Task) Init(ctx context.Context) (err error) {
 		return
 	}
 
+	if t.schema, err = database.NewSchema(t.param.Table().Fields()); err != nil {
+		return
+	}
 	return
 }
 
@@ -83,9 +93,20 @@ func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err e
 	handler := database.NewBaseFetchHandler(func() (element.Record, error) {
 		return sender.CreateRecord()
 	}, func(r element.Record) error {
+		if t.times != nil {
+			if err := t.times.Convert(r); err != nil {
+				return err
+			}
+		}
 		return sender.SendWriter(r)
 	})
 
+	if t.schema != nil {
+		if err = plugin.SendSchema(sender, t.schema); err != nil {
+			return
+		}
+	}
+
 	param := newQueryParam(t.param)
 	if err = t.querier.FetchRecord(ctx, param, handler); err != nil {
 		return
@@ -1,10 +1,10 @@
-package mysql
+package rdbm
 
 import (
 	"context"
 	"errors"
-	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
@@ -14,6 +14,9 @@ import (
 type mockSender struct {
 	createErr error
 	sendErr   error
+	schemaErr error
+	schema    *element.Schema
+	record    element.Record
 }
 
 func (m *mockSender) CreateRecord() (element.Record, error) {
@@ -21,6 +24,7 @@ func (m *mockSender) CreateRecord() (element.Record, error) {
 }
 
 func (m *mockSender) SendWriter(record element.Record) error {
+	m.record = record
 	return m.sendErr
 }
 
@@ -35,6 +39,24 @@ func (m *mockSender) Terminate() error {
 func (m *mockSender) Shutdown() error {
 	return nil
 }
+
+func (m *mockSender) SendSchema(schema *element.Schema) error {
+	m.schema = schema
+	return m.schemaErr
+}
+
+func testSchema() *element.Schema {
+	s, err := element.NewSchema([]element.ColumnSchema{
+		{
+			Name: "a",
+			Type: element.TypeBigInt,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
 func TestTask_Init(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -58,7 +80,7 @@ func TestTask_Init(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 			},
-			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromString(testPluginConf),
 			jobConf: testJSONFromString(`{
 				"job":{
 					"content":[
@@ -113,7 +135,7 @@ func TestTask_Init(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 			},
-			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromString(testPluginConf),
 			jobConf: testJSONFromString(`{
 				"job":{
 					"content":[
@@ -138,7 +160,7 @@ func TestTask_Init(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 			},
-			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromString(testPluginConf),
 			jobConf: testJSONFromString(`{
 				"job":{
 					"content":[
@@ -166,7 +188,7 @@ func TestTask_Init(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 			},
-			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromString(testPluginConf),
 			jobConf: testJSONFromString(`{
 				"job":{
 					"content":[
@@ -195,7 +217,7 @@ func TestTask_Init(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 			},
-			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromString(testPluginConf),
 			jobConf: testJSONFromString(`{
 				"job":{
 					"content":[
@@ -224,7 +246,98 @@ func TestTask_Init(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 			},
-			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"job":{
+					"content":[
+						{
+							"reader": {
+								"name":"mysqlreader",
+								"parameter":{
+								}
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+		{
+			name: "8",
+			t: &Task{
+				BaseTask: plugin.NewBaseTask(),
+				newQuerier: func(name string, conf *config.JSON) (Querier, error) {
+					if name != "mysql" {
+						return nil, errors.New("mock dialect error")
+					}
+					return &mockQuerier{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(`{}`),
+			jobConf: testJSONFromString(`{
+				"job":{
+					"content":[
+						{
+							"reader": {
+								"name":"rdbmsreader",
+								"parameter":{
+									"dialect":"mysql"
+								}
+							}
+						}
+					]
+				}
+			}`),
+		},
+		{
+			name: "9",
+			t: &Task{
+				BaseTask: plugin.NewBaseTask(),
+				newQuerier: func(name string, conf *config.JSON) (Querier, error) {
+					return &mockQuerier{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"job":{
+					"content":[
+						{
+							"reader": {
+								"name":"mysqlreader",
+								"parameter":{
+									"time":{
+										"location":"Asia/Shanghai"
+									},
+									"columnTime":{
+										"a":{
+											"layout":"2006-01-02"
+										}
+									}
+								}
+							}
+						}
+					]
+				}
+			}`),
+		},
+		{
+			name: "10",
+			t: &Task{
+				BaseTask: plugin.NewBaseTask(),
+				newQuerier: func(name string, conf *config.JSON) (Querier, error) {
+					return &mockQuerier{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
 			jobConf: testJSONFromString(`{
 				"job":{
 					"content":[
@@ -232,6 +345,9 @@ func TestTask_Init(t *testing.T) {
 							"reader": {
 								"name":"mysqlreader",
 								"parameter":{
+									"time":{
+										"location":"Mars/Olympus"
+									}
 								}
 							}
 						}
@@ -283,16 +399,31 @@ func TestTask_Destroy(t *testing.T) {
 	}
 }
 
+func testTimeRecord(t time.Time) element.Record {
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewTimeColumnValue(t), "t", 0))
+	return r
+}
+
+func testTimeConverter(conf element.TimeConfig) *element.TimeConverter {
+	c, err := element.NewSourceTimeConverter(conf, nil)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
 func TestTask_StartRead(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		sender plugin.RecordSender
 	}
 	tests := []struct {
-		name    string
-		t       *Task
-		args    args
-		wantErr bool
+		name       string
+		t          *Task
+		args       args
+		wantErr    bool
+		wantRecord string
 	}{
 		{
 			name: "1",
@@ -320,11 +451,86 @@ func TestTask_StartRead(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "3",
+			t: &Task{
+				BaseTask: plugin.NewBaseTask(),
+				querier:  &mockQuerier{},
+				schema:   testSchema(),
+			},
+			args: args{
+				ctx:    context.TODO(),
+				sender: &mockSender{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "4",
+			t: &Task{
+				BaseTask: plugin.NewBaseTask(),
+				querier:  &mockQuerier{},
+				schema:   testSchema(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				sender: &mockSender{
+					schemaErr: errors.New("mock error"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "5",
+			t: &Task{
+				BaseTask: plugin.NewBaseTask(),
+				querier: &mockQuerier{
+					record: testTimeRecord(time.Date(2021, 1, 1, 8, 0, 0, 0, time.UTC)),
+				},
+				times: testTimeConverter(element.TimeConfig{
+					Layout:   "2006-01-02 15:04:05Z07:00",
+					Location: "Asia/Shanghai",
+				}),
+			},
+			args: args{
+				ctx:    context.TODO(),
+				sender: &mockSender{},
+			},
+			wantRecord: "2021-01-01 08:00:00+08:00",
+		},
+		{
+			name: "6",
+			t: &Task{
+				BaseTask: plugin.NewBaseTask(),
+				querier: &mockQuerier{
+					record: testTimeRecord(time.Date(2021, 1, 1, 8, 0, 0, 0, time.UTC)),
+				},
+			},
+			args: args{
+				ctx:    context.TODO(),
+				sender: &mockSender{},
+			},
+			wantRecord: "2021-01-01T08:00:00Z",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if err := tt.t.StartRead(tt.args.ctx, tt.args.sender); (err != nil) != tt.wantErr {
 				t.Errorf("Task.StartRead() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := tt.args.sender.(*mockSender).schema; got != tt.t.schema {
+				t.Errorf("Task.StartRead() schema = %v, want %v", got, tt.t.schema)
+			}
+			if tt.wantRecord == "" {
+				return
+			}
+			c, err := tt.args.sender.(*mockSender).record.GetByName("t")
+			if err != nil {
+				t.Errorf("Task.StartRead() GetByName() error = %v", err)
+				return
+			}
+			if got, _ := c.AsString(); got != tt.wantRecord {
+				t.Errorf("Task.StartRead() record = %v, want %v", got, tt.wantRecord)
 			}
 		})
 	}
@@ -0,0 +1,38 @@
+package rdbms
+
+import (
+	"path/filepath"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/rdbm"
+)
+
+func init() {
+	reader, err := NewReader(filepath.Join("resources", "plugin.json"))
+	if err != nil {
+		panic(err)
+	}
+	name, err := reader.PluginConf().GetString("name")
+	if err != nil {
+		panic(err)
+	}
+	if name == "" {
+		panic("name is empty")
+	}
+	loader.RegisterReader(name, reader)
+}
+
+//Reader 通用关系型数据库读取器，数据库方言由工作配置参数中的dialect指定，
+//需要预先通过database.RegisterDialect注册对应的数据库方言
+type Reader struct {
+	*rdbm.Reader
+}
+
+//NewReader 创建读取器
+func NewReader(filename string) (r *Reader, err error) {
+	r = &Reader{}
+	if r.Reader, err = rdbm.NewReader(filename); err != nil {
+		return nil, err
+	}
+	return
+}
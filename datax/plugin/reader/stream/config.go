@@ -0,0 +1,103 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//生成模式
+const (
+	ModeConstant = "constant" //常量，默认的生成模式
+	ModeSequence = "sequence" //序列
+	ModeRandom   = "random"   //随机值
+	ModeTemplate = "template" //模板
+)
+
+type paramConfig struct {
+	Column           []columnConfig `json:"column"`           //列配置
+	SliceRecordCount int64          `json:"sliceRecordCount"` //每个任务生成的记录数
+	Seed             *int64         `json:"seed"`             //随机数种子，每个任务使用seed加任务ID，为空时使用当前时间
+	TaskID           *int           `json:"taskID"`           //由Job.Split生成的任务ID
+}
+
+type columnConfig struct {
+	Name   string             `json:"name"`   //列名
+	Type   element.ColumnType `json:"type"`   //列类型，默认为字符串
+	Mode   string             `json:"mode"`   //生成模式，支持constant，sequence，random，template，默认为constant
+	Value  *string            `json:"value"`  //常量值，序列的起始值或者模板，常量值为空时生成空值
+	Step   string             `json:"step"`   //序列的步长，时间使用go的时间间隔格式，默认为1或者1s
+	Min    string             `json:"min"`    //随机值的最小值，字符串和字节流为最小长度
+	Max    string             `json:"max"`    //随机值的最大值，字符串和字节流为最大长度
+	Format string             `json:"format"` //时间格式，使用go的时间格式，默认为time.RFC3339Nano
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	if len(p.Column) == 0 {
+		return fmt.Errorf("column is empty")
+	}
+	if p.SliceRecordCount < 0 {
+		return fmt.Errorf("sliceRecordCount(%v) is less than 0", p.SliceRecordCount)
+	}
+	_, err = p.generators()
+	return
+}
+
+//generators 生成每列的值生成器
+func (p *paramConfig) generators() (generators []generator, err error) {
+	for i := range p.Column {
+		var g generator
+		if g, err = newGenerator(&p.Column[i]); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", i, err)
+		}
+		generators = append(generators, g)
+	}
+	return
+}
+
+//seed 获取任务taskID的随机数种子
+func (p *paramConfig) seed(taskID int) int64 {
+	if p.Seed == nil {
+		return time.Now().UnixNano()
+	}
+	return *p.Seed + int64(taskID)
+}
+
+//columnType 获取列类型，默认为字符串
+func (c *columnConfig) columnType() element.ColumnType {
+	if c.Type == "" {
+		return element.TypeString
+	}
+	return c.Type
+}
+
+//mode 获取生成模式，默认为constant
+func (c *columnConfig) mode() string {
+	if c.Mode == "" {
+		return ModeConstant
+	}
+	return c.Mode
+}
+
+//layout 获取时间格式，默认为time.RFC3339Nano
+func (c *columnConfig) layout() string {
+	if c.Format == "" {
+		return time.RFC3339Nano
+	}
+	return c.Format
+}
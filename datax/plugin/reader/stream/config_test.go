@@ -0,0 +1,54 @@
+package stream
+
+import (
+	"testing"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"column":[{"name":"a"},{"name":"b","type":"bigInt","mode":"sequence"}],"sliceRecordCount":10}`,
+		},
+		{
+			name:    "2",
+			json:    `{"column":[],"sliceRecordCount":10}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"column":[{"name":"a"}],"sliceRecordCount":-1}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"column":[{"name":"a","mode":"mock"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"column":"a"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_seed(t *testing.T) {
+	seed := int64(10)
+	p := &paramConfig{Seed: &seed}
+	if got := p.seed(2); got != 12 {
+		t.Errorf("paramConfig.seed() = %v, want %v", got, 12)
+	}
+}
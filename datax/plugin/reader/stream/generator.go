@@ -0,0 +1,398 @@
+package stream
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/shopspring/decimal"
+)
+
+//随机值的默认范围
+var (
+	defaultMinTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	defaultMaxTime = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+//随机字符串和字节流的默认长度范围
+const (
+	defaultMinLength = 1
+	defaultMaxLength = 16
+)
+
+//letters 随机字符串使用的字符
+const letters = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+//position 生成的记录的位置，也是模板的数据
+type position struct {
+	Index  int64 //记录在所有任务中的序号，为任务ID乘以sliceRecordCount再加上Seq
+	Seq    int64 //记录在任务中的序号
+	TaskID int   //任务ID
+
+	rand *rand.Rand
+}
+
+//generator 列值生成器
+type generator interface {
+	//generate 生成位置为p的记录的列值以及列值的字节数
+	generate(p *position) (element.ColumnValue, int, error)
+}
+
+//newGenerator 通过列配置c生成列值生成器
+func newGenerator(c *columnConfig) (generator, error) {
+	if c.Name == "" {
+		return nil, fmt.Errorf("name is empty")
+	}
+	typ := c.columnType()
+	switch typ {
+	case element.TypeBool, element.TypeBigInt, element.TypeDecimal,
+		element.TypeString, element.TypeBytes, element.TypeTime:
+	default:
+		return nil, fmt.Errorf("type(%v) is not supported", c.Type)
+	}
+
+	switch c.mode() {
+	case ModeConstant:
+		return newConstant(c)
+	case ModeSequence:
+		return newSequence(c)
+	case ModeRandom:
+		return newRandom(c)
+	case ModeTemplate:
+		return newTemplate(c)
+	}
+	return nil, fmt.Errorf("mode(%v) is not supported", c.Mode)
+}
+
+//constant 常量生成器
+type constant struct {
+	v    element.ColumnValue
+	size int
+}
+
+func newConstant(c *columnConfig) (g *constant, err error) {
+	g = &constant{}
+	if c.Value == nil {
+		g.v = element.NewNilColumnValue(c.columnType())
+		return
+	}
+	if g.v, err = parseValue(c.columnType(), *c.Value, c.layout()); err != nil {
+		return nil, err
+	}
+	g.size = len(*c.Value)
+	return
+}
+
+func (g *constant) generate(p *position) (element.ColumnValue, int, error) {
+	return g.v, g.size, nil
+}
+
+//sequence 序列生成器，第Index条记录的值为起始值加上Index倍的步长，字符串和字节流为起始值加上Index，
+//布尔值从起始值开始交替
+type sequence struct {
+	typ element.ColumnType
+
+	start     string
+	bigStart  *big.Int
+	bigStep   *big.Int
+	decStart  decimal.Decimal
+	decStep   decimal.Decimal
+	timeStart time.Time
+	timeStep  time.Duration
+	boolStart bool
+	layout    string
+}
+
+func newSequence(c *columnConfig) (g *sequence, err error) {
+	g = &sequence{
+		typ:    c.columnType(),
+		layout: c.layout(),
+	}
+	if c.Value != nil {
+		g.start = *c.Value
+	}
+	switch g.typ {
+	case element.TypeBool:
+		if g.start != "" {
+			if g.boolStart, err = strconv.ParseBool(g.start); err != nil {
+				return nil, err
+			}
+		}
+		if c.Step != "" {
+			return nil, fmt.Errorf("step is not supported by %v", g.typ)
+		}
+	case element.TypeBigInt:
+		if g.bigStart, err = parseBigInt(g.start, "0"); err != nil {
+			return nil, err
+		}
+		if g.bigStep, err = parseBigInt(c.Step, "1"); err != nil {
+			return nil, err
+		}
+	case element.TypeDecimal:
+		if g.decStart, err = parseDecimal(g.start, "0"); err != nil {
+			return nil, err
+		}
+		if g.decStep, err = parseDecimal(c.Step, "1"); err != nil {
+			return nil, err
+		}
+	case element.TypeTime:
+		if g.timeStart, err = parseTime(g.start, g.layout, time.Unix(0, 0).UTC()); err != nil {
+			return nil, err
+		}
+		g.timeStep = time.Second
+		if c.Step != "" {
+			if g.timeStep, err = time.ParseDuration(c.Step); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		if c.Step != "" {
+			return nil, fmt.Errorf("step is not supported by %v", g.typ)
+		}
+	}
+	return
+}
+
+func (g *sequence) generate(p *position) (element.ColumnValue, int, error) {
+	switch g.typ {
+	case element.TypeBool:
+		return element.NewBoolColumnValue(g.boolStart != (p.Index%2 != 0)), 1, nil
+	case element.TypeBigInt:
+		v := new(big.Int).Mul(g.bigStep, big.NewInt(p.Index))
+		return element.NewBigIntColumnValue(v.Add(v, g.bigStart)), 8, nil
+	case element.TypeDecimal:
+		return element.NewDecimalColumnValue(g.decStep.Mul(decimal.NewFromInt(p.Index)).Add(g.decStart)), 16, nil
+	case element.TypeTime:
+		t := g.timeStart.Add(g.timeStep * time.Duration(p.Index))
+		return element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(g.layout)), 8, nil
+	case element.TypeBytes:
+		v := g.start + strconv.FormatInt(p.Index, 10)
+		return element.NewBytesColumnValue([]byte(v)), len(v), nil
+	}
+	v := g.start + strconv.FormatInt(p.Index, 10)
+	return element.NewStringColumnValue(v), len(v), nil
+}
+
+//random 随机值生成器，值在最小值和最大值之间均匀分布(包含最大值)，整数默认为[0, 2147483647]，
+//高精度实数默认为[0, 1]，标度为最小值和最大值的标度中的较大者，默认为6，时间默认为[2000-01-01, 2030-01-01]，
+//精确到秒，字符串和字节流的长度默认为[1, 16]，字符串由字母和数字组成
+type random struct {
+	typ element.ColumnType
+
+	bigMin  *big.Int
+	bigN    *big.Int
+	scale   int32
+	timeMin time.Time
+	timeN   int64
+	minLen  int
+	maxLen  int
+	layout  string
+}
+
+func newRandom(c *columnConfig) (g *random, err error) {
+	g = &random{
+		typ:    c.columnType(),
+		layout: c.layout(),
+	}
+	switch g.typ {
+	case element.TypeBool:
+		if c.Min != "" || c.Max != "" {
+			return nil, fmt.Errorf("min and max are not supported by %v", g.typ)
+		}
+	case element.TypeBigInt:
+		var max *big.Int
+		if g.bigMin, err = parseBigInt(c.Min, "0"); err != nil {
+			return nil, err
+		}
+		if max, err = parseBigInt(c.Max, strconv.Itoa(math.MaxInt32)); err != nil {
+			return nil, err
+		}
+		if g.bigN, err = span(g.bigMin, max); err != nil {
+			return nil, err
+		}
+	case element.TypeDecimal:
+		var min, max decimal.Decimal
+		if min, err = parseDecimal(c.Min, "0"); err != nil {
+			return nil, err
+		}
+		if max, err = parseDecimal(c.Max, "1.000000"); err != nil {
+			return nil, err
+		}
+		if g.scale = -min.Exponent(); -max.Exponent() > g.scale {
+			g.scale = -max.Exponent()
+		}
+		if g.scale < 0 {
+			g.scale = 0
+		}
+		g.bigMin = min.Shift(g.scale).BigInt()
+		if g.bigN, err = span(g.bigMin, max.Shift(g.scale).BigInt()); err != nil {
+			return nil, err
+		}
+	case element.TypeTime:
+		var max time.Time
+		if g.timeMin, err = parseTime(c.Min, g.layout, defaultMinTime); err != nil {
+			return nil, err
+		}
+		if max, err = parseTime(c.Max, g.layout, defaultMaxTime); err != nil {
+			return nil, err
+		}
+		if max.Before(g.timeMin) {
+			return nil, fmt.Errorf("max(%v) is less than min(%v)", c.Max, c.Min)
+		}
+		g.timeN = max.Unix() - g.timeMin.Unix() + 1
+	default:
+		if g.minLen, err = parseLength(c.Min, defaultMinLength); err != nil {
+			return nil, err
+		}
+		if g.maxLen, err = parseLength(c.Max, defaultMaxLength); err != nil {
+			return nil, err
+		}
+		if g.maxLen < g.minLen {
+			return nil, fmt.Errorf("max(%v) is less than min(%v)", g.maxLen, g.minLen)
+		}
+	}
+	return
+}
+
+func (g *random) generate(p *position) (element.ColumnValue, int, error) {
+	switch g.typ {
+	case element.TypeBool:
+		return element.NewBoolColumnValue(p.rand.Intn(2) == 1), 1, nil
+	case element.TypeBigInt:
+		v := new(big.Int).Rand(p.rand, g.bigN)
+		return element.NewBigIntColumnValue(v.Add(v, g.bigMin)), 8, nil
+	case element.TypeDecimal:
+		v := new(big.Int).Rand(p.rand, g.bigN)
+		return element.NewDecimalColumnValue(decimal.NewFromBigInt(v.Add(v, g.bigMin), -g.scale)), 16, nil
+	case element.TypeTime:
+		t := g.timeMin.Add(time.Duration(p.rand.Int63n(g.timeN)) * time.Second)
+		return element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(g.layout)), 8, nil
+	}
+
+	b := make([]byte, g.minLen+p.rand.Intn(g.maxLen-g.minLen+1))
+	if g.typ == element.TypeBytes {
+		p.rand.Read(b)
+		return element.NewBytesColumnValue(b), len(b), nil
+	}
+	for i := range b {
+		b[i] = letters[p.rand.Intn(len(letters))]
+	}
+	return element.NewStringColumnValue(string(b)), len(b), nil
+}
+
+//templateGenerator 模板生成器，使用go的text/template，模板的数据为记录的位置，
+//如{{.Index}}，{{.Seq}}，{{.TaskID}}，生成的字符串再转化为对应类型的列值
+type templateGenerator struct {
+	typ    element.ColumnType
+	tmpl   *template.Template
+	layout string
+	buf    bytes.Buffer
+}
+
+func newTemplate(c *columnConfig) (g *templateGenerator, err error) {
+	if c.Value == nil {
+		return nil, fmt.Errorf("value of template is empty")
+	}
+	g = &templateGenerator{
+		typ:    c.columnType(),
+		layout: c.layout(),
+	}
+	if g.tmpl, err = template.New(c.Name).Option("missingkey=error").Parse(*c.Value); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (g *templateGenerator) generate(p *position) (v element.ColumnValue, n int, err error) {
+	g.buf.Reset()
+	if err = g.tmpl.Execute(&g.buf, p); err != nil {
+		return
+	}
+	if v, err = parseValue(g.typ, g.buf.String(), g.layout); err != nil {
+		return
+	}
+	return v, g.buf.Len(), nil
+}
+
+//parseValue 将字符串s转化为类型为typ的列值，时间使用格式layout
+func parseValue(typ element.ColumnType, s string, layout string) (element.ColumnValue, error) {
+	switch typ {
+	case element.TypeBool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		return element.NewBoolColumnValue(v), nil
+	case element.TypeBigInt:
+		return element.NewBigIntColumnValueFromString(s)
+	case element.TypeDecimal:
+		return element.NewDecimalColumnValueFromString(s)
+	case element.TypeBytes:
+		return element.NewBytesColumnValue([]byte(s)), nil
+	case element.TypeTime:
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return nil, err
+		}
+		return element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(layout)), nil
+	}
+	return element.NewStringColumnValue(s), nil
+}
+
+//parseBigInt 将字符串s转化为整数，s为空时使用默认值def
+func parseBigInt(s, def string) (*big.Int, error) {
+	if s == "" {
+		s = def
+	}
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("%v is not valid int", s)
+	}
+	return v, nil
+}
+
+//parseDecimal 将字符串s转化为高精度实数，s为空时使用默认值def
+func parseDecimal(s, def string) (decimal.Decimal, error) {
+	if s == "" {
+		s = def
+	}
+	return decimal.NewFromString(s)
+}
+
+//parseTime 将字符串s按照格式layout转化为时间，s为空时使用默认值def
+func parseTime(s, layout string, def time.Time) (time.Time, error) {
+	if s == "" {
+		return def, nil
+	}
+	return time.Parse(layout, s)
+}
+
+//parseLength 将字符串s转化为非负的长度，s为空时使用默认值def
+func parseLength(s string, def int) (int, error) {
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("length(%v) is less than 0", n)
+	}
+	return n, nil
+}
+
+//span 获取[min, max]中整数的个数
+func span(min, max *big.Int) (*big.Int, error) {
+	if max.Cmp(min) < 0 {
+		return nil, fmt.Errorf("max(%v) is less than min(%v)", max, min)
+	}
+	n := new(big.Int).Sub(max, min)
+	return n.Add(n, big.NewInt(1)), nil
+}
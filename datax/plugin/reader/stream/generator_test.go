@@ -0,0 +1,293 @@
+package stream
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testString(s string) *string {
+	return &s
+}
+
+//testGenerate 通过列配置c生成位置为indexes的列值，每个列值为列类型:列值
+func testGenerate(t *testing.T, c columnConfig, indexes ...int64) (values []string) {
+	g, err := newGenerator(&c)
+	if err != nil {
+		t.Fatalf("newGenerator() error = %v", err)
+	}
+	p := &position{rand: rand.New(rand.NewSource(1))}
+	for _, i := range indexes {
+		p.Index = i
+		v, _, err := g.generate(p)
+		if err != nil {
+			t.Fatalf("generator.generate() error = %v", err)
+		}
+		values = append(values, v.Type().String()+":"+v.String())
+	}
+	return
+}
+
+func TestConstant(t *testing.T) {
+	tests := []struct {
+		name string
+		c    columnConfig
+		want []string
+	}{
+		{
+			name: "1",
+			c:    columnConfig{Name: "a", Value: testString("abc")},
+			want: []string{"string:abc", "string:abc"},
+		},
+		{
+			name: "2",
+			c:    columnConfig{Name: "a", Type: element.TypeBigInt},
+			want: []string{"bigInt:<nil>", "bigInt:<nil>"},
+		},
+		{
+			name: "3",
+			c:    columnConfig{Name: "a", Type: element.TypeTime, Value: testString("2021-01-02"), Format: "2006-01-02"},
+			want: []string{"time:2021-01-02T00:00:00Z", "time:2021-01-02T00:00:00Z"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testGenerate(t, tt.c, 0, 1); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("constant = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSequence(t *testing.T) {
+	tests := []struct {
+		name string
+		c    columnConfig
+		want []string
+	}{
+		{
+			name: "1",
+			c:    columnConfig{Name: "a", Type: element.TypeBigInt, Mode: ModeSequence},
+			want: []string{"bigInt:0", "bigInt:1", "bigInt:2"},
+		},
+		{
+			name: "2",
+			c:    columnConfig{Name: "a", Type: element.TypeBigInt, Mode: ModeSequence, Value: testString("100"), Step: "-10"},
+			want: []string{"bigInt:100", "bigInt:90", "bigInt:80"},
+		},
+		{
+			name: "3",
+			c:    columnConfig{Name: "a", Type: element.TypeDecimal, Mode: ModeSequence, Value: testString("1.5"), Step: "0.25"},
+			want: []string{"decimal:1.5", "decimal:1.75", "decimal:2"},
+		},
+		{
+			name: "4",
+			c: columnConfig{Name: "a", Type: element.TypeTime, Mode: ModeSequence,
+				Value: testString("2021-01-02 00:00:00"), Step: "1h", Format: "2006-01-02 15:04:05"},
+			want: []string{"time:2021-01-02T00:00:00Z", "time:2021-01-02T01:00:00Z", "time:2021-01-02T02:00:00Z"},
+		},
+		{
+			name: "5",
+			c:    columnConfig{Name: "a", Mode: ModeSequence, Value: testString("user_")},
+			want: []string{"string:user_0", "string:user_1", "string:user_2"},
+		},
+		{
+			name: "6",
+			c:    columnConfig{Name: "a", Type: element.TypeBytes, Mode: ModeSequence},
+			want: []string{"bytes:0", "bytes:1", "bytes:2"},
+		},
+		{
+			name: "7",
+			c:    columnConfig{Name: "a", Type: element.TypeBool, Mode: ModeSequence, Value: testString("true")},
+			want: []string{"bool:true", "bool:false", "bool:true"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testGenerate(t, tt.c, 0, 1, 2); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sequence = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRandom(t *testing.T) {
+	tests := []struct {
+		name  string
+		c     columnConfig
+		check func(v element.ColumnValue) bool
+	}{
+		{
+			name: "1",
+			c:    columnConfig{Name: "a", Type: element.TypeBigInt, Mode: ModeRandom, Min: "-2", Max: "2"},
+			check: func(v element.ColumnValue) bool {
+				i, _ := v.AsBigInt()
+				return i.Int64() >= -2 && i.Int64() <= 2
+			},
+		},
+		{
+			name: "2",
+			c:    columnConfig{Name: "a", Type: element.TypeDecimal, Mode: ModeRandom, Min: "1", Max: "1.01"},
+			check: func(v element.ColumnValue) bool {
+				return v.String() == "1" || v.String() == "1.01"
+			},
+		},
+		{
+			name: "3",
+			c: columnConfig{Name: "a", Type: element.TypeTime, Mode: ModeRandom,
+				Min: "2021-01-01", Max: "2021-01-02", Format: "2006-01-02"},
+			check: func(v element.ColumnValue) bool {
+				tm, _ := v.AsTime()
+				return !tm.Before(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+					!tm.After(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)) && tm.Nanosecond() == 0
+			},
+		},
+		{
+			name: "4",
+			c:    columnConfig{Name: "a", Mode: ModeRandom, Min: "2", Max: "3"},
+			check: func(v element.ColumnValue) bool {
+				s, _ := v.AsString()
+				return (len(s) == 2 || len(s) == 3) && strings.Trim(s, letters) == ""
+			},
+		},
+		{
+			name: "5",
+			c:    columnConfig{Name: "a", Type: element.TypeBytes, Mode: ModeRandom, Max: "0", Min: "0"},
+			check: func(v element.ColumnValue) bool {
+				b, _ := v.AsBytes()
+				return len(b) == 0
+			},
+		},
+		{
+			name: "6",
+			c:    columnConfig{Name: "a", Type: element.TypeBool, Mode: ModeRandom},
+			check: func(v element.ColumnValue) bool {
+				return v.Type() == element.TypeBool
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := newGenerator(&tt.c)
+			if err != nil {
+				t.Fatalf("newGenerator() error = %v", err)
+			}
+			p := &position{rand: rand.New(rand.NewSource(1))}
+			for i := 0; i < 100; i++ {
+				v, _, err := g.generate(p)
+				if err != nil {
+					t.Fatalf("random.generate() error = %v", err)
+				}
+				if !tt.check(v) {
+					t.Fatalf("random.generate() = %v", v)
+				}
+			}
+		})
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	c := columnConfig{Name: "a", Type: element.TypeBigInt, Mode: ModeTemplate, Value: testString("{{.TaskID}}{{.Seq}}")}
+	g, err := newGenerator(&c)
+	if err != nil {
+		t.Fatalf("newGenerator() error = %v", err)
+	}
+	v, n, err := g.generate(&position{Seq: 3, TaskID: 2})
+	if err != nil {
+		t.Fatalf("templateGenerator.generate() error = %v", err)
+	}
+	if v.String() != "23" || n != 2 {
+		t.Errorf("templateGenerator.generate() = %v %v, want 23 2", v, n)
+	}
+
+	c = columnConfig{Name: "a", Type: element.TypeBigInt, Mode: ModeTemplate, Value: testString("a{{.Seq}}")}
+	if g, err = newGenerator(&c); err != nil {
+		t.Fatalf("newGenerator() error = %v", err)
+	}
+	if _, _, err = g.generate(&position{}); err == nil {
+		t.Errorf("templateGenerator.generate() error = nil, wantErr true")
+	}
+}
+
+func TestNewGeneratorErr(t *testing.T) {
+	tests := []struct {
+		name string
+		c    columnConfig
+	}{
+		{
+			name: "1",
+			c:    columnConfig{},
+		},
+		{
+			name: "2",
+			c:    columnConfig{Name: "a", Type: "mock"},
+		},
+		{
+			name: "3",
+			c:    columnConfig{Name: "a", Type: element.TypeBool, Value: testString("abc")},
+		},
+		{
+			name: "4",
+			c:    columnConfig{Name: "a", Type: element.TypeBigInt, Mode: ModeSequence, Step: "1.5"},
+		},
+		{
+			name: "5",
+			c:    columnConfig{Name: "a", Type: element.TypeDecimal, Mode: ModeSequence, Value: testString("abc")},
+		},
+		{
+			name: "6",
+			c:    columnConfig{Name: "a", Type: element.TypeTime, Mode: ModeSequence, Step: "1x"},
+		},
+		{
+			name: "7",
+			c:    columnConfig{Name: "a", Mode: ModeSequence, Step: "1"},
+		},
+		{
+			name: "8",
+			c:    columnConfig{Name: "a", Type: element.TypeBool, Mode: ModeSequence, Value: testString("abc")},
+		},
+		{
+			name: "9",
+			c:    columnConfig{Name: "a", Type: element.TypeBigInt, Mode: ModeRandom, Min: "2", Max: "1"},
+		},
+		{
+			name: "10",
+			c:    columnConfig{Name: "a", Type: element.TypeDecimal, Mode: ModeRandom, Max: "abc"},
+		},
+		{
+			name: "11",
+			c:    columnConfig{Name: "a", Type: element.TypeTime, Mode: ModeRandom, Min: "2030-01-02T00:00:00Z"},
+		},
+		{
+			name: "12",
+			c:    columnConfig{Name: "a", Mode: ModeRandom, Min: "-1"},
+		},
+		{
+			name: "13",
+			c:    columnConfig{Name: "a", Mode: ModeRandom, Min: "3", Max: "2"},
+		},
+		{
+			name: "14",
+			c:    columnConfig{Name: "a", Type: element.TypeBool, Mode: ModeRandom, Max: "1"},
+		},
+		{
+			name: "15",
+			c:    columnConfig{Name: "a", Mode: ModeTemplate},
+		},
+		{
+			name: "16",
+			c:    columnConfig{Name: "a", Mode: ModeTemplate, Value: testString("{{.Index")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newGenerator(&tt.c); err == nil {
+				t.Errorf("newGenerator() error = nil, wantErr true")
+			}
+		})
+	}
+}
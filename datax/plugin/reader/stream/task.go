@@ -0,0 +1,78 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//Task 任务
+type Task struct {
+	*plugin.BaseTask
+
+	param      *paramConfig
+	generators []generator
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.param.TaskID != nil {
+		t.SetTaskID(*t.param.TaskID)
+	}
+	t.generators, err = t.param.generators()
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//StartRead 开始读，生成sliceRecordCount条记录并发往写入器
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	p := &position{
+		TaskID: t.TaskID(),
+		rand:   rand.New(rand.NewSource(t.param.seed(t.TaskID()))),
+	}
+	for ; p.Seq < t.param.SliceRecordCount; p.Seq++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		p.Index = int64(p.TaskID)*t.param.SliceRecordCount + p.Seq
+		var record element.Record
+		if record, err = sender.CreateRecord(); err != nil {
+			return
+		}
+		for i, g := range t.generators {
+			var v element.ColumnValue
+			var n int
+			if v, n, err = g.generate(p); err != nil {
+				return fmt.Errorf("column(%v) err: %v", t.param.Column[i].Name, err)
+			}
+			if err = record.Add(element.NewDefaultColumn(v, t.param.Column[i].Name, n)); err != nil {
+				return
+			}
+		}
+		if err = sender.SendWriter(record); err != nil {
+			return
+		}
+	}
+	return sender.Terminate()
+}
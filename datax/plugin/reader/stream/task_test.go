@@ -0,0 +1,179 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+)
+
+func testTask(t *testing.T, param string) *Task {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+func TestTask_Init(t *testing.T) {
+	tests := []struct {
+		name       string
+		param      string
+		wantTaskID int
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			param:      `{"column":[{"name":"a"}],"taskID":3}`,
+			wantTaskID: 3,
+		},
+		{
+			name:  "2",
+			param: `{"column":[{"name":"a"}]}`,
+		},
+		{
+			name:    "3",
+			param:   `{"column":[{"name":"a","type":"mock"}]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: plugin.NewBaseTask(),
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			if err := task.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Fatalf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if task.TaskID() != tt.wantTaskID {
+				t.Errorf("Task.TaskID() = %v, want %v", task.TaskID(), tt.wantTaskID)
+			}
+		})
+	}
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartRead(t *testing.T) {
+	tests := []struct {
+		name  string
+		param string
+		want  []string
+	}{
+		{
+			name: "1",
+			param: `{"column":[{"name":"id","type":"bigInt","mode":"sequence","value":"1"},` +
+				`{"name":"name","mode":"template","value":"u{{.Index}}_{{.TaskID}}_{{.Seq}}"},` +
+				`{"name":"n","type":"decimal"}],"sliceRecordCount":2,"taskID":1}`,
+			want: []string{
+				"id:bigInt:3 name:string:u2_1_0 n:decimal:<nil>",
+				"id:bigInt:4 name:string:u3_1_1 n:decimal:<nil>",
+			},
+		},
+		{
+			name:  "2",
+			param: `{"column":[{"name":"a","value":"x"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &mockSender{}
+			if err := testTask(t, tt.param).StartRead(context.TODO(), sender); err != nil {
+				t.Fatalf("Task.StartRead() error = %v", err)
+			}
+			if !sender.terminated {
+				t.Errorf("Task.StartRead() terminated = %v", sender.terminated)
+			}
+			if got := testRecords(sender.records); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartReadSeed(t *testing.T) {
+	param := `{"column":[{"name":"a","mode":"random"},{"name":"b","type":"bigInt","mode":"random"}],` +
+		`"sliceRecordCount":10,"seed":1}`
+	var got [][]string
+	for i := 0; i < 2; i++ {
+		sender := &mockSender{}
+		if err := testTask(t, param).StartRead(context.TODO(), sender); err != nil {
+			t.Fatalf("Task.StartRead() error = %v", err)
+		}
+		got = append(got, testRecords(sender.records))
+	}
+	if len(got[0]) != 10 || !reflect.DeepEqual(got[0], got[1]) {
+		t.Errorf("Task.StartRead() = %v, want the same records", got)
+	}
+}
+
+func TestTask_StartReadErr(t *testing.T) {
+	param := `{"column":[{"name":"a","value":"x"}],"sliceRecordCount":1}`
+	errMock := errors.New("mock error")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		param  string
+		sender *mockSender
+	}{
+		{
+			name:   "1",
+			ctx:    context.TODO(),
+			param:  param,
+			sender: &mockSender{createErr: errMock},
+		},
+		{
+			name:   "2",
+			ctx:    context.TODO(),
+			param:  param,
+			sender: &mockSender{sendErr: errMock},
+		},
+		{
+			name:   "3",
+			ctx:    canceled,
+			param:  param,
+			sender: &mockSender{},
+		},
+		{
+			name:   "4",
+			ctx:    context.TODO(),
+			param:  `{"column":[{"name":"a","type":"bigInt","mode":"template","value":"a"}],"sliceRecordCount":1}`,
+			sender: &mockSender{},
+		},
+		{
+			name:   "5",
+			ctx:    context.TODO(),
+			param:  `{"column":[{"name":"a","value":"x"},{"name":"a","value":"y"}],"sliceRecordCount":1}`,
+			sender: &mockSender{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testTask(t, tt.param).StartRead(tt.ctx, tt.sender); err == nil {
+				t.Errorf("Task.StartRead() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
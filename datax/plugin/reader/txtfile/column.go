@@ -0,0 +1,40 @@
+package txtfile
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//columnValue 将字符串s按照列配置转化为列值，isNull为true时生成对应类型的空值，
+//非字符串类型的空字符串也会转化为空值
+func (c *columnConfig) columnValue(s string, isNull bool) (element.ColumnValue, error) {
+	typ := c.columnType()
+	if isNull || (s == "" && typ != element.TypeString) {
+		return element.NewNilColumnValue(typ), nil
+	}
+
+	switch typ {
+	case element.TypeBool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		return element.NewBoolColumnValue(v), nil
+	case element.TypeBigInt:
+		return element.NewBigIntColumnValueFromString(s)
+	case element.TypeDecimal:
+		return element.NewDecimalColumnValueFromString(s)
+	case element.TypeBytes:
+		return element.NewBytesColumnValue([]byte(s)), nil
+	case element.TypeTime:
+		layout := c.layout()
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return nil, err
+		}
+		return element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(layout)), nil
+	}
+	return element.NewStringColumnValue(s), nil
+}
@@ -0,0 +1,133 @@
+package txtfile
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestColumnConfig_columnValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        *columnConfig
+		s        string
+		isNull   bool
+		wantType element.ColumnType
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			c:        &columnConfig{},
+			s:        "abc",
+			wantType: element.TypeString,
+			want:     "abc",
+		},
+		{
+			name:     "2",
+			c:        &columnConfig{},
+			s:        "",
+			wantType: element.TypeString,
+			want:     "",
+		},
+		{
+			name:     "3",
+			c:        &columnConfig{Type: element.TypeBigInt},
+			s:        "",
+			wantType: element.TypeBigInt,
+			want:     "<nil>",
+		},
+		{
+			name:     "4",
+			c:        &columnConfig{Type: element.TypeString},
+			s:        `\N`,
+			isNull:   true,
+			wantType: element.TypeString,
+			want:     "<nil>",
+		},
+		{
+			name:     "5",
+			c:        &columnConfig{Type: element.TypeBool},
+			s:        "true",
+			wantType: element.TypeBool,
+			want:     "true",
+		},
+		{
+			name:     "6",
+			c:        &columnConfig{Type: element.TypeBigInt},
+			s:        "123456789012345678901234567890",
+			wantType: element.TypeBigInt,
+			want:     "123456789012345678901234567890",
+		},
+		{
+			name:     "7",
+			c:        &columnConfig{Type: element.TypeDecimal},
+			s:        "1.25",
+			wantType: element.TypeDecimal,
+			want:     "1.25",
+		},
+		{
+			name:     "8",
+			c:        &columnConfig{Type: element.TypeBytes},
+			s:        "abc",
+			wantType: element.TypeBytes,
+			want:     "abc",
+		},
+		{
+			name:     "9",
+			c:        &columnConfig{Type: element.TypeTime, Format: "2006-01-02"},
+			s:        "2021-02-03",
+			wantType: element.TypeTime,
+			want:     "2021-02-03T00:00:00Z",
+		},
+		{
+			name:    "10",
+			c:       &columnConfig{Type: element.TypeBool},
+			s:       "yes",
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			c:       &columnConfig{Type: element.TypeBigInt},
+			s:       "1.5",
+			wantErr: true,
+		},
+		{
+			name:    "12",
+			c:       &columnConfig{Type: element.TypeDecimal},
+			s:       "abc",
+			wantErr: true,
+		},
+		{
+			name:    "13",
+			c:       &columnConfig{Type: element.TypeTime},
+			s:       "2021-02-03",
+			wantErr: true,
+		},
+		{
+			name:     "14",
+			c:        &columnConfig{Type: element.TypeTime},
+			s:        "",
+			wantType: element.TypeTime,
+			want:     "<nil>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.columnValue(tt.s, tt.isNull)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("columnConfig.columnValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Type() != tt.wantType {
+				t.Errorf("columnConfig.columnValue() type = %v, want %v", got.Type(), tt.wantType)
+			}
+			if got.String() != tt.want {
+				t.Errorf("columnConfig.columnValue() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
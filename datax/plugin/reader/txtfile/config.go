@@ -0,0 +1,120 @@
+package txtfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file/delimited"
+)
+
+type paramConfig struct {
+	file.Config
+
+	FieldDelimiter string         `json:"fieldDelimiter"` //字段分隔符，默认为逗号
+	QuoteChar      string         `json:"quoteChar"`      //引号，默认不处理引号，设置后不能配置splitSize
+	EscapeChar     string         `json:"escapeChar"`     //转义符
+	SkipHeader     bool           `json:"skipHeader"`     //是否跳过首行表头
+	NullFormat     string         `json:"nullFormat"`     //表示空值的字符串
+	Column         []columnConfig `json:"column"`         //列配置，为空时所有列都当做字符串读取
+}
+
+type columnConfig struct {
+	Index  *int               `json:"index"`  //列在文件中的序号，从0开始
+	Name   string             `json:"name"`   //列名
+	Type   element.ColumnType `json:"type"`   //列类型
+	Format string             `json:"format"` //时间格式，使用go的时间格式，默认为time.RFC3339Nano
+	Value  *string            `json:"value"`  //常量值，设置后不从文件中读取
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	if err = p.Validate(); err != nil {
+		return
+	}
+
+	if _, err = p.options(); err != nil {
+		return
+	}
+
+	//切分位置只按照换行符对齐，引号中的换行符会导致相邻切分的行被破坏
+	if p.QuoteChar != "" && p.SplitSize > 0 {
+		return fmt.Errorf("splitSize(%v) is not supported when quoteChar is set", p.SplitSize)
+	}
+
+	for i, v := range p.Column {
+		if v.Index == nil && v.Value == nil {
+			return fmt.Errorf("column(%v) index and value are both empty", i)
+		}
+		if v.Index == nil && v.Name == "" {
+			return fmt.Errorf("column(%v) name of constant value is empty", i)
+		}
+		if v.Index != nil && *v.Index < 0 {
+			return fmt.Errorf("column(%v) index(%v) is less than 0", i, *v.Index)
+		}
+		switch v.Type {
+		case "", element.TypeBool, element.TypeBigInt, element.TypeDecimal,
+			element.TypeString, element.TypeBytes, element.TypeTime:
+		default:
+			return fmt.Errorf("column(%v) type(%v) is not supported", i, v.Type)
+		}
+	}
+	return
+}
+
+//options 获取分隔文本选项
+func (p *paramConfig) options() (opts delimited.Options, err error) {
+	if opts.Delimiter, err = toRune("fieldDelimiter", p.FieldDelimiter); err != nil {
+		return
+	}
+	if opts.Quote, err = toRune("quoteChar", p.QuoteChar); err != nil {
+		return
+	}
+	if opts.Escape, err = toRune("escapeChar", p.EscapeChar); err != nil {
+		return
+	}
+	return
+}
+
+//toRune 将只有一个字符的字符串s转化为字符，空字符串转化为0
+func toRune(name, s string) (rune, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if utf8.RuneCountInString(s) != 1 {
+		return 0, fmt.Errorf("%v(%v) is not a single character", name, s)
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return r, nil
+}
+
+//columnType 获取列类型，默认为字符串
+func (c *columnConfig) columnType() element.ColumnType {
+	if c.Type == "" {
+		return element.TypeString
+	}
+	return c.Type
+}
+
+//layout 获取时间格式，默认为time.RFC3339Nano
+func (c *columnConfig) layout() string {
+	if c.Format == "" {
+		return time.RFC3339Nano
+	}
+	return c.Format
+}
@@ -0,0 +1,153 @@
+package txtfile
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file/delimited"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"path":["a.csv"],"encoding":"gbk","compress":"gzip","fieldDelimiter":"\t",
+				"column":[{"index":0,"type":"bigInt"},{"name":"c","value":"1"}]}`,
+		},
+		{
+			name:    "2",
+			json:    `{"path":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"path":["a.csv"],"encoding":"big5"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"path":["a.csv"],"compress":"lzo"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"path":["a.csv"],"fieldDelimiter":"||"}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"path":["a.csv"],"quoteChar":"''"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"path":["a.csv"],"escapeChar":"\\\\"}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"path":["a.csv"],"column":[{"type":"string"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			json:    `{"path":["a.csv"],"column":[{"index":-1}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			json:    `{"path":["a.csv"],"column":[{"index":0,"type":"map"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			json:    `{"path":["a.csv"],"column":[{"value":"1"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "12",
+			json:    `{"path":"a.csv"}`,
+			wantErr: true,
+		},
+		{
+			name:    "13",
+			json:    `{"path":["a.csv"],"quoteChar":"\"","splitSize":1024}`,
+			wantErr: true,
+		},
+		{
+			name: "14",
+			json: `{"path":["a.csv"],"quoteChar":"\"","splitSize":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_options(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        *paramConfig
+		wantOpts delimited.Options
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			p:        &paramConfig{},
+			wantOpts: delimited.Options{},
+		},
+		{
+			name: "2",
+			p: &paramConfig{
+				FieldDelimiter: "|",
+				QuoteChar:      `"`,
+				EscapeChar:     `\`,
+			},
+			wantOpts: delimited.Options{
+				Delimiter: '|',
+				Quote:     '"',
+				Escape:    '\\',
+			},
+		},
+		{
+			name: "3",
+			p: &paramConfig{
+				FieldDelimiter: "丨",
+			},
+			wantOpts: delimited.Options{
+				Delimiter: '丨',
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOpts, err := tt.p.options()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("paramConfig.options() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotOpts, tt.wantOpts) {
+				t.Errorf("paramConfig.options() = %v, want %v", gotOpts, tt.wantOpts)
+			}
+		})
+	}
+}
+
+func TestColumnConfig_columnType(t *testing.T) {
+	if got := (&columnConfig{}).columnType(); got != element.TypeString {
+		t.Errorf("columnConfig.columnType() = %v, want %v", got, element.TypeString)
+	}
+	if got := (&columnConfig{Type: element.TypeTime}).columnType(); got != element.TypeTime {
+		t.Errorf("columnConfig.columnType() = %v, want %v", got, element.TypeTime)
+	}
+}
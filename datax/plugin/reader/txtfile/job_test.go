@@ -0,0 +1,56 @@
+package txtfile
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
+)
+
+func TestJob_Init(t *testing.T) {
+	dir := testTempDir(t)
+	testWriteFile(t, dir, "a.csv", "1,2\n")
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.csv") + `"]}`),
+		},
+		{
+			name:    "2",
+			jobConf: testJSONFromString(`{}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJobConf(`{"path":[]}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.json") + `"]}`),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.csv") + `"],"fieldDelimiter":"||"}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				Job: file.NewJob(),
+			}
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
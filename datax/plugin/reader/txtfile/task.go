@@ -0,0 +1,188 @@
+package txtfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file/delimited"
+)
+
+//Task 任务
+type Task struct {
+	*plugin.BaseTask
+
+	param *paramConfig
+	opts  delimited.Options
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.opts, err = t.param.options(); err != nil {
+		return
+	}
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//StartRead 开始读，在没有切分配置时读取所有匹配的文件
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	var splits []file.Split
+	if splits, err = t.param.Splits(); err != nil {
+		return
+	}
+
+	for _, v := range splits {
+		if err = t.readSplit(ctx, v, sender); err != nil {
+			return
+		}
+	}
+	return sender.Terminate()
+}
+
+//readSplit 读取切分s中的记录并发往写入器
+func (t *Task) readSplit(ctx context.Context, s file.Split, sender plugin.RecordSender) (err error) {
+	var header []string
+	if t.param.SkipHeader && s.Start > 0 {
+		if header, err = t.readHeader(s.Path); err != nil {
+			return
+		}
+	}
+
+	var rc io.ReadCloser
+	if rc, err = t.param.Open(s); err != nil {
+		return
+	}
+	defer rc.Close()
+
+	r := delimited.NewReader(rc, t.opts)
+
+	if t.param.SkipHeader && s.Start == 0 {
+		if header, err = r.Read(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("read file(%v) err: %v", s.Path, err)
+		}
+	}
+
+	var raw []string
+	var record element.Record
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if raw, err = r.Read(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("read file(%v) err: %v", s.Path, err)
+		}
+
+		if record, err = sender.CreateRecord(); err != nil {
+			return
+		}
+
+		if err = t.fillRecord(record, header, raw); err != nil {
+			return fmt.Errorf("file(%v) line(%v) err: %v", s.Path, r.Line(), err)
+		}
+
+		if err = sender.SendWriter(record); err != nil {
+			return
+		}
+	}
+}
+
+//readHeader 读取文件filename的首行表头
+func (t *Task) readHeader(filename string) (header []string, err error) {
+	var rc io.ReadCloser
+	if rc, err = t.param.Open(file.Split{Path: filename}); err != nil {
+		return
+	}
+	defer rc.Close()
+
+	r := delimited.NewReader(rc, t.opts)
+	if header, err = r.Read(); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("read file(%v) err: %v", filename, err)
+	}
+	return header, nil
+}
+
+//fillRecord 将一行的字段raw按照列配置转化为列并加入记录record，
+//列名默认使用表头header中对应的名字，没有表头时使用列序号
+func (t *Task) fillRecord(record element.Record, header []string, raw []string) (err error) {
+	name := func(i int) string {
+		if i < len(header) {
+			return header[i]
+		}
+		return strconv.Itoa(i)
+	}
+
+	if len(t.param.Column) == 0 {
+		for i, v := range raw {
+			var cv element.ColumnValue
+			if cv, err = (&columnConfig{}).columnValue(v, t.isNull(v)); err != nil {
+				return
+			}
+			if err = record.Add(element.NewDefaultColumn(cv, name(i), len(v))); err != nil {
+				return
+			}
+		}
+		return
+	}
+
+	for i, c := range t.param.Column {
+		var s string
+		var isNull bool
+		if c.Value != nil {
+			s = *c.Value
+		} else {
+			if *c.Index >= len(raw) {
+				return fmt.Errorf("column(%v) index(%v) is out of range(%v)", i, *c.Index, len(raw))
+			}
+			s = raw[*c.Index]
+			isNull = t.isNull(s)
+		}
+
+		var cv element.ColumnValue
+		if cv, err = c.columnValue(s, isNull); err != nil {
+			return fmt.Errorf("column(%v) value(%v) err: %v", i, s, err)
+		}
+
+		colName := c.Name
+		if colName == "" {
+			colName = name(*c.Index)
+		}
+		if err = record.Add(element.NewDefaultColumn(cv, colName, len(s))); err != nil {
+			return
+		}
+	}
+	return
+}
+
+//isNull 判断字符串s是否为空值字符串，nullFormat为空时不处理
+func (t *Task) isNull(s string) bool {
+	return t.param.NullFormat != "" && s == t.param.NullFormat
+}
@@ -0,0 +1,278 @@
+package txtfile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
+)
+
+func testGzip(t *testing.T, s string) string {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	return buf.String()
+}
+
+func testTask(t *testing.T, param string) *Task {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+func TestTask_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":["a.csv"],"fieldDelimiter":"|"}`,
+		},
+		{
+			name:    "2",
+			param:   `{"path":["a.csv"],"fieldDelimiter":"||"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: plugin.NewBaseTask(),
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			if err := task.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartRead(t *testing.T) {
+	dir := testTempDir(t)
+	abs := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+	testWriteFile(t, dir, "a.csv", "id,name\n1,\"a,b\"\n2,\\N\n")
+	testWriteFile(t, dir, "b.csv", "id,name\n3,c\n")
+	testWriteFile(t, dir, "c.csv.gz", testGzip(t, "1|2021-01-02|true\n"))
+	testWriteFile(t, dir, "d.csv", string([]byte{0xd6, 0xd0, '\t', 0xce, 0xc4, '\n'}))
+	testWriteFile(t, dir, "e.csv", "1,2\n3\n")
+	testWriteFile(t, dir, "f.csv", "a,b\n\"1\n")
+	tests := []struct {
+		name    string
+		param   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":["` + abs("a.csv") + `"],"quoteChar":"\"","skipHeader":true,"nullFormat":"\\N"}`,
+			want: []string{
+				"id:string:1 name:string:a,b",
+				"id:string:2 name:string:<nil>",
+			},
+		},
+		{
+			name:    "2",
+			param:   `{"path":["` + abs("[ab].csv") + `"],"column":[{"index":0,"type":"bigInt"},{"index":1,"name":"n"},{"name":"k","value":"v"}]}`,
+			wantErr: true,
+		},
+		{
+			name:  "3",
+			param: `{"path":["` + abs("[ab].csv") + `"],"quoteChar":"\"","skipHeader":true,"column":[{"index":0,"type":"bigInt"},{"index":1,"name":"n"},{"name":"k","value":"v"}]}`,
+			want: []string{
+				"id:bigInt:1 n:string:a,b k:string:v",
+				"id:bigInt:2 n:string:\\N k:string:v",
+				"id:bigInt:3 n:string:c k:string:v",
+			},
+		},
+		{
+			name:  "4",
+			param: `{"path":["` + abs("c.csv.gz") + `"],"compress":"gzip","fieldDelimiter":"|","column":[{"index":0,"type":"decimal"},{"index":1,"type":"time","format":"2006-01-02"},{"index":2,"type":"bool"}]}`,
+			want: []string{
+				"0:decimal:1 1:time:2021-01-02T00:00:00Z 2:bool:true",
+			},
+		},
+		{
+			name:  "5",
+			param: `{"path":["` + abs("d.csv") + `"],"encoding":"gbk","fieldDelimiter":"\t"}`,
+			want: []string{
+				"0:string:中 1:string:文",
+			},
+		},
+		{
+			name:  "6",
+			param: `{"path":["` + abs("e.csv") + `"],"column":[{"index":1}]}`,
+			want: []string{
+				"1:string:2",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			param:   `{"path":["` + abs("f.csv") + `"],"quoteChar":"\""}`,
+			want:    []string{"0:string:a 1:string:b"},
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			param:   `{"path":["` + abs("a.csv") + `"],"compress":"gzip"}`,
+			wantErr: true,
+		},
+		{
+			name:  "9",
+			param: `{"path":["` + abs("a.csv") + `"],"quoteChar":"\"","skipHeader":true,"split":{"path":"` + abs("a.csv") + `","start":9,"end":0}}`,
+			want: []string{
+				"id:string:2 name:string:\\N",
+			},
+		},
+		{
+			name:  "10",
+			param: `{"path":["` + abs("a.csv") + `"],"split":{"path":"` + abs("b.csv") + `"}}`,
+			want: []string{
+				"0:string:id 1:string:name",
+				"0:string:3 1:string:c",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &mockSender{}
+			err := testTask(t, tt.param).StartRead(context.TODO(), sender)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Task.StartRead() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if sender.terminated == tt.wantErr {
+				t.Errorf("Task.StartRead() terminated = %v", sender.terminated)
+			}
+			if got := testRecords(sender.records); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartReadSplit(t *testing.T) {
+	dir := testTempDir(t)
+	var lines []string
+	for i := 0; i < 100; i++ {
+		lines = append(lines, strings.Repeat("x", i%7)+","+strings.Repeat("y", i%5))
+	}
+	data := "a,b\n" + strings.Join(lines, "\n") + "\n"
+	testWriteFile(t, dir, "a.csv", data)
+	param := `{"path":["` + filepath.Join(dir, "a.csv") + `"],"skipHeader":true,"splitSize":37}`
+
+	j := &Job{
+		Job: file.NewJob(),
+	}
+	j.SetPluginJobConf(testJobConf(param))
+	if err := j.Init(context.TODO()); err != nil {
+		t.Fatalf("Job.Init() error = %v", err)
+	}
+	confs, err := j.Split(context.TODO(), 1)
+	if err != nil {
+		t.Fatalf("Job.Split() error = %v", err)
+	}
+	if len(confs) < 2 {
+		t.Fatalf("Job.Split() = %v, want more than 1", len(confs))
+	}
+
+	var got []string
+	for _, conf := range confs {
+		task := &Task{
+			BaseTask: plugin.NewBaseTask(),
+		}
+		task.SetPluginJobConf(conf)
+		if err = task.Init(context.TODO()); err != nil {
+			t.Fatalf("Task.Init() error = %v", err)
+		}
+		sender := &mockSender{}
+		if err = task.StartRead(context.TODO(), sender); err != nil {
+			t.Fatalf("Task.StartRead() error = %v", err)
+		}
+		got = append(got, testRecords(sender.records)...)
+	}
+
+	var want []string
+	for _, v := range lines {
+		f := strings.Split(v, ",")
+		want = append(want, "a:string:"+f[0]+" b:string:"+f[1])
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Task.StartRead() = %v, want %v", got, want)
+	}
+}
+
+func TestTask_StartReadErr(t *testing.T) {
+	dir := testTempDir(t)
+	param := `{"path":["` + testWriteFile(t, dir, "a.csv", "1,2\n") + `"]}`
+	errMock := errors.New("mock error")
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		sender *mockSender
+	}{
+		{
+			name:   "1",
+			ctx:    context.TODO(),
+			sender: &mockSender{createErr: errMock},
+		},
+		{
+			name:   "2",
+			ctx:    context.TODO(),
+			sender: &mockSender{sendErr: errMock},
+		},
+		{
+			name:   "3",
+			ctx:    testCanceledContext(),
+			sender: &mockSender{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testTask(t, param).StartRead(tt.ctx, tt.sender); err == nil {
+				t.Errorf("Task.StartRead() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func testCanceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package xlsx
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+//columnValue 将单元格的原始值s按照列配置转化为列值，空单元格会转化为对应类型的空值，
+//数值型的单元格转化为时间时当做excel日期序列号，date1904为true时使用1904日期系统
+func (c *columnConfig) columnValue(s string, date1904 bool) (element.ColumnValue, error) {
+	typ := c.columnType()
+	if s == "" {
+		return element.NewNilColumnValue(typ), nil
+	}
+
+	switch typ {
+	case element.TypeBool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		return element.NewBoolColumnValue(v), nil
+	case element.TypeBigInt:
+		if v, err := element.NewBigIntColumnValueFromString(s); err == nil {
+			return v, nil
+		}
+		//excel的数值可能以科学计数法存储，如1.5E+20
+		d, err := decimal.NewFromString(s)
+		if err != nil {
+			return nil, err
+		}
+		if !d.Equal(d.Truncate(0)) {
+			return nil, fmt.Errorf("%v is not an integer", s)
+		}
+		return element.NewBigIntColumnValue(d.Truncate(0).BigInt()), nil
+	case element.TypeDecimal:
+		return element.NewDecimalColumnValueFromString(s)
+	case element.TypeBytes:
+		return element.NewBytesColumnValue([]byte(s)), nil
+	case element.TypeTime:
+		layout := c.layout()
+		var t time.Time
+		if serial, err := strconv.ParseFloat(s, 64); err == nil {
+			if t, err = excelize.ExcelDateToTime(serial, date1904); err != nil {
+				return nil, err
+			}
+		} else if t, err = time.Parse(layout, s); err != nil {
+			return nil, err
+		}
+		return element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(layout)), nil
+	}
+	return element.NewStringColumnValue(s), nil
+}
@@ -0,0 +1,133 @@
+package xlsx
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestColumnConfig_columnValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        *columnConfig
+		s        string
+		date1904 bool
+		wantType element.ColumnType
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			c:        &columnConfig{},
+			s:        "abc",
+			wantType: element.TypeString,
+			want:     "abc",
+		},
+		{
+			name:     "2",
+			c:        &columnConfig{Type: element.TypeBool},
+			s:        "1",
+			wantType: element.TypeBool,
+			want:     "true",
+		},
+		{
+			name:     "3",
+			c:        &columnConfig{Type: element.TypeBigInt},
+			s:        "123",
+			wantType: element.TypeBigInt,
+			want:     "123",
+		},
+		{
+			name:     "4",
+			c:        &columnConfig{Type: element.TypeBigInt},
+			s:        "1.5E+20",
+			wantType: element.TypeBigInt,
+			want:     "150000000000000000000",
+		},
+		{
+			name:    "5",
+			c:       &columnConfig{Type: element.TypeBigInt},
+			s:       "1.5",
+			wantErr: true,
+		},
+		{
+			name:     "6",
+			c:        &columnConfig{Type: element.TypeDecimal},
+			s:        "1.25",
+			wantType: element.TypeDecimal,
+			want:     "1.25",
+		},
+		{
+			name:     "7",
+			c:        &columnConfig{Type: element.TypeBytes},
+			s:        "xyz",
+			wantType: element.TypeBytes,
+			want:     "xyz",
+		},
+		{
+			name:     "8",
+			c:        &columnConfig{Type: element.TypeTime, Format: "2006-01-02 15:04:05"},
+			s:        "44198.5",
+			wantType: element.TypeTime,
+			want:     "2021-01-02T12:00:00Z",
+		},
+		{
+			name:     "9",
+			c:        &columnConfig{Type: element.TypeTime, Format: "2006-01-02"},
+			s:        "42736",
+			date1904: true,
+			wantType: element.TypeTime,
+			want:     "2021-01-02T00:00:00Z",
+		},
+		{
+			name:     "10",
+			c:        &columnConfig{Type: element.TypeTime, Format: "2006-01-02"},
+			s:        "2021-01-02",
+			wantType: element.TypeTime,
+			want:     "2021-01-02T00:00:00Z",
+		},
+		{
+			name:    "11",
+			c:       &columnConfig{Type: element.TypeTime},
+			s:       "2021-01-02",
+			wantErr: true,
+		},
+		{
+			name:     "12",
+			c:        &columnConfig{Type: element.TypeTime},
+			s:        "",
+			wantType: element.TypeTime,
+			want:     "<nil>",
+		},
+		{
+			name:    "13",
+			c:       &columnConfig{Type: element.TypeBool},
+			s:       "yes",
+			wantErr: true,
+		},
+		{
+			name:    "14",
+			c:       &columnConfig{Type: element.TypeBigInt},
+			s:       "a",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.columnValue(tt.s, tt.date1904)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("columnConfig.columnValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Type() != tt.wantType {
+				t.Errorf("columnConfig.columnValue() type = %v, want %v", got.Type(), tt.wantType)
+			}
+			if got.String() != tt.want {
+				t.Errorf("columnConfig.columnValue() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package xlsx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/xuri/excelize/v2"
+)
+
+type paramConfig struct {
+	streamfile.FileSystemConfig
+
+	Path       []string       `json:"path"`       //文件路径，支持通配符，s3://<bucket>/<key>形式的路径从对象存储读取
+	Sheet      []string       `json:"sheet"`      //工作表名
+	SheetIndex []int          `json:"sheetIndex"` //工作表序号，从0开始，和sheet都为空时读取第一个工作表
+	Header     bool           `json:"header"`     //读取范围的首行是否为表头
+	Range      string         `json:"range"`      //读取的单元格范围，如A2:D100，只有起始单元格如B2时读取到工作表末尾，默认为A1
+	Column     []columnConfig `json:"column"`     //列配置，为空时所有单元格都当做字符串读取
+	Split      *split         `json:"split"`      //由Job.Split生成的切分配置
+
+	cellRange cellRange
+}
+
+//split 切分，文件Path中需要读取的工作表
+type split struct {
+	Path  string `json:"path"`  //文件路径
+	Sheet string `json:"sheet"` //工作表名
+}
+
+type columnConfig struct {
+	Index  *int               `json:"index"`  //列在读取范围中的序号，从0开始
+	Name   string             `json:"name"`   //列名，默认使用表头中对应的名字，没有表头时使用列号如A
+	Type   element.ColumnType `json:"type"`   //列类型
+	Format string             `json:"format"` //文本单元格的时间格式，使用go的时间格式，默认为time.RFC3339Nano
+}
+
+//cellRange 单元格范围，行列号都从1开始，结束行列号为0时代表到末尾
+type cellRange struct {
+	startCol int
+	startRow int
+	endCol   int
+	endRow   int
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	if len(p.Path) == 0 {
+		return fmt.Errorf("path is empty")
+	}
+
+	if err = p.FileSystemConfig.Validate(); err != nil {
+		return
+	}
+
+	for _, v := range p.SheetIndex {
+		if v < 0 {
+			return fmt.Errorf("sheetIndex(%v) is less than 0", v)
+		}
+	}
+
+	if p.cellRange, err = parseRange(p.Range); err != nil {
+		return
+	}
+
+	for i, v := range p.Column {
+		if v.Index == nil {
+			return fmt.Errorf("column(%v) index is empty", i)
+		}
+		if *v.Index < 0 {
+			return fmt.Errorf("column(%v) index(%v) is less than 0", i, *v.Index)
+		}
+		switch v.Type {
+		case "", element.TypeBool, element.TypeBigInt, element.TypeDecimal,
+			element.TypeString, element.TypeBytes, element.TypeTime:
+		default:
+			return fmt.Errorf("column(%v) type(%v) is not supported", i, v.Type)
+		}
+	}
+	return
+}
+
+//sheets 获取文件f中需要读取的工作表，sheet和sheetIndex都为空时读取第一个工作表
+func (p *paramConfig) sheets(f *excelize.File) (sheets []string, err error) {
+	list := f.GetSheetList()
+	if len(p.Sheet) == 0 && len(p.SheetIndex) == 0 {
+		if len(list) == 0 {
+			return nil, nil
+		}
+		return list[:1], nil
+	}
+
+	added := make(map[string]bool)
+	add := func(sheet string) {
+		if !added[sheet] {
+			added[sheet] = true
+			sheets = append(sheets, sheet)
+		}
+	}
+
+	for _, v := range p.Sheet {
+		var index int
+		if index, err = f.GetSheetIndex(v); err != nil {
+			return nil, err
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("sheet(%v) does not exist", v)
+		}
+		add(list[index])
+	}
+
+	for _, v := range p.SheetIndex {
+		if v >= len(list) {
+			return nil, fmt.Errorf("sheetIndex(%v) is out of range(%v)", v, len(list))
+		}
+		add(list[v])
+	}
+	return
+}
+
+//parseRange 将形如A2:D100或者B2的字符串s解析为单元格范围，空字符串代表从A1开始的所有单元格
+func parseRange(s string) (r cellRange, err error) {
+	if s == "" {
+		return cellRange{startCol: 1, startRow: 1}, nil
+	}
+
+	cells := strings.Split(s, ":")
+	if len(cells) > 2 {
+		return r, fmt.Errorf("range(%v) is not valid", s)
+	}
+
+	if r.startCol, r.startRow, err = excelize.CellNameToCoordinates(cells[0]); err != nil {
+		return r, fmt.Errorf("range(%v) err: %v", s, err)
+	}
+
+	if len(cells) == 2 {
+		if r.endCol, r.endRow, err = excelize.CellNameToCoordinates(cells[1]); err != nil {
+			return r, fmt.Errorf("range(%v) err: %v", s, err)
+		}
+		if r.endCol < r.startCol || r.endRow < r.startRow {
+			return r, fmt.Errorf("range(%v) end is before start", s)
+		}
+	}
+	return
+}
+
+//contains 判断第row行是否在范围内，超过结束行时over为true
+func (r cellRange) contains(row int) (ok bool, over bool) {
+	if r.endRow > 0 && row > r.endRow {
+		return false, true
+	}
+	return row >= r.startRow, false
+}
+
+//cells 截取一行单元格cells中在范围内的部分
+func (r cellRange) cells(cells []string) []string {
+	if r.startCol > len(cells) {
+		return nil
+	}
+	if r.endCol > 0 && r.endCol < len(cells) {
+		cells = cells[:r.endCol]
+	}
+	return cells[r.startCol-1:]
+}
+
+//columnName 获取范围内第i个单元格的列号，如A
+func (r cellRange) columnName(i int) string {
+	name, _ := excelize.ColumnNumberToName(r.startCol + i)
+	return name
+}
+
+//columnType 获取列类型，默认为字符串
+func (c *columnConfig) columnType() element.ColumnType {
+	if c.Type == "" {
+		return element.TypeString
+	}
+	return c.Type
+}
+
+//layout 获取时间格式，默认为time.RFC3339Nano
+func (c *columnConfig) layout() string {
+	if c.Format == "" {
+		return time.RFC3339Nano
+	}
+	return c.Format
+}
+
+//openFile 打开文件系统fs中的文件filename
+func openFile(fs streamfile.FileSystem, filename string) (f *excelize.File, err error) {
+	var rf streamfile.File
+	if rf, err = fs.Open(filename); err != nil {
+		return
+	}
+	defer rf.Close()
+	return excelize.OpenReader(rf)
+}
@@ -0,0 +1,251 @@
+package xlsx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	index := 1
+	tests := []struct {
+		name    string
+		json    string
+		want    *paramConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"path":["a.xlsx"],"sheet":["a"],"sheetIndex":[1],"header":true,"range":"B2:D10",` +
+				`"column":[{"index":1,"name":"b","type":"time","format":"2006-01-02"}],"split":{"path":"a.xlsx","sheet":"a"}}`,
+			want: &paramConfig{
+				Path:       []string{"a.xlsx"},
+				Sheet:      []string{"a"},
+				SheetIndex: []int{1},
+				Header:     true,
+				Range:      "B2:D10",
+				Column:     []columnConfig{{Index: &index, Name: "b", Type: "time", Format: "2006-01-02"}},
+				Split:      &split{Path: "a.xlsx", Sheet: "a"},
+				cellRange:  cellRange{startCol: 2, startRow: 2, endCol: 4, endRow: 10},
+			},
+		},
+		{
+			name: "2",
+			json: `{"path":["a.xlsx"]}`,
+			want: &paramConfig{
+				Path:      []string{"a.xlsx"},
+				cellRange: cellRange{startCol: 1, startRow: 1},
+			},
+		},
+		{
+			name:    "3",
+			json:    `{"path":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"path":["a.xlsx"],"sheetIndex":[-1]}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"path":["a.xlsx"],"range":"A"}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"path":["a.xlsx"],"column":[{"name":"a"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"path":["a.xlsx"],"column":[{"index":-1}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"path":["a.xlsx"],"column":[{"index":0,"type":"json"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			json:    `{"path":"a.xlsx"}`,
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			json:    `{"path":["s3://bucket/a.xlsx"],"s3":{"secretKey":"sk"}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newParamConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParamConfig_sheets(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetName("Sheet1", "a")
+	f.NewSheet("b")
+	f.NewSheet("c")
+	tests := []struct {
+		name    string
+		p       *paramConfig
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			p:    &paramConfig{},
+			want: []string{"a"},
+		},
+		{
+			name: "2",
+			p:    &paramConfig{Sheet: []string{"C", "a"}, SheetIndex: []int{1, 2}},
+			want: []string{"c", "a", "b"},
+		},
+		{
+			name:    "3",
+			p:       &paramConfig{Sheet: []string{"d"}},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			p:       &paramConfig{SheetIndex: []int{3}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.sheets(f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("paramConfig.sheets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paramConfig.sheets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    cellRange
+		wantErr bool
+	}{
+		{
+			name: "1",
+			s:    "",
+			want: cellRange{startCol: 1, startRow: 1},
+		},
+		{
+			name: "2",
+			s:    "B3",
+			want: cellRange{startCol: 2, startRow: 3},
+		},
+		{
+			name: "3",
+			s:    "B3:AA10",
+			want: cellRange{startCol: 2, startRow: 3, endCol: 27, endRow: 10},
+		},
+		{
+			name:    "4",
+			s:       "B3:A10",
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			s:       "B3:C1",
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			s:       "A1:B2:C3",
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			s:       "A1:3",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRange(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCellRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        cellRange
+		row      int
+		cells    []string
+		wantOk   bool
+		wantOver bool
+		want     []string
+		wantName string
+	}{
+		{
+			name:     "1",
+			r:        cellRange{startCol: 1, startRow: 1},
+			row:      1,
+			cells:    []string{"a", "b"},
+			wantOk:   true,
+			want:     []string{"a", "b"},
+			wantName: "B",
+		},
+		{
+			name:     "2",
+			r:        cellRange{startCol: 2, startRow: 2, endCol: 3, endRow: 3},
+			row:      1,
+			cells:    []string{"a", "b", "c", "d"},
+			want:     []string{"b", "c"},
+			wantName: "C",
+		},
+		{
+			name:     "3",
+			r:        cellRange{startCol: 3, startRow: 2, endCol: 3, endRow: 3},
+			row:      4,
+			cells:    []string{"a"},
+			wantOver: true,
+			wantName: "D",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, over := tt.r.contains(tt.row)
+			if ok != tt.wantOk || over != tt.wantOver {
+				t.Errorf("cellRange.contains() = %v %v, want %v %v", ok, over, tt.wantOk, tt.wantOver)
+			}
+			if got := tt.r.cells(tt.cells); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cellRange.cells() = %v, want %v", got, tt.want)
+			}
+			if got := tt.r.columnName(1); got != tt.wantName {
+				t.Errorf("cellRange.columnName() = %v, want %v", got, tt.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package xlsx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/xuri/excelize/v2"
+)
+
+//Job 工作
+type Job struct {
+	*plugin.BaseJob
+
+	param     *paramConfig
+	fs        streamfile.FileSystem
+	filenames []string
+}
+
+//Init 初始化
+func (j *Job) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if j.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+	j.fs = streamfile.NewFileSystem(&j.param.FileSystemConfig)
+
+	if j.filenames, err = streamfile.Glob(j.fs, j.param.Path); err != nil {
+		return
+	}
+	return
+}
+
+//Destroy 销毁
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//Split 切分，每个文件的每个工作表一个任务
+func (j *Job) Split(ctx context.Context, number int) (confs []*config.JSON, err error) {
+	for _, filename := range j.filenames {
+		var sheets []string
+		if sheets, err = j.sheets(filename); err != nil {
+			return nil, err
+		}
+
+		for _, sheet := range sheets {
+			conf := j.PluginJobConf().CloneConfig()
+			if err = conf.Set(coreconst.DataxJobContentReaderParameter+".split", &split{
+				Path:  filename,
+				Sheet: sheet,
+			}); err != nil {
+				return nil, err
+			}
+			confs = append(confs, conf)
+		}
+	}
+	return
+}
+
+//sheets 获取文件filename中需要读取的工作表
+func (j *Job) sheets(filename string) (sheets []string, err error) {
+	var f *excelize.File
+	if f, err = openFile(j.fs, filename); err != nil {
+		return nil, fmt.Errorf("open file(%v) err: %v", filename, err)
+	}
+	defer f.Close()
+
+	if sheets, err = j.param.sheets(f); err != nil {
+		return nil, fmt.Errorf("file(%v) err: %v", filename, err)
+	}
+	return
+}
@@ -0,0 +1,135 @@
+package xlsx
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+)
+
+func testJob(t *testing.T, param string) *Job {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	j.SetPluginJobConf(testJobConf(param))
+	if err := j.Init(context.TODO()); err != nil {
+		t.Fatalf("Job.Init() error = %v", err)
+	}
+	return j
+}
+
+func TestJob_Init(t *testing.T) {
+	dir := testTempDir(t)
+	testWriteXlsx(t, dir, "a.xlsx", testSheet{name: "a"})
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.xlsx") + `"]}`),
+		},
+		{
+			name:    "2",
+			jobConf: testJobConf(`{"path":["` + filepath.Join(dir, "*.csv") + `"]}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJobConf(`{"path":[]}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			jobConf: testJSONFromString(`{}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				BaseJob: plugin.NewBaseJob(),
+			}
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJob_Split(t *testing.T) {
+	dir := testTempDir(t)
+	a := testWriteXlsx(t, dir, "a.xlsx", testSheet{name: "x"}, testSheet{name: "y"}, testSheet{name: "z"})
+	b := testWriteXlsx(t, dir, "b.xlsx", testSheet{name: "y"}, testSheet{name: "x"})
+	tests := []struct {
+		name    string
+		param   string
+		want    []split
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":["` + filepath.Join(dir, "*.xlsx") + `"]}`,
+			want:  []split{{Path: a, Sheet: "x"}, {Path: b, Sheet: "y"}},
+		},
+		{
+			name:  "2",
+			param: `{"path":["` + filepath.Join(dir, "*.xlsx") + `"],"sheet":["x"],"sheetIndex":[0]}`,
+			want:  []split{{Path: a, Sheet: "x"}, {Path: b, Sheet: "x"}, {Path: b, Sheet: "y"}},
+		},
+		{
+			name:    "3",
+			param:   `{"path":["` + filepath.Join(dir, "*.xlsx") + `"],"sheet":["z"]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			confs, err := testJob(t, tt.param).Split(context.TODO(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Job.Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []split
+			for _, conf := range confs {
+				var sc *config.JSON
+				if sc, err = conf.GetConfig(coreconst.DataxJobContentReaderParameter + ".split"); err != nil {
+					t.Fatal(err)
+				}
+				s := split{}
+				if err = json.Unmarshal([]byte(sc.String()), &s); err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, s)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.Split() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	c := filepath.Join(dir, "c.xlsx")
+	if err := ioutil.WriteFile(c, []byte("not xlsx"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := testJob(t, `{"path":["`+c+`"]}`).Split(context.TODO(), 1); err == nil {
+		t.Errorf("Job.Split() error = nil, wantErr true")
+	}
+}
+
+func TestJob_Destroy(t *testing.T) {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	if err := j.Destroy(context.TODO()); err != nil {
+		t.Errorf("Job.Destroy() error = %v", err)
+	}
+}
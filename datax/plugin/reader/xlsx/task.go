@@ -0,0 +1,205 @@
+package xlsx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/xuri/excelize/v2"
+)
+
+//Task 任务
+type Task struct {
+	*plugin.BaseTask
+
+	param *paramConfig
+	fs    streamfile.FileSystem
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+	t.fs = streamfile.NewFileSystem(&t.param.FileSystemConfig)
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//StartRead 开始读，在没有切分配置时读取所有匹配的文件中配置的工作表
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	if t.param.Split != nil {
+		if err = t.readFile(ctx, t.param.Split.Path, []string{t.param.Split.Sheet}, sender); err != nil {
+			return
+		}
+		return sender.Terminate()
+	}
+
+	var filenames []string
+	if filenames, err = streamfile.Glob(t.fs, t.param.Path); err != nil {
+		return
+	}
+
+	for _, v := range filenames {
+		if err = t.readFile(ctx, v, nil, sender); err != nil {
+			return
+		}
+	}
+	return sender.Terminate()
+}
+
+//readFile 读取文件filename中的工作表sheets并发往写入器，sheets为nil时读取配置的工作表
+func (t *Task) readFile(ctx context.Context, filename string, sheets []string, sender plugin.RecordSender) (err error) {
+	var f *excelize.File
+	if f, err = openFile(t.fs, filename); err != nil {
+		return fmt.Errorf("open file(%v) err: %v", filename, err)
+	}
+	defer f.Close()
+
+	if sheets == nil {
+		if sheets, err = t.param.sheets(f); err != nil {
+			return fmt.Errorf("file(%v) err: %v", filename, err)
+		}
+	}
+
+	var props excelize.WorkbookPropsOptions
+	if props, err = f.GetWorkbookProps(); err != nil {
+		return fmt.Errorf("file(%v) err: %v", filename, err)
+	}
+	date1904 := props.Date1904 != nil && *props.Date1904
+
+	for _, sheet := range sheets {
+		if err = t.readSheet(ctx, f, sheet, date1904, sender); err != nil {
+			return
+		}
+	}
+	return
+}
+
+//readSheet 读取文件f中工作表sheet在范围内的行并发往写入器，范围内全为空的行会被跳过
+func (t *Task) readSheet(ctx context.Context, f *excelize.File, sheet string, date1904 bool, sender plugin.RecordSender) (err error) {
+	var rows *excelize.Rows
+	if rows, err = f.Rows(sheet); err != nil {
+		return fmt.Errorf("file(%v) sheet(%v) err: %v", f.Path, sheet, err)
+	}
+	defer rows.Close()
+
+	var header []string
+	headerRead := !t.param.Header
+	for row := 1; rows.Next(); row++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		ok, over := t.param.cellRange.contains(row)
+		if over {
+			break
+		}
+		if !ok {
+			continue
+		}
+
+		var cells []string
+		if cells, err = rows.Columns(excelize.Options{RawCellValue: true}); err != nil {
+			return fmt.Errorf("file(%v) sheet(%v) row(%v) err: %v", f.Path, sheet, row, err)
+		}
+		cells = t.param.cellRange.cells(cells)
+
+		if !headerRead {
+			header, headerRead = cells, true
+			continue
+		}
+
+		if isBlank(cells) {
+			continue
+		}
+
+		var record element.Record
+		if record, err = sender.CreateRecord(); err != nil {
+			return
+		}
+
+		if err = t.fillRecord(record, header, cells, date1904); err != nil {
+			return fmt.Errorf("file(%v) sheet(%v) row(%v) err: %v", f.Path, sheet, row, err)
+		}
+
+		if err = sender.SendWriter(record); err != nil {
+			return
+		}
+	}
+
+	if err = rows.Error(); err != nil {
+		return fmt.Errorf("file(%v) sheet(%v) err: %v", f.Path, sheet, err)
+	}
+	return
+}
+
+//fillRecord 将一行范围内的单元格cells按照列配置转化为列并加入记录record，
+//列名默认使用表头header中对应的名字，没有表头时使用列号
+func (t *Task) fillRecord(record element.Record, header []string, cells []string, date1904 bool) (err error) {
+	name := func(i int) string {
+		if i < len(header) && header[i] != "" {
+			return header[i]
+		}
+		return t.param.cellRange.columnName(i)
+	}
+
+	if len(t.param.Column) == 0 {
+		for i, v := range cells {
+			var cv element.ColumnValue
+			if cv, err = (&columnConfig{}).columnValue(v, date1904); err != nil {
+				return
+			}
+			if err = record.Add(element.NewDefaultColumn(cv, name(i), len(v))); err != nil {
+				return
+			}
+		}
+		return
+	}
+
+	for i, c := range t.param.Column {
+		var s string
+		if *c.Index < len(cells) {
+			s = cells[*c.Index]
+		}
+
+		var cv element.ColumnValue
+		if cv, err = c.columnValue(s, date1904); err != nil {
+			return fmt.Errorf("column(%v) value(%v) err: %v", i, s, err)
+		}
+
+		colName := c.Name
+		if colName == "" {
+			colName = name(*c.Index)
+		}
+		if err = record.Add(element.NewDefaultColumn(cv, colName, len(s))); err != nil {
+			return
+		}
+	}
+	return
+}
+
+//isBlank 判断单元格cells是否全为空
+func isBlank(cells []string) bool {
+	for _, v := range cells {
+		if v != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,218 @@
+package xlsx
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+)
+
+func testTask(t *testing.T, param string) *Task {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+func TestTask_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":["a.xlsx"]}`,
+		},
+		{
+			name:    "2",
+			param:   `{"path":[]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: plugin.NewBaseTask(),
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			if err := task.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartRead(t *testing.T) {
+	dir := testTempDir(t)
+	tm := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := testWriteXlsx(t, dir, "a.xlsx", testSheet{
+		name: "users",
+		rows: [][]interface{}{
+			{"title"},
+			{"id", "name", "birth", "vip", "score"},
+			{1, "alice", tm, true, 1.5},
+			{},
+			{2, "bob", nil, false},
+			{3, "carol", "2021-01-02 03:04:05", nil, 2},
+		},
+	}, testSheet{
+		name: "other",
+		rows: [][]interface{}{
+			{"x", "y"},
+		},
+	})
+	b := testWriteXlsx(t, dir, "b.xlsx", testSheet{
+		name: "b",
+		rows: [][]interface{}{
+			{"id", "score"},
+			{4, "x"},
+		},
+	})
+	column := `"column":[{"index":0,"type":"bigInt"},{"index":1,"name":"user"},{"index":2,"type":"time","format":"2006-01-02 15:04:05"},` +
+		`{"index":3,"type":"bool"},{"index":4,"type":"decimal"},{"index":5}]`
+	tests := []struct {
+		name    string
+		param   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":["` + a + `"],"header":true,"range":"A2",` + column + `}`,
+			want: []string{
+				"id:bigInt:1 user:string:alice birth:time:2021-01-02T03:04:05Z vip:bool:true score:decimal:1.5 F:string:<nil>",
+				"id:bigInt:2 user:string:bob birth:time:<nil> vip:bool:false score:decimal:<nil> F:string:<nil>",
+				"id:bigInt:3 user:string:carol birth:time:2021-01-02T03:04:05Z vip:bool:<nil> score:decimal:2 F:string:<nil>",
+			},
+		},
+		{
+			name:  "2",
+			param: `{"path":["` + a + `"],"range":"B3:C5"}`,
+			want: []string{
+				"B:string:alice C:string:44198.12783564815",
+				"B:string:bob C:string:<nil>",
+			},
+		},
+		{
+			name:  "3",
+			param: `{"path":["` + a + `"],"sheetIndex":[1,0],"range":"A1:B2"}`,
+			want: []string{
+				"A:string:x B:string:y",
+				"A:string:title",
+				"A:string:id B:string:name",
+			},
+		},
+		{
+			name:  "4",
+			param: `{"path":["` + a + `"],"header":true,"split":{"path":"` + b + `","sheet":"b"}}`,
+			want: []string{
+				"id:string:4 score:string:x",
+			},
+		},
+		{
+			name:    "5",
+			param:   `{"path":["` + b + `"],"header":true,"column":[{"index":1,"type":"bigInt"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			param:   `{"path":["` + filepath.Join(dir, "*.csv") + `"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			param:   `{"path":["` + a + `"],"sheet":["none"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			param:   `{"path":["` + a + `"],"split":{"path":"` + a + `","sheet":"none"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			param:   `{"path":["` + filepath.Join(dir, "c.xlsx") + `"],"split":{"path":"` + filepath.Join(dir, "c.xlsx") + `","sheet":"a"}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &mockSender{}
+			err := testTask(t, tt.param).StartRead(context.TODO(), sender)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Task.StartRead() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if sender.terminated == tt.wantErr {
+				t.Errorf("Task.StartRead() terminated = %v", sender.terminated)
+			}
+			if got := testRecords(sender.records); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartReadErr(t *testing.T) {
+	dir := testTempDir(t)
+	param := `{"path":["` + testWriteXlsx(t, dir, "a.xlsx", testSheet{
+		name: "a",
+		rows: [][]interface{}{{"a"}},
+	}) + `"]}`
+	errMock := errors.New("mock error")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		sender *mockSender
+	}{
+		{
+			name:   "1",
+			ctx:    context.TODO(),
+			sender: &mockSender{createErr: errMock},
+		},
+		{
+			name:   "2",
+			ctx:    context.TODO(),
+			sender: &mockSender{sendErr: errMock},
+		},
+		{
+			name:   "3",
+			ctx:    canceled,
+			sender: &mockSender{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testTask(t, param).StartRead(tt.ctx, tt.sender); err == nil {
+				t.Errorf("Task.StartRead() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/file"
+	streamavro "github.com/Breeze0806/go-etl/storage/stream/file/avro"
+)
+
+type paramConfig struct {
+	file.Config
+
+	Column      []streamavro.Column `json:"column"`      //列定义，为空时通过第一条记录推断
+	Compression streamavro.Codec    `json:"compression"` //数据块的压缩格式，支持none，deflate，snappy，默认为snappy
+	BlockSize   int                 `json:"blockSize"`   //每个数据块的记录数，默认为1000
+	RecordName  string              `json:"recordName"`  //模式中的记录名，默认为Record
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//validate 校验配置，整个文件的压缩使用compress而不是compression
+func (p *paramConfig) validate() (err error) {
+	if err = p.ValidateBinary(); err != nil {
+		return
+	}
+
+	for i := range p.Column {
+		if err = p.Column[i].Validate(); err != nil {
+			return
+		}
+	}
+
+	if !p.Compression.IsValid() {
+		return fmt.Errorf("compression(%v) is not supported", p.Compression)
+	}
+	return
+}
+
+//options 获取写入选项
+func (p *paramConfig) options() streamavro.Options {
+	return streamavro.Options{
+		Name:      p.RecordName,
+		Codec:     p.Compression,
+		BlockSize: p.BlockSize,
+	}
+}
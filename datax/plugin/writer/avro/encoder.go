@@ -0,0 +1,39 @@
+package avro
+
+import (
+	"io"
+
+	"github.com/Breeze0806/go-etl/element"
+	streamavro "github.com/Breeze0806/go-etl/storage/stream/file/avro"
+)
+
+//encoder avro记录编码器，关闭时写入剩余的数据块
+type encoder struct {
+	w   *streamavro.Writer
+	err error
+}
+
+func newEncoder(w io.Writer, param *paramConfig) *encoder {
+	e := &encoder{}
+	e.w, e.err = streamavro.NewWriter(w, param.Column, param.options())
+	return e
+}
+
+//Encode 写入记录record，由于数据按照数据块写入，返回记录的字节数作为写入的字节数
+func (e *encoder) Encode(record element.Record) (n int, err error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	if err = e.w.Write(record); err != nil {
+		return
+	}
+	return int(record.ByteSize()), nil
+}
+
+//Close 写入剩余的数据块
+func (e *encoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Close()
+}
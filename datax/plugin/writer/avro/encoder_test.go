@@ -0,0 +1,47 @@
+package avro
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	streamavro "github.com/Breeze0806/go-etl/storage/stream/file/avro"
+)
+
+func TestEncoder(t *testing.T) {
+	buf := &bytes.Buffer{}
+	e := newEncoder(buf, &paramConfig{})
+	r := testRecord(testColumns()...)
+	n, err := e.Encode(r)
+	if err != nil {
+		t.Fatalf("encoder.Encode() error = %v", err)
+	}
+	if n != int(r.ByteSize()) {
+		t.Errorf("encoder.Encode() n = %v, want %v", n, r.ByteSize())
+	}
+	if err = e.Close(); err != nil {
+		t.Fatalf("encoder.Close() error = %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("Obj\x01")) {
+		t.Errorf("encoder.Close() = %q is not avro", buf.Bytes())
+	}
+}
+
+func TestEncoderErr(t *testing.T) {
+	e := newEncoder(&bytes.Buffer{}, &paramConfig{
+		Column: []streamavro.Column{{Name: "a"}},
+	})
+	if _, err := e.Encode(testRecord(testColumns()...)); err == nil {
+		t.Errorf("encoder.Encode() error = nil, wantErr true")
+	}
+	if err := e.Close(); err == nil {
+		t.Errorf("encoder.Close() error = nil, wantErr true")
+	}
+
+	e = newEncoder(&bytes.Buffer{}, &paramConfig{
+		Column: []streamavro.Column{{Name: "a", Type: element.TypeBigInt}},
+	})
+	if _, err := e.Encode(testRecord(testColumns()...)); err == nil {
+		t.Errorf("encoder.Encode() error = nil, wantErr true")
+	}
+}
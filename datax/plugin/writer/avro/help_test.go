@@ -0,0 +1,116 @@
+package avro
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+	streamavro "github.com/Breeze0806/go-etl/storage/stream/file/avro"
+)
+
+type mockReceiver struct {
+	records []element.Record
+	err     error
+}
+
+func (m *mockReceiver) GetFromReader() (element.Record, error) {
+	if len(m.records) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, exchange.ErrTerminate
+	}
+	r := m.records[0]
+	m.records = m.records[1:]
+	return r, nil
+}
+
+func (m *mockReceiver) Shutdown() error {
+	return nil
+}
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func testTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "avro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+//testJobConf 生成写入器参数为param的工作配置
+func testJobConf(param string) *config.JSON {
+	return testJSONFromString(`{
+		"job":{
+			"content":[
+				{
+					"writer": {
+						"name":"avrowriter",
+						"parameter":` + param + `
+					}
+				}
+			]
+		}
+	}`)
+}
+
+//testRecord 生成列为columns的记录
+func testRecord(columns ...element.Column) element.Record {
+	r := element.NewDefaultRecord()
+	for _, c := range columns {
+		r.Add(c)
+	}
+	return r
+}
+
+//testReadDir 读取目录dir中的所有avro文件，返回文件名到文件中每行的映射，
+//每行的列为列名:列类型:列值，列之间用空格分隔
+func testReadDir(t *testing.T, dir string) map[string][]string {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string][]string)
+	for _, v := range infos {
+		r, err := streamavro.NewReader(streamfile.Local, filepath.Join(dir, v.Name()), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows := []string{}
+		for {
+			columns, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var cols []string
+			for _, c := range columns {
+				cols = append(cols, fmt.Sprintf("%v:%v:%v", c.Name(), c.Type(), c.String()))
+			}
+			rows = append(rows, strings.Join(cols, " "))
+		}
+		r.Close()
+		files[v.Name()] = rows
+	}
+	return files
+}
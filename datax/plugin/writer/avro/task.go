@@ -0,0 +1,58 @@
+package avro
+
+import (
+	"context"
+	"io"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/file"
+)
+
+//Task 任务
+type Task struct {
+	*writer.BaseTask
+
+	param *paramConfig
+	part  *file.PartWriter
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.param.TaskID != nil {
+		t.SetTaskID(*t.param.TaskID)
+	}
+	t.part = file.NewPartWriter(&t.param.Config, t.TaskID(), func(w io.Writer, isNew bool) file.Encoder {
+		return newEncoder(w, t.param)
+	})
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	if t.part != nil {
+		return t.part.Close()
+	}
+	return
+}
+
+//StartWrite 开始写，收到终止记录后关闭文件
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (err error) {
+	defer func() {
+		if cerr := t.part.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	return t.part.StartWrite(ctx, receiver)
+}
@@ -0,0 +1,174 @@
+package avro
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testTask(t *testing.T, param string) *Task {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+func testColumns() []element.Column {
+	d, _ := element.NewDecimalColumnValueFromString("-12345.678")
+	return []element.Column{
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue("a"), "name", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 123000000, time.UTC)), "time", 0),
+		element.NewDefaultColumn(element.NewBoolColumnValue(true), "bool", 0),
+		element.NewDefaultColumn(element.NewBytesColumnValue([]byte("xyz")), "bytes", 0),
+		element.NewDefaultColumn(d, "decimal", 0),
+	}
+}
+
+func TestTask_Init(t *testing.T) {
+	tests := []struct {
+		name       string
+		param      string
+		wantTaskID int
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			param:      `{"path":"/tmp","fileName":"a","taskID":3}`,
+			wantTaskID: 3,
+		},
+		{
+			name:  "2",
+			param: `{"path":"/tmp","fileName":"a"}`,
+		},
+		{
+			name:    "3",
+			param:   `{"path":"/tmp"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: writer.NewBaseTask(),
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			if err := task.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Fatalf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if task.TaskID() != tt.wantTaskID {
+				t.Errorf("Task.TaskID() = %v, want %v", task.TaskID(), tt.wantTaskID)
+			}
+		})
+	}
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		records []element.Record
+		want    map[string][]string
+	}{
+		{
+			name:    "1",
+			param:   `"fileName":"a","taskID":1,"suffix":".avro"`,
+			records: []element.Record{testRecord(testColumns()...), testRecord(testColumns()...)},
+			want: map[string][]string{
+				"a__1.avro": {
+					"id:bigInt:1 name:string:a nil:string:<nil> time:time:2021-01-02T03:04:05.123Z " +
+						"bool:bool:true bytes:bytes:xyz decimal:decimal:-12345.678",
+					"id:bigInt:1 name:string:a nil:string:<nil> time:time:2021-01-02T03:04:05.123Z " +
+						"bool:bool:true bytes:bytes:xyz decimal:decimal:-12345.678",
+				},
+			},
+		},
+		{
+			name: "2",
+			param: `"fileName":"a","compression":"deflate","recordCount":1,` +
+				`"column":[{"name":"id","type":"bigInt"},{"name":"time","type":"time","logicalType":"date"}]`,
+			records: []element.Record{
+				testRecord(testColumns()[0], testColumns()[3]),
+				testRecord(testColumns()[0], element.NewDefaultColumn(element.NewNilTimeColumnValue(), "time", 0)),
+			},
+			want: map[string][]string{
+				"a__0":   {"id:bigInt:1 time:time:2021-01-02T00:00:00Z"},
+				"a__0_1": {"id:bigInt:1 time:time:<nil>"},
+			},
+		},
+		{
+			name:  "3",
+			param: `"fileName":"a"`,
+			want:  map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testTempDir(t)
+			task := testTask(t, `{"path":"`+dir+`",`+tt.param+`}`)
+			if err := task.StartWrite(context.TODO(), &mockReceiver{records: tt.records}); err != nil {
+				t.Fatalf("Task.StartWrite() error = %v", err)
+			}
+			if err := task.Destroy(context.TODO()); err != nil {
+				t.Fatalf("Task.Destroy() error = %v", err)
+			}
+			if got := testReadDir(t, dir); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartWrite() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartWriteErr(t *testing.T) {
+	errMock := errors.New("mock error")
+	dir := testTempDir(t)
+	task := testTask(t, `{"path":"`+dir+`","fileName":"a"}`)
+	if err := task.StartWrite(context.TODO(), &mockReceiver{
+		records: []element.Record{testRecord(testColumns()...)},
+		err:     errMock,
+	}); err != errMock {
+		t.Errorf("Task.StartWrite() error = %v, want %v", err, errMock)
+	}
+
+	task = testTask(t, `{"path":"`+dir+`/not_exist","fileName":"a"}`)
+	if err := task.StartWrite(context.TODO(), &mockReceiver{
+		records: []element.Record{testRecord(testColumns()...)},
+	}); err == nil {
+		t.Errorf("Task.StartWrite() error = nil, wantErr true")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	task = testTask(t, `{"path":"`+dir+`","fileName":"b"}`)
+	if err := task.StartWrite(ctx, &mockReceiver{
+		records: []element.Record{testRecord(testColumns()...)},
+	}); err != nil {
+		t.Errorf("Task.StartWrite() error = %v", err)
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package avro
+
+import (
+	"path/filepath"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/file"
+)
+
+func init() {
+	writer, err := NewWriter(filepath.Join("resources", "plugin.json"))
+	if err != nil {
+		panic(err)
+	}
+	name, err := writer.PluginConf().GetString("name")
+	if err != nil {
+		panic(err)
+	}
+	if name == "" {
+		panic("name is empty")
+	}
+	loader.RegisterWriter(name, writer)
+}
+
+//Writer avro文件写入器
+type Writer struct {
+	pluginConf *config.JSON
+}
+
+//NewWriter 通过插件配置文件filename创建avro文件写入器
+func NewWriter(filename string) (w *Writer, err error) {
+	w = &Writer{}
+	w.pluginConf, err = config.NewJSONFromFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+//PluginConf 插件配置
+func (w *Writer) PluginConf() *config.JSON {
+	return w.pluginConf
+}
+
+//Job 工作
+func (w *Writer) Job() writer.Job {
+	job := &Job{
+		Job: file.NewJob(),
+	}
+	job.SetPluginConf(w.pluginConf)
+	return job
+}
+
+//Task 任务
+func (w *Writer) Task() writer.Task {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginConf(w.pluginConf)
+	return task
+}
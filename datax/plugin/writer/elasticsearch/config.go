@@ -0,0 +1,198 @@
+package elasticsearch
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go/time2"
+)
+
+//批量操作
+const (
+	ActionIndex  = "index"  //写入文档，存在时覆盖，默认的操作
+	ActionCreate = "create" //创建文档，存在时失败
+	ActionUpdate = "update" //部分更新文档，需要设置idColumn
+)
+
+//默认值
+const (
+	defaultBatchSize    = 1000
+	defaultBatchTimeout = 1 * time.Second
+	defaultTimeout      = 30 * time.Second
+	defaultMaxRetries   = 3
+	defaultWait         = time.Second
+	defaultMaxWait      = 30 * time.Second
+)
+
+type paramConfig struct {
+	URL             string            `json:"url"`             //elasticsearch地址，如http://127.0.0.1:9200
+	Header          map[string]string `json:"header"`          //请求头
+	Username        string            `json:"username"`        //用户名，不为空时使用Basic认证
+	Password        string            `json:"password"`        //密码
+	Index           string            `json:"index"`           //索引名模板，{列名}替换为列值，{列名|时间格式}替换为按go的时间格式格式化的时间列，如logs-{create_time|2006.01.02}
+	IDColumn        string            `json:"idColumn"`        //作为文档ID的列名，为空时由elasticsearch生成ID
+	Action          string            `json:"action"`          //批量操作，支持index，create和update，默认为index
+	DocAsUpsert     bool              `json:"docAsUpsert"`     //update操作在文档不存在时是否写入文档
+	Column          []string          `json:"column"`          //写入文档的列名，为空时写入记录的所有列
+	DateFormat      string            `json:"dateFormat"`      //文档中的时间格式，使用go的时间格式，默认为time.RFC3339Nano
+	BatchSize       int               `json:"batchSize"`       //每批次的记录数，默认为1000
+	BatchTimeout    time2.Duration    `json:"batchTimeout"`    //批次的最长等待时间，默认为1s
+	Retry           retryConfig       `json:"retry"`           //重试配置，也用于重试被拒绝(429)的文档
+	Timeout         string            `json:"timeout"`         //单次请求的超时时间，使用go的时间间隔格式，默认为30s
+	MaxDirtyRecords int               `json:"maxDirtyRecords"` //单个任务允许的最大脏记录数，超过时任务失败，默认为0即不允许脏记录
+	TaskID          *int              `json:"taskID"`          //由Job.Split生成的任务ID
+}
+
+//retryConfig 重试配置，在网络错误，429和5xx时重试，等待时间从wait开始每次翻倍，
+//响应头中有Retry-After时按照其等待
+type retryConfig struct {
+	MaxRetries *int   `json:"maxRetries"` //最大重试次数，默认为3
+	Wait       string `json:"wait"`       //首次重试的等待时间，使用go的时间间隔格式，默认为1s
+	MaxWait    string `json:"maxWait"`    //最长的等待时间，使用go的时间间隔格式，默认为30s
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	var u *url.URL
+	if u, err = url.Parse(p.URL); err != nil {
+		return fmt.Errorf("url(%v) err: %v", p.URL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url(%v) is not http or https", p.URL)
+	}
+
+	if _, err = newIndexTemplate(p.Index); err != nil {
+		return
+	}
+
+	switch p.action() {
+	case ActionIndex, ActionCreate:
+	case ActionUpdate:
+		if p.IDColumn == "" {
+			return fmt.Errorf("idColumn is empty when action is %v", ActionUpdate)
+		}
+	default:
+		return fmt.Errorf("action(%v) is not supported", p.Action)
+	}
+
+	for i, v := range p.Column {
+		if v == "" {
+			return fmt.Errorf("column(%v) is empty", i)
+		}
+	}
+
+	if p.BatchTimeout.Duration < 0 {
+		return fmt.Errorf("batchTimeout(%v) is less than 0", p.BatchTimeout.Duration)
+	}
+	if _, err = p.timeout(); err != nil {
+		return
+	}
+	if _, _, _, err = p.Retry.options(); err != nil {
+		return
+	}
+	if p.MaxDirtyRecords < 0 {
+		return fmt.Errorf("maxDirtyRecords(%v) is less than 0", p.MaxDirtyRecords)
+	}
+	return
+}
+
+//bulkURL 获取批量接口的地址
+func (p *paramConfig) bulkURL() string {
+	return strings.TrimRight(p.URL, "/") + "/_bulk"
+}
+
+//action 获取批量操作，默认为index
+func (p *paramConfig) action() string {
+	if p.Action == "" {
+		return ActionIndex
+	}
+	return p.Action
+}
+
+//header 获取请求头，username不为空且请求头中没有Authorization时添加Basic认证请求头
+func (p *paramConfig) header() map[string]string {
+	header := make(map[string]string)
+	for k, v := range p.Header {
+		header[http.CanonicalHeaderKey(k)] = v
+	}
+	if _, ok := header["Authorization"]; !ok && p.Username != "" {
+		header["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(p.Username+":"+p.Password))
+	}
+	return header
+}
+
+//layout 获取时间格式，默认为time.RFC3339Nano
+func (p *paramConfig) layout() string {
+	if p.DateFormat == "" {
+		return time.RFC3339Nano
+	}
+	return p.DateFormat
+}
+
+func (p *paramConfig) getBatchSize() int {
+	if p.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return p.BatchSize
+}
+
+func (p *paramConfig) getBatchTimeout() time.Duration {
+	if p.BatchTimeout.Duration == 0 {
+		return defaultBatchTimeout
+	}
+	return p.BatchTimeout.Duration
+}
+
+//timeout 获取单次请求的超时时间，默认为30s
+func (p *paramConfig) timeout() (time.Duration, error) {
+	return parseDuration("timeout", p.Timeout, defaultTimeout)
+}
+
+//options 获取最大重试次数，首次重试的等待时间和最长的等待时间
+func (r *retryConfig) options() (maxRetries int, wait, maxWait time.Duration, err error) {
+	maxRetries = defaultMaxRetries
+	if r.MaxRetries != nil {
+		if maxRetries = *r.MaxRetries; maxRetries < 0 {
+			return 0, 0, 0, fmt.Errorf("maxRetries(%v) is less than 0", maxRetries)
+		}
+	}
+	if wait, err = parseDuration("wait", r.Wait, defaultWait); err != nil {
+		return
+	}
+	if maxWait, err = parseDuration("maxWait", r.MaxWait, defaultMaxWait); err != nil {
+		return
+	}
+	return
+}
+
+//parseDuration 将名为name的时间间隔s转化为正的时间间隔，s为空时使用默认值def
+func parseDuration(name, s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%v(%v) err: %v", name, s, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%v(%v) is not positive", name, s)
+	}
+	return d, nil
+}
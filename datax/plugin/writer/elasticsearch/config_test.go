@@ -0,0 +1,154 @@
+package elasticsearch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"url":"http://localhost:9200","index":"logs-{time|2006.01}","idColumn":"id","action":"update","column":["a"],"batchTimeout":"2s"}`,
+		},
+		{
+			name:    "2",
+			json:    `{"url":"ftp://localhost","index":"test"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"url":"http://localhost:9200"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"url":"http://localhost:9200","index":"logs-{time"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"url":"http://localhost:9200","index":"test","action":"delete"}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"url":"http://localhost:9200","index":"test","action":"update"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"url":"http://localhost:9200","index":"test","column":[""]}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"url":"http://localhost:9200","index":"test","batchTimeout":"-1s"}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			json:    `{"url":"http://localhost:9200","index":"test","timeout":"0s"}`,
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			json:    `{"url":"http://localhost:9200","index":"test","retry":{"maxRetries":-1}}`,
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			json:    `{"url":"http://localhost:9200","index":"test","retry":{"maxWait":"1x"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "12",
+			json:    `{"url":"%zz","index":"test"}`,
+			wantErr: true,
+		},
+		{
+			name:    "13",
+			json:    `{"url":1}`,
+			wantErr: true,
+		},
+		{
+			name:    "14",
+			json:    `{"url":"http://localhost:9200","index":"test","maxDirtyRecords":-1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_defaults(t *testing.T) {
+	p := &paramConfig{URL: "http://localhost:9200/"}
+	if got := p.bulkURL(); got != "http://localhost:9200/_bulk" {
+		t.Errorf("paramConfig.bulkURL() = %v, want http://localhost:9200/_bulk", got)
+	}
+	if got := p.action(); got != ActionIndex {
+		t.Errorf("paramConfig.action() = %v, want %v", got, ActionIndex)
+	}
+	if got := p.layout(); got != time.RFC3339Nano {
+		t.Errorf("paramConfig.layout() = %v, want %v", got, time.RFC3339Nano)
+	}
+	if got := p.getBatchSize(); got != defaultBatchSize {
+		t.Errorf("paramConfig.getBatchSize() = %v, want %v", got, defaultBatchSize)
+	}
+	if got := p.getBatchTimeout(); got != defaultBatchTimeout {
+		t.Errorf("paramConfig.getBatchTimeout() = %v, want %v", got, defaultBatchTimeout)
+	}
+
+	p = &paramConfig{Action: ActionCreate, DateFormat: "2006-01-02", BatchSize: 10}
+	if got := p.action(); got != ActionCreate {
+		t.Errorf("paramConfig.action() = %v, want %v", got, ActionCreate)
+	}
+	if got := p.layout(); got != "2006-01-02" {
+		t.Errorf("paramConfig.layout() = %v, want 2006-01-02", got)
+	}
+	if got := p.getBatchSize(); got != 10 {
+		t.Errorf("paramConfig.getBatchSize() = %v, want 10", got)
+	}
+}
+
+func TestParamConfig_header(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *paramConfig
+		want map[string]string
+	}{
+		{
+			name: "1",
+			p:    &paramConfig{},
+			want: map[string]string{},
+		},
+		{
+			name: "2",
+			p:    &paramConfig{Header: map[string]string{"x-key": "a"}, Username: "user", Password: "pass"},
+			want: map[string]string{"X-Key": "a", "Authorization": "Basic dXNlcjpwYXNz"},
+		},
+		{
+			name: "3",
+			p:    &paramConfig{Header: map[string]string{"authorization": "ApiKey xyz"}, Username: "user"},
+			want: map[string]string{"Authorization": "ApiKey xyz"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.header(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paramConfig.header() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
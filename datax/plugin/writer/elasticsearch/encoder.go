@@ -0,0 +1,215 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//encoder 将记录编码成批量接口请求体的编码器，每条记录为一行操作和一行文档
+type encoder struct {
+	buf         *bytes.Buffer
+	enc         *json.Encoder
+	decoder     element.TimeDecoder
+	index       *indexTemplate
+	action      string
+	idColumn    string
+	docAsUpsert bool
+	column      []string
+}
+
+func newEncoder(param *paramConfig) (e *encoder, err error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	e = &encoder{
+		buf:         buf,
+		enc:         enc,
+		decoder:     element.NewStringTimeDecoder(param.layout()),
+		action:      param.action(),
+		idColumn:    param.IDColumn,
+		docAsUpsert: param.DocAsUpsert,
+		column:      param.Column,
+	}
+	if e.index, err = newIndexTemplate(param.Index); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//Reset 清空请求体
+func (e *encoder) Reset() {
+	e.buf.Reset()
+}
+
+//Bytes 获取请求体，在下次编码前有效
+func (e *encoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
+
+//Encode 将记录record编码成操作和文档追加到请求体中，出错时请求体不变，
+//整数和高精度实数按原样写入JSON数字，不会丢失精度
+func (e *encoder) Encode(record element.Record) (err error) {
+	n := e.buf.Len()
+	defer func() {
+		if err != nil {
+			e.buf.Truncate(n)
+		}
+	}()
+
+	if err = e.writeAction(record); err != nil {
+		return
+	}
+	e.buf.WriteByte('\n')
+
+	if e.action == ActionUpdate {
+		e.buf.WriteString(`{"doc":`)
+	}
+	if err = e.writeRecord(record); err != nil {
+		return
+	}
+	if e.action == ActionUpdate {
+		if e.docAsUpsert {
+			e.buf.WriteString(`,"doc_as_upsert":true`)
+		}
+		e.buf.WriteByte('}')
+	}
+	e.buf.WriteByte('\n')
+	return
+}
+
+//writeAction 写入记录record的操作，如{"index":{"_index":"a","_id":"1"}}
+func (e *encoder) writeAction(record element.Record) (err error) {
+	var index string
+	if index, err = e.index.render(record); err != nil {
+		return
+	}
+	e.buf.WriteString(`{"` + e.action + `":{"_index":`)
+	if err = e.writeString(index); err != nil {
+		return
+	}
+
+	if e.idColumn != "" {
+		var c element.Column
+		if c, err = record.GetByName(e.idColumn); err != nil {
+			return
+		}
+		if c.IsNil() {
+			return fmt.Errorf("id column(%v) is nil", e.idColumn)
+		}
+		var id string
+		if id, err = c.AsString(); err != nil {
+			return fmt.Errorf("id column(%v) err: %v", e.idColumn, err)
+		}
+		e.buf.WriteString(`,"_id":`)
+		if err = e.writeString(id); err != nil {
+			return
+		}
+	}
+	e.buf.WriteString("}}")
+	return
+}
+
+//writeRecord 将记录record以列名为键的JSON对象写入缓存，
+//设置了列名时只写入这些列
+func (e *encoder) writeRecord(record element.Record) (err error) {
+	n := record.ColumnNumber()
+	if len(e.column) > 0 {
+		n = len(e.column)
+	}
+	e.buf.WriteByte('{')
+	for i := 0; i < n; i++ {
+		var c element.Column
+		if len(e.column) > 0 {
+			c, err = record.GetByName(e.column[i])
+		} else {
+			c, err = record.GetByIndex(i)
+		}
+		if err != nil {
+			return
+		}
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err = e.writeString(c.Name()); err != nil {
+			return
+		}
+		e.buf.WriteByte(':')
+		if err = e.writeValue(c); err != nil {
+			return fmt.Errorf("column(%v) err: %v", c.Name(), err)
+		}
+	}
+	e.buf.WriteByte('}')
+	return
+}
+
+//writeValue 将列c的值以JSON格式写入缓存
+func (e *encoder) writeValue(c element.Column) (err error) {
+	if c.IsNil() {
+		e.buf.WriteString("null")
+		return
+	}
+
+	switch c.Type() {
+	case element.TypeBool:
+		var b bool
+		if b, err = c.AsBool(); err != nil {
+			return
+		}
+		if b {
+			e.buf.WriteString("true")
+		} else {
+			e.buf.WriteString("false")
+		}
+		return
+	case element.TypeBigInt:
+		var v fmt.Stringer
+		if v, err = c.AsBigInt(); err != nil {
+			return
+		}
+		e.buf.WriteString(v.String())
+		return
+	case element.TypeDecimal:
+		var v fmt.Stringer
+		if v, err = c.AsDecimal(); err != nil {
+			return
+		}
+		e.buf.WriteString(v.String())
+		return
+	case element.TypeTime:
+		var tm time.Time
+		if tm, err = c.AsTime(); err != nil {
+			return
+		}
+		var v interface{}
+		if v, err = e.decoder.TimeDecode(tm); err != nil {
+			return
+		}
+		return e.writeString(v.(string))
+	case element.TypeBytes:
+		var b []byte
+		if b, err = c.AsBytes(); err != nil {
+			return
+		}
+		return e.writeString(string(b))
+	}
+
+	var s string
+	if s, err = c.AsString(); err != nil {
+		return
+	}
+	return e.writeString(s)
+}
+
+//writeString 将字符串s以JSON字符串写入缓存
+func (e *encoder) writeString(s string) (err error) {
+	if err = e.enc.Encode(s); err != nil {
+		return
+	}
+	//json.Encoder会在末尾添加换行符
+	e.buf.Truncate(e.buf.Len() - 1)
+	return
+}
@@ -0,0 +1,127 @@
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   *paramConfig
+		records []element.Record
+		want    string
+	}{
+		{
+			name:    "1",
+			param:   &paramConfig{Index: "test"},
+			records: []element.Record{testRecord(testColumns()...)},
+			want: `{"index":{"_index":"test"}}` + "\n" +
+				`{"id":123456789012345678901234567890,"name":"a\"<b>","nil":null,"time":"2021-01-02T03:04:05Z",` +
+				`"bool":true,"bytes":"xyz","decimal":0.10000000000000000001,"false":false}` + "\n",
+		},
+		{
+			name:    "2",
+			param:   &paramConfig{Index: "test-{time|2006.01}", IDColumn: "id", Action: ActionCreate, Column: []string{"name", "time"}, DateFormat: "2006-01-02"},
+			records: []element.Record{testRecord(testColumns()...), testRecord(testColumns()...)},
+			want: `{"create":{"_index":"test-2021.01","_id":"123456789012345678901234567890"}}` + "\n" +
+				`{"name":"a\"<b>","time":"2021-01-02"}` + "\n" +
+				`{"create":{"_index":"test-2021.01","_id":"123456789012345678901234567890"}}` + "\n" +
+				`{"name":"a\"<b>","time":"2021-01-02"}` + "\n",
+		},
+		{
+			name:    "3",
+			param:   &paramConfig{Index: "test", IDColumn: "name", Action: ActionUpdate, Column: []string{"bool"}},
+			records: []element.Record{testRecord(testColumns()...)},
+			want:    `{"update":{"_index":"test","_id":"a\"<b>"}}` + "\n" + `{"doc":{"bool":true}}` + "\n",
+		},
+		{
+			name:    "4",
+			param:   &paramConfig{Index: "test", IDColumn: "id", Action: ActionUpdate, DocAsUpsert: true, Column: []string{"false"}},
+			records: []element.Record{testRecord(testColumns()...)},
+			want: `{"update":{"_index":"test","_id":"123456789012345678901234567890"}}` + "\n" +
+				`{"doc":{"false":false},"doc_as_upsert":true}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := newEncoder(tt.param)
+			if err != nil {
+				t.Fatal(err)
+			}
+			e.Reset()
+			for _, r := range tt.records {
+				if err = e.Encode(r); err != nil {
+					t.Fatalf("encoder.Encode() error = %v", err)
+				}
+			}
+			if got := string(e.Bytes()); got != tt.want {
+				t.Errorf("encoder.Encode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//mockTimeValue 类型为时间但是无法转化为时间的列值
+type mockTimeValue struct {
+	element.ColumnValue
+}
+
+func (m *mockTimeValue) Type() element.ColumnType {
+	return element.TypeTime
+}
+
+func TestEncoder_EncodeErr(t *testing.T) {
+	tests := []struct {
+		name   string
+		param  *paramConfig
+		record element.Record
+	}{
+		{
+			name:  "1",
+			param: &paramConfig{Index: "test"},
+			record: testRecord(element.NewDefaultColumn(&mockTimeValue{
+				ColumnValue: element.NewStringColumnValue("abc"),
+			}, "time", 0)),
+		},
+		{
+			name:   "2",
+			param:  &paramConfig{Index: "test", Column: []string{"none"}},
+			record: testRecord(testColumns()...),
+		},
+		{
+			name:   "3",
+			param:  &paramConfig{Index: "{none}"},
+			record: testRecord(testColumns()...),
+		},
+		{
+			name:   "4",
+			param:  &paramConfig{Index: "test", IDColumn: "none"},
+			record: testRecord(testColumns()...),
+		},
+		{
+			name:   "5",
+			param:  &paramConfig{Index: "test", IDColumn: "nil"},
+			record: testRecord(testColumns()...),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := newEncoder(tt.param)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = e.Encode(tt.record); err == nil {
+				t.Errorf("encoder.Encode() error = nil, wantErr true")
+			}
+			if len(e.Bytes()) != 0 {
+				t.Errorf("encoder.Encode() = %s, want empty", e.Bytes())
+			}
+		})
+	}
+
+	if _, err := newEncoder(&paramConfig{}); err == nil {
+		t.Errorf("newEncoder() error = nil, wantErr true")
+	}
+}
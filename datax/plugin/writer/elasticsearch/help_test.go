@@ -0,0 +1,107 @@
+package elasticsearch
+
+import (
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockReceiver struct {
+	records []element.Record
+	err     error
+	sleep   time.Duration //每次获取记录前的等待时间
+}
+
+func (m *mockReceiver) GetFromReader() (element.Record, error) {
+	time.Sleep(m.sleep)
+	if len(m.records) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, exchange.ErrTerminate
+	}
+	r := m.records[0]
+	m.records = m.records[1:]
+	return r, nil
+}
+
+//ReleaseRecords 模拟记录池复用记录，将释放的记录的列都置为空值
+func (m *mockReceiver) ReleaseRecords(records ...element.Record) {
+	for _, r := range records {
+		for i := 0; i < r.ColumnNumber(); i++ {
+			c, _ := r.GetByIndex(i)
+			r.Set(i, element.NewDefaultColumn(element.NewNilStringColumnValue(), c.Name(), 0))
+		}
+	}
+}
+
+func (m *mockReceiver) Shutdown() error {
+	return nil
+}
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+//testJobConf 生成写入器参数为param的工作配置
+func testJobConf(param string) *config.JSON {
+	return testJSONFromString(`{
+		"job":{
+			"content":[
+				{
+					"writer": {
+						"name":"eswriter",
+						"parameter":` + param + `
+					}
+				}
+			]
+		}
+	}`)
+}
+
+//testRecord 生成列为columns的记录
+func testRecord(columns ...element.Column) element.Record {
+	r := element.NewDefaultRecord()
+	for _, c := range columns {
+		r.Add(c)
+	}
+	return r
+}
+
+func testColumns() []element.Column {
+	i, _ := element.NewBigIntColumnValueFromString("123456789012345678901234567890")
+	d, _ := element.NewDecimalColumnValueFromString("0.10000000000000000001")
+	return []element.Column{
+		element.NewDefaultColumn(i, "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue(`a"<b>`), "name", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)), "time", 0),
+		element.NewDefaultColumn(element.NewBoolColumnValue(true), "bool", 0),
+		element.NewDefaultColumn(element.NewBytesColumnValue([]byte("xyz")), "bytes", 0),
+		element.NewDefaultColumn(d, "decimal", 0),
+		element.NewDefaultColumn(element.NewBoolColumnValue(false), "false", 0),
+	}
+}
+
+//mockCollector 收集脏记录的任务收集器
+type mockCollector struct {
+	records []element.Record
+	errs    []string
+}
+
+func (m *mockCollector) CollectDirtyRecordWithError(record element.Record, err error) {
+	m.records = append(m.records, record)
+	m.errs = append(m.errs, err.Error())
+}
+
+func (m *mockCollector) CollectDirtyRecordWithMsg(record element.Record, msgErr string) {}
+
+func (m *mockCollector) CollectDirtyRecord(record element.Record, err error, msgErr string) {}
+
+func (m *mockCollector) CollectMessage(key string, value string) {}
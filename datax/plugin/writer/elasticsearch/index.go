@@ -0,0 +1,97 @@
+package elasticsearch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//indexPart 索引名模板的一部分，column为空时为常量text，
+//否则为列column的值，layout不为空时按照layout格式化时间列
+type indexPart struct {
+	text   string
+	column string
+	layout string
+}
+
+//indexTemplate 索引名模板，{列名}替换为列值，{列名|时间格式}替换为格式化后的时间列
+type indexTemplate struct {
+	parts []indexPart
+}
+
+//newIndexTemplate 解析索引名模板s
+func newIndexTemplate(s string) (t *indexTemplate, err error) {
+	if s == "" {
+		return nil, fmt.Errorf("index is empty")
+	}
+	t = &indexTemplate{}
+	for rest := s; rest != ""; {
+		i := strings.IndexAny(rest, "{}")
+		if i < 0 {
+			t.parts = append(t.parts, indexPart{text: rest})
+			break
+		}
+		if rest[i] == '}' {
+			return nil, fmt.Errorf("index(%v) has unexpected }", s)
+		}
+		if i > 0 {
+			t.parts = append(t.parts, indexPart{text: rest[:i]})
+		}
+		rest = rest[i+1:]
+
+		j := strings.IndexAny(rest, "{}")
+		if j < 0 || rest[j] == '{' {
+			return nil, fmt.Errorf("index(%v) has unclosed {", s)
+		}
+		part := indexPart{column: rest[:j]}
+		if k := strings.Index(part.column, "|"); k >= 0 {
+			part.column, part.layout = part.column[:k], part.column[k+1:]
+			if part.layout == "" {
+				return nil, fmt.Errorf("index(%v) layout is empty", s)
+			}
+		}
+		if part.column == "" {
+			return nil, fmt.Errorf("index(%v) column is empty", s)
+		}
+		t.parts = append(t.parts, part)
+		rest = rest[j+1:]
+	}
+	return
+}
+
+//render 生成记录record的索引名
+func (t *indexTemplate) render(record element.Record) (string, error) {
+	var b strings.Builder
+	for _, v := range t.parts {
+		if v.column == "" {
+			b.WriteString(v.text)
+			continue
+		}
+
+		c, err := record.GetByName(v.column)
+		if err != nil {
+			return "", err
+		}
+		if c.IsNil() {
+			return "", fmt.Errorf("index column(%v) is nil", v.column)
+		}
+
+		if v.layout != "" {
+			var tm time.Time
+			if tm, err = c.AsTime(); err != nil {
+				return "", fmt.Errorf("index column(%v) err: %v", v.column, err)
+			}
+			b.WriteString(tm.Format(v.layout))
+			continue
+		}
+
+		var s string
+		if s, err = c.AsString(); err != nil {
+			return "", fmt.Errorf("index column(%v) err: %v", v.column, err)
+		}
+		b.WriteString(s)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,136 @@
+package elasticsearch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestNewIndexTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []indexPart
+		wantErr bool
+	}{
+		{
+			name: "1",
+			s:    "test",
+			want: []indexPart{{text: "test"}},
+		},
+		{
+			name: "2",
+			s:    "logs-{region}-{time|2006.01.02}",
+			want: []indexPart{{text: "logs-"}, {column: "region"}, {text: "-"}, {column: "time", layout: "2006.01.02"}},
+		},
+		{
+			name: "3",
+			s:    "{region}{id}",
+			want: []indexPart{{column: "region"}, {column: "id"}},
+		},
+		{
+			name:    "4",
+			s:       "",
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			s:       "logs-{region",
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			s:       "logs-{re{gion}",
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			s:       "logs-}",
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			s:       "logs-{}",
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			s:       "logs-{time|}",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newIndexTemplate(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newIndexTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got.parts, tt.want) {
+				t.Errorf("newIndexTemplate() = %+v, want %+v", got.parts, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexTemplate_render(t *testing.T) {
+	record := testRecord(
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue("cn"), "region", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)), "time", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+	)
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			s:    "test",
+			want: "test",
+		},
+		{
+			name: "2",
+			s:    "logs-{region}-{time|2006.01.02}",
+			want: "logs-cn-2021.01.02",
+		},
+		{
+			name: "3",
+			s:    "{id}",
+			want: "1",
+		},
+		{
+			name:    "4",
+			s:       "{none}",
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			s:       "{nil}",
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			s:       "{region|2006}",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, err := newIndexTemplate(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := index.render(record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("indexTemplate.render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("indexTemplate.render() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package elasticsearch
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+)
+
+//Job 工作
+type Job struct {
+	*plugin.BaseJob
+}
+
+//Init 初始化
+func (j *Job) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
+		return
+	}
+
+	_, err = newParamConfig(paramConf)
+	return
+}
+
+//Destroy 销毁
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//Split 切分成number个任务
+func (j *Job) Split(ctx context.Context, number int) (confs []*config.JSON, err error) {
+	if number <= 0 {
+		number = 1
+	}
+	for i := 0; i < number; i++ {
+		conf := j.PluginJobConf().CloneConfig()
+		if err = conf.Set(coreconst.DataxJobContentWriterParameter+".taskID", i); err != nil {
+			return nil, err
+		}
+		confs = append(confs, conf)
+	}
+	return
+}
@@ -0,0 +1,104 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+)
+
+func testJob(t *testing.T, param string) *Job {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	j.SetPluginJobConf(testJobConf(param))
+	if err := j.Init(context.TODO()); err != nil {
+		t.Fatalf("Job.Init() error = %v", err)
+	}
+	return j
+}
+
+func TestJob_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJobConf(`{"url":"http://localhost:9200","index":"test"}`),
+		},
+		{
+			name:    "2",
+			jobConf: testJobConf(`{"url":"http://localhost:9200"}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJSONFromString(`{}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				BaseJob: plugin.NewBaseJob(),
+			}
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJob_Split(t *testing.T) {
+	tests := []struct {
+		name   string
+		number int
+		want   int
+	}{
+		{
+			name:   "1",
+			number: 3,
+			want:   3,
+		},
+		{
+			name:   "2",
+			number: 0,
+			want:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := testJob(t, `{"url":"http://localhost:9200","index":"test"}`)
+			confs, err := j.Split(context.TODO(), tt.number)
+			if err != nil {
+				t.Fatalf("Job.Split() error = %v", err)
+			}
+			if len(confs) != tt.want {
+				t.Fatalf("Job.Split() = %v, want %v confs", len(confs), tt.want)
+			}
+			for i, conf := range confs {
+				id, err := conf.GetInt64(coreconst.DataxJobContentWriterParameter + ".taskID")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if id != int64(i) {
+					t.Errorf("Job.Split() taskID = %v, want %v", id, i)
+				}
+			}
+		})
+	}
+}
+
+func TestJob_Destroy(t *testing.T) {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	if err := j.Destroy(context.TODO()); err != nil {
+		t.Errorf("Job.Destroy() error = %v", err)
+	}
+}
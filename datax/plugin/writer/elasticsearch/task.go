@@ -0,0 +1,189 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/tidwall/gjson"
+)
+
+//Task 任务
+type Task struct {
+	*writer.BaseTask
+
+	param   *paramConfig
+	client  *client
+	encoder *encoder
+	dirties int //脏记录数
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.param.TaskID != nil {
+		t.SetTaskID(*t.param.TaskID)
+	}
+	if t.client, err = newClient(t.param); err != nil {
+		return
+	}
+	if t.encoder, err = newEncoder(t.param); err != nil {
+		return
+	}
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	if t.client != nil {
+		t.client.client.CloseIdleConnections()
+	}
+	return
+}
+
+//StartWrite 开始写，记录数达到batchSize或者每隔batchTimeout发送一批记录，
+//收到终止记录后发送剩余的记录
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) error {
+	return plugin.BatchWrite(ctx, receiver, plugin.BatchOptions{
+		BatchSize:    t.param.getBatchSize(),
+		BatchTimeout: t.param.getBatchTimeout(),
+	}, nil, func(records []element.Record) error {
+		return t.send(ctx, records)
+	})
+}
+
+//send 将一批记录records通过批量接口写入，编码失败和写入失败的记录作为脏记录处理，
+//被拒绝(429)的记录按照重试配置重新发送，重试次数用尽后作为脏记录处理
+func (t *Task) send(ctx context.Context, records []element.Record) (err error) {
+	for retries := 0; len(records) > 0; retries++ {
+		t.encoder.Reset()
+		var sent []element.Record
+		for _, r := range records {
+			if eerr := t.encoder.Encode(r); eerr != nil {
+				if err = t.dirty(r, eerr); err != nil {
+					return
+				}
+				continue
+			}
+			sent = append(sent, r)
+		}
+		if len(sent) == 0 {
+			return
+		}
+
+		var resp *response
+		if resp, err = t.client.do(ctx, http.MethodPost, t.param.bulkURL(), t.encoder.Bytes()); err != nil {
+			return
+		}
+		log.Debugf("eswriter task(%v) send %v records, %v bytes", t.TaskID(), len(sent), len(t.encoder.Bytes()))
+
+		if records, err = t.handleResponse(sent, resp.body); err != nil {
+			return fmt.Errorf("request(%v %v) err: %v", http.MethodPost, t.param.bulkURL(), err)
+		}
+		if len(records) == 0 {
+			return
+		}
+
+		if retries >= t.client.maxRetries {
+			for _, r := range records {
+				if err = t.dirty(r, fmt.Errorf("rejected after %v retries", retries)); err != nil {
+					return
+				}
+			}
+			return
+		}
+		wait := t.client.backoff(retries)
+		log.Debugf("eswriter task(%v) %v records are rejected, retry after %v", t.TaskID(), len(records), wait)
+		if err = sleep(ctx, wait); err != nil {
+			return
+		}
+	}
+	return
+}
+
+//handleResponse 处理发送记录sent后批量接口的响应体body，写入失败的记录作为脏记录处理，
+//返回被拒绝(429)的记录
+func (t *Task) handleResponse(sent []element.Record, body []byte) (rejected []element.Record, err error) {
+	if !gjson.ValidBytes(body) {
+		return nil, fmt.Errorf("response is not valid json")
+	}
+	resp := gjson.ParseBytes(body)
+	if !resp.Get("errors").Bool() {
+		return
+	}
+
+	items := resp.Get("items").Array()
+	if len(items) != len(sent) {
+		return nil, fmt.Errorf("response has %v items, want %v", len(items), len(sent))
+	}
+	for i, item := range items {
+		var result gjson.Result
+		item.ForEach(func(_, v gjson.Result) bool {
+			result = v
+			return false
+		})
+
+		status := result.Get("status").Int()
+		switch {
+		case status == http.StatusTooManyRequests:
+			rejected = append(rejected, sent[i])
+		case status < 200 || status >= 300:
+			if err = t.dirty(sent[i], fmt.Errorf("status(%v) %v: %v", status,
+				result.Get("error.type").String(), result.Get("error.reason").String())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return
+}
+
+//dirty 处理脏记录record，打印错误日志，设置了任务收集器时收集脏记录的副本，
+//脏记录数超过maxDirtyRecords时返回错误err
+func (t *Task) dirty(record element.Record, err error) error {
+	t.dirties++
+	log.Errorf("eswriter task(%v) dirty record(%v) err: %v", t.TaskID(), t.dirties, err)
+	if collector := t.TaskCollector(); collector != nil {
+		//写入后记录会被释放并可能被记录池复用，所以需要收集副本
+		r, cerr := copyRecord(record)
+		if cerr != nil {
+			return fmt.Errorf("copy dirty record err: %v", cerr)
+		}
+		collector.CollectDirtyRecordWithError(r, err)
+	}
+	if t.dirties > t.param.MaxDirtyRecords {
+		return fmt.Errorf("dirty records(%v) are more than maxDirtyRecords(%v), err: %v",
+			t.dirties, t.param.MaxDirtyRecords, err)
+	}
+	return nil
+}
+
+//copyRecord 复制记录record
+func copyRecord(record element.Record) (element.Record, error) {
+	r := element.NewDefaultRecord()
+	for i := 0; i < record.ColumnNumber(); i++ {
+		c, err := record.GetByIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		if c, err = c.Clone(); err != nil {
+			return nil, err
+		}
+		if err = r.Add(c); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,330 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/tidwall/gjson"
+)
+
+func testTask(t *testing.T, param string) *Task {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+//testServer elasticsearch批量接口的本地替身，文档中name为fail时写入失败，
+//name为reject时前rejects次被拒绝，记录写入成功的操作和文档
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	rejects  int
+	requests int
+	docs     []string //写入成功的操作和文档，以空格连接
+}
+
+func newTestServer(rejects int) *testServer {
+	s := &testServer{
+		rejects: rejects,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *testServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	if r.Method != http.MethodPost || r.URL.Path != "/_bulk" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+
+	var items []string
+	errors := false
+	for i := 0; i+1 < len(lines); i += 2 {
+		action, doc := gjson.Parse(lines[i]), gjson.Parse(lines[i+1])
+		var name string
+		action.ForEach(func(k, _ gjson.Result) bool {
+			name = k.String()
+			return false
+		})
+		status := 201
+		item := `{"` + name + `":{"status":%v%v}}`
+		errBody := ""
+		switch doc.Get("name").String() + doc.Get("doc.name").String() {
+		case "fail":
+			status = 400
+			errBody = `,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}`
+		case "reject":
+			if s.rejects > 0 {
+				status = 429
+			}
+		}
+		if status >= 300 {
+			errors = true
+		} else {
+			s.docs = append(s.docs, lines[i]+" "+lines[i+1])
+		}
+		items = append(items, fmt.Sprintf(item, status, errBody))
+	}
+	if errors && s.rejects > 0 {
+		s.rejects--
+	}
+	fmt.Fprintf(w, `{"took":1,"errors":%v,"items":[%v]}`, errors, strings.Join(items, ","))
+}
+
+//testDoc 生成id和name列的记录
+func testDoc(id int64, name string) element.Record {
+	return testRecord(
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(id), "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue(name), "name", 0),
+	)
+}
+
+func TestTask_Init(t *testing.T) {
+	tests := []struct {
+		name       string
+		param      string
+		wantTaskID int
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			param:      `{"url":"http://localhost:9200","index":"test","taskID":3}`,
+			wantTaskID: 3,
+		},
+		{
+			name:  "2",
+			param: `{"url":"http://localhost:9200","index":"test"}`,
+		},
+		{
+			name:    "3",
+			param:   `{"url":"http://localhost:9200","index":"{test"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: writer.NewBaseTask(),
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			if err := task.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Fatalf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if task.TaskID() != tt.wantTaskID {
+				t.Errorf("Task.TaskID() = %v, want %v", task.TaskID(), tt.wantTaskID)
+			}
+		})
+	}
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	tests := []struct {
+		name         string
+		param        string
+		rejects      int
+		receiver     *mockReceiver
+		want         []string
+		wantRequests int
+		wantDirty    []string
+	}{
+		{
+			name:     "1",
+			param:    `"index":"test-{id}","idColumn":"id","batchSize":2`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a"), testDoc(2, "b"), testDoc(3, "c")}},
+			want: []string{
+				`{"index":{"_index":"test-1","_id":"1"}} {"id":1,"name":"a"}`,
+				`{"index":{"_index":"test-2","_id":"2"}} {"id":2,"name":"b"}`,
+				`{"index":{"_index":"test-3","_id":"3"}} {"id":3,"name":"c"}`,
+			},
+			wantRequests: 2,
+		},
+		{
+			name:     "2",
+			param:    `"index":"test","idColumn":"id","action":"update","docAsUpsert":true,"column":["name"]`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}},
+			want: []string{
+				`{"update":{"_index":"test","_id":"1"}} {"doc":{"name":"a"},"doc_as_upsert":true}`,
+			},
+			wantRequests: 1,
+		},
+		{
+			name:     "3",
+			param:    `"index":"test","retry":{"wait":"1ms"},"maxDirtyRecords":1`,
+			rejects:  2,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a"), testDoc(2, "reject"), testDoc(3, "fail")}},
+			want: []string{
+				`{"index":{"_index":"test"}} {"id":1,"name":"a"}`,
+				`{"index":{"_index":"test"}} {"id":2,"name":"reject"}`,
+			},
+			wantRequests: 3,
+			wantDirty:    []string{"status(400) mapper_parsing_exception: failed to parse"},
+		},
+		{
+			name:         "4",
+			param:        `"index":"test","retry":{"maxRetries":1,"wait":"1ms"},"maxDirtyRecords":1`,
+			rejects:      2,
+			receiver:     &mockReceiver{records: []element.Record{testDoc(1, "reject")}},
+			wantRequests: 2,
+			wantDirty:    []string{"rejected after 1 retries"},
+		},
+		{
+			name:      "5",
+			param:     `"index":"test-{none}","batchTimeout":"5ms","maxDirtyRecords":1`,
+			receiver:  &mockReceiver{records: []element.Record{testDoc(1, "a")}, sleep: 20 * time.Millisecond},
+			wantDirty: []string{"column does not exist"},
+		},
+		{
+			name:     "6",
+			param:    `"index":"test"`,
+			receiver: &mockReceiver{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(tt.rejects)
+			defer ts.Close()
+			collector := &mockCollector{}
+			task := testTask(t, `{"url":"`+ts.URL+`",`+tt.param+`}`)
+			task.SetTaskCollector(collector)
+			if err := task.StartWrite(context.TODO(), tt.receiver); err != nil {
+				t.Fatalf("Task.StartWrite() error = %v", err)
+			}
+			if !reflect.DeepEqual(ts.docs, tt.want) {
+				t.Errorf("Task.StartWrite() docs = %v, want %v", ts.docs, tt.want)
+			}
+			if ts.requests != tt.wantRequests {
+				t.Errorf("Task.StartWrite() requests = %v, want %v", ts.requests, tt.wantRequests)
+			}
+			if !reflect.DeepEqual(collector.errs, tt.wantDirty) {
+				t.Errorf("Task.StartWrite() dirty = %v, want %v", collector.errs, tt.wantDirty)
+			}
+			//释放后被复用的记录不影响收集的脏记录
+			for _, r := range collector.records {
+				if c, err := r.GetByName("name"); err != nil || c.IsNil() {
+					t.Errorf("Task.StartWrite() dirty record = %v, want name column", r)
+				}
+			}
+		})
+	}
+}
+
+func TestTask_StartWriteErr(t *testing.T) {
+	ts := newTestServer(0)
+	defer ts.Close()
+	invalid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/items/_bulk":
+			w.Write([]byte(`{"errors":true,"items":[]}`))
+		case "/invalid/_bulk":
+			w.Write([]byte(`not json`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer invalid.Close()
+
+	errMock := errors.New("mock error")
+	tests := []struct {
+		name     string
+		param    string
+		receiver *mockReceiver
+	}{
+		{
+			name:     "1",
+			param:    `{"url":"` + invalid.URL + `","index":"test"}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}},
+		},
+		{
+			name:     "2",
+			param:    `{"url":"` + invalid.URL + `/items","index":"test"}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}},
+		},
+		{
+			name:     "3",
+			param:    `{"url":"` + invalid.URL + `/invalid","index":"test"}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}},
+		},
+		{
+			name:     "4",
+			param:    `{"url":"` + ts.URL + `","index":"test"}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}, err: errMock},
+		},
+		{
+			name:     "5",
+			param:    `{"url":"` + ts.URL + `","index":"test","batchSize":1}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a"), testDoc(2, "fail")}},
+		},
+		{
+			name:     "6",
+			param:    `{"url":"` + ts.URL + `","index":"test","column":["none"],"batchTimeout":"5ms"}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}, sleep: 20 * time.Millisecond},
+		},
+		{
+			name:     "7",
+			param:    `{"url":"` + ts.URL + `","index":"test","retry":{"maxRetries":0}}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "reject")}},
+		},
+		{
+			name:     "8",
+			param:    `{"url":"` + ts.URL + `","index":"test","maxDirtyRecords":1}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "fail"), testDoc(2, "fail")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts.rejects = 1
+			if err := testTask(t, tt.param).StartWrite(context.TODO(), tt.receiver); err == nil {
+				t.Errorf("Task.StartWrite() error = nil, wantErr true")
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	task := testTask(t, `{"url":"`+invalid.URL+`","index":"test"}`)
+	if err := task.StartWrite(ctx, &mockReceiver{records: []element.Record{testDoc(1, "a")}}); err != nil {
+		t.Errorf("Task.StartWrite() error = %v", err)
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+	if err := testTask(t, `{"url":"http://localhost:9200","index":"test"}`).Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
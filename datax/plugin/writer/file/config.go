@@ -0,0 +1,120 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/config"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+//写入模式
+const (
+	WriteModeTruncate    = "truncate"    //写入前删除同一文件名前缀的文件
+	WriteModeAppend      = "append"      //追加写入
+	WriteModeNonConflict = "nonConflict" //存在同一文件名前缀的文件时报错
+)
+
+//Config 文件写入配置，可以内嵌在具体文件写入器的参数配置中
+type Config struct {
+	streamfile.FileSystemConfig
+
+	Path        string              `json:"path"`        //文件目录，s3://<bucket>/<prefix>形式的目录写入对象存储
+	FileName    string              `json:"fileName"`    //文件名前缀，每个任务写入的文件名为<fileName>__<taskID>
+	Suffix      string              `json:"suffix"`      //文件名后缀，如.csv
+	WriteMode   string              `json:"writeMode"`   //写入模式，支持truncate，append，nonConflict，默认为truncate
+	Encoding    string              `json:"encoding"`    //文件编码，支持utf-8和gbk，默认为utf-8
+	Compress    streamfile.Compress `json:"compress"`    //压缩格式，支持gzip，bzip2，zip，默认不压缩
+	FileSize    int64               `json:"fileSize"`    //单个文件的最大字节数(压缩前)，小于等于0时不轮转
+	RecordCount int64               `json:"recordCount"` //单个文件的最大记录数，小于等于0时不轮转
+	TaskID      *int                `json:"taskID"`      //由Job.Split生成的任务ID
+
+	fs streamfile.FileSystem
+}
+
+//NewConfig 通过写入器参数配置conf获取文件写入配置，配置不合法时会报错
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.Validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//Validate 校验配置
+func (c *Config) Validate() (err error) {
+	if c.Path == "" {
+		return fmt.Errorf("path is empty")
+	}
+
+	if c.FileName == "" {
+		return fmt.Errorf("fileName is empty")
+	}
+
+	switch c.Mode() {
+	case WriteModeTruncate, WriteModeAppend, WriteModeNonConflict:
+	default:
+		return fmt.Errorf("writeMode(%v) is not supported", c.WriteMode)
+	}
+
+	if !streamfile.IsValidEncoding(c.Encoding) {
+		return fmt.Errorf("encoding(%v) is not supported", c.Encoding)
+	}
+
+	if !c.Compress.IsValid() {
+		return fmt.Errorf("compress(%v) is not supported", c.Compress)
+	}
+
+	if c.Mode() == WriteModeAppend && c.Compress == streamfile.CompressZip {
+		return fmt.Errorf("compress(%v) does not support writeMode(%v)", c.Compress, c.WriteMode)
+	}
+
+	if c.Mode() == WriteModeAppend && strings.HasPrefix(c.Path, streamfile.SchemeS3) {
+		return fmt.Errorf("s3 does not support writeMode(%v)", c.WriteMode)
+	}
+	return c.FileSystemConfig.Validate()
+}
+
+//ValidateBinary 校验二进制格式文件(如parquet，xlsx)的配置，
+//这些格式不支持追加写入，也不支持整个文件的压缩和编码转换
+func (c *Config) ValidateBinary() (err error) {
+	if err = c.Validate(); err != nil {
+		return
+	}
+
+	if c.Mode() == WriteModeAppend {
+		return fmt.Errorf("writeMode(%v) is not supported", c.WriteMode)
+	}
+
+	if c.Compress != streamfile.CompressNone {
+		return fmt.Errorf("compress(%v) is not supported", c.Compress)
+	}
+
+	switch c.Encoding {
+	case "", "utf-8":
+	default:
+		return fmt.Errorf("encoding(%v) is not supported", c.Encoding)
+	}
+	return
+}
+
+//FileSystem 获取文件系统，按照目录的前缀选择本地文件系统或者对象存储
+func (c *Config) FileSystem() streamfile.FileSystem {
+	if c.fs == nil {
+		c.fs = streamfile.NewFileSystem(&c.FileSystemConfig)
+	}
+	return c.fs
+}
+
+//Mode 获取写入模式，默认为truncate
+func (c *Config) Mode() string {
+	if c.WriteMode == "" {
+		return WriteModeTruncate
+	}
+	return c.WriteMode
+}
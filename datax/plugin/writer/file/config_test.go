@@ -0,0 +1,143 @@
+package file
+
+import (
+	"testing"
+
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"path":"/tmp","fileName":"a","writeMode":"append","compress":"gzip","encoding":"gbk"}`,
+		},
+		{
+			name:    "2",
+			json:    `{"fileName":"a"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"path":"/tmp"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"path":"/tmp","fileName":"a","writeMode":"replace"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"path":"/tmp","fileName":"a","encoding":"big5"}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"path":"/tmp","fileName":"a","compress":"lzo"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"path":"/tmp","fileName":"a","compress":"zip","writeMode":"append"}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"path":1}`,
+			wantErr: true,
+		},
+		{
+			name: "9",
+			json: `{"path":"s3://bucket/out","fileName":"a","compress":"gzip","s3":{"region":"us-west-2"}}`,
+		},
+		{
+			name:    "10",
+			json:    `{"path":"s3://bucket/out","fileName":"a","writeMode":"append"}`,
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			json:    `{"path":"s3://bucket/out","fileName":"a","s3":{"partSize":1}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Mode(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *Config
+		want string
+	}{
+		{
+			name: "1",
+			c:    &Config{},
+			want: WriteModeTruncate,
+		},
+		{
+			name: "2",
+			c:    &Config{WriteMode: WriteModeAppend},
+			want: WriteModeAppend,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Mode(); got != tt.want {
+				t.Errorf("Config.Mode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateBinary(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *Config
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    &Config{Path: "/tmp", FileName: "a", Encoding: "utf-8"},
+		},
+		{
+			name:    "2",
+			c:       &Config{Path: "/tmp"},
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			c:       &Config{Path: "/tmp", FileName: "a", WriteMode: WriteModeAppend},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			c:       &Config{Path: "/tmp", FileName: "a", Compress: streamfile.CompressGzip},
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			c:       &Config{Path: "/tmp", FileName: "a", Encoding: "gbk"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.ValidateBinary(); (err != nil) != tt.wantErr {
+				t.Errorf("Config.ValidateBinary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
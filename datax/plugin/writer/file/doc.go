@@ -0,0 +1,24 @@
+/*
+Package file 实现了按行写入文本文件的通用写入工作和分片文件写入器，
+供txtfilewriter，jsonlwriter等文件写入器使用
+
+写入工作在Prepare时按照写入模式writeMode处理目录path中文件名前缀为<fileName>__的文件：
+truncate会删除这些文件，append会保留这些文件并追加写入，nonConflict在这些文件存在时报错
+
+写入工作会切分成与读取任务相同数量的任务，每个任务写入各自的分片文件，
+第一个文件名为<fileName>__<taskID><suffix>，当文件大小(压缩前)达到fileSize或者
+记录数达到recordCount时轮转到<fileName>__<taskID>_<序号><suffix>
+
+path为s3://<bucket>/<prefix>形式时写入s3兼容对象存储，每个分片文件通过分段上传流式写入，
+对象存储的配置见读取工作，对象存储不支持append写入模式，写入出错时会放弃正在上传的分片文件，
+不会生成不完整的对象
+
+具体的文件格式通过Encoder将记录编码后写入，例如
+
+	part := file.NewPartWriter(conf, taskID, func(w io.Writer, isNew bool) file.Encoder {
+		return newJSONEncoder(w)
+	})
+	defer part.Close()
+	err := part.StartWrite(ctx, receiver)
+*/
+package file
@@ -0,0 +1,132 @@
+package file
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+type mockReceiver struct {
+	records []element.Record
+	err     error
+}
+
+func (m *mockReceiver) GetFromReader() (element.Record, error) {
+	if len(m.records) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, exchange.ErrTerminate
+	}
+	r := m.records[0]
+	m.records = m.records[1:]
+	return r, nil
+}
+
+func (m *mockReceiver) Shutdown() error {
+	return nil
+}
+
+//mockEncoder 将记录的每列转化为字符串后用逗号连接成一行写入，新文件会先写入表头head
+type mockEncoder struct {
+	w     io.Writer
+	isNew bool
+}
+
+func (m *mockEncoder) Encode(record element.Record) (n int, err error) {
+	var line string
+	if m.isNew {
+		line = "head\n"
+		m.isNew = false
+	}
+	var fields []string
+	for i := 0; i < record.ColumnNumber(); i++ {
+		c, _ := record.GetByIndex(i)
+		fields = append(fields, c.String())
+	}
+	return io.WriteString(m.w, line+strings.Join(fields, ",")+"\n")
+}
+
+func newMockEncoder(w io.Writer, isNew bool) Encoder {
+	return &mockEncoder{w: w, isNew: isNew}
+}
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func testTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+//testJobConf 生成写入器参数为param的工作配置
+func testJobConf(param string) *config.JSON {
+	return testJSONFromString(`{
+		"job":{
+			"content":[
+				{
+					"writer": {
+						"name":"txtfilewriter",
+						"parameter":` + param + `
+					}
+				}
+			]
+		}
+	}`)
+}
+
+//testRecord 生成值为values的字符串列组成的记录
+func testRecord(values ...string) element.Record {
+	r := element.NewDefaultRecord()
+	for i, v := range values {
+		r.Add(element.NewDefaultColumn(element.NewStringColumnValue(v), string(rune('a'+i)), 0))
+	}
+	return r
+}
+
+//testReadDir 读取目录dir中的所有文件，返回文件名到文件内容的映射
+func testReadDir(t *testing.T, dir string, c streamfile.Compress) map[string]string {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	var names []string
+	for _, v := range infos {
+		names = append(names, v.Name())
+	}
+	sort.Strings(names)
+	for _, v := range names {
+		rc, err := streamfile.Open(streamfile.Local, filepath.Join(dir, v), c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[v] = string(b)
+	}
+	return files
+}
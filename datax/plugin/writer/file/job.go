@@ -0,0 +1,85 @@
+package file
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+//Job 文件写入工作
+type Job struct {
+	*plugin.BaseJob
+
+	conf *Config
+}
+
+//NewJob 创建文件写入工作
+func NewJob() *Job {
+	return &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+}
+
+//Init 初始化
+func (j *Job) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
+		return
+	}
+
+	if j.conf, err = NewConfig(paramConf); err != nil {
+		return
+	}
+	return
+}
+
+//Prepare 预备，创建文件目录并按照写入模式处理已存在的同一文件名前缀的文件
+func (j *Job) Prepare(ctx context.Context) (err error) {
+	fs := j.conf.FileSystem()
+	if err = fs.MkdirAll(j.conf.Path); err != nil {
+		return
+	}
+
+	var filenames []string
+	if filenames, err = fs.Glob(streamfile.Join(j.conf.Path, j.conf.FileName+"__*")); err != nil {
+		return
+	}
+
+	switch j.conf.Mode() {
+	case WriteModeTruncate:
+		for _, v := range filenames {
+			if err = fs.Remove(v); err != nil {
+				return
+			}
+		}
+	case WriteModeNonConflict:
+		if len(filenames) > 0 {
+			return fmt.Errorf("writeMode(%v) file(%v) already exists", j.conf.WriteMode, filenames[0])
+		}
+	}
+	return
+}
+
+//Destroy 销毁
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//Split 切分，每个任务写入各自的分片文件
+func (j *Job) Split(ctx context.Context, number int) (confs []*config.JSON, err error) {
+	if number <= 0 {
+		number = 1
+	}
+	for i := 0; i < number; i++ {
+		conf := j.PluginJobConf().CloneConfig()
+		if err = conf.Set(coreconst.DataxJobContentWriterParameter+".taskID", i); err != nil {
+			return nil, err
+		}
+		confs = append(confs, conf)
+	}
+	return
+}
@@ -0,0 +1,168 @@
+package file
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+)
+
+func testJob(t *testing.T, param string) *Job {
+	j := NewJob()
+	j.SetPluginJobConf(testJobConf(param))
+	if err := j.Init(context.TODO()); err != nil {
+		t.Fatalf("Job.Init() error = %v", err)
+	}
+	return j
+}
+
+func TestJob_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":"/tmp","fileName":"a"}`,
+		},
+		{
+			name:    "2",
+			param:   `{"path":"/tmp"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJob()
+			j.SetPluginJobConf(testJobConf(tt.param))
+			if err := j.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	j := NewJob()
+	j.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := j.Init(context.TODO()); err == nil {
+		t.Errorf("Job.Init() error = nil, wantErr true")
+	}
+}
+
+func TestJob_Prepare(t *testing.T) {
+	tests := []struct {
+		name      string
+		writeMode string
+		files     []string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			writeMode: WriteModeTruncate,
+			files:     []string{"a__0", "a__1_1.csv", "b__0", "a.csv"},
+			want:      []string{"a.csv", "b__0"},
+		},
+		{
+			name:      "2",
+			writeMode: WriteModeAppend,
+			files:     []string{"a__0", "b__0"},
+			want:      []string{"a__0", "b__0"},
+		},
+		{
+			name:      "3",
+			writeMode: WriteModeNonConflict,
+			files:     []string{"b__0", "a.csv"},
+			want:      []string{"a.csv", "b__0"},
+		},
+		{
+			name:      "4",
+			writeMode: WriteModeNonConflict,
+			files:     []string{"a__0"},
+			want:      []string{"a__0"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testTempDir(t)
+			for _, v := range tt.files {
+				if err := ioutil.WriteFile(filepath.Join(dir, v), nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			j := testJob(t, `{"path":"`+dir+`","fileName":"a","writeMode":"`+tt.writeMode+`"}`)
+			if err := j.Prepare(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Prepare() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for k := range testReadDir(t, dir, "") {
+				got = append(got, k)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.Prepare() files = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_PrepareMkdir(t *testing.T) {
+	dir := filepath.Join(testTempDir(t), "a", "b")
+	j := testJob(t, `{"path":"`+dir+`","fileName":"a"}`)
+	if err := j.Prepare(context.TODO()); err != nil {
+		t.Fatalf("Job.Prepare() error = %v", err)
+	}
+	if _, err := ioutil.ReadDir(dir); err != nil {
+		t.Errorf("Job.Prepare() dir error = %v", err)
+	}
+}
+
+func TestJob_Split(t *testing.T) {
+	tests := []struct {
+		name   string
+		number int
+		want   []int
+	}{
+		{
+			name:   "1",
+			number: 3,
+			want:   []int{0, 1, 2},
+		},
+		{
+			name:   "2",
+			number: 0,
+			want:   []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := testJob(t, `{"path":"/tmp","fileName":"a"}`)
+			confs, err := j.Split(context.TODO(), tt.number)
+			if err != nil {
+				t.Fatalf("Job.Split() error = %v", err)
+			}
+			var got []int
+			for _, v := range confs {
+				id, err := v.GetInt64(coreconst.DataxJobContentWriterParameter + ".taskID")
+				if err != nil {
+					t.Fatalf("GetInt64() error = %v", err)
+				}
+				got = append(got, int(id))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.Split() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_Destroy(t *testing.T) {
+	j := NewJob()
+	if err := j.Destroy(context.TODO()); err != nil {
+		t.Errorf("Job.Destroy() error = %v", err)
+	}
+}
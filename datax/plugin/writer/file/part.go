@@ -0,0 +1,197 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+//Encoder 记录编码器，将记录编码后写入文件，
+//编码器实现了io.Closer时，关闭文件前会先关闭编码器，可以用来写入文件尾
+type Encoder interface {
+	//将记录record编码后写入，返回写入的字节数
+	Encode(record element.Record) (n int, err error)
+}
+
+//NewEncoderFunc 生成记录编码器的函数，w为文件数据流，
+//isNew代表文件是新建的或者为空，可以用来决定是否写入表头
+type NewEncoderFunc func(w io.Writer, isNew bool) Encoder
+
+//PartWriter 任务的分片文件写入器，按照文件大小或者记录数轮转文件，
+//第一个文件名为<fileName>__<taskID><suffix>，之后为<fileName>__<taskID>_<序号><suffix>
+type PartWriter struct {
+	conf       *Config
+	taskID     int
+	newEncoder NewEncoderFunc
+
+	index int   //轮转序号
+	size  int64 //当前文件已写入的字节数
+	count int64 //当前文件已写入的记录数
+
+	fw  io.WriteCloser
+	bw  *bufio.Writer
+	ew  io.WriteCloser
+	enc Encoder
+}
+
+//NewPartWriter 通过文件写入配置conf，任务ID taskID以及生成记录编码器的函数newEncoder
+//生成分片文件写入器
+func NewPartWriter(conf *Config, taskID int, newEncoder NewEncoderFunc) *PartWriter {
+	return &PartWriter{
+		conf:       conf,
+		taskID:     taskID,
+		newEncoder: newEncoder,
+	}
+}
+
+//Filename 当前分片文件名
+func (p *PartWriter) Filename() string {
+	name := p.conf.FileName + "__" + strconv.Itoa(p.taskID)
+	if p.index > 0 {
+		name += "_" + strconv.Itoa(p.index)
+	}
+	return streamfile.Join(p.conf.Path, name+p.conf.Suffix)
+}
+
+//StartWrite 从receiver中读取记录并写入，直到收到终止记录或者ctx取消，
+//出错时放弃当前分片文件，避免对象存储中生成不完整的对象
+func (p *PartWriter) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (err error) {
+	defer func() {
+		if err != nil {
+			p.Abort(err)
+		}
+	}()
+	var record element.Record
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		record, err = receiver.GetFromReader()
+		switch err {
+		case nil:
+		case exchange.ErrEmpty:
+			continue
+		case exchange.ErrTerminate:
+			return nil
+		default:
+			return
+		}
+
+		if err = p.Write(record); err != nil {
+			return
+		}
+		plugin.ReleaseRecords(receiver, record)
+	}
+}
+
+//Write 写入记录record，需要时会轮转文件
+func (p *PartWriter) Write(record element.Record) (err error) {
+	if p.enc != nil && p.needRotate() {
+		if err = p.Close(); err != nil {
+			return
+		}
+		p.index++
+	}
+
+	if p.enc == nil {
+		if err = p.open(); err != nil {
+			return
+		}
+	}
+
+	var n int
+	n, err = p.enc.Encode(record)
+	p.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("write file(%v) err: %v", p.Filename(), err)
+	}
+	p.count++
+	return
+}
+
+//needRotate 当前文件是否需要轮转
+func (p *PartWriter) needRotate() bool {
+	return (p.conf.FileSize > 0 && p.size >= p.conf.FileSize) ||
+		(p.conf.RecordCount > 0 && p.count >= p.conf.RecordCount)
+}
+
+//open 打开当前分片文件
+func (p *PartWriter) open() (err error) {
+	filename := p.Filename()
+	appendMode := p.conf.Mode() == WriteModeAppend
+	isNew := true
+	if appendMode {
+		if fi, serr := p.conf.FileSystem().Stat(filename); serr == nil && fi.Size() > 0 {
+			isNew = false
+		}
+	}
+
+	if p.fw, err = streamfile.Create(p.conf.FileSystem(), filename, p.conf.Compress, appendMode); err != nil {
+		return fmt.Errorf("create file(%v) err: %v", filename, err)
+	}
+	p.bw = bufio.NewWriter(p.fw)
+	if p.ew, err = streamfile.NewEncodeWriter(p.bw, p.conf.Encoding); err != nil {
+		p.fw.Close()
+		return
+	}
+	p.enc = p.newEncoder(p.ew, isNew)
+	p.size, p.count = 0, 0
+	return
+}
+
+//Close 关闭当前分片文件，没有打开的文件时不做处理
+func (p *PartWriter) Close() (err error) {
+	if p.enc == nil {
+		return nil
+	}
+	enc := p.enc
+	p.enc = nil
+	if c, ok := enc.(io.Closer); ok {
+		err = c.Close()
+	}
+	if cerr := p.ew.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = p.bw.Flush()
+	}
+	if cerr := p.fw.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("close file(%v) err: %v", p.Filename(), err)
+	}
+	return
+}
+
+//Abort 因为错误err放弃当前分片文件，没有打开的文件时不做处理，
+//文件不能放弃写入时，比如本地文件，和Close一样保留已写入的数据
+func (p *PartWriter) Abort(err error) error {
+	if p.enc == nil {
+		return nil
+	}
+	a, ok := p.fw.(streamfile.Aborter)
+	if !ok {
+		return p.Close()
+	}
+	enc := p.enc
+	p.enc = nil
+	if c, ok := enc.(io.Closer); ok {
+		c.Close()
+	}
+	p.ew.Close()
+	if aerr := a.Abort(err); aerr != nil {
+		return fmt.Errorf("abort file(%v) err: %v", p.Filename(), aerr)
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+func TestPartWriter_Write(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *Config
+		records []element.Record
+		times   int
+		want    map[string]string
+	}{
+		{
+			name:    "1",
+			conf:    &Config{FileName: "a"},
+			records: []element.Record{testRecord("1", "x"), testRecord("2", "y"), testRecord("3", "z")},
+			times:   1,
+			want: map[string]string{
+				"a__1": "head\n1,x\n2,y\n3,z\n",
+			},
+		},
+		{
+			name:    "2",
+			conf:    &Config{FileName: "a", Suffix: ".csv", RecordCount: 2},
+			records: []element.Record{testRecord("1", "x"), testRecord("2", "y"), testRecord("3", "z")},
+			times:   1,
+			want: map[string]string{
+				"a__1.csv":   "head\n1,x\n2,y\n",
+				"a__1_1.csv": "head\n3,z\n",
+			},
+		},
+		{
+			name:    "3",
+			conf:    &Config{FileName: "a", FileSize: 13},
+			records: []element.Record{testRecord("1", "x"), testRecord("2", "y"), testRecord("3", "z")},
+			times:   1,
+			want: map[string]string{
+				"a__1":   "head\n1,x\n2,y\n",
+				"a__1_1": "head\n3,z\n",
+			},
+		},
+		{
+			name:    "4",
+			conf:    &Config{FileName: "a", WriteMode: WriteModeAppend, Compress: streamfile.CompressGzip},
+			records: []element.Record{testRecord("1", "x")},
+			times:   2,
+			want: map[string]string{
+				"a__1": "head\n1,x\n1,x\n",
+			},
+		},
+		{
+			name:    "5",
+			conf:    &Config{FileName: "a", Encoding: "gbk"},
+			records: []element.Record{testRecord("中", "文")},
+			times:   1,
+			want: map[string]string{
+				"a__1": string([]byte{'h', 'e', 'a', 'd', '\n', 0xd6, 0xd0, ',', 0xce, 0xc4, '\n'}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.conf.Path = testTempDir(t)
+			for i := 0; i < tt.times; i++ {
+				p := NewPartWriter(tt.conf, 1, newMockEncoder)
+				for _, v := range tt.records {
+					if err := p.Write(v); err != nil {
+						t.Fatalf("PartWriter.Write() error = %v", err)
+					}
+				}
+				if err := p.Close(); err != nil {
+					t.Fatalf("PartWriter.Close() error = %v", err)
+				}
+				if err := p.Close(); err != nil {
+					t.Fatalf("PartWriter.Close() error = %v", err)
+				}
+			}
+			if got := testReadDir(t, tt.conf.Path, tt.conf.Compress); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PartWriter.Write() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartWriter_WriteErr(t *testing.T) {
+	p := NewPartWriter(&Config{
+		Path:     filepath.Join(testTempDir(t), "not_exist"),
+		FileName: "a",
+	}, 0, newMockEncoder)
+	if err := p.Write(testRecord("1")); err == nil {
+		t.Errorf("PartWriter.Write() error = nil, wantErr true")
+	}
+}
+
+//mockCloseEncoder 关闭时写入文件尾tail的编码器
+type mockCloseEncoder struct {
+	*mockEncoder
+	err error
+}
+
+func (m *mockCloseEncoder) Close() error {
+	if m.err != nil {
+		return m.err
+	}
+	_, err := io.WriteString(m.w, "tail\n")
+	return err
+}
+
+func TestPartWriter_CloseEncoder(t *testing.T) {
+	errMock := errors.New("mock error")
+	tests := []struct {
+		name    string
+		err     error
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			want: map[string]string{
+				"a__0":   "head\n1\ntail\n",
+				"a__0_1": "head\n2\ntail\n",
+			},
+		},
+		{
+			name:    "2",
+			err:     errMock,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testTempDir(t)
+			p := NewPartWriter(&Config{Path: dir, FileName: "a", RecordCount: 1}, 0, func(w io.Writer, isNew bool) Encoder {
+				return &mockCloseEncoder{
+					mockEncoder: &mockEncoder{w: w, isNew: isNew},
+					err:         tt.err,
+				}
+			})
+			var err error
+			for _, v := range []element.Record{testRecord("1"), testRecord("2")} {
+				if err = p.Write(v); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				err = p.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PartWriter.Close() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := testReadDir(t, dir, streamfile.CompressNone); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PartWriter.Close() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartWriter_StartWrite(t *testing.T) {
+	errMock := errors.New("mock error")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		receiver *mockReceiver
+		want     map[string]string
+		wantErr  error
+	}{
+		{
+			name:     "1",
+			ctx:      context.TODO(),
+			receiver: &mockReceiver{records: []element.Record{testRecord("1"), testRecord("2")}},
+			want: map[string]string{
+				"a__0": "head\n1\n2\n",
+			},
+		},
+		{
+			name:     "2",
+			ctx:      context.TODO(),
+			receiver: &mockReceiver{records: []element.Record{testRecord("1")}, err: errMock},
+			want: map[string]string{
+				"a__0": "head\n1\n",
+			},
+			wantErr: errMock,
+		},
+		{
+			name:     "3",
+			ctx:      canceled,
+			receiver: &mockReceiver{records: []element.Record{testRecord("1")}},
+			want:     map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testTempDir(t)
+			p := NewPartWriter(&Config{Path: dir, FileName: "a"}, 0, newMockEncoder)
+			if err := p.StartWrite(tt.ctx, tt.receiver); err != tt.wantErr {
+				t.Errorf("PartWriter.StartWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := p.Close(); err != nil {
+				t.Fatalf("PartWriter.Close() error = %v", err)
+			}
+			if got := testReadDir(t, dir, ""); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PartWriter.StartWrite() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+//mockAbortFileSystem 创建的文件可以放弃写入，放弃时删除文件
+type mockAbortFileSystem struct {
+	streamfile.FileSystem
+
+	aborted []string
+}
+
+func (m *mockAbortFileSystem) Create(name string, appendMode bool) (io.WriteCloser, error) {
+	f, err := m.FileSystem.Create(name, appendMode)
+	if err != nil {
+		return nil, err
+	}
+	return &mockAbortFile{WriteCloser: f, fs: m, name: name}, nil
+}
+
+type mockAbortFile struct {
+	io.WriteCloser
+
+	fs   *mockAbortFileSystem
+	name string
+}
+
+func (m *mockAbortFile) Abort(err error) error {
+	m.fs.aborted = append(m.fs.aborted, filepath.Base(m.name))
+	m.WriteCloser.Close()
+	return m.fs.Remove(m.name)
+}
+
+func TestPartWriter_Abort(t *testing.T) {
+	errMock := errors.New("mock error")
+	tests := []struct {
+		name        string
+		receiver    *mockReceiver
+		want        map[string]string
+		wantAborted []string
+		wantErr     error
+	}{
+		{
+			name:     "1",
+			receiver: &mockReceiver{records: []element.Record{testRecord("1"), testRecord("2")}},
+			want: map[string]string{
+				"a__0":   "head\n1\n",
+				"a__0_1": "head\n2\n",
+			},
+		},
+		{
+			name:        "2",
+			receiver:    &mockReceiver{records: []element.Record{testRecord("1"), testRecord("2")}, err: errMock},
+			want:        map[string]string{"a__0": "head\n1\n"},
+			wantAborted: []string{"a__0_1"},
+			wantErr:     errMock,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testTempDir(t)
+			fs := &mockAbortFileSystem{FileSystem: streamfile.Local}
+			conf := &Config{Path: dir, FileName: "a", RecordCount: 1}
+			conf.fs = fs
+			p := NewPartWriter(conf, 0, newMockEncoder)
+			if err := p.StartWrite(context.TODO(), tt.receiver); err != tt.wantErr {
+				t.Errorf("PartWriter.StartWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := p.Close(); err != nil {
+				t.Fatalf("PartWriter.Close() error = %v", err)
+			}
+			if !reflect.DeepEqual(fs.aborted, tt.wantAborted) {
+				t.Errorf("PartWriter.StartWrite() aborted = %v, want %v", fs.aborted, tt.wantAborted)
+			}
+			if got := testReadDir(t, dir, ""); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PartWriter.StartWrite() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//maxErrorBody 错误信息中响应体的最大长度
+const maxErrorBody = 256
+
+//response 响应
+type response struct {
+	url    string      //请求地址
+	header http.Header //响应头
+	body   []byte      //响应体
+}
+
+//statusError 非2xx的响应状态
+type statusError struct {
+	code       int
+	body       []byte
+	retryAfter time.Duration //响应头Retry-After中的等待时间
+}
+
+func (e *statusError) Error() string {
+	body := e.body
+	if len(body) > maxErrorBody {
+		body = body[:maxErrorBody]
+	}
+	return fmt.Sprintf("status(%v) body(%s)", e.code, body)
+}
+
+//retryable 是否可以重试，429和5xx可以重试
+func (e *statusError) retryable() bool {
+	return e.code == http.StatusTooManyRequests || e.code >= http.StatusInternalServerError
+}
+
+//client 支持重试的HTTP客户端
+type client struct {
+	client      *http.Client
+	header      map[string]string
+	contentType string //请求头中没有Content-Type时使用的Content-Type
+	maxRetries  int
+	wait        time.Duration
+	maxWait     time.Duration
+}
+
+func newClient(param *paramConfig) (c *client, err error) {
+	c = &client{
+		header:      param.header(),
+		contentType: param.contentType(),
+	}
+	var timeout time.Duration
+	if timeout, err = param.timeout(); err != nil {
+		return nil, err
+	}
+	c.client = &http.Client{
+		Timeout: timeout,
+	}
+	if c.maxRetries, c.wait, c.maxWait, err = param.Retry.options(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//do 以方法method向地址url发送请求体为body的请求，在网络错误，429和5xx时重试
+func (c *client) do(ctx context.Context, method, url string, body []byte) (resp *response, err error) {
+	for retries := 0; ; retries++ {
+		if resp, err = c.doOnce(ctx, method, url, body); err == nil {
+			return
+		}
+
+		wait := c.backoff(retries)
+		if se, ok := err.(*statusError); ok {
+			if !se.retryable() {
+				return nil, fmt.Errorf("request(%v %v) err: %v", method, url, err)
+			}
+			if se.retryAfter > 0 {
+				wait = se.retryAfter
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if retries >= c.maxRetries {
+			return nil, fmt.Errorf("request(%v %v) err: %v after %v retries", method, url, err, retries)
+		}
+		log.Debugf("request(%v %v) err: %v, retry after %v", method, url, err, wait)
+		if err = sleep(ctx, wait); err != nil {
+			return
+		}
+	}
+}
+
+//doOnce 发送一次请求，非2xx的响应返回statusError
+func (c *client) doOnce(ctx context.Context, method, url string, body []byte) (resp *response, err error) {
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body)); err != nil {
+		return
+	}
+	for k, v := range c.header {
+		req.Header.Set(k, v)
+	}
+	if len(body) > 0 && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", c.contentType)
+	}
+
+	var r *http.Response
+	if r, err = c.client.Do(req); err != nil {
+		return
+	}
+	defer r.Body.Close()
+
+	resp = &response{
+		url:    url,
+		header: r.Header,
+	}
+	if resp.body, err = ioutil.ReadAll(r.Body); err != nil {
+		return nil, err
+	}
+	if r.StatusCode < 200 || r.StatusCode >= 300 {
+		return nil, &statusError{
+			code:       r.StatusCode,
+			body:       resp.body,
+			retryAfter: retryAfter(r.Header.Get("Retry-After")),
+		}
+	}
+	return
+}
+
+//backoff 获取第retries次重试的等待时间，从wait开始每次翻倍，最多为maxWait
+func (c *client) backoff(retries int) time.Duration {
+	wait := c.wait
+	for i := 0; i < retries && wait < c.maxWait; i++ {
+		wait *= 2
+	}
+	if wait > c.maxWait {
+		return c.maxWait
+	}
+	return wait
+}
+
+//retryAfter 解析响应头Retry-After，支持秒数和HTTP时间，无法解析时返回0
+func retryAfter(s string) time.Duration {
+	if s == "" {
+		return 0
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return time.Duration(n) * time.Second
+	}
+	if t, err := http.ParseTime(s); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+//sleep 等待时间d，ctx取消时返回错误
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testClient(t *testing.T, param string) *client {
+	p, err := newParamConfig(testJSONFromString(param))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := newClient(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClient_do(t *testing.T) {
+	var count int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		switch r.URL.Path {
+		case "/retry":
+			if count < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+		case "/fail":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		case "/bad":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("bad request"))
+			return
+		}
+		if r.Header.Get("X-Token") != "abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(r.Method + " " + r.Header.Get("Content-Type")))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		body      []byte
+		want      string
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			path:      "/",
+			want:      "POST ",
+			wantCount: 1,
+		},
+		{
+			name:      "2",
+			path:      "/retry",
+			body:      []byte(`{}`),
+			want:      "POST application/x-ndjson",
+			wantCount: 3,
+		},
+		{
+			name:      "3",
+			path:      "/fail",
+			wantCount: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "4",
+			path:      "/bad",
+			wantCount: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count = 0
+			c := testClient(t, `{"url":"`+ts.URL+`","header":{"x-token":"abc"},"format":"ndjson",`+
+				`"retry":{"maxRetries":2,"wait":"1ms"}}`)
+			resp, err := c.do(context.TODO(), http.MethodPost, ts.URL+tt.path, tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("client.do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if count != tt.wantCount {
+				t.Errorf("client.do() requests = %v, want %v", count, tt.wantCount)
+			}
+			if err == nil && string(resp.body) != tt.want {
+				t.Errorf("client.do() = %v, want %v", string(resp.body), tt.want)
+			}
+		})
+	}
+
+	c := testClient(t, `{"url":"`+ts.URL+`","retry":{"wait":"1h"}}`)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.do(ctx, http.MethodPost, ts.URL+"/fail", nil); err != context.DeadlineExceeded {
+		t.Errorf("client.do() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClient_backoff(t *testing.T) {
+	c := &client{wait: time.Second, maxWait: 5 * time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := c.backoff(i); got != w {
+			t.Errorf("client.backoff(%v) = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if got := retryAfter("2"); got != 2*time.Second {
+		t.Errorf("retryAfter() = %v, want %v", got, 2*time.Second)
+	}
+	if got := retryAfter(""); got != 0 {
+		t.Errorf("retryAfter() = %v, want 0", got)
+	}
+	if got := retryAfter("abc"); got != 0 {
+		t.Errorf("retryAfter() = %v, want 0", got)
+	}
+	if got := retryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)); got <= 0 || got > time.Hour {
+		t.Errorf("retryAfter() = %v, want (0, 1h]", got)
+	}
+}
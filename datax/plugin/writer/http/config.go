@@ -0,0 +1,237 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go/time2"
+	"github.com/tidwall/gjson"
+)
+
+//请求体格式
+const (
+	FormatJSON   = "json"   //JSON数组，默认的格式
+	FormatNDJSON = "ndjson" //每行一个JSON对象
+)
+
+//默认值
+const (
+	defaultBatchSize    = 1000
+	defaultBatchTimeout = 1 * time.Second
+	defaultTimeout      = 30 * time.Second
+	defaultMaxRetries   = 3
+	defaultWait         = time.Second
+	defaultMaxWait      = 30 * time.Second
+	defaultAuthScheme   = "Bearer"
+)
+
+type paramConfig struct {
+	URL          string            `json:"url"`          //请求地址
+	Method       string            `json:"method"`       //请求方法，支持POST，PUT和PATCH，默认为POST
+	Header       map[string]string `json:"header"`       //请求头
+	AuthToken    string            `json:"authToken"`    //认证令牌，不为空时作为请求头Authorization发送
+	AuthScheme   string            `json:"authScheme"`   //认证方式，默认为Bearer
+	Format       string            `json:"format"`       //请求体格式，支持json和ndjson，默认为json
+	Column       []string          `json:"column"`       //写入的列名，为空时写入记录的所有列
+	DateFormat   string            `json:"dateFormat"`   //时间格式，使用go的时间格式，默认为time.RFC3339Nano
+	BatchSize    int               `json:"batchSize"`    //每批次的记录数，默认为1000
+	BatchTimeout time2.Duration    `json:"batchTimeout"` //批次的最长等待时间，默认为1s
+	Response     responseConfig    `json:"response"`     //响应校验配置
+	Retry        retryConfig       `json:"retry"`        //重试配置
+	Timeout      string            `json:"timeout"`      //单次请求的超时时间，使用go的时间间隔格式，默认为30s
+	TaskID       *int              `json:"taskID"`       //由Job.Split生成的任务ID
+}
+
+//responseConfig 响应校验配置，除了要求2xx的响应状态外，
+//path不为空时要求响应为JSON且path对应的值等于value，value为空时要求该值为true
+type responseConfig struct {
+	Path  string  `json:"path"`  //响应中用于校验的JSON路径，如code
+	Value *string `json:"value"` //期望的值，如0
+}
+
+//validate 校验响应体body
+func (r *responseConfig) validate(body []byte) error {
+	if r.Path == "" {
+		return nil
+	}
+	if !gjson.ValidBytes(body) {
+		return fmt.Errorf("response is not valid json")
+	}
+	v := gjson.GetBytes(body, r.Path)
+	if r.Value == nil {
+		if v.Type != gjson.True {
+			return fmt.Errorf("response %v(%v) is not true", r.Path, v.Raw)
+		}
+		return nil
+	}
+	if !v.Exists() || v.String() != *r.Value {
+		return fmt.Errorf("response %v(%v) is not %v", r.Path, v.Raw, *r.Value)
+	}
+	return nil
+}
+
+//retryConfig 重试配置，在网络错误，429和5xx时重试，等待时间从wait开始每次翻倍，
+//响应头中有Retry-After时按照其等待
+type retryConfig struct {
+	MaxRetries *int   `json:"maxRetries"` //最大重试次数，默认为3
+	Wait       string `json:"wait"`       //首次重试的等待时间，使用go的时间间隔格式，默认为1s
+	MaxWait    string `json:"maxWait"`    //最长的等待时间，使用go的时间间隔格式，默认为30s
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	var u *url.URL
+	if u, err = url.Parse(p.URL); err != nil {
+		return fmt.Errorf("url(%v) err: %v", p.URL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url(%v) is not http or https", p.URL)
+	}
+
+	switch p.method() {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return fmt.Errorf("method(%v) is not supported", p.Method)
+	}
+
+	switch p.format() {
+	case FormatJSON, FormatNDJSON:
+	default:
+		return fmt.Errorf("format(%v) is not supported", p.Format)
+	}
+
+	for i, v := range p.Column {
+		if v == "" {
+			return fmt.Errorf("column(%v) is empty", i)
+		}
+	}
+
+	if p.BatchTimeout.Duration < 0 {
+		return fmt.Errorf("batchTimeout(%v) is less than 0", p.BatchTimeout.Duration)
+	}
+	if _, err = p.timeout(); err != nil {
+		return
+	}
+	if _, _, _, err = p.Retry.options(); err != nil {
+		return
+	}
+	return
+}
+
+//method 获取请求方法，默认为POST
+func (p *paramConfig) method() string {
+	if p.Method == "" {
+		return http.MethodPost
+	}
+	return p.Method
+}
+
+//format 获取请求体格式，默认为json
+func (p *paramConfig) format() string {
+	if p.Format == "" {
+		return FormatJSON
+	}
+	return p.Format
+}
+
+//contentType 获取请求体格式对应的Content-Type
+func (p *paramConfig) contentType() string {
+	if p.format() == FormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "application/json"
+}
+
+//header 获取请求头，authToken不为空且请求头中没有Authorization时添加认证请求头
+func (p *paramConfig) header() map[string]string {
+	header := make(map[string]string)
+	for k, v := range p.Header {
+		header[http.CanonicalHeaderKey(k)] = v
+	}
+	if _, ok := header["Authorization"]; !ok && p.AuthToken != "" {
+		header["Authorization"] = defaultString(p.AuthScheme, defaultAuthScheme) + " " + p.AuthToken
+	}
+	return header
+}
+
+//layout 获取时间格式，默认为time.RFC3339Nano
+func (p *paramConfig) layout() string {
+	if p.DateFormat == "" {
+		return time.RFC3339Nano
+	}
+	return p.DateFormat
+}
+
+func (p *paramConfig) getBatchSize() int {
+	if p.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return p.BatchSize
+}
+
+func (p *paramConfig) getBatchTimeout() time.Duration {
+	if p.BatchTimeout.Duration == 0 {
+		return defaultBatchTimeout
+	}
+	return p.BatchTimeout.Duration
+}
+
+//timeout 获取单次请求的超时时间，默认为30s
+func (p *paramConfig) timeout() (time.Duration, error) {
+	return parseDuration("timeout", p.Timeout, defaultTimeout)
+}
+
+//options 获取最大重试次数，首次重试的等待时间和最长的等待时间
+func (r *retryConfig) options() (maxRetries int, wait, maxWait time.Duration, err error) {
+	maxRetries = defaultMaxRetries
+	if r.MaxRetries != nil {
+		if maxRetries = *r.MaxRetries; maxRetries < 0 {
+			return 0, 0, 0, fmt.Errorf("maxRetries(%v) is less than 0", maxRetries)
+		}
+	}
+	if wait, err = parseDuration("wait", r.Wait, defaultWait); err != nil {
+		return
+	}
+	if maxWait, err = parseDuration("maxWait", r.MaxWait, defaultMaxWait); err != nil {
+		return
+	}
+	return
+}
+
+//parseDuration 将名为name的时间间隔s转化为正的时间间隔，s为空时使用默认值def
+func parseDuration(name, s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%v(%v) err: %v", name, s, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%v(%v) is not positive", name, s)
+	}
+	return d, nil
+}
+
+//defaultString s为空时返回默认值def
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,206 @@
+package http
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"url":"http://localhost/hook","method":"PUT","format":"ndjson","column":["a"],"batchTimeout":"2s"}`,
+		},
+		{
+			name:    "2",
+			json:    `{"url":"ftp://localhost"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"url":"http://localhost","method":"GET"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"url":"http://localhost","format":"csv"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"url":"http://localhost","column":[""]}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"url":"http://localhost","batchTimeout":"-1s"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"url":"http://localhost","timeout":"0s"}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"url":"http://localhost","retry":{"maxRetries":-1}}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			json:    `{"url":"http://localhost","retry":{"wait":"1x"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			json:    `{"url":"http://localhost","retry":{"maxWait":"1x"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			json:    `{"url":"%zz"}`,
+			wantErr: true,
+		},
+		{
+			name:    "12",
+			json:    `{"url":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_defaults(t *testing.T) {
+	p := &paramConfig{}
+	if got := p.method(); got != "POST" {
+		t.Errorf("paramConfig.method() = %v, want POST", got)
+	}
+	if got := p.format(); got != FormatJSON {
+		t.Errorf("paramConfig.format() = %v, want %v", got, FormatJSON)
+	}
+	if got := p.contentType(); got != "application/json" {
+		t.Errorf("paramConfig.contentType() = %v, want application/json", got)
+	}
+	if got := p.layout(); got != time.RFC3339Nano {
+		t.Errorf("paramConfig.layout() = %v, want %v", got, time.RFC3339Nano)
+	}
+	if got := p.getBatchSize(); got != defaultBatchSize {
+		t.Errorf("paramConfig.getBatchSize() = %v, want %v", got, defaultBatchSize)
+	}
+	if got := p.getBatchTimeout(); got != defaultBatchTimeout {
+		t.Errorf("paramConfig.getBatchTimeout() = %v, want %v", got, defaultBatchTimeout)
+	}
+
+	p = &paramConfig{Format: FormatNDJSON, BatchSize: 10}
+	if got := p.contentType(); got != "application/x-ndjson" {
+		t.Errorf("paramConfig.contentType() = %v, want application/x-ndjson", got)
+	}
+	if got := p.getBatchSize(); got != 10 {
+		t.Errorf("paramConfig.getBatchSize() = %v, want 10", got)
+	}
+}
+
+func TestParamConfig_header(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *paramConfig
+		want map[string]string
+	}{
+		{
+			name: "1",
+			p:    &paramConfig{},
+			want: map[string]string{},
+		},
+		{
+			name: "2",
+			p:    &paramConfig{Header: map[string]string{"x-key": "a"}, AuthToken: "abc"},
+			want: map[string]string{"X-Key": "a", "Authorization": "Bearer abc"},
+		},
+		{
+			name: "3",
+			p:    &paramConfig{AuthToken: "abc", AuthScheme: "Token"},
+			want: map[string]string{"Authorization": "Token abc"},
+		},
+		{
+			name: "4",
+			p:    &paramConfig{Header: map[string]string{"authorization": "Basic xyz"}, AuthToken: "abc"},
+			want: map[string]string{"Authorization": "Basic xyz"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.header(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paramConfig.header() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponseConfig_validate(t *testing.T) {
+	zero := "0"
+	tests := []struct {
+		name    string
+		r       *responseConfig
+		body    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			r:    &responseConfig{},
+			body: "ok",
+		},
+		{
+			name: "2",
+			r:    &responseConfig{Path: "code", Value: &zero},
+			body: `{"code":0}`,
+		},
+		{
+			name:    "3",
+			r:       &responseConfig{Path: "code", Value: &zero},
+			body:    `{"code":1}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			r:       &responseConfig{Path: "code", Value: &zero},
+			body:    `{}`,
+			wantErr: true,
+		},
+		{
+			name: "5",
+			r:    &responseConfig{Path: "success"},
+			body: `{"success":true}`,
+		},
+		{
+			name:    "6",
+			r:       &responseConfig{Path: "success"},
+			body:    `{"success":"true"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			r:       &responseConfig{Path: "success"},
+			body:    "ok",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.validate([]byte(tt.body)); (err != nil) != tt.wantErr {
+				t.Errorf("responseConfig.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//encoder 将一批记录编码成JSON数组或者NDJSON请求体的编码器
+type encoder struct {
+	buf     *bytes.Buffer
+	enc     *json.Encoder
+	decoder element.TimeDecoder
+	format  string
+	column  []string
+}
+
+func newEncoder(param *paramConfig) *encoder {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	return &encoder{
+		buf:     buf,
+		enc:     enc,
+		decoder: element.NewStringTimeDecoder(param.layout()),
+		format:  param.format(),
+		column:  param.Column,
+	}
+}
+
+//Encode 将记录records编码成请求体，返回的请求体在下次编码前有效，
+//整数和高精度实数按原样写入JSON数字，不会丢失精度
+func (e *encoder) Encode(records []element.Record) (body []byte, err error) {
+	e.buf.Reset()
+	if e.format == FormatJSON {
+		e.buf.WriteByte('[')
+	}
+	for i, r := range records {
+		if i > 0 && e.format == FormatJSON {
+			e.buf.WriteByte(',')
+		}
+		if err = e.writeRecord(r); err != nil {
+			return
+		}
+		if e.format == FormatNDJSON {
+			e.buf.WriteByte('\n')
+		}
+	}
+	if e.format == FormatJSON {
+		e.buf.WriteByte(']')
+	}
+	return e.buf.Bytes(), nil
+}
+
+//writeRecord 将记录record以列名为键的JSON对象写入缓存，
+//设置了列名时只写入这些列
+func (e *encoder) writeRecord(record element.Record) (err error) {
+	n := record.ColumnNumber()
+	if len(e.column) > 0 {
+		n = len(e.column)
+	}
+	e.buf.WriteByte('{')
+	for i := 0; i < n; i++ {
+		var c element.Column
+		if len(e.column) > 0 {
+			c, err = record.GetByName(e.column[i])
+		} else {
+			c, err = record.GetByIndex(i)
+		}
+		if err != nil {
+			return
+		}
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err = e.writeString(c.Name()); err != nil {
+			return
+		}
+		e.buf.WriteByte(':')
+		if err = e.writeValue(c); err != nil {
+			return fmt.Errorf("column(%v) err: %v", c.Name(), err)
+		}
+	}
+	e.buf.WriteByte('}')
+	return
+}
+
+//writeValue 将列c的值以JSON格式写入缓存
+func (e *encoder) writeValue(c element.Column) (err error) {
+	if c.IsNil() {
+		e.buf.WriteString("null")
+		return
+	}
+
+	switch c.Type() {
+	case element.TypeBool:
+		var b bool
+		if b, err = c.AsBool(); err != nil {
+			return
+		}
+		if b {
+			e.buf.WriteString("true")
+		} else {
+			e.buf.WriteString("false")
+		}
+		return
+	case element.TypeBigInt:
+		var v fmt.Stringer
+		if v, err = c.AsBigInt(); err != nil {
+			return
+		}
+		e.buf.WriteString(v.String())
+		return
+	case element.TypeDecimal:
+		var v fmt.Stringer
+		if v, err = c.AsDecimal(); err != nil {
+			return
+		}
+		e.buf.WriteString(v.String())
+		return
+	case element.TypeTime:
+		var tm time.Time
+		if tm, err = c.AsTime(); err != nil {
+			return
+		}
+		var v interface{}
+		if v, err = e.decoder.TimeDecode(tm); err != nil {
+			return
+		}
+		return e.writeString(v.(string))
+	case element.TypeBytes:
+		var b []byte
+		if b, err = c.AsBytes(); err != nil {
+			return
+		}
+		return e.writeString(string(b))
+	}
+
+	var s string
+	if s, err = c.AsString(); err != nil {
+		return
+	}
+	return e.writeString(s)
+}
+
+//writeString 将字符串s以JSON字符串写入缓存
+func (e *encoder) writeString(s string) (err error) {
+	if err = e.enc.Encode(s); err != nil {
+		return
+	}
+	//json.Encoder会在末尾添加换行符
+	e.buf.Truncate(e.buf.Len() - 1)
+	return
+}
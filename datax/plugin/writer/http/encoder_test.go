@@ -0,0 +1,90 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   *paramConfig
+		records []element.Record
+		want    string
+	}{
+		{
+			name:    "1",
+			param:   &paramConfig{DateFormat: "2006-01-02 15:04:05"},
+			records: []element.Record{testRecord(testColumns()...), testRecord(testColumns()[:2]...)},
+			want: `[{"id":123456789012345678901234567890,"name":"a\"<b>","nil":null,"time":"2021-01-02 03:04:05",` +
+				`"bool":true,"bytes":"xyz","decimal":0.10000000000000000001,"false":false},` +
+				`{"id":123456789012345678901234567890,"name":"a\"<b>"}]`,
+		},
+		{
+			name:    "2",
+			param:   &paramConfig{Format: FormatNDJSON},
+			records: []element.Record{testRecord(testColumns()[3]), testRecord()},
+			want:    `{"time":"2021-01-02T03:04:05Z"}` + "\n{}\n",
+		},
+		{
+			name:    "3",
+			param:   &paramConfig{Column: []string{"bool", "id"}},
+			records: []element.Record{testRecord(testColumns()...)},
+			want:    `[{"bool":true,"id":123456789012345678901234567890}]`,
+		},
+		{
+			name:  "4",
+			param: &paramConfig{},
+			want:  `[]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := newEncoder(tt.param).Encode(tt.records)
+			if err != nil {
+				t.Fatalf("encoder.Encode() error = %v", err)
+			}
+			if string(body) != tt.want {
+				t.Errorf("encoder.Encode() = %v, want %v", string(body), tt.want)
+			}
+		})
+	}
+}
+
+//mockTimeValue 类型为时间但是无法转化为时间的列值
+type mockTimeValue struct {
+	element.ColumnValue
+}
+
+func (m *mockTimeValue) Type() element.ColumnType {
+	return element.TypeTime
+}
+
+func TestEncoder_EncodeErr(t *testing.T) {
+	tests := []struct {
+		name   string
+		param  *paramConfig
+		record element.Record
+	}{
+		{
+			name:  "1",
+			param: &paramConfig{},
+			record: testRecord(element.NewDefaultColumn(&mockTimeValue{
+				ColumnValue: element.NewStringColumnValue("abc"),
+			}, "time", 0)),
+		},
+		{
+			name:   "2",
+			param:  &paramConfig{Column: []string{"none"}},
+			record: testRecord(testColumns()...),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newEncoder(tt.param).Encode([]element.Record{tt.record}); err == nil {
+				t.Errorf("encoder.Encode() error = nil, wantErr true")
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//Task 任务
+type Task struct {
+	*writer.BaseTask
+
+	param   *paramConfig
+	client  *client
+	encoder *encoder
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.param.TaskID != nil {
+		t.SetTaskID(*t.param.TaskID)
+	}
+	if t.client, err = newClient(t.param); err != nil {
+		return
+	}
+	t.encoder = newEncoder(t.param)
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	if t.client != nil {
+		t.client.client.CloseIdleConnections()
+	}
+	return
+}
+
+//StartWrite 开始写，记录数达到batchSize或者每隔batchTimeout发送一批记录，
+//收到终止记录后发送剩余的记录
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) error {
+	return plugin.BatchWrite(ctx, receiver, plugin.BatchOptions{
+		BatchSize:    t.param.getBatchSize(),
+		BatchTimeout: t.param.getBatchTimeout(),
+	}, nil, func(records []element.Record) error {
+		return t.send(ctx, records)
+	})
+}
+
+//send 将一批记录records编码成请求体发送并校验响应
+func (t *Task) send(ctx context.Context, records []element.Record) (err error) {
+	if len(records) == 0 {
+		return
+	}
+	var body []byte
+	if body, err = t.encoder.Encode(records); err != nil {
+		return
+	}
+	var resp *response
+	if resp, err = t.client.do(ctx, t.param.method(), t.param.URL, body); err != nil {
+		return
+	}
+	if err = t.param.Response.validate(resp.body); err != nil {
+		return fmt.Errorf("request(%v %v) err: %v", t.param.method(), t.param.URL, err)
+	}
+	log.Debugf("httpwriter task(%v) send %v records, %v bytes", t.TaskID(), len(records), len(body))
+	return
+}
@@ -0,0 +1,229 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testTask(t *testing.T, param string) *Task {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+//testServer 记录请求的HTTP服务，/fail返回400，/invalid返回校验失败的响应
+type testServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	bodys   []string
+	headers []http.Header
+}
+
+func newTestServer() *testServer {
+	s := &testServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.mu.Lock()
+		s.bodys = append(s.bodys, string(body))
+		s.headers = append(s.headers, r.Header)
+		s.mu.Unlock()
+		switch r.URL.Path {
+		case "/fail":
+			w.WriteHeader(http.StatusBadRequest)
+		case "/invalid":
+			w.Write([]byte(`{"code":1}`))
+		default:
+			w.Write([]byte(`{"code":0}`))
+		}
+	}))
+	return s
+}
+
+func TestTask_Init(t *testing.T) {
+	tests := []struct {
+		name       string
+		param      string
+		wantTaskID int
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			param:      `{"url":"http://localhost","taskID":3}`,
+			wantTaskID: 3,
+		},
+		{
+			name:  "2",
+			param: `{"url":"http://localhost"}`,
+		},
+		{
+			name:    "3",
+			param:   `{"url":"http://localhost","method":"GET"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: writer.NewBaseTask(),
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			err := task.Init(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && task.TaskID() != tt.wantTaskID {
+				t.Errorf("Task.Init() taskID = %v, want %v", task.TaskID(), tt.wantTaskID)
+			}
+		})
+	}
+
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	record := func(i int64) element.Record {
+		return testRecord(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(i), "id", 0))
+	}
+	tests := []struct {
+		name      string
+		param     string
+		receiver  *mockReceiver
+		want      []string
+		wantToken string
+	}{
+		{
+			name:     "1",
+			param:    `{"url":"` + ts.URL + `","batchSize":2,"response":{"path":"code","value":"0"}}`,
+			receiver: &mockReceiver{records: []element.Record{record(1), record(2), record(3)}},
+			want:     []string{`[{"id":1},{"id":2}]`, `[{"id":3}]`},
+		},
+		{
+			name:      "2",
+			param:     `{"url":"` + ts.URL + `","method":"PUT","format":"ndjson","authToken":"abc"}`,
+			receiver:  &mockReceiver{records: []element.Record{record(1), record(2)}},
+			want:      []string{"{\"id\":1}\n{\"id\":2}\n"},
+			wantToken: "Bearer abc",
+		},
+		{
+			name:     "3",
+			param:    `{"url":"` + ts.URL + `","batchTimeout":"5ms"}`,
+			receiver: &mockReceiver{records: []element.Record{record(1), record(2)}, sleep: 20 * time.Millisecond},
+			want:     []string{`[{"id":1}]`, `[{"id":2}]`},
+		},
+		{
+			name:     "4",
+			param:    `{"url":"` + ts.URL + `"}`,
+			receiver: &mockReceiver{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts.bodys, ts.headers = nil, nil
+			if err := testTask(t, tt.param).StartWrite(context.TODO(), tt.receiver); err != nil {
+				t.Fatalf("Task.StartWrite() error = %v", err)
+			}
+			if !reflect.DeepEqual(ts.bodys, tt.want) {
+				t.Errorf("Task.StartWrite() bodys = %v, want %v", ts.bodys, tt.want)
+			}
+			for _, h := range ts.headers {
+				if got := h.Get("Authorization"); got != tt.wantToken {
+					t.Errorf("Task.StartWrite() Authorization = %v, want %v", got, tt.wantToken)
+				}
+			}
+		})
+	}
+}
+
+func TestTask_StartWriteErr(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	errMock := errors.New("mock error")
+	records := func() []element.Record {
+		return []element.Record{testRecord(testColumns()...)}
+	}
+	tests := []struct {
+		name     string
+		param    string
+		receiver *mockReceiver
+	}{
+		{
+			name:     "1",
+			param:    `{"url":"` + ts.URL + `/fail"}`,
+			receiver: &mockReceiver{records: records()},
+		},
+		{
+			name:     "2",
+			param:    `{"url":"` + ts.URL + `/invalid","response":{"path":"code","value":"0"}}`,
+			receiver: &mockReceiver{records: records()},
+		},
+		{
+			name:     "3",
+			param:    `{"url":"` + ts.URL + `"}`,
+			receiver: &mockReceiver{records: records(), err: errMock},
+		},
+		{
+			name:     "4",
+			param:    `{"url":"` + ts.URL + `","column":["none"]}`,
+			receiver: &mockReceiver{records: records()},
+		},
+		{
+			name:     "5",
+			param:    `{"url":"` + ts.URL + `/fail","batchSize":1}`,
+			receiver: &mockReceiver{records: append(records(), records()...)},
+		},
+		{
+			name:     "6",
+			param:    `{"url":"` + ts.URL + `/fail","batchTimeout":"5ms"}`,
+			receiver: &mockReceiver{records: records(), sleep: 20 * time.Millisecond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testTask(t, tt.param).StartWrite(context.TODO(), tt.receiver); err == nil {
+				t.Errorf("Task.StartWrite() error = nil, wantErr true")
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := testTask(t, `{"url":"`+ts.URL+`/fail"}`).StartWrite(ctx, &mockReceiver{records: records()}); err != nil {
+		t.Errorf("Task.StartWrite() error = %v", err)
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+	if err := testTask(t, `{"url":"http://localhost"}`).Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package jsonl
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/file"
+)
+
+type paramConfig struct {
+	file.Config
+
+	DateFormat string `json:"dateFormat"` //时间格式，使用go的时间格式，默认为time.RFC3339Nano
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.Validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//layout 获取时间格式，默认为time.RFC3339Nano
+func (p *paramConfig) layout() string {
+	if p.DateFormat == "" {
+		return time.RFC3339Nano
+	}
+	return p.DateFormat
+}
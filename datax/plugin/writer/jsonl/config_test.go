@@ -0,0 +1,63 @@
+package jsonl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"path":"/tmp","fileName":"a","dateFormat":"2006-01-02"}`,
+		},
+		{
+			name:    "2",
+			json:    `{"fileName":"a"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"path":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_layout(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *paramConfig
+		want string
+	}{
+		{
+			name: "1",
+			p:    &paramConfig{},
+			want: time.RFC3339Nano,
+		},
+		{
+			name: "2",
+			p:    &paramConfig{DateFormat: "2006-01-02"},
+			want: "2006-01-02",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.layout(); got != tt.want {
+				t.Errorf("paramConfig.layout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package jsonl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//encoder JSON Lines记录编码器
+type encoder struct {
+	w       io.Writer
+	buf     *bytes.Buffer
+	enc     *json.Encoder
+	decoder element.TimeDecoder
+}
+
+func newEncoder(w io.Writer, param *paramConfig) *encoder {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	return &encoder{
+		w:       w,
+		buf:     buf,
+		enc:     enc,
+		decoder: element.NewStringTimeDecoder(param.layout()),
+	}
+}
+
+//Encode 将记录record编码成以列名为键的JSON对象并作为一行写入，
+//整数和高精度实数按原样写入JSON数字，不会丢失精度，JSON列作为嵌套的JSON写入
+func (e *encoder) Encode(record element.Record) (n int, err error) {
+	e.buf.Reset()
+	e.buf.WriteByte('{')
+	for i := 0; i < record.ColumnNumber(); i++ {
+		var c element.Column
+		if c, err = record.GetByIndex(i); err != nil {
+			return
+		}
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err = e.writeString(c.Name()); err != nil {
+			return
+		}
+		e.buf.WriteByte(':')
+		if err = e.writeValue(c); err != nil {
+			return 0, fmt.Errorf("column(%v) err: %v", c.Name(), err)
+		}
+	}
+	e.buf.WriteString("}\n")
+	return e.w.Write(e.buf.Bytes())
+}
+
+//writeValue 将列c的值以JSON格式写入缓存
+func (e *encoder) writeValue(c element.Column) (err error) {
+	if c.IsNil() {
+		e.buf.WriteString("null")
+		return
+	}
+
+	switch c.Type() {
+	case element.TypeBool:
+		var b bool
+		if b, err = c.AsBool(); err != nil {
+			return
+		}
+		if b {
+			e.buf.WriteString("true")
+		} else {
+			e.buf.WriteString("false")
+		}
+		return
+	case element.TypeBigInt:
+		var v fmt.Stringer
+		if v, err = c.AsBigInt(); err != nil {
+			return
+		}
+		e.buf.WriteString(v.String())
+		return
+	case element.TypeDecimal:
+		var v fmt.Stringer
+		if v, err = c.AsDecimal(); err != nil {
+			return
+		}
+		e.buf.WriteString(v.String())
+		return
+	case element.TypeTime:
+		var tm time.Time
+		if tm, err = c.AsTime(); err != nil {
+			return
+		}
+		var v interface{}
+		if v, err = e.decoder.TimeDecode(tm); err != nil {
+			return
+		}
+		return e.writeString(v.(string))
+	case element.TypeBytes:
+		var b []byte
+		if b, err = c.AsBytes(); err != nil {
+			return
+		}
+		return e.writeString(string(b))
+	case element.TypeJSON:
+		var b []byte
+		if b, err = c.AsBytes(); err != nil {
+			return
+		}
+		//JSON按原样嵌套写入，压缩空白保证不会换行
+		return json.Compact(e.buf, b)
+	}
+
+	var s string
+	if s, err = c.AsString(); err != nil {
+		return
+	}
+	return e.writeString(s)
+}
+
+//writeString 将字符串s以JSON字符串写入缓存
+func (e *encoder) writeString(s string) (err error) {
+	if err = e.enc.Encode(s); err != nil {
+		return
+	}
+	//json.Encoder会在末尾添加换行符
+	e.buf.Truncate(e.buf.Len() - 1)
+	return
+}
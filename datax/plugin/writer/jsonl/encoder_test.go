@@ -0,0 +1,80 @@
+package jsonl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   *paramConfig
+		records []element.Record
+		want    string
+	}{
+		{
+			name:    "1",
+			param:   &paramConfig{DateFormat: "2006-01-02 15:04:05"},
+			records: []element.Record{testRecord(testColumns()...), testRecord(testColumns()[:2]...)},
+			want: `{"id":123456789012345678901234567890,"name":"a\"<b>","nil":null,"time":"2021-01-02 03:04:05",` +
+				`"bool":true,"bytes":"xyz","decimal":0.10000000000000000001,"false":false}` + "\n" +
+				`{"id":123456789012345678901234567890,"name":"a\"<b>"}` + "\n",
+		},
+		{
+			name:    "2",
+			param:   &paramConfig{},
+			records: []element.Record{testRecord(testColumns()[3]), testRecord()},
+			want:    `{"time":"2021-01-02T03:04:05Z"}` + "\n{}\n",
+		},
+		{
+			name:  "3",
+			param: &paramConfig{},
+			records: []element.Record{testRecord(
+				element.NewDefaultColumn(testJSONColumnValue("{\"a\": [1, \"<b>\"],\n \"b\": null}"), "json", 0),
+				element.NewDefaultColumn(element.NewNilJSONColumnValue(), "nil", 0),
+			)},
+			want: `{"json":{"a":[1,"<b>"],"b":null},"nil":null}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			e := newEncoder(buf, tt.param)
+			n := 0
+			for _, v := range tt.records {
+				m, err := e.Encode(v)
+				if err != nil {
+					t.Fatalf("encoder.Encode() error = %v", err)
+				}
+				n += m
+			}
+			if buf.String() != tt.want {
+				t.Errorf("encoder.Encode() = %v, want %v", buf.String(), tt.want)
+			}
+			if n != len(tt.want) {
+				t.Errorf("encoder.Encode() n = %v, want %v", n, len(tt.want))
+			}
+		})
+	}
+}
+
+//mockTimeValue 类型为时间但是无法转化为时间的列值
+type mockTimeValue struct {
+	element.ColumnValue
+}
+
+func (m *mockTimeValue) Type() element.ColumnType {
+	return element.TypeTime
+}
+
+func TestEncoder_EncodeErr(t *testing.T) {
+	e := newEncoder(&bytes.Buffer{}, &paramConfig{})
+	r := testRecord(element.NewDefaultColumn(&mockTimeValue{
+		ColumnValue: element.NewStringColumnValue("abc"),
+	}, "time", 0))
+	if _, err := e.Encode(r); err == nil {
+		t.Errorf("encoder.Encode() error = nil, wantErr true")
+	}
+}
@@ -0,0 +1,42 @@
+package jsonl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/file"
+)
+
+func TestJob_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `{"path":"/tmp","fileName":"a"}`,
+		},
+		{
+			name:    "2",
+			param:   `{"path":"/tmp"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			param:   `{"path":"/tmp","fileName":"a","writeMode":"x"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				Job: file.NewJob(),
+			}
+			j.SetPluginJobConf(testJobConf(tt.param))
+			if err := j.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
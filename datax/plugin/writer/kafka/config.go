@@ -0,0 +1,132 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	"github.com/Breeze0806/go/time2"
+	"github.com/Shopify/sarama"
+)
+
+var (
+	defaultBatchSize    = 1000
+	defaultBatchTimeout = 1 * time.Second
+)
+
+type paramConfig struct {
+	kafka.Config
+
+	Topic          string         `json:"topic"`          //主题
+	Format         kafka.Format   `json:"format"`         //消息值的格式，支持json，csv和avro，默认为json
+	Column         []kafka.Column `json:"column"`         //写入的列定义，按列名从记录中选择列，为空时写入记录的所有列，avro格式必须设置
+	KeyColumn      string         `json:"keyColumn"`      //作为消息键的列名，为空时消息没有键，有键的消息按照键的哈希选择分区
+	FieldDelimiter string         `json:"fieldDelimiter"` //CSV格式的字段分隔符，默认为逗号
+	NullFormat     string         `json:"nullFormat"`     //CSV格式中空值的文本，默认为空字符串
+	RecordName     string         `json:"recordName"`     //avro格式的记录名，默认为Record
+	Compression    string         `json:"compression"`    //消息的压缩方式，支持none，gzip，snappy，lz4和zstd，默认为none
+	BatchSize      int            `json:"batchSize"`      //每批次的消息数，默认为1000
+	BatchTimeout   time2.Duration `json:"batchTimeout"`   //批次的最长等待时间，默认为1s
+	TaskID         *int           `json:"taskID"`         //由Job.Split生成的任务ID
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	if err = p.Config.Validate(); err != nil {
+		return
+	}
+	if p.Topic == "" {
+		return fmt.Errorf("topic is empty")
+	}
+	if !p.Format.IsValid() {
+		return fmt.Errorf("format(%v) is not supported", p.Format)
+	}
+	var opts kafka.Options
+	if opts, err = p.options(); err != nil {
+		return
+	}
+	if _, err = kafka.NewCodec(p.Format, p.Column, opts); err != nil {
+		return
+	}
+	if _, err = p.compression(); err != nil {
+		return
+	}
+	if p.BatchTimeout.Duration < 0 {
+		return fmt.Errorf("batchTimeout(%v) is less than 0", p.BatchTimeout.Duration)
+	}
+	return
+}
+
+//options 获取编解码选项
+func (p *paramConfig) options() (opts kafka.Options, err error) {
+	opts = kafka.Options{
+		NullFormat: p.NullFormat,
+		Name:       p.RecordName,
+	}
+	if p.FieldDelimiter != "" {
+		if utf8.RuneCountInString(p.FieldDelimiter) != 1 {
+			return opts, fmt.Errorf("fieldDelimiter(%v) is not a character", p.FieldDelimiter)
+		}
+		opts.Delimiter, _ = utf8.DecodeRuneInString(p.FieldDelimiter)
+	}
+	return
+}
+
+//compression 获取消息的压缩方式，默认为none
+func (p *paramConfig) compression() (sarama.CompressionCodec, error) {
+	switch p.Compression {
+	case "", "none":
+		return sarama.CompressionNone, nil
+	case "gzip":
+		return sarama.CompressionGZIP, nil
+	case "snappy":
+		return sarama.CompressionSnappy, nil
+	case "lz4":
+		return sarama.CompressionLZ4, nil
+	case "zstd":
+		return sarama.CompressionZSTD, nil
+	}
+	return sarama.CompressionNone, fmt.Errorf("compression(%v) is not supported", p.Compression)
+}
+
+//saramaConfig 生成同步生产者的配置，等待所有副本确认，有键的消息按照键的哈希选择分区
+func (p *paramConfig) saramaConfig() (conf *sarama.Config, err error) {
+	if conf, err = p.Config.SaramaConfig(); err != nil {
+		return
+	}
+	conf.Producer.Return.Successes = true
+	conf.Producer.RequiredAcks = sarama.WaitForAll
+	conf.Producer.Partitioner = sarama.NewHashPartitioner
+	if conf.Producer.Compression, err = p.compression(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) getBatchSize() int {
+	if p.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return p.BatchSize
+}
+
+func (p *paramConfig) getBatchTimeout() time.Duration {
+	if p.BatchTimeout.Duration == 0 {
+		return defaultBatchTimeout
+	}
+	return p.BatchTimeout.Duration
+}
@@ -0,0 +1,132 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"brokers":["127.0.0.1:9092"],"topic":"test","format":"csv","fieldDelimiter":"|","compression":"gzip","batchTimeout":"2s"}`,
+		},
+		{
+			name:    "2",
+			json:    `{"topic":"test"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"brokers":["127.0.0.1:9092"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","format":"xml"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","format":"avro"}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","fieldDelimiter":"||"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","compression":"xz"}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"brokers":["127.0.0.1:9092"],"topic":"test","batchTimeout":"-1s"}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			json:    `{"brokers":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_compression(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression string
+		want        sarama.CompressionCodec
+	}{
+		{name: "1", compression: "", want: sarama.CompressionNone},
+		{name: "2", compression: "none", want: sarama.CompressionNone},
+		{name: "3", compression: "gzip", want: sarama.CompressionGZIP},
+		{name: "4", compression: "snappy", want: sarama.CompressionSnappy},
+		{name: "5", compression: "lz4", want: sarama.CompressionLZ4},
+		{name: "6", compression: "zstd", want: sarama.CompressionZSTD},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &paramConfig{Compression: tt.compression}
+			if got, err := p.compression(); err != nil || got != tt.want {
+				t.Errorf("paramConfig.compression() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParamConfig_saramaConfig(t *testing.T) {
+	p, err := newParamConfig(testJSONFromString(`{"brokers":["127.0.0.1:9092"],"topic":"test","compression":"snappy"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err := p.saramaConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !conf.Producer.Return.Successes || conf.Producer.RequiredAcks != sarama.WaitForAll ||
+		conf.Producer.Compression != sarama.CompressionSnappy {
+		t.Errorf("paramConfig.saramaConfig() = %+v", conf.Producer)
+	}
+	if err = conf.Validate(); err != nil {
+		t.Errorf("paramConfig.saramaConfig().Validate() error = %v", err)
+	}
+
+	if _, err = (&paramConfig{Compression: "xz"}).saramaConfig(); err == nil {
+		t.Errorf("paramConfig.saramaConfig() error = nil, wantErr true")
+	}
+}
+
+func TestParamConfig_batch(t *testing.T) {
+	p := &paramConfig{}
+	if got := p.getBatchSize(); got != defaultBatchSize {
+		t.Errorf("paramConfig.getBatchSize() = %v, want %v", got, defaultBatchSize)
+	}
+	if got := p.getBatchTimeout(); got != defaultBatchTimeout {
+		t.Errorf("paramConfig.getBatchTimeout() = %v, want %v", got, defaultBatchTimeout)
+	}
+	p.BatchSize = 10
+	p.BatchTimeout.Duration = time.Minute
+	if got := p.getBatchSize(); got != 10 {
+		t.Errorf("paramConfig.getBatchSize() = %v, want 10", got)
+	}
+	if got := p.getBatchTimeout(); got != time.Minute {
+		t.Errorf("paramConfig.getBatchTimeout() = %v, want %v", got, time.Minute)
+	}
+}
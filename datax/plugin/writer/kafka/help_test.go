@@ -0,0 +1,121 @@
+package kafka
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Shopify/sarama"
+)
+
+type mockReceiver struct {
+	records []element.Record
+	err     error
+	sleep   time.Duration //每次获取记录前的等待时间
+}
+
+func (m *mockReceiver) GetFromReader() (element.Record, error) {
+	time.Sleep(m.sleep)
+	if len(m.records) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, exchange.ErrTerminate
+	}
+	r := m.records[0]
+	m.records = m.records[1:]
+	return r, nil
+}
+
+func (m *mockReceiver) Shutdown() error {
+	return nil
+}
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+//testJobConf 生成写入器参数为param的工作配置
+func testJobConf(param string) *config.JSON {
+	return testJSONFromString(`{
+		"job":{
+			"content":[
+				{
+					"writer": {
+						"name":"kafkawriter",
+						"parameter":` + param + `
+					}
+				}
+			]
+		}
+	}`)
+}
+
+//testRecord 生成列为columns的记录
+func testRecord(columns ...element.Column) element.Record {
+	r := element.NewDefaultRecord()
+	for _, c := range columns {
+		r.Add(c)
+	}
+	return r
+}
+
+func testColumns() []element.Column {
+	i, _ := element.NewBigIntColumnValueFromString("123456789012345678901234567890")
+	d, _ := element.NewDecimalColumnValueFromString("0.10000000000000000001")
+	return []element.Column{
+		element.NewDefaultColumn(i, "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue(`a"<b>`), "name", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)), "time", 0),
+		element.NewDefaultColumn(element.NewBoolColumnValue(true), "bool", 0),
+		element.NewDefaultColumn(element.NewBytesColumnValue([]byte("xyz")), "bytes", 0),
+		element.NewDefaultColumn(d, "decimal", 0),
+		element.NewDefaultColumn(element.NewBoolColumnValue(false), "false", 0),
+	}
+}
+
+//mockProducer 记录发送消息的同步生产者
+type mockProducer struct {
+	mu       sync.Mutex
+	batches  [][]*sarama.ProducerMessage
+	err      error
+	closeErr error
+}
+
+func (m *mockProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	return 0, 0, m.SendMessages([]*sarama.ProducerMessage{msg})
+}
+
+func (m *mockProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.batches = append(m.batches, msgs)
+	return nil
+}
+
+func (m *mockProducer) Close() error {
+	return m.closeErr
+}
+
+//testBroker 生成主题test只有分区0的kafka模拟broker，produce为生产请求的响应
+func testBroker(t *testing.T, produce *sarama.MockProduceResponse) *sarama.MockBroker {
+	broker := sarama.NewMockBroker(t, 1)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("test", 0, broker.BrokerID()),
+		"ProduceRequest": produce,
+	})
+	return broker
+}
@@ -0,0 +1,126 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	"github.com/Shopify/sarama"
+)
+
+//Task 任务
+type Task struct {
+	*writer.BaseTask
+
+	param       *paramConfig
+	codec       kafka.Codec
+	producer    sarama.SyncProducer
+	newProducer func(addrs []string, conf *sarama.Config) (sarama.SyncProducer, error)
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.param.TaskID != nil {
+		t.SetTaskID(*t.param.TaskID)
+	}
+
+	var opts kafka.Options
+	if opts, err = t.param.options(); err != nil {
+		return
+	}
+	if t.codec, err = kafka.NewCodec(t.param.Format, t.param.Column, opts); err != nil {
+		return
+	}
+
+	var conf *sarama.Config
+	if conf, err = t.param.saramaConfig(); err != nil {
+		return
+	}
+	if t.producer, err = t.newProducer(t.param.Brokers, conf); err != nil {
+		return fmt.Errorf("brokers(%v) err: %v", t.param.Brokers, err)
+	}
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	if t.producer != nil {
+		return t.producer.Close()
+	}
+	return
+}
+
+//StartWrite 开始写，每条记录生成一条消息，消息数达到batchSize或者每隔batchTimeout发送一批消息，
+//收到终止记录后发送剩余的消息
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) error {
+	return plugin.BatchWrite(ctx, receiver, plugin.BatchOptions{
+		BatchSize:    t.param.getBatchSize(),
+		BatchTimeout: t.param.getBatchTimeout(),
+	}, nil, func(records []element.Record) error {
+		return t.send(records)
+	})
+}
+
+//send 将一批记录records编码成消息并同步发送
+func (t *Task) send(records []element.Record) (err error) {
+	if len(records) == 0 {
+		return
+	}
+	msgs := make([]*sarama.ProducerMessage, len(records))
+	for i, r := range records {
+		if msgs[i], err = t.message(r); err != nil {
+			return
+		}
+	}
+	if err = t.producer.SendMessages(msgs); err != nil {
+		if errs, ok := err.(sarama.ProducerErrors); ok && len(errs) > 0 {
+			err = fmt.Errorf("%v first err: %v", errs, errs[0].Err)
+		}
+		return fmt.Errorf("topic(%v) err: %v", t.param.Topic, err)
+	}
+	log.Debugf("kafkawriter task(%v) send %v messages", t.TaskID(), len(msgs))
+	return
+}
+
+//message 将记录record编码成消息，keyColumn对应的列不为空值时作为消息键
+func (t *Task) message(record element.Record) (msg *sarama.ProducerMessage, err error) {
+	var value []byte
+	if value, err = t.codec.Encode(record); err != nil {
+		return
+	}
+	msg = &sarama.ProducerMessage{
+		Topic: t.param.Topic,
+		Value: sarama.ByteEncoder(value),
+	}
+	if t.param.KeyColumn == "" {
+		return
+	}
+
+	var c element.Column
+	if c, err = record.GetByName(t.param.KeyColumn); err != nil {
+		return nil, fmt.Errorf("keyColumn(%v) err: %v", t.param.KeyColumn, err)
+	}
+	if c.IsNil() {
+		return
+	}
+	var key []byte
+	if key, err = c.AsBytes(); err != nil {
+		return nil, fmt.Errorf("keyColumn(%v) err: %v", t.param.KeyColumn, err)
+	}
+	msg.Key = sarama.ByteEncoder(key)
+	return
+}
@@ -0,0 +1,301 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Shopify/sarama"
+)
+
+//testTask 生成使用生产者producer的任务
+func testTask(t *testing.T, param string, producer sarama.SyncProducer) *Task {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+		newProducer: func(addrs []string, conf *sarama.Config) (sarama.SyncProducer, error) {
+			return producer, nil
+		},
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+func testUser(id int64, name element.ColumnValue) element.Record {
+	return testRecord(
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(id), "id", 0),
+		element.NewDefaultColumn(name, "name", 0),
+	)
+}
+
+//testMessages 将每批消息转化为键和值的字符串
+func testMessages(t *testing.T, batches [][]*sarama.ProducerMessage) (got [][]string) {
+	for _, msgs := range batches {
+		var batch []string
+		for _, m := range msgs {
+			if m.Topic != "test" {
+				t.Errorf("topic = %v, want test", m.Topic)
+			}
+			var key []byte
+			if m.Key != nil {
+				key, _ = m.Key.Encode()
+			}
+			value, _ := m.Value.Encode()
+			batch = append(batch, string(key)+"="+string(value))
+		}
+		got = append(got, batch)
+	}
+	return
+}
+
+func TestTask_Init(t *testing.T) {
+	errMock := errors.New("mock error")
+	tests := []struct {
+		name        string
+		param       string
+		newProducer func(addrs []string, conf *sarama.Config) (sarama.SyncProducer, error)
+		wantTaskID  int
+		wantErr     bool
+	}{
+		{
+			name:        "1",
+			param:       `{"brokers":["127.0.0.1:9092"],"topic":"test","taskID":3}`,
+			newProducer: func(addrs []string, conf *sarama.Config) (sarama.SyncProducer, error) { return &mockProducer{}, nil },
+			wantTaskID:  3,
+		},
+		{
+			name:        "2",
+			param:       `{"brokers":["127.0.0.1:9092"],"topic":"test"}`,
+			newProducer: func(addrs []string, conf *sarama.Config) (sarama.SyncProducer, error) { return nil, errMock },
+			wantErr:     true,
+		},
+		{
+			name:    "3",
+			param:   `{"brokers":["127.0.0.1:9092"]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask:    writer.NewBaseTask(),
+				newProducer: tt.newProducer,
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			err := task.Init(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && task.TaskID() != tt.wantTaskID {
+				t.Errorf("Task.Init() taskID = %v, want %v", task.TaskID(), tt.wantTaskID)
+			}
+		})
+	}
+
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	tests := []struct {
+		name     string
+		param    string
+		receiver *mockReceiver
+		want     [][]string
+	}{
+		{
+			name:  "1",
+			param: `{"brokers":["127.0.0.1:9092"],"topic":"test","keyColumn":"name","batchSize":2}`,
+			receiver: &mockReceiver{records: []element.Record{
+				testUser(1, element.NewStringColumnValue("a")),
+				testUser(2, element.NewNilStringColumnValue()),
+				testUser(3, element.NewStringColumnValue("c")),
+			}},
+			want: [][]string{{`a={"id":1,"name":"a"}`, `={"id":2,"name":null}`}, {`c={"id":3,"name":"c"}`}},
+		},
+		{
+			name:  "2",
+			param: `{"brokers":["127.0.0.1:9092"],"topic":"test","format":"csv","column":[{"name":"name"},{"name":"id"}],"keyColumn":"id"}`,
+			receiver: &mockReceiver{records: []element.Record{
+				testUser(1, element.NewStringColumnValue("a,b")),
+			}},
+			want: [][]string{{`1="a,b",1`}},
+		},
+		{
+			name:  "3",
+			param: `{"brokers":["127.0.0.1:9092"],"topic":"test","batchTimeout":"5ms"}`,
+			receiver: &mockReceiver{records: []element.Record{
+				testUser(1, element.NewStringColumnValue("a")),
+				testUser(2, element.NewStringColumnValue("b")),
+			}, sleep: 20 * time.Millisecond},
+			want: [][]string{{`={"id":1,"name":"a"}`}, {`={"id":2,"name":"b"}`}},
+		},
+		{
+			name:     "4",
+			param:    `{"brokers":["127.0.0.1:9092"],"topic":"test"}`,
+			receiver: &mockReceiver{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &mockProducer{}
+			if err := testTask(t, tt.param, producer).StartWrite(context.TODO(), tt.receiver); err != nil {
+				t.Fatalf("Task.StartWrite() error = %v", err)
+			}
+			if got := testMessages(t, producer.batches); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartWrite() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartWriteAvro(t *testing.T) {
+	producer := &mockProducer{}
+	task := testTask(t, `{"brokers":["127.0.0.1:9092"],"topic":"test","format":"avro",`+
+		`"column":[{"name":"id","type":"bigInt"},{"name":"name"}]}`, producer)
+	receiver := &mockReceiver{records: []element.Record{testUser(1, element.NewStringColumnValue("a"))}}
+	if err := task.StartWrite(context.TODO(), receiver); err != nil {
+		t.Fatalf("Task.StartWrite() error = %v", err)
+	}
+	if len(producer.batches) != 1 || len(producer.batches[0]) != 1 {
+		t.Fatalf("Task.StartWrite() batches = %v", producer.batches)
+	}
+	value, _ := producer.batches[0][0].Value.Encode()
+	columns, err := task.codec.Decode(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(columns) != 2 || columns[0].String() != "1" || columns[1].String() != "a" {
+		t.Errorf("Task.StartWrite() = %v", columns)
+	}
+}
+
+func TestTask_StartWriteErr(t *testing.T) {
+	errMock := errors.New("mock error")
+	records := func() []element.Record {
+		return []element.Record{testUser(1, element.NewStringColumnValue("a"))}
+	}
+	tests := []struct {
+		name     string
+		param    string
+		producer *mockProducer
+		receiver *mockReceiver
+	}{
+		{
+			name:     "1",
+			param:    `{"brokers":["127.0.0.1:9092"],"topic":"test"}`,
+			producer: &mockProducer{err: sarama.ProducerErrors{{Err: errMock}}},
+			receiver: &mockReceiver{records: records()},
+		},
+		{
+			name:     "2",
+			param:    `{"brokers":["127.0.0.1:9092"],"topic":"test"}`,
+			producer: &mockProducer{},
+			receiver: &mockReceiver{records: records(), err: errMock},
+		},
+		{
+			name:     "3",
+			param:    `{"brokers":["127.0.0.1:9092"],"topic":"test","column":[{"name":"none"}]}`,
+			producer: &mockProducer{},
+			receiver: &mockReceiver{records: records()},
+		},
+		{
+			name:     "4",
+			param:    `{"brokers":["127.0.0.1:9092"],"topic":"test","keyColumn":"none"}`,
+			producer: &mockProducer{},
+			receiver: &mockReceiver{records: records()},
+		},
+		{
+			name:     "5",
+			param:    `{"brokers":["127.0.0.1:9092"],"topic":"test","batchSize":1}`,
+			producer: &mockProducer{err: errMock},
+			receiver: &mockReceiver{records: append(records(), records()...)},
+		},
+		{
+			name:     "6",
+			param:    `{"brokers":["127.0.0.1:9092"],"topic":"test","batchTimeout":"5ms"}`,
+			producer: &mockProducer{err: errMock},
+			receiver: &mockReceiver{records: records(), sleep: 20 * time.Millisecond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testTask(t, tt.param, tt.producer).StartWrite(context.TODO(), tt.receiver); err == nil {
+				t.Errorf("Task.StartWrite() error = nil, wantErr true")
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	task := testTask(t, `{"brokers":["127.0.0.1:9092"],"topic":"test"}`, &mockProducer{err: errMock})
+	if err := task.StartWrite(ctx, &mockReceiver{records: records()}); err != nil {
+		t.Errorf("Task.StartWrite() error = %v", err)
+	}
+}
+
+//TestTask_StartWriteBroker 通过模拟broker测试真实的同步生产者
+func TestTask_StartWriteBroker(t *testing.T) {
+	tests := []struct {
+		name    string
+		produce *sarama.MockProduceResponse
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			produce: sarama.NewMockProduceResponse(t).SetVersion(3),
+		},
+		{
+			name:    "2",
+			produce: sarama.NewMockProduceResponse(t).SetVersion(3).SetError("test", 0, sarama.ErrMessageSizeTooLarge),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := testBroker(t, tt.produce)
+			defer broker.Close()
+			task := &Task{
+				BaseTask:    writer.NewBaseTask(),
+				newProducer: sarama.NewSyncProducer,
+			}
+			task.SetPluginJobConf(testJobConf(`{"brokers":["` + broker.Addr() + `"],"topic":"test","keyColumn":"name"}`))
+			if err := task.Init(context.TODO()); err != nil {
+				t.Fatalf("Task.Init() error = %v", err)
+			}
+			defer task.Destroy(context.TODO())
+			receiver := &mockReceiver{records: []element.Record{
+				testUser(1, element.NewStringColumnValue("a")),
+				testUser(2, element.NewStringColumnValue("b")),
+			}}
+			if err := task.StartWrite(context.TODO(), receiver); (err != nil) != tt.wantErr {
+				t.Errorf("Task.StartWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+	errMock := errors.New("mock error")
+	if err := testTask(t, `{"brokers":["127.0.0.1:9092"],"topic":"test"}`, &mockProducer{closeErr: errMock}).
+		Destroy(context.TODO()); err != errMock {
+		t.Errorf("Task.Destroy() error = %v, want %v", err, errMock)
+	}
+}
@@ -0,0 +1,104 @@
+package mongodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/storage/mongodb"
+	"github.com/Breeze0806/go/time2"
+)
+
+//写入模式
+const (
+	WriteModeInsert = "insert" //插入文档，默认的写入模式
+	WriteModeUpsert = "upsert" //按照upsertKey更新文档中的字段，文档不存在时插入
+)
+
+//默认值
+const (
+	defaultBatchSize    = 1000
+	defaultBatchTimeout = 1 * time.Second
+)
+
+type paramConfig struct {
+	mongodb.Config
+
+	Column       []mongodb.Column `json:"column"`       //写入的字段，依次对应记录中的列
+	WriteMode    string           `json:"writeMode"`    //写入模式，支持insert和upsert，默认为insert
+	UpsertKey    []string         `json:"upsertKey"`    //upsert时用于匹配文档的字段名，需要在column中
+	BatchSize    int              `json:"batchSize"`    //每批次的记录数，每批次通过一次bulkWrite写入，默认为1000
+	BatchTimeout time2.Duration   `json:"batchTimeout"` //批次的最长等待时间，默认为1s
+	TaskID       *int             `json:"taskID"`       //由Job.Split生成的任务ID
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	if err = p.Config.Validate(); err != nil {
+		return
+	}
+	if len(p.Column) == 0 {
+		return fmt.Errorf("column is empty")
+	}
+	names := make(map[string]bool)
+	for _, v := range p.Column {
+		if err = v.Validate(); err != nil {
+			return
+		}
+		names[v.Name] = true
+	}
+
+	switch p.writeMode() {
+	case WriteModeInsert:
+	case WriteModeUpsert:
+		if len(p.UpsertKey) == 0 {
+			return fmt.Errorf("upsertKey is empty when writeMode is %v", WriteModeUpsert)
+		}
+		for _, v := range p.UpsertKey {
+			if !names[v] {
+				return fmt.Errorf("upsertKey(%v) is not in column", v)
+			}
+		}
+	default:
+		return fmt.Errorf("writeMode(%v) is not supported", p.WriteMode)
+	}
+
+	if p.BatchTimeout.Duration < 0 {
+		return fmt.Errorf("batchTimeout(%v) is less than 0", p.BatchTimeout.Duration)
+	}
+	return
+}
+
+//writeMode 获取写入模式，默认为insert
+func (p *paramConfig) writeMode() string {
+	if p.WriteMode == "" {
+		return WriteModeInsert
+	}
+	return p.WriteMode
+}
+
+func (p *paramConfig) getBatchSize() int {
+	if p.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return p.BatchSize
+}
+
+func (p *paramConfig) getBatchTimeout() time.Duration {
+	if p.BatchTimeout.Duration == 0 {
+		return defaultBatchTimeout
+	}
+	return p.BatchTimeout.Duration
+}
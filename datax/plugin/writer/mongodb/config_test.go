@@ -0,0 +1,96 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":[{"name":"_id","type":"objectId"},{"name":"a.b"}],"batchTimeout":"2s"}`,
+		},
+		{
+			name: "2",
+			json: `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":[{"name":"id"},{"name":"a"}],"writeMode":"upsert","upsertKey":["id"]}`,
+		},
+		{
+			name:    "3",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","column":[{"name":"a"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":[{"name":"a","type":"int"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":[{"name":"a"}],"writeMode":"delete"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":[{"name":"a"}],"writeMode":"upsert"}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":[{"name":"a"}],"writeMode":"upsert","upsertKey":["id"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			json:    `{"uri":"mongodb://127.0.0.1","database":"db","collection":"c","column":[{"name":"a"}],"batchTimeout":"-1s"}`,
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			json:    `{"uri":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_defaults(t *testing.T) {
+	p := &paramConfig{}
+	if got := p.writeMode(); got != WriteModeInsert {
+		t.Errorf("paramConfig.writeMode() = %v, want %v", got, WriteModeInsert)
+	}
+	if got := p.getBatchSize(); got != defaultBatchSize {
+		t.Errorf("paramConfig.getBatchSize() = %v, want %v", got, defaultBatchSize)
+	}
+	if got := p.getBatchTimeout(); got != defaultBatchTimeout {
+		t.Errorf("paramConfig.getBatchTimeout() = %v, want %v", got, defaultBatchTimeout)
+	}
+
+	p = &paramConfig{WriteMode: WriteModeUpsert, BatchSize: 10}
+	p.BatchTimeout.Duration = 2 * time.Second
+	if got := p.writeMode(); got != WriteModeUpsert {
+		t.Errorf("paramConfig.writeMode() = %v, want %v", got, WriteModeUpsert)
+	}
+	if got := p.getBatchSize(); got != 10 {
+		t.Errorf("paramConfig.getBatchSize() = %v, want 10", got)
+	}
+	if got := p.getBatchTimeout(); got != 2*time.Second {
+		t.Errorf("paramConfig.getBatchTimeout() = %v, want 2s", got)
+	}
+}
@@ -0,0 +1,255 @@
+package mongodb
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type mockReceiver struct {
+	records []element.Record
+	err     error
+	sleep   time.Duration //每次获取记录前的等待时间
+}
+
+func (m *mockReceiver) GetFromReader() (element.Record, error) {
+	time.Sleep(m.sleep)
+	if len(m.records) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, exchange.ErrTerminate
+	}
+	r := m.records[0]
+	m.records = m.records[1:]
+	return r, nil
+}
+
+func (m *mockReceiver) Shutdown() error {
+	return nil
+}
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+//testJobConf 生成写入器参数为param的工作配置
+func testJobConf(param string) *config.JSON {
+	return testJSONFromString(`{
+		"job":{
+			"content":[
+				{
+					"writer": {
+						"name":"mongodbwriter",
+						"parameter":` + param + `
+					}
+				}
+			]
+		}
+	}`)
+}
+
+//testRecord 生成列为columns的记录
+func testRecord(columns ...element.Column) element.Record {
+	r := element.NewDefaultRecord()
+	for _, c := range columns {
+		r.Add(c)
+	}
+	return r
+}
+
+//mockCollector 收集脏记录的任务收集器
+type mockCollector struct {
+	records []element.Record
+	errs    []string
+}
+
+func (m *mockCollector) CollectDirtyRecordWithError(record element.Record, err error) {
+	m.records = append(m.records, record)
+	m.errs = append(m.errs, err.Error())
+}
+
+func (m *mockCollector) CollectDirtyRecordWithMsg(record element.Record, msgErr string) {}
+
+func (m *mockCollector) CollectDirtyRecord(record element.Record, err error, msgErr string) {}
+
+func (m *mockCollector) CollectMessage(key string, value string) {}
+
+//操作码
+const (
+	opReply = 1
+	opQuery = 2004
+	opMsg   = 2013
+)
+
+//testServer mongodb协议的本地替身，握手和结束会话由替身处理，
+//其他命令交由handler处理，handler返回空时回复{ok:1}，
+//OP_MSG中的文档序列会合并到命令中，如insert的documents和update的updates
+type testServer struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	handler func(name string, cmd bson.Raw) interface{}
+}
+
+func newTestServer(handler func(name string, cmd bson.Raw) interface{}) *testServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	s := &testServer{
+		listener: l,
+		handler:  handler,
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+//URI 获取连接串
+func (s *testServer) URI() string {
+	return "mongodb://" + s.listener.Addr().String() + "/?connect=direct"
+}
+
+//Close 关闭
+func (s *testServer) Close() {
+	s.listener.Close()
+}
+
+func (s *testServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		header := make([]byte, 16)
+		if _, err := io.ReadFull(r, header); err != nil {
+			return
+		}
+		body := make([]byte, binary.LittleEndian.Uint32(header)-16)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return
+		}
+		requestID := binary.LittleEndian.Uint32(header[4:])
+
+		var reply []byte
+		switch binary.LittleEndian.Uint32(header[12:]) {
+		case opQuery:
+			//跳过flags和集合名
+			i := 4 + strings.IndexByte(string(body[4:]), 0) + 1 + 8
+			doc := s.do(bson.Raw(body[i:]))
+			reply = make([]byte, 20)
+			binary.LittleEndian.PutUint32(reply[16:], 1)
+			reply = append(reply, doc...)
+			reply = s.message(opReply, requestID, reply)
+		case opMsg:
+			doc := s.do(s.command(body[4:]))
+			reply = s.message(opMsg, requestID, append(make([]byte, 5), doc...))
+		default:
+			return
+		}
+		if _, err := conn.Write(reply); err != nil {
+			return
+		}
+	}
+}
+
+//command 将OP_MSG的各个段合并成一个命令，文档序列作为命令中的数组
+func (s *testServer) command(sections []byte) bson.Raw {
+	var cmd bson.D
+	for len(sections) > 0 {
+		kind := sections[0]
+		sections = sections[1:]
+		if kind == 0 {
+			doc := bson.Raw(sections[:binary.LittleEndian.Uint32(sections)])
+			if err := bson.Unmarshal(doc, &cmd); err != nil {
+				panic(err)
+			}
+			sections = sections[len(doc):]
+			continue
+		}
+		size := binary.LittleEndian.Uint32(sections)
+		seq := sections[4:size]
+		sections = sections[size:]
+		i := strings.IndexByte(string(seq), 0)
+		identifier := string(seq[:i])
+		docs := bson.A{}
+		for seq = seq[i+1:]; len(seq) > 0; {
+			doc := bson.Raw(seq[:binary.LittleEndian.Uint32(seq)])
+			docs = append(docs, doc)
+			seq = seq[len(doc):]
+		}
+		cmd = append(cmd, bson.E{Key: identifier, Value: docs})
+	}
+	b, err := bson.Marshal(cmd)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+//message 生成回复请求requestID的消息
+func (s *testServer) message(opCode, requestID uint32, body []byte) []byte {
+	header := make([]byte, 16)
+	binary.LittleEndian.PutUint32(header, uint32(len(body)+16))
+	binary.LittleEndian.PutUint32(header[8:], requestID)
+	binary.LittleEndian.PutUint32(header[12:], opCode)
+	return append(header, body...)
+}
+
+func (s *testServer) do(cmd bson.Raw) []byte {
+	var reply interface{}
+	name := cmd.Index(0).Key()
+	switch strings.ToLower(name) {
+	case "ismaster", "hello":
+		reply = bson.D{
+			{Key: "ismaster", Value: true},
+			{Key: "maxBsonObjectSize", Value: 16 * 1024 * 1024},
+			{Key: "maxMessageSizeBytes", Value: 48000000},
+			{Key: "maxWriteBatchSize", Value: 100000},
+			{Key: "minWireVersion", Value: 0},
+			{Key: "maxWireVersion", Value: 8},
+			{Key: "ok", Value: 1},
+		}
+	case "endsessions", "killcursors":
+	default:
+		s.mu.Lock()
+		reply = s.handler(name, cmd)
+		s.mu.Unlock()
+	}
+	if reply == nil {
+		reply = bson.D{{Key: "ok", Value: 1}}
+	}
+	b, err := bson.Marshal(reply)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+//testError 生成命令失败的回复
+func testError(msg string) bson.D {
+	return bson.D{
+		{Key: "ok", Value: 0},
+		{Key: "errmsg", Value: msg},
+		{Key: "code", Value: 2},
+	}
+}
@@ -0,0 +1,88 @@
+package mongodb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//modeler 将记录转化为批量写入的操作
+type modeler struct {
+	column    []mongodb.Column
+	writeMode string
+	upsertKey map[string]bool
+}
+
+func newModeler(param *paramConfig) *modeler {
+	m := &modeler{
+		column:    param.Column,
+		writeMode: param.writeMode(),
+		upsertKey: make(map[string]bool),
+	}
+	for _, v := range param.UpsertKey {
+		m.upsertKey[v] = true
+	}
+	return m
+}
+
+//Model 将记录record转化为批量写入的操作，记录中的列依次对应column中的字段，
+//insert时嵌套的字段写入嵌套的文档，upsert时以upsertKey中的字段匹配文档并通过$set更新其他字段
+func (m *modeler) Model(record element.Record) (mongo.WriteModel, error) {
+	if record.ColumnNumber() < len(m.column) {
+		return nil, fmt.Errorf("record has %v columns, want %v", record.ColumnNumber(), len(m.column))
+	}
+
+	var filter, set, doc bson.D
+	for i, c := range m.column {
+		col, err := record.GetByIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		v, err := c.Value(col)
+		if err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", c.Name, err)
+		}
+
+		switch {
+		case m.writeMode == WriteModeInsert:
+			doc = setField(doc, strings.Split(c.Name, "."), v)
+		case m.upsertKey[c.Name]:
+			filter = append(filter, bson.E{Key: c.Name, Value: v})
+		default:
+			set = append(set, bson.E{Key: c.Name, Value: v})
+		}
+	}
+
+	if m.writeMode == WriteModeInsert {
+		return mongo.NewInsertOneModel().SetDocument(doc), nil
+	}
+	var update bson.D
+	if len(set) > 0 {
+		update = bson.D{{Key: "$set", Value: set}}
+	} else {
+		//只有upsertKey时在文档不存在时插入
+		update = bson.D{{Key: "$setOnInsert", Value: filter}}
+	}
+	return mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true), nil
+}
+
+//setField 在文档doc中按照路径path设置字段的值v，路径中的文档不存在时创建
+func setField(doc bson.D, path []string, v interface{}) bson.D {
+	for i := range doc {
+		if doc[i].Key != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			doc[i].Value = v
+			return doc
+		}
+		sub, _ := doc[i].Value.(bson.D)
+		doc[i].Value = setField(sub, path[1:], v)
+		return doc
+	}
+	return setField(append(doc, bson.E{Key: path[0]}), path, v)
+}
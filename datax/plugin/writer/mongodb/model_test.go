@@ -0,0 +1,133 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//testModel 将写入操作转化为字符串方便比较，insert为文档，upsert为过滤文档和更新文档，用空格分隔
+func testModel(t *testing.T, model mongo.WriteModel) string {
+	marshal := func(v interface{}) string {
+		b, err := bson.MarshalExtJSON(v, false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	switch m := model.(type) {
+	case *mongo.InsertOneModel:
+		return marshal(m.Document)
+	case *mongo.UpdateOneModel:
+		if m.Upsert == nil || !*m.Upsert {
+			t.Errorf("UpdateOneModel.Upsert is not true")
+		}
+		return marshal(m.Filter) + " " + marshal(m.Update)
+	}
+	t.Fatalf("unexpected model %T", model)
+	return ""
+}
+
+func TestModeler_Model(t *testing.T) {
+	record := testRecord(
+		element.NewDefaultColumn(element.NewStringColumnValue("5f1d7e2a9b1e8a3c4d5e6f70"), "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue("a"), "name", 0),
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(18), "age", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)), "created", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+	)
+
+	tests := []struct {
+		name    string
+		param   *paramConfig
+		record  element.Record
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			param: &paramConfig{
+				Column: []mongodb.Column{
+					{Name: "_id", Type: mongodb.TypeObjectID},
+					{Name: "user.name"},
+					{Name: "user.age"},
+					{Name: "created"},
+					{Name: "nil"},
+				},
+			},
+			record: record,
+			want:   `{"_id":{"$oid":"5f1d7e2a9b1e8a3c4d5e6f70"},"user":{"name":"a","age":18},"created":{"$date":"2021-01-02T03:04:05Z"},"nil":null}`,
+		},
+		{
+			name: "2",
+			param: &paramConfig{
+				Column:    []mongodb.Column{{Name: "id"}, {Name: "name"}, {Name: "age"}},
+				WriteMode: WriteModeUpsert,
+				UpsertKey: []string{"id", "age"},
+			},
+			record: record,
+			want:   `{"id":"5f1d7e2a9b1e8a3c4d5e6f70","age":18} {"$set":{"name":"a"}}`,
+		},
+		{
+			name: "3",
+			param: &paramConfig{
+				Column:    []mongodb.Column{{Name: "id"}},
+				WriteMode: WriteModeUpsert,
+				UpsertKey: []string{"id"},
+			},
+			record: record,
+			want:   `{"id":"5f1d7e2a9b1e8a3c4d5e6f70"} {"$setOnInsert":{"id":"5f1d7e2a9b1e8a3c4d5e6f70"}}`,
+		},
+		{
+			name: "4",
+			param: &paramConfig{
+				Column: []mongodb.Column{{Name: "a"}, {Name: "b"}},
+			},
+			record:  testRecord(element.NewDefaultColumn(element.NewStringColumnValue("a"), "a", 0)),
+			wantErr: true,
+		},
+		{
+			name: "5",
+			param: &paramConfig{
+				Column: []mongodb.Column{{Name: "_id", Type: mongodb.TypeObjectID}, {Name: "name", Type: mongodb.TypeObjectID}},
+			},
+			record:  record,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newModeler(tt.param).Model(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("modeler.Model() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if s := testModel(t, got); s != tt.want {
+				t.Errorf("modeler.Model() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetField(t *testing.T) {
+	var doc bson.D
+	doc = setField(doc, []string{"a"}, 1)
+	doc = setField(doc, []string{"b", "c"}, 2)
+	doc = setField(doc, []string{"b", "d"}, 3)
+	doc = setField(doc, []string{"a"}, 4)
+	doc = setField(doc, []string{"a", "e"}, 5)
+	b, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"a":{"e":5},"b":{"c":2,"d":3}}`
+	if string(b) != want {
+		t.Errorf("setField() = %v, want %v", string(b), want)
+	}
+}
@@ -0,0 +1,114 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//Task 任务
+type Task struct {
+	*writer.BaseTask
+
+	param      *paramConfig
+	client     *mongo.Client
+	collection *mongo.Collection
+	modeler    *modeler
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.param.TaskID != nil {
+		t.SetTaskID(*t.param.TaskID)
+	}
+	t.modeler = newModeler(t.param)
+	if t.client, err = t.param.Connect(ctx); err != nil {
+		return
+	}
+	t.collection = t.param.GetCollection(t.client)
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	if t.client != nil {
+		err = t.client.Disconnect(ctx)
+		t.client = nil
+	}
+	return
+}
+
+//StartWrite 开始写，记录数达到batchSize或者每隔batchTimeout发送一批记录，
+//收到终止记录后发送剩余的记录
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) error {
+	return plugin.BatchWrite(ctx, receiver, plugin.BatchOptions{
+		BatchSize:    t.param.getBatchSize(),
+		BatchTimeout: t.param.getBatchTimeout(),
+	}, nil, func(records []element.Record) error {
+		return t.send(ctx, records)
+	})
+}
+
+//send 将一批记录records通过一次无序的bulkWrite写入，转化失败和写入失败的记录作为脏记录处理
+func (t *Task) send(ctx context.Context, records []element.Record) (err error) {
+	var sent []element.Record
+	var models []mongo.WriteModel
+	for _, r := range records {
+		model, merr := t.modeler.Model(r)
+		if merr != nil {
+			if err = t.dirty(r, merr); err != nil {
+				return
+			}
+			continue
+		}
+		sent = append(sent, r)
+		models = append(models, model)
+	}
+	if len(models) == 0 {
+		return
+	}
+
+	_, err = t.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	log.Debugf("mongodbwriter task(%v) send %v records", t.TaskID(), len(sent))
+	bwe, ok := err.(mongo.BulkWriteException)
+	if !ok || bwe.WriteConcernError != nil {
+		if err != nil {
+			return fmt.Errorf("collection(%v) err: %v", t.param.Collection, err)
+		}
+		return
+	}
+	for _, v := range bwe.WriteErrors {
+		if v.Index < 0 || v.Index >= len(sent) {
+			return fmt.Errorf("collection(%v) err: %v", t.param.Collection, v)
+		}
+		if err = t.dirty(sent[v.Index], fmt.Errorf("code(%v) %v", v.Code, v.Message)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+//dirty 处理脏记录record，设置了任务收集器时收集脏记录并继续写入，否则返回错误err
+func (t *Task) dirty(record element.Record, err error) error {
+	if collector := t.TaskCollector(); collector != nil {
+		collector.CollectDirtyRecordWithError(record, err)
+		return nil
+	}
+	return err
+}
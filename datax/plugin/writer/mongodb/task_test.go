@@ -0,0 +1,287 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func testTask(t *testing.T, param string) *Task {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	t.Cleanup(func() {
+		task.Destroy(context.TODO())
+	})
+	return task
+}
+
+//testWriteServer 记录写入文档的替身，insert中name为fail的文档写入失败，
+//update中过滤文档的name为fail时同样写入失败，集合名为fail时命令失败，集合名为concern时返回写关注错误
+type testWriteServer struct {
+	*testServer
+
+	requests int
+	docs     []string //insert的文档或者upsert的更新语句
+}
+
+func newTestWriteServer() *testWriteServer {
+	s := &testWriteServer{}
+	s.testServer = newTestServer(s.handle)
+	return s
+}
+
+func (s *testWriteServer) handle(name string, cmd bson.Raw) interface{} {
+	var key string
+	switch name {
+	case "insert":
+		key = "documents"
+	case "update":
+		key = "updates"
+	default:
+		return nil
+	}
+	s.requests++
+	switch cmd.Lookup(name).StringValue() {
+	case "fail":
+		return testError("mock error")
+	case "concern":
+		return bson.D{
+			{Key: "n", Value: 0},
+			{Key: "writeConcernError", Value: bson.D{{Key: "code", Value: 64}, {Key: "errmsg", Value: "waiting for replication timed out"}}},
+			{Key: "ok", Value: 1},
+		}
+	}
+
+	docs, _ := cmd.Lookup(key).Array().Values()
+	var writeErrors bson.A
+	for i, v := range docs {
+		doc := v.Document()
+		path := []string{"name"}
+		if name == "update" {
+			path = []string{"q", "name"}
+		}
+		if n, _ := doc.Lookup(path...).StringValueOK(); n == "fail" {
+			writeErrors = append(writeErrors, bson.D{
+				{Key: "index", Value: i},
+				{Key: "code", Value: 11000},
+				{Key: "errmsg", Value: "duplicate key"},
+			})
+			continue
+		}
+		s.docs = append(s.docs, doc.String())
+	}
+	reply := bson.D{{Key: "n", Value: len(docs) - len(writeErrors)}}
+	if len(writeErrors) > 0 {
+		reply = append(reply, bson.E{Key: "writeErrors", Value: writeErrors})
+	}
+	return append(reply, bson.E{Key: "ok", Value: 1})
+}
+
+//testDoc 生成id和name列的记录
+func testDoc(id int64, name string) element.Record {
+	return testRecord(
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(id), "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue(name), "name", 0),
+	)
+}
+
+func TestTask_Init(t *testing.T) {
+	ts := newTestWriteServer()
+	defer ts.Close()
+
+	tests := []struct {
+		name       string
+		param      string
+		wantTaskID int
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			param:      `{"uri":"` + ts.URI() + `","database":"db","collection":"c","column":[{"name":"id"}],"taskID":3}`,
+			wantTaskID: 3,
+		},
+		{
+			name:  "2",
+			param: `{"uri":"` + ts.URI() + `","database":"db","collection":"c","column":[{"name":"id"}]}`,
+		},
+		{
+			name:    "3",
+			param:   `{"uri":"` + ts.URI() + `","database":"db","collection":"c"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			param:   `{"uri":"mongodb://127.0.0.1:1/?connect=direct","database":"db","collection":"c","column":[{"name":"id"}],"timeout":"100ms"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: writer.NewBaseTask(),
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			defer task.Destroy(context.TODO())
+			if err := task.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Fatalf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if task.TaskID() != tt.wantTaskID {
+				t.Errorf("Task.TaskID() = %v, want %v", task.TaskID(), tt.wantTaskID)
+			}
+		})
+	}
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	tests := []struct {
+		name         string
+		param        string
+		receiver     *mockReceiver
+		want         []string
+		wantRequests int
+		wantDirty    []string
+	}{
+		{
+			name:     "1",
+			param:    `"column":[{"name":"_id"},{"name":"user.name"}],"batchSize":2`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a"), testDoc(2, "b"), testDoc(3, "c")}},
+			want: []string{
+				`{"_id": {"$numberLong":"1"},"user": {"name": "a"}}`,
+				`{"_id": {"$numberLong":"2"},"user": {"name": "b"}}`,
+				`{"_id": {"$numberLong":"3"},"user": {"name": "c"}}`,
+			},
+			wantRequests: 2,
+		},
+		{
+			name:     "2",
+			param:    `"column":[{"name":"_id"},{"name":"name"}],"writeMode":"upsert","upsertKey":["_id"]`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}},
+			want: []string{
+				`{"q": {"_id": {"$numberLong":"1"}},"u": {"$set": {"name": "a"}},"upsert": true}`,
+			},
+			wantRequests: 1,
+		},
+		{
+			name:     "3",
+			param:    `"column":[{"name":"_id"},{"name":"name"}]`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a"), testDoc(2, "fail"), testDoc(3, "c")}},
+			want: []string{
+				`{"_id": {"$numberLong":"1"},"name": "a"}`,
+				`{"_id": {"$numberLong":"3"},"name": "c"}`,
+			},
+			wantRequests: 1,
+			wantDirty:    []string{"code(11000) duplicate key"},
+		},
+		{
+			name:      "4",
+			param:     `"column":[{"name":"_id"},{"name":"name","type":"objectId"}],"batchTimeout":"5ms"`,
+			receiver:  &mockReceiver{records: []element.Record{testDoc(1, "a")}, sleep: 20 * time.Millisecond},
+			wantDirty: []string{"column(name) err: the provided hex string is not a valid ObjectID"},
+		},
+		{
+			name:     "5",
+			param:    `"column":[{"name":"_id"}]`,
+			receiver: &mockReceiver{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestWriteServer()
+			defer ts.Close()
+			collector := &mockCollector{}
+			task := testTask(t, `{"uri":"`+ts.URI()+`","database":"db","collection":"c",`+tt.param+`}`)
+			task.SetTaskCollector(collector)
+			if err := task.StartWrite(context.TODO(), tt.receiver); err != nil {
+				t.Fatalf("Task.StartWrite() error = %v", err)
+			}
+			if !reflect.DeepEqual(ts.docs, tt.want) {
+				t.Errorf("Task.StartWrite() docs = %v, want %v", ts.docs, tt.want)
+			}
+			if ts.requests != tt.wantRequests {
+				t.Errorf("Task.StartWrite() requests = %v, want %v", ts.requests, tt.wantRequests)
+			}
+			if !reflect.DeepEqual(collector.errs, tt.wantDirty) {
+				t.Errorf("Task.StartWrite() dirty = %v, want %v", collector.errs, tt.wantDirty)
+			}
+		})
+	}
+}
+
+func TestTask_StartWriteErr(t *testing.T) {
+	ts := newTestWriteServer()
+	defer ts.Close()
+
+	errMock := errors.New("mock error")
+	tests := []struct {
+		name     string
+		param    string
+		receiver *mockReceiver
+	}{
+		{
+			name:     "1",
+			param:    `{"uri":"` + ts.URI() + `","database":"db","collection":"fail","column":[{"name":"name"}]}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}},
+		},
+		{
+			name:     "2",
+			param:    `{"uri":"` + ts.URI() + `","database":"db","collection":"concern","column":[{"name":"name"}]}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}},
+		},
+		{
+			name:     "3",
+			param:    `{"uri":"` + ts.URI() + `","database":"db","collection":"c","column":[{"name":"name"}]}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}, err: errMock},
+		},
+		{
+			name:     "4",
+			param:    `{"uri":"` + ts.URI() + `","database":"db","collection":"c","column":[{"name":"id"},{"name":"name"}],"batchSize":1}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a"), testDoc(2, "fail")}},
+		},
+		{
+			name:     "5",
+			param:    `{"uri":"` + ts.URI() + `","database":"db","collection":"c","column":[{"name":"id"},{"name":"name"},{"name":"none"}]}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testTask(t, tt.param).StartWrite(context.TODO(), tt.receiver); err == nil {
+				t.Errorf("Task.StartWrite() error = nil, wantErr true")
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	task := testTask(t, `{"uri":"`+ts.URI()+`","database":"db","collection":"c","column":[{"name":"name"}]}`)
+	cancel()
+	if err := task.StartWrite(ctx, &mockReceiver{records: []element.Record{testDoc(1, "a")}}); err != nil {
+		t.Errorf("Task.StartWrite() error = %v", err)
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
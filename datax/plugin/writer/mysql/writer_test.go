@@ -6,39 +6,40 @@ import (
 	"testing"
 
 	"github.com/Breeze0806/go-etl/config"
-	"github.com/Breeze0806/go-etl/datax/common/plugin"
-	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
 )
 
 func testWriter(filename string) *Writer {
-	w, err := NewWriter(filename)
+	writer, err := NewWriter(filename)
 	if err != nil {
 		panic(err)
 	}
-	return w
+	return writer
+}
+
+func testJSONFromFile(filename string) *config.JSON {
+	conf, err := config.NewJSONFromFile(filename)
+	if err != nil {
+		panic(err)
+	}
+	return conf
 }
 
 func TestWriter_Job(t *testing.T) {
 	tests := []struct {
 		name string
 		w    *Writer
-		want writer.Job
 		conf *config.JSON
 	}{
 		{
 			name: "1",
 			w:    testWriter(filepath.Join("resources", "plugin.json")),
-			want: &Job{
-				BaseJob: plugin.NewBaseJob(),
-			},
 			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.want.SetPluginConf(tt.conf)
-			if got := tt.w.Job(); !reflect.DeepEqual(got.PluginConf(), tt.want.PluginConf()) {
-				t.Errorf("Writer.Job() = %v, want %v", got, tt.want)
+			if got := tt.w.Job(); !reflect.DeepEqual(got.PluginConf(), tt.conf) {
+				t.Errorf("Writer.Job().PluginConf() = %v, want %v", got.PluginConf(), tt.conf)
 			}
 		})
 	}
@@ -48,23 +49,18 @@ func TestWriter_Task(t *testing.T) {
 	tests := []struct {
 		name string
 		w    *Writer
-		want writer.Task
 		conf *config.JSON
 	}{
 		{
 			name: "1",
 			w:    testWriter(filepath.Join("resources", "plugin.json")),
-			want: &Task{
-				BaseTask: writer.NewBaseTask(),
-			},
 			conf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.want.SetPluginConf(tt.conf)
-			if got := tt.w.Task(); !reflect.DeepEqual(got.PluginConf(), tt.want.PluginConf()) {
-				t.Errorf("Writer.Task() = %v, want %v", got, tt.want)
+			if got := tt.w.Task(); !reflect.DeepEqual(got.PluginConf(), tt.conf) {
+				t.Errorf("Writer.Task().PluginConf() = %v, want %v", got.PluginConf(), tt.conf)
 			}
 		})
 	}
@@ -75,37 +71,38 @@ func TestNewWriter(t *testing.T) {
 		filename string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		wantW   *Writer
-		wantErr bool
+		name     string
+		args     args
+		wantConf *config.JSON
+		wantErr  bool
 	}{
 		{
 			name: "1",
 			args: args{
 				filename: filepath.Join("resources", "plugin.json"),
 			},
-			wantW: &Writer{
-				pluginConf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
-			},
+			wantConf: testJSONFromFile(filepath.Join("resources", "plugin.json")),
 		},
 		{
 			name: "2",
 			args: args{
-				filename: filepath.Join("resources", "tmpplugin.json"),
+				filename: filepath.Join("tmpresources", "tmpplugin.json"),
 			},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotW, err := NewWriter(tt.args.filename)
+			gotR, err := NewWriter(tt.args.filename)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewWriter() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(gotW, tt.wantW) {
-				t.Errorf("NewWriter() = %v, want %v", gotW, tt.wantW)
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(gotR.PluginConf(), tt.wantConf) {
+				t.Errorf("NewWriter().PluginConf() = %v, want %v", gotR.PluginConf(), tt.wantConf)
 			}
 		})
 	}
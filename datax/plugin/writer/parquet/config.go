@@ -0,0 +1,56 @@
+package parquet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/file"
+	streamparquet "github.com/Breeze0806/go-etl/storage/stream/file/parquet"
+)
+
+type paramConfig struct {
+	file.Config
+
+	Column       []streamparquet.Column `json:"column"`       //列定义，为空时通过第一条记录推断
+	Compression  streamparquet.Codec    `json:"compression"`  //压缩格式，支持none，snappy，gzip，zstd，默认为snappy
+	RowGroupSize int64                  `json:"rowGroupSize"` //行组大小，单位字节，默认为128MB
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//validate 校验配置，整个文件的压缩使用compress而不是compression
+func (p *paramConfig) validate() (err error) {
+	if err = p.ValidateBinary(); err != nil {
+		return
+	}
+
+	for i := range p.Column {
+		if err = p.Column[i].Validate(); err != nil {
+			return
+		}
+	}
+
+	if !p.Compression.IsValid() {
+		return fmt.Errorf("compression(%v) is not supported", p.Compression)
+	}
+	return
+}
+
+//options 获取写入选项
+func (p *paramConfig) options() streamparquet.Options {
+	return streamparquet.Options{
+		RowGroupSize: p.RowGroupSize,
+		Codec:        p.Compression,
+	}
+}
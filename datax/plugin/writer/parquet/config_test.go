@@ -0,0 +1,78 @@
+package parquet
+
+import (
+	"reflect"
+	"testing"
+
+	streamparquet "github.com/Breeze0806/go-etl/storage/stream/file/parquet"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"path":"/tmp","fileName":"a","column":[{"name":"a","type":"DECIMAL","precision":10,"scale":2}],"compression":"zstd"}`,
+		},
+		{
+			name:    "2",
+			json:    `{"fileName":"a"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"path":"/tmp","fileName":"a","writeMode":"append"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"path":"/tmp","fileName":"a","compress":"gzip"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"path":"/tmp","fileName":"a","encoding":"gbk"}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"path":"/tmp","fileName":"a","column":[{"name":"a","type":"INT128"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"path":"/tmp","fileName":"a","compression":"lzo"}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"path":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_options(t *testing.T) {
+	p := &paramConfig{
+		Compression:  streamparquet.CodecGzip,
+		RowGroupSize: 1024,
+	}
+	want := streamparquet.Options{
+		RowGroupSize: 1024,
+		Codec:        streamparquet.CodecGzip,
+	}
+	if got := p.options(); !reflect.DeepEqual(got, want) {
+		t.Errorf("paramConfig.options() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+package parquet
+
+import (
+	"io"
+
+	"github.com/Breeze0806/go-etl/element"
+	streamparquet "github.com/Breeze0806/go-etl/storage/stream/file/parquet"
+)
+
+//encoder parquet记录编码器，关闭时写入文件尾
+type encoder struct {
+	w   *streamparquet.Writer
+	err error
+}
+
+func newEncoder(w io.Writer, param *paramConfig) *encoder {
+	e := &encoder{}
+	e.w, e.err = streamparquet.NewWriter(w, param.Column, param.options())
+	return e
+}
+
+//Encode 写入记录record，由于数据按照行组写入，返回记录按照PLAIN编码的字节数作为写入的字节数
+func (e *encoder) Encode(record element.Record) (n int, err error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	size := e.w.EncodedSize()
+	if err = e.w.Write(record); err != nil {
+		return
+	}
+	return int(e.w.EncodedSize() - size), nil
+}
+
+//Close 写入剩余的行组和文件尾
+func (e *encoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Close()
+}
@@ -0,0 +1,47 @@
+package parquet
+
+import (
+	"bytes"
+	"testing"
+
+	streamparquet "github.com/Breeze0806/go-etl/storage/stream/file/parquet"
+)
+
+func TestEncoder(t *testing.T) {
+	buf := &bytes.Buffer{}
+	e := newEncoder(buf, &paramConfig{})
+	r := testRecord(testColumns()...)
+	n, err := e.Encode(r)
+	if err != nil {
+		t.Fatalf("encoder.Encode() error = %v", err)
+	}
+	//列的字节数都为0时也需要返回编码后的字节数，否则无法按照文件大小轮转
+	if r.ByteSize() != 0 || n <= 0 {
+		t.Errorf("encoder.Encode() n = %v, want encoded size", n)
+	}
+	if err = e.Close(); err != nil {
+		t.Fatalf("encoder.Close() error = %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("PAR1")) || !bytes.HasSuffix(buf.Bytes(), []byte("PAR1")) {
+		t.Errorf("encoder.Close() = %q is not parquet", buf.Bytes())
+	}
+}
+
+func TestEncoderErr(t *testing.T) {
+	e := newEncoder(&bytes.Buffer{}, &paramConfig{
+		Column: []streamparquet.Column{{Name: "a"}},
+	})
+	if _, err := e.Encode(testRecord(testColumns()...)); err == nil {
+		t.Errorf("encoder.Encode() error = nil, wantErr true")
+	}
+	if err := e.Close(); err == nil {
+		t.Errorf("encoder.Close() error = nil, wantErr true")
+	}
+
+	e = newEncoder(&bytes.Buffer{}, &paramConfig{
+		Column: []streamparquet.Column{{Name: "a", Type: streamparquet.TypeInt32}},
+	})
+	if _, err := e.Encode(testRecord(testColumns()...)); err == nil {
+		t.Errorf("encoder.Encode() error = nil, wantErr true")
+	}
+}
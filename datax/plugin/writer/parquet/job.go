@@ -0,0 +1,28 @@
+package parquet
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/file"
+)
+
+//Job 工作
+type Job struct {
+	*file.Job
+}
+
+//Init 初始化
+func (j *Job) Init(ctx context.Context) (err error) {
+	if err = j.Job.Init(ctx); err != nil {
+		return
+	}
+
+	var paramConf *config.JSON
+	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
+		return
+	}
+	_, err = newParamConfig(paramConf)
+	return
+}
@@ -1,10 +1,12 @@
-package mysql
+package rdbm
 
 import (
 	"encoding/json"
 	"time"
 
 	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
 	"github.com/Breeze0806/go/time2"
 )
 
@@ -14,6 +16,7 @@ var (
 )
 
 type paramConfig struct {
+	Dialect      string         `json:"dialect"`
 	Username     string         `json:"username"`
 	Password     string         `json:"password"`
 	Column       []string       `json:"column"`
@@ -21,6 +24,12 @@ type paramConfig struct {
 	WriteMode    string         `json:"writeMode"`
 	BatchSize    int            `json:"batchSize"`
 	BatchTimeout time2.Duration `json:"batchTimeout"`
+
+	Time       element.TimeConfig            `json:"time"`       //时间配置，location为写入时时间转化到的时区
+	ColumnTime map[string]element.TimeConfig `json:"columnTime"` //列名对应的时间配置，会覆盖time中的配置
+
+	CastPolicy       database.CastPolicy            `json:"castPolicy"`       //类型转化策略，为空时不做转化
+	ColumnCastPolicy map[string]database.CastPolicy `json:"columnCastPolicy"` //列名对应的类型转化策略，会覆盖castPolicy
 }
 
 type connConfig struct {
@@ -42,6 +51,15 @@ func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
 	return
 }
 
+//dialectName 获取数据库方言名，优先使用工作配置中的dialect，
+//不存在时使用插件配置pluginConf中的dialect
+func (p *paramConfig) dialectName(pluginConf *config.JSON) (string, error) {
+	if p.Dialect != "" {
+		return p.Dialect, nil
+	}
+	return pluginConf.GetString("dialect")
+}
+
 func (p *paramConfig) getBatchSize() int {
 	if p.BatchSize <= 0 {
 		return defalutBatchSize
@@ -0,0 +1,57 @@
+/*
+Package rdbm 实现了关系型数据库通用的写入工作和写入任务，所有通过
+database.RegisterDialect注册的数据库方言都可以使用
+
+数据库方言名优先取自工作配置参数中的dialect，不存在时取自插件配置中的dialect，
+因此具体数据库的写入器只需要在插件配置中声明dialect，例如mysqlwriter：
+
+	{
+	    "name" : "mysqlwriter",
+	    "developer":"Breeze0806",
+	    "dialect":"mysql",
+	    "description":"..."
+	}
+
+而通用的rdbmswriter则需要在工作配置参数中声明dialect
+
+写入任务会按照batchSize和batchTimeout批量写入记录，写入模式writeMode由
+对应数据库方言的ExecParameter决定，默认为insert
+
+写入任务可以通过time和columnTime配置时间列的时区location和转化为字符串时的格式layout，
+columnTime按照列名覆盖time中的配置，layout默认为RFC3339Nano：
+
+	"parameter":{
+	    "time":{
+	        "location":"Asia/Shanghai",
+	        "layout":"2006-01-02 15:04:05"
+	    },
+	    "columnTime":{
+	        "birthday":{
+	            "layout":"2006-01-02"
+	        }
+	    }
+	}
+
+写入时时间会被转化到location时区，没有时区的字段如DATETIME会写入location时区中的时钟读数，
+mysql驱动写入前会把时间转化到url中loc参数的时区(默认为UTC)，写入任务会抵消这次转化，
+所以不需要让loc与location保持一致
+
+当列类型与表字段对应的类型不一致时，写入任务可以通过castPolicy和columnCastPolicy
+配置类型转化策略，columnCastPolicy按照列名覆盖castPolicy，不配置时不做转化：
+
+	strict       严格转化，实数的小数位多于字段的小数位数时报错，整数字段的小数位数为0
+	lenient      去除字符串首尾空白，空字符串作为空值，多余的小数位会被截断
+	truncate     多余的小数位会被截断
+	round        多余的小数位会被四舍五入
+	nullOnError  严格转化，转化失败时写入空值
+
+例如将VARCHAR中的数值写入DECIMAL(10,2)时：
+
+	"parameter":{
+	    "castPolicy":"round",
+	    "columnCastPolicy":{
+	        "remark":"nullOnError"
+	    }
+	}
+*/
+package rdbm
@@ -1,4 +1,4 @@
-package mysql
+package rdbm
 
 import (
 	"context"
@@ -91,6 +91,8 @@ type mockExecer struct {
 	fetchErr error
 	batchN   int
 	batchErr error
+	capture  bool             //是否保存BatchExec收到的记录
+	records  []element.Record //BatchExec收到的记录
 }
 
 func (m *mockExecer) Table(bt *database.BaseTable) database.Table {
@@ -110,6 +112,9 @@ func (m *mockExecer) FetchTableWithParam(ctx context.Context, param database.Par
 }
 
 func (m *mockExecer) BatchExec(ctx context.Context, opts *database.ParameterOptions) (err error) {
+	if m.capture {
+		m.records = append(m.records, opts.Records...)
+	}
 	m.batchN--
 	if m.batchN <= 0 {
 		return m.batchErr
@@ -129,13 +134,12 @@ func (m *mockExecer) Close() error {
 	return nil
 }
 
-func testJSONFromFile(filename string) *config.JSON {
-	conf, err := config.NewJSONFromFile(filename)
-	if err != nil {
-		panic(err)
-	}
-	return conf
-}
+const testPluginConf = `{
+    "name" : "mysqlwriter",
+    "developer":"Breeze0806",
+    "dialect":"mysql",
+    "description":"use github.com/go-sql-driver/mysql."
+}`
 
 func testJSONFromString(json string) *config.JSON {
 	conf, err := config.NewJSONFromString(json)
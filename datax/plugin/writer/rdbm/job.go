@@ -1,4 +1,4 @@
-package mysql
+package rdbm
 
 import (
 	"context"
@@ -19,10 +19,6 @@ type Job struct {
 
 //Init 初始化
 func (j *Job) Init(ctx context.Context) (err error) {
-	var name string
-	if name, err = j.PluginConf().GetString("dialect"); err != nil {
-		return
-	}
 	var paramConf *config.JSON
 	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
 		return
@@ -33,6 +29,11 @@ func (j *Job) Init(ctx context.Context) (err error) {
 		return
 	}
 
+	var name string
+	if name, err = paramConfig.dialectName(j.PluginConf()); err != nil {
+		return
+	}
+
 	var jobSettingConf *config.JSON
 	if jobSettingConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobSetting); err != nil {
 		jobSettingConf, _ = config.NewJSONFromString("{}")
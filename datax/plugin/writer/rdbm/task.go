@@ -1,15 +1,14 @@
-package mysql
+package rdbm
 
 import (
 	"context"
-	"sync"
+	"fmt"
 	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
 	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
-	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
 )
@@ -21,18 +20,16 @@ type Task struct {
 	execer      Execer
 	newExecer   func(name string, conf *config.JSON) (Execer, error)
 	param       *parameter
+	times       *element.TimeConverter //时间列转化器，没有时间配置时为空
+	casts       *database.Caster       //类型转化器，没有类型转化策略时为空
 	jobID       int64
 	taskgroupID int
 }
 
 //Init 初始化
 func (t *Task) Init(ctx context.Context) (err error) {
-	var name string
-	if name, err = t.PluginConf().GetString("dialect"); err != nil {
-		return
-	}
 	var paramConf *config.JSON
-	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentReaderParameter); err != nil {
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
 		return
 	}
 
@@ -41,6 +38,11 @@ func (t *Task) Init(ctx context.Context) (err error) {
 		return
 	}
 
+	var name string
+	if name, err = paramConfig.dialectName(t.PluginConf()); err != nil {
+		return
+	}
+
 	if t.jobID, err = t.PluginJobConf().GetInt64(coreconst.DataxCoreContainerJobID); err != nil {
 		return
 	}
@@ -79,11 +81,25 @@ func (t *Task) Init(ctx context.Context) (err error) {
 
 	t.param = newParameter(paramConfig, t.execer)
 
+	//驱动写入时间时会转化到固定时区，如mysql，需要抵消这次转化
+	var bind *time.Location
+	if l, ok := t.param.Table().(database.TimeLocationer); ok {
+		bind = l.TimeLocation()
+	}
+	if t.times, err = element.NewTargetTimeConverter(paramConfig.Time, paramConfig.ColumnTime, bind); err != nil {
+		return
+	}
+
 	param := newTableParam(t.param)
 	if _, err = t.execer.FetchTableWithParam(ctx, param); err != nil {
 		return
 	}
 
+	if t.casts, err = database.NewCaster(t.param.Table(),
+		paramConfig.CastPolicy, paramConfig.ColumnCastPolicy); err != nil {
+		return
+	}
+
 	return
 }
 
@@ -92,85 +108,53 @@ func (t *Task) Destroy(ctx context.Context) (err error) {
 	return t.execer.Close()
 }
 
-//StartWrite 开始写
-func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (err error) {
+//StartWrite 开始写，记录数达到batchSize或者每隔batchTimeout写入一批记录，
+//收到终止记录后写入剩余的记录
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) error {
 	opts := &database.ParameterOptions{
 		TxOptions: nil,
 		Table:     t.param.Table(),
 		Mode:      t.param.paramConfig.WriteMode,
 	}
-	recordChan := make(chan element.Record)
-	var rerr error
-	afterCtx, cancel := context.WithCancel(ctx)
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer func() {
-			wg.Done()
-			close(recordChan)
-			log.Debugf("job id: %v taskgroup id：%v get records end", t.jobID, t.taskgroupID)
-		}()
-		log.Debugf("job id: %v taskgroup id：%v start to get records", t.jobID, t.taskgroupID)
-		for {
-			select {
-			case <-afterCtx.Done():
+	checked := false
+	log.Debugf("job id: %v taskgroup id：%v start to BatchExec", t.jobID, t.taskgroupID)
+	return plugin.BatchWrite(ctx, receiver, plugin.BatchOptions{
+		BatchSize:    t.param.paramConfig.getBatchSize(),
+		BatchTimeout: t.param.paramConfig.getBatchTimeout(),
+	}, func(record element.Record) (err error) {
+		//收到第一条记录时读取器已经发送了记录模式，只需要检查一次
+		if !checked {
+			checked = true
+			if err = t.checkSchema(receiver); err != nil {
 				return
-			default:
 			}
-			var record element.Record
-			record, rerr = receiver.GetFromReader()
-			if rerr != nil && rerr != exchange.ErrEmpty {
+		}
+		if t.times != nil {
+			if err = t.times.Convert(record); err != nil {
 				return
 			}
-
-			if rerr != exchange.ErrEmpty {
-				select {
-				case <-afterCtx.Done():
-					return
-				case recordChan <- record:
-				}
-
-			}
 		}
-	}()
-	ticker := time.NewTicker(t.param.paramConfig.getBatchTimeout())
-	defer ticker.Stop()
-	var records []element.Record
-	log.Debugf("job id: %v taskgroup id：%v start to BatchExec", t.jobID, t.taskgroupID)
-	for {
-		select {
-		case record, ok := <-recordChan:
-			if !ok {
-				err = rerr
-				goto End
-			}
-			records = append(records, record)
-			opts.Records = records
-			if len(records) >= t.param.paramConfig.getBatchSize() {
-				if err = t.execer.BatchExec(ctx, opts); err != nil {
-					log.Debugf("job id: %v taskgroup id：%v BatchExec error: %v", t.jobID, t.taskgroupID, err)
-					goto End
-				}
-				records = nil
-			}
-		case <-ticker.C:
-			if err = t.execer.BatchExec(ctx, opts); err != nil {
-				log.Debugf("job id: %v taskgroup id：%v BatchExec error: %v", t.jobID, t.taskgroupID, err)
-				goto End
-			}
-			records = nil
+		if t.casts != nil {
+			err = t.casts.Cast(record)
 		}
-	}
-End:
-	cancel()
-	log.Debugf("job id: %v taskgroup id：%v wait all goroutine", t.jobID, t.taskgroupID)
-	wg.Wait()
-	log.Debugf("job id: %v taskgroup id：%v wait all goroutine end", t.jobID, t.taskgroupID)
-	switch {
-	case ctx.Err() != nil:
-		return nil
-	case err == exchange.ErrTerminate:
+		return
+	}, func(records []element.Record) (err error) {
+		opts.Records = records
+		if err = t.execer.BatchExec(ctx, opts); err != nil {
+			log.Debugf("job id: %v taskgroup id：%v BatchExec error: %v", t.jobID, t.taskgroupID, err)
+		}
+		return
+	})
+}
+
+//checkSchema 当读取器发送了记录模式时，检查记录模式能否写入表
+func (t *Task) checkSchema(receiver plugin.RecordReceiver) error {
+	schema := plugin.ReceiveSchema(receiver)
+	if schema == nil {
 		return nil
 	}
-	return
+	if err := database.CheckSchema(t.param.Table(), schema); err != nil {
+		return fmt.Errorf("table(%v) check schema err: %v", t.param.Table().Quoted(), err)
+	}
+	return nil
 }
@@ -0,0 +1,1037 @@
+package rdbm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+type mockReceiver struct {
+	err       error
+	n         int
+	ticker    *time.Ticker
+	newRecord func() element.Record //生成记录，为空时生成空记录
+}
+
+func newMockReceiver(n int, err error, wait time.Duration) *mockReceiver {
+	return &mockReceiver{
+		err:    err,
+		n:      n,
+		ticker: time.NewTicker(wait),
+	}
+}
+func newMockReceiverWithoutWait(n int, err error) *mockReceiver {
+	return &mockReceiver{
+		err: err,
+		n:   n,
+	}
+}
+func (m *mockReceiver) GetFromReader() (element.Record, error) {
+	m.n--
+	if m.n <= 0 {
+		return nil, m.err
+	}
+	if m.ticker != nil {
+		select {
+		case <-m.ticker.C:
+			return element.NewDefaultRecord(), nil
+		}
+	}
+	if m.newRecord != nil {
+		return m.newRecord(), nil
+	}
+	return element.NewDefaultRecord(), nil
+}
+
+func (m *mockReceiver) Shutdown() error {
+	m.ticker.Stop()
+	return nil
+}
+
+type mockSchemaReceiver struct {
+	*mockReceiver
+
+	schema *element.Schema
+}
+
+func (m *mockSchemaReceiver) Schema() *element.Schema {
+	return m.schema
+}
+
+func testSchema(columns ...element.ColumnSchema) *element.Schema {
+	s, err := element.NewSchema(columns)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func testParameterWithFields(goTypes map[string]database.GoType, names ...string) *parameter {
+	p := newParameter(&paramConfig{}, &mockExecer{})
+	for _, v := range names {
+		p.Table().(database.FieldAdder).AddField(
+			database.NewBaseField(v, newMockFieldType(goTypes[v])))
+	}
+	return p
+}
+
+func TestTask_Init(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		t       *Task
+		args    args
+		conf    *config.JSON
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name: "1",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+
+								}
+							}
+						}
+					]
+				}
+			}`),
+		},
+		{
+			name: "2",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(`{}`),
+			jobConf: testJSONFromString(`{
+				"job":{
+					"core":{
+						"container":{
+							"job":{
+								"id" : 1
+							},
+							"taskGroup":{
+								"id":  1
+							}
+						}
+					},
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+
+								}
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+		{
+			name: "3",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter"
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+		{
+			name: "4",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+									"username": 1
+								}
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+		{
+			name: "5",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return nil, errors.New("mock error")
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+								}
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+		{
+			name: "6",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{
+						queryErr: errors.New("mock error"),
+					}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+								}
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+		{
+			name: "7",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{
+						fetchErr: errors.New("mock error"),
+					}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+								}
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+		{
+			name: "8",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : "1"
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+
+								}
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+		{
+			name: "9",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  "1"
+						}
+					}
+				},
+				"job":{
+
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+
+								}
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+		{
+			name: "10",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					if name != "mysql" {
+						return nil, errors.New("mock dialect error")
+					}
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(`{}`),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"rdbmswriter",
+								"parameter":{
+									"dialect":"mysql"
+								}
+							}
+						}
+					]
+				}
+			}`),
+		},
+		{
+			name: "11",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+									"time":{
+										"location":"UTC"
+									}
+								}
+							}
+						}
+					]
+				}
+			}`),
+		},
+		{
+			name: "12",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+									"time":{
+										"location":"Mars/Olympus"
+									}
+								}
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+		{
+			name: "13",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+									"castPolicy":"round"
+								}
+							}
+						}
+					]
+				}
+			}`),
+		},
+		{
+			name: "14",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+									"castPolicy":"ceil"
+								}
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+		{
+			name: "15",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				newExecer: func(name string, conf *config.JSON) (Execer, error) {
+					return &mockExecer{}, nil
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			conf: testJSONFromString(testPluginConf),
+			jobConf: testJSONFromString(`{
+				"core":{
+					"container":{
+						"job":{
+							"id" : 1
+						},
+						"taskGroup":{
+							"id":  1
+						}
+					}
+				},
+				"job":{
+					"content":[
+						{
+							"writer": {
+								"name":"mysqlwriter",
+								"parameter":{
+									"columnCastPolicy":{
+										"a":"round"
+									}
+								}
+							}
+						}
+					]
+				}
+			}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.t.SetPluginConf(tt.conf)
+			tt.t.SetPluginJobConf(tt.jobConf)
+			err := tt.t.Init(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		t       *Task
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "1",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer:   &mockExecer{},
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.t.Destroy(tt.args.ctx); (err != nil) != tt.wantErr {
+				t.Errorf("Task.Destroy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func testTimeConverter(conf element.TimeConfig) *element.TimeConverter {
+	c, err := element.NewTargetTimeConverter(conf, nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+//testCastParameter 生成单条记录批量写入的参数，表中只有int64类型的字段t
+func testCastParameter() *parameter {
+	p := testParameterWithFields(map[string]database.GoType{
+		"t": database.GoTypeInt64,
+	}, "t")
+	p.paramConfig.BatchSize = 1
+	return p
+}
+
+func testCaster(policy database.CastPolicy, p *parameter) *database.Caster {
+	c, err := database.NewCaster(p.Table(), policy, nil)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		receiver plugin.RecordReceiver
+	}
+	tests := []struct {
+		name       string
+		t          *Task
+		args       args
+		wait       time.Duration
+		wantErr    bool
+		wantRecord string
+	}{
+		{
+			name: "1",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer:   &mockExecer{},
+				param:    newParameter(&paramConfig{}, &mockExecer{}),
+			},
+			args: args{
+				ctx:      context.TODO(),
+				receiver: newMockReceiver(1000, exchange.ErrTerminate, 1*time.Millisecond),
+			},
+		},
+		{
+			name: "2",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer:   &mockExecer{},
+				param:    newParameter(&paramConfig{}, &mockExecer{}),
+			},
+			args: args{
+				ctx:      context.TODO(),
+				receiver: newMockReceiverWithoutWait(10000, exchange.ErrTerminate),
+			},
+		},
+
+		{
+			name: "3",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer:   &mockExecer{},
+				param:    newParameter(&paramConfig{}, &mockExecer{}),
+			},
+			args: args{
+				ctx:      context.TODO(),
+				receiver: newMockReceiverWithoutWait(10000, errors.New("mock error")),
+			},
+			wantErr: true,
+		},
+
+		{
+			name: "4",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer:   &mockExecer{},
+				param:    newParameter(&paramConfig{}, &mockExecer{}),
+			},
+			args: args{
+				ctx:      context.TODO(),
+				receiver: newMockReceiverWithoutWait(10000, errors.New("mock error")),
+			},
+			wait:    100 * time.Microsecond,
+			wantErr: false,
+		},
+		{
+			name: "5",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer: &mockExecer{
+					batchErr: errors.New("mock error"),
+					batchN:   1,
+				},
+				param: newParameter(&paramConfig{}, &mockExecer{}),
+			},
+			args: args{
+				ctx:      context.TODO(),
+				receiver: newMockReceiver(1000, exchange.ErrTerminate, 1*time.Millisecond),
+			},
+			wantErr: true,
+		},
+		{
+			name: "6",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer: &mockExecer{
+					batchErr: errors.New("mock error"),
+					batchN:   1,
+				},
+				param: newParameter(&paramConfig{}, &mockExecer{}),
+			},
+			args: args{
+				ctx:      context.TODO(),
+				receiver: newMockReceiverWithoutWait(10000, exchange.ErrTerminate),
+			},
+			wantErr: true,
+		},
+		{
+			name: "7",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer:   &mockExecer{},
+				param: testParameterWithFields(map[string]database.GoType{
+					"a": database.GoTypeInt64,
+					"b": database.GoTypeTime,
+				}, "a", "b"),
+			},
+			args: args{
+				ctx: context.TODO(),
+				receiver: &mockSchemaReceiver{
+					mockReceiver: newMockReceiverWithoutWait(10, exchange.ErrTerminate),
+					schema: testSchema(element.ColumnSchema{
+						Name: "a",
+						Type: element.TypeString,
+					}, element.ColumnSchema{
+						Name: "b",
+						Type: element.TypeTime,
+					}),
+				},
+			},
+		},
+		{
+			name: "8",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer:   &mockExecer{},
+				param: testParameterWithFields(map[string]database.GoType{
+					"a": database.GoTypeInt64,
+				}, "a"),
+			},
+			args: args{
+				ctx: context.TODO(),
+				receiver: &mockSchemaReceiver{
+					mockReceiver: newMockReceiverWithoutWait(10, exchange.ErrTerminate),
+					schema: testSchema(element.ColumnSchema{
+						Name: "a",
+						Type: element.TypeTime,
+					}),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "9",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer:   &mockExecer{},
+				param: testParameterWithFields(map[string]database.GoType{
+					"a": database.GoTypeInt64,
+				}, "a"),
+			},
+			args: args{
+				ctx: context.TODO(),
+				receiver: &mockSchemaReceiver{
+					mockReceiver: newMockReceiverWithoutWait(10, exchange.ErrTerminate),
+					schema: testSchema(element.ColumnSchema{
+						Name: "b",
+						Type: element.TypeBigInt,
+					}),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "10",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer: &mockExecer{
+					capture: true,
+				},
+				param: newParameter(&paramConfig{
+					BatchSize: 1,
+				}, &mockExecer{}),
+				times: testTimeConverter(element.TimeConfig{
+					Layout:   "2006-01-02 15:04:05",
+					Location: "Asia/Shanghai",
+				}),
+			},
+			args: args{
+				ctx: context.TODO(),
+				receiver: &mockReceiver{
+					err: exchange.ErrTerminate,
+					n:   2,
+					newRecord: func() element.Record {
+						r := element.NewDefaultRecord()
+						r.Add(element.NewDefaultColumn(element.NewTimeColumnValue(
+							time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)), "t", 0))
+						return r
+					},
+				},
+			},
+			wantRecord: "2021-01-01 08:00:00",
+		},
+		{
+			name: "11",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer: &mockExecer{
+					capture: true,
+				},
+				param: testCastParameter(),
+				casts: testCaster(database.CastRound, testCastParameter()),
+			},
+			args: args{
+				ctx: context.TODO(),
+				receiver: &mockReceiver{
+					err: exchange.ErrTerminate,
+					n:   2,
+					newRecord: func() element.Record {
+						r := element.NewDefaultRecord()
+						r.Add(element.NewDefaultColumn(element.NewStringColumnValue("12.5"), "t", 0))
+						return r
+					},
+				},
+			},
+			wantRecord: "13",
+		},
+		{
+			name: "12",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer:   &mockExecer{},
+				param:    testCastParameter(),
+				casts:    testCaster(database.CastStrict, testCastParameter()),
+			},
+			args: args{
+				ctx: context.TODO(),
+				receiver: &mockReceiver{
+					err: exchange.ErrTerminate,
+					n:   2,
+					newRecord: func() element.Record {
+						r := element.NewDefaultRecord()
+						r.Add(element.NewDefaultColumn(element.NewStringColumnValue("12.5"), "t", 0))
+						return r
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "13",
+			t: &Task{
+				BaseTask: writer.NewBaseTask(),
+				execer: &mockExecer{
+					capture: true,
+				},
+				param: newParameter(&paramConfig{}, &mockExecer{}),
+			},
+			args: args{
+				ctx: context.TODO(),
+				receiver: &mockReceiver{
+					err: exchange.ErrTerminate,
+					n:   2,
+					newRecord: func() element.Record {
+						r := element.NewDefaultRecord()
+						r.Add(element.NewDefaultColumn(element.NewStringColumnValue("x"), "t", 0))
+						return r
+					},
+				},
+			},
+			wantRecord: "x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(tt.args.ctx)
+			defer cancel()
+			if tt.wait != 0 {
+				go func() {
+					<-time.After(tt.wait)
+					cancel()
+				}()
+			}
+			if err := tt.t.StartWrite(ctx, tt.args.receiver); (err != nil) != tt.wantErr {
+				t.Errorf("Task.StartWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantRecord == "" {
+				return
+			}
+			records := tt.t.execer.(*mockExecer).records
+			if len(records) != 1 {
+				t.Errorf("Task.StartWrite() records = %v, want 1", len(records))
+				return
+			}
+			c, err := records[0].GetByName("t")
+			if err != nil {
+				t.Errorf("Task.StartWrite() GetByName() error = %v", err)
+				return
+			}
+			if got, _ := c.AsString(); got != tt.wantRecord {
+				t.Errorf("Task.StartWrite() record = %v, want %v", got, tt.wantRecord)
+			}
+		})
+	}
+}
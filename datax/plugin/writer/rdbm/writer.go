@@ -0,0 +1,52 @@
+package rdbm
+
+import (
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+//Writer 关系型数据库写入器
+type Writer struct {
+	pluginConf *config.JSON
+}
+
+//NewWriter 通过插件配置文件filename创建关系型数据库写入器
+func NewWriter(filename string) (w *Writer, err error) {
+	w = &Writer{}
+	w.pluginConf, err = config.NewJSONFromFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+//PluginConf 插件配置
+func (w *Writer) PluginConf() *config.JSON {
+	return w.pluginConf
+}
+
+//Job 工作
+func (w *Writer) Job() writer.Job {
+	job := &Job{
+		BaseJob: plugin.NewBaseJob(),
+		newExecer: func(name string, conf *config.JSON) (Execer, error) {
+			return database.Open(name, conf)
+		},
+	}
+	job.SetPluginConf(w.pluginConf)
+	return job
+}
+
+//Task 任务
+func (w *Writer) Task() writer.Task {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+		newExecer: func(name string, conf *config.JSON) (Execer, error) {
+			return database.Open(name, conf)
+		},
+	}
+	task.SetPluginConf(w.pluginConf)
+	return task
+}
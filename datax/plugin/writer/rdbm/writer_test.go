@@ -0,0 +1,25 @@
+package rdbm
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	w := &Writer{
+		pluginConf: testJSONFromString(testPluginConf),
+	}
+	if got := w.Job().PluginConf(); !reflect.DeepEqual(got, w.PluginConf()) {
+		t.Errorf("Writer.Job().PluginConf() = %v, want %v", got, w.PluginConf())
+	}
+	if got := w.Task().PluginConf(); !reflect.DeepEqual(got, w.PluginConf()) {
+		t.Errorf("Writer.Task().PluginConf() = %v, want %v", got, w.PluginConf())
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	if _, err := NewWriter(filepath.Join("tmpresources", "tmpplugin.json")); err == nil {
+		t.Errorf("NewWriter() error = %v, wantErr true", err)
+	}
+}
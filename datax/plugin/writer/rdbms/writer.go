@@ -0,0 +1,38 @@
+package rdbms
+
+import (
+	"path/filepath"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/rdbm"
+)
+
+func init() {
+	writer, err := NewWriter(filepath.Join("resources", "plugin.json"))
+	if err != nil {
+		panic(err)
+	}
+	name, err := writer.PluginConf().GetString("name")
+	if err != nil {
+		panic(err)
+	}
+	if name == "" {
+		panic("name is empty")
+	}
+	loader.RegisterWriter(name, writer)
+}
+
+//Writer 通用关系型数据库写入器，数据库方言由工作配置参数中的dialect指定，
+//需要预先通过database.RegisterDialect注册对应的数据库方言
+type Writer struct {
+	*rdbm.Writer
+}
+
+//NewWriter 创建写入器
+func NewWriter(filename string) (w *Writer, err error) {
+	w = &Writer{}
+	if w.Writer, err = rdbm.NewWriter(filename); err != nil {
+		return nil, err
+	}
+	return
+}
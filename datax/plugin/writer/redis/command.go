@@ -0,0 +1,245 @@
+package redis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//command redis命令
+type command struct {
+	name string
+	args []interface{}
+}
+
+//commander 将记录转化为写入命令
+type commander struct {
+	buf         *bytes.Buffer
+	enc         *json.Encoder
+	decoder     element.TimeDecoder
+	key         *keyTemplate
+	typ         string
+	format      string
+	column      []string
+	scoreColumn string
+	ttl         time.Duration
+}
+
+func newCommander(param *paramConfig) (c *commander, err error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	c = &commander{
+		buf:         buf,
+		enc:         enc,
+		decoder:     element.NewStringTimeDecoder(param.layout()),
+		typ:         param.typ(),
+		format:      param.format(),
+		column:      param.Column,
+		scoreColumn: param.ScoreColumn,
+		ttl:         param.TTL.Duration,
+	}
+	if c.key, err = newKeyTemplate(param.Key); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//Commands 将记录record转化为写入命令，string使用SET，hash使用HSET，list使用RPUSH，
+//zset使用ZADD，设置过期时间时string使用SET的PX选项，其他类型追加PEXPIRE命令
+func (c *commander) Commands(record element.Record) (cmds []command, err error) {
+	var key string
+	if key, err = c.key.render(record); err != nil {
+		return
+	}
+
+	var columns []element.Column
+	if columns, err = c.columns(record); err != nil {
+		return
+	}
+
+	if c.typ == TypeHash {
+		return c.hashCommands(key, columns)
+	}
+
+	var value string
+	if value, err = c.value(columns); err != nil {
+		return
+	}
+
+	var cmd command
+	switch c.typ {
+	case TypeList:
+		cmd = command{name: "RPUSH", args: []interface{}{key, value}}
+	case TypeZSet:
+		var score string
+		if score, err = c.score(record); err != nil {
+			return
+		}
+		cmd = command{name: "ZADD", args: []interface{}{key, score, value}}
+	default:
+		cmd = command{name: "SET", args: []interface{}{key, value}}
+		if c.ttl > 0 {
+			cmd.args = append(cmd.args, "PX", c.ttl.Milliseconds())
+		}
+		return []command{cmd}, nil
+	}
+	return c.withTTL(key, cmd), nil
+}
+
+//hashCommands 生成将列columns写入哈希key的命令，每列一个字段，空值的列不写入，
+//所有列都为空值时不生成命令
+func (c *commander) hashCommands(key string, columns []element.Column) (cmds []command, err error) {
+	cmd := command{name: "HSET", args: []interface{}{key}}
+	for _, v := range columns {
+		if v.IsNil() {
+			continue
+		}
+		var s string
+		if s, err = c.text(v); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", v.Name(), err)
+		}
+		cmd.args = append(cmd.args, v.Name(), s)
+	}
+	if len(cmd.args) == 1 {
+		return nil, nil
+	}
+	return c.withTTL(key, cmd), nil
+}
+
+//withTTL 设置过期时间时在命令cmd后追加设置键key过期时间的命令
+func (c *commander) withTTL(key string, cmd command) []command {
+	cmds := []command{cmd}
+	if c.ttl > 0 {
+		cmds = append(cmds, command{name: "PEXPIRE", args: []interface{}{key, c.ttl.Milliseconds()}})
+	}
+	return cmds
+}
+
+//columns 获取记录record中写入的列，设置了列名时只获取这些列
+func (c *commander) columns(record element.Record) (columns []element.Column, err error) {
+	if len(c.column) == 0 {
+		columns = make([]element.Column, record.ColumnNumber())
+		for i := range columns {
+			if columns[i], err = record.GetByIndex(i); err != nil {
+				return nil, err
+			}
+		}
+		return
+	}
+
+	columns = make([]element.Column, len(c.column))
+	for i, v := range c.column {
+		if columns[i], err = record.GetByName(v); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+//score 获取记录record中zset的分值
+func (c *commander) score(record element.Record) (string, error) {
+	col, err := record.GetByName(c.scoreColumn)
+	if err != nil {
+		return "", err
+	}
+	if col.IsNil() {
+		return "", fmt.Errorf("score column(%v) is nil", c.scoreColumn)
+	}
+	d, err := col.AsDecimal()
+	if err != nil {
+		return "", fmt.Errorf("score column(%v) err: %v", c.scoreColumn, err)
+	}
+	return d.String(), nil
+}
+
+//value 将列columns序列化为值，json格式为以列名为键的JSON对象，text格式为第一列的文本，空值为空字符串
+func (c *commander) value(columns []element.Column) (string, error) {
+	if c.format == FormatText {
+		if columns[0].IsNil() {
+			return "", nil
+		}
+		s, err := c.text(columns[0])
+		if err != nil {
+			return "", fmt.Errorf("column(%v) err: %v", columns[0].Name(), err)
+		}
+		return s, nil
+	}
+
+	c.buf.Reset()
+	c.buf.WriteByte('{')
+	for i, v := range columns {
+		if i > 0 {
+			c.buf.WriteByte(',')
+		}
+		if err := c.writeString(v.Name()); err != nil {
+			return "", err
+		}
+		c.buf.WriteByte(':')
+		if err := c.writeValue(v); err != nil {
+			return "", fmt.Errorf("column(%v) err: %v", v.Name(), err)
+		}
+	}
+	c.buf.WriteByte('}')
+	return c.buf.String(), nil
+}
+
+//text 将非空的列col转化为文本，时间按照时间格式转化，字节流按原样转化
+func (c *commander) text(col element.Column) (string, error) {
+	switch col.Type() {
+	case element.TypeTime:
+		tm, err := col.AsTime()
+		if err != nil {
+			return "", err
+		}
+		v, err := c.decoder.TimeDecode(tm)
+		if err != nil {
+			return "", err
+		}
+		return v.(string), nil
+	case element.TypeBytes:
+		b, err := col.AsBytes()
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+	return col.AsString()
+}
+
+//writeValue 将列col的值以JSON格式写入缓存，整数和高精度实数按原样写入JSON数字
+func (c *commander) writeValue(col element.Column) (err error) {
+	if col.IsNil() {
+		c.buf.WriteString("null")
+		return
+	}
+
+	switch col.Type() {
+	case element.TypeBool, element.TypeBigInt, element.TypeDecimal:
+		var s string
+		if s, err = col.AsString(); err != nil {
+			return
+		}
+		c.buf.WriteString(s)
+		return
+	}
+
+	var s string
+	if s, err = c.text(col); err != nil {
+		return
+	}
+	return c.writeString(s)
+}
+
+//writeString 将字符串s以JSON字符串写入缓存
+func (c *commander) writeString(s string) (err error) {
+	if err = c.enc.Encode(s); err != nil {
+		return
+	}
+	//json.Encoder会在末尾添加换行符
+	c.buf.Truncate(c.buf.Len() - 1)
+	return
+}
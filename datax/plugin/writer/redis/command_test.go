@@ -0,0 +1,160 @@
+package redis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go/time2"
+)
+
+func TestCommander_Commands(t *testing.T) {
+	tests := []struct {
+		name   string
+		param  *paramConfig
+		record element.Record
+		want   []command
+	}{
+		{
+			name:   "1",
+			param:  &paramConfig{Key: "user:{id}"},
+			record: testRecord(testColumns()...),
+			want: []command{
+				{name: "SET", args: []interface{}{"user:123456789012345678901234567890",
+					`{"id":123456789012345678901234567890,"name":"a\"<b>","nil":null,"time":"2021-01-02T03:04:05Z",` +
+						`"bool":true,"bytes":"xyz","decimal":0.10000000000000000001,"false":false}`}},
+			},
+		},
+		{
+			name:   "2",
+			param:  &paramConfig{Key: "user:{time|20060102}", Format: FormatText, Column: []string{"time"}, DateFormat: "2006-01-02", TTL: time2.NewDuration(time.Minute)},
+			record: testRecord(testColumns()...),
+			want: []command{
+				{name: "SET", args: []interface{}{"user:20210102", "2021-01-02", "PX", int64(60000)}},
+			},
+		},
+		{
+			name:   "3",
+			param:  &paramConfig{Key: "user:{id}", Type: TypeHash, Column: []string{"name", "nil", "bytes", "decimal"}, TTL: time2.NewDuration(time.Second)},
+			record: testRecord(testColumns()...),
+			want: []command{
+				{name: "HSET", args: []interface{}{"user:123456789012345678901234567890",
+					"name", `a"<b>`, "bytes", "xyz", "decimal", "0.10000000000000000001"}},
+				{name: "PEXPIRE", args: []interface{}{"user:123456789012345678901234567890", int64(1000)}},
+			},
+		},
+		{
+			name:   "4",
+			param:  &paramConfig{Key: "user:{id}", Type: TypeHash, Column: []string{"nil"}},
+			record: testRecord(testColumns()...),
+		},
+		{
+			name:   "5",
+			param:  &paramConfig{Key: "names", Type: TypeList, Format: FormatText, Column: []string{"nil"}},
+			record: testRecord(testColumns()...),
+			want: []command{
+				{name: "RPUSH", args: []interface{}{"names", ""}},
+			},
+		},
+		{
+			name:   "6",
+			param:  &paramConfig{Key: "names", Type: TypeZSet, ScoreColumn: "decimal", Column: []string{"name", "bool"}, TTL: time2.NewDuration(time.Second)},
+			record: testRecord(testColumns()...),
+			want: []command{
+				{name: "ZADD", args: []interface{}{"names", "0.10000000000000000001", `{"name":"a\"<b>","bool":true}`}},
+				{name: "PEXPIRE", args: []interface{}{"names", int64(1000)}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newCommander(tt.param)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := c.Commands(tt.record)
+			if err != nil {
+				t.Fatalf("commander.Commands() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("commander.Commands() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//mockTimeValue 类型为时间但是无法转化为时间的列值
+type mockTimeValue struct {
+	element.ColumnValue
+}
+
+func (m *mockTimeValue) Type() element.ColumnType {
+	return element.TypeTime
+}
+
+func TestCommander_CommandsErr(t *testing.T) {
+	mock := testRecord(element.NewDefaultColumn(&mockTimeValue{
+		ColumnValue: element.NewStringColumnValue("abc"),
+	}, "time", 0))
+	tests := []struct {
+		name   string
+		param  *paramConfig
+		record element.Record
+	}{
+		{
+			name:   "1",
+			param:  &paramConfig{Key: "test"},
+			record: mock,
+		},
+		{
+			name:   "2",
+			param:  &paramConfig{Key: "test", Format: FormatText, Column: []string{"time"}},
+			record: mock,
+		},
+		{
+			name:   "3",
+			param:  &paramConfig{Key: "test", Type: TypeHash},
+			record: mock,
+		},
+		{
+			name:   "4",
+			param:  &paramConfig{Key: "test", Column: []string{"none"}},
+			record: testRecord(testColumns()...),
+		},
+		{
+			name:   "5",
+			param:  &paramConfig{Key: "{nil}"},
+			record: testRecord(testColumns()...),
+		},
+		{
+			name:   "6",
+			param:  &paramConfig{Key: "test", Type: TypeZSet, ScoreColumn: "none"},
+			record: testRecord(testColumns()...),
+		},
+		{
+			name:   "7",
+			param:  &paramConfig{Key: "test", Type: TypeZSet, ScoreColumn: "nil"},
+			record: testRecord(testColumns()...),
+		},
+		{
+			name:   "8",
+			param:  &paramConfig{Key: "test", Type: TypeZSet, ScoreColumn: "name"},
+			record: testRecord(testColumns()...),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newCommander(tt.param)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = c.Commands(tt.record); err == nil {
+				t.Errorf("commander.Commands() error = nil, wantErr true")
+			}
+		})
+	}
+	if _, err := newCommander(&paramConfig{}); err == nil {
+		t.Errorf("newCommander() error = nil, wantErr true")
+	}
+}
@@ -0,0 +1,202 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go/time2"
+	"github.com/gomodule/redigo/redis"
+)
+
+//数据类型
+const (
+	TypeString = "string" //字符串，键对应序列化后的值，默认的类型
+	TypeHash   = "hash"   //哈希，每列一个字段
+	TypeList   = "list"   //列表，序列化后的值追加到列表末尾
+	TypeZSet   = "zset"   //有序集合，序列化后的值作为成员，scoreColumn作为分值
+)
+
+//值的序列化格式
+const (
+	FormatJSON = "json" //以列名为键的JSON对象，默认的格式
+	FormatText = "text" //单列的文本
+)
+
+//默认值
+const (
+	defaultBatchSize    = 1000
+	defaultBatchTimeout = 1 * time.Second
+	defaultTimeout      = 30 * time.Second
+	defaultScanCount    = 1000
+)
+
+type paramConfig struct {
+	Address      string         `json:"address"`      //redis地址，如127.0.0.1:6379
+	Password     string         `json:"password"`     //密码
+	DB           int            `json:"db"`           //数据库序号
+	Timeout      string         `json:"timeout"`      //连接，读和写的超时时间，使用go的时间间隔格式，默认为30s
+	Type         string         `json:"type"`         //数据类型，支持string，hash，list和zset，默认为string
+	Key          string         `json:"key"`          //键模板，{列名}替换为列值，{列名|时间格式}替换为按go的时间格式格式化的时间列，如user:{id}
+	Column       []string       `json:"column"`       //写入的列名，为空时写入记录的所有列
+	Format       string         `json:"format"`       //值的序列化格式，支持json和text，text要求只写入一列，默认为json，对hash无效
+	ScoreColumn  string         `json:"scoreColumn"`  //zset的分值列名
+	DateFormat   string         `json:"dateFormat"`   //时间格式，使用go的时间格式，默认为time.RFC3339Nano
+	TTL          time2.Duration `json:"ttl"`          //键的过期时间，为0时不过期
+	BatchSize    int            `json:"batchSize"`    //每批次的记录数，每批次的命令通过管道一起发送，默认为1000
+	BatchTimeout time2.Duration `json:"batchTimeout"` //批次的最长等待时间，默认为1s
+	Verify       *verifyConfig  `json:"verify"`       //Job.Post中的校验配置，为空时不校验
+	TaskID       *int           `json:"taskID"`       //由Job.Split生成的任务ID
+}
+
+//verifyConfig 校验配置，在写入完成后通过SCAN统计匹配pattern的键数，
+//list和zset统计这些键中的元素总数，计数小于minCount时报错
+type verifyConfig struct {
+	Pattern  string `json:"pattern"`  //SCAN的匹配模式，默认由键模板生成，列替换为*
+	MinCount int64  `json:"minCount"` //最少的计数
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	if p.Address == "" {
+		return fmt.Errorf("address is empty")
+	}
+	if p.DB < 0 {
+		return fmt.Errorf("db(%v) is less than 0", p.DB)
+	}
+	if _, err = p.timeout(); err != nil {
+		return
+	}
+
+	switch p.typ() {
+	case TypeString, TypeHash, TypeList:
+	case TypeZSet:
+		if p.ScoreColumn == "" {
+			return fmt.Errorf("scoreColumn is empty when type is %v", TypeZSet)
+		}
+	default:
+		return fmt.Errorf("type(%v) is not supported", p.Type)
+	}
+
+	if _, err = newKeyTemplate(p.Key); err != nil {
+		return
+	}
+
+	for i, v := range p.Column {
+		if v == "" {
+			return fmt.Errorf("column(%v) is empty", i)
+		}
+	}
+
+	switch p.format() {
+	case FormatJSON:
+	case FormatText:
+		if len(p.Column) != 1 && p.typ() != TypeHash {
+			return fmt.Errorf("column(%v) is not one column when format is %v", p.Column, FormatText)
+		}
+	default:
+		return fmt.Errorf("format(%v) is not supported", p.Format)
+	}
+
+	if p.TTL.Duration < 0 {
+		return fmt.Errorf("ttl(%v) is less than 0", p.TTL.Duration)
+	}
+	if p.BatchTimeout.Duration < 0 {
+		return fmt.Errorf("batchTimeout(%v) is less than 0", p.BatchTimeout.Duration)
+	}
+	return
+}
+
+//typ 获取数据类型，默认为string
+func (p *paramConfig) typ() string {
+	if p.Type == "" {
+		return TypeString
+	}
+	return p.Type
+}
+
+//format 获取值的序列化格式，默认为json
+func (p *paramConfig) format() string {
+	if p.Format == "" {
+		return FormatJSON
+	}
+	return p.Format
+}
+
+//layout 获取时间格式，默认为time.RFC3339Nano
+func (p *paramConfig) layout() string {
+	if p.DateFormat == "" {
+		return time.RFC3339Nano
+	}
+	return p.DateFormat
+}
+
+//timeout 获取连接，读和写的超时时间，默认为30s
+func (p *paramConfig) timeout() (time.Duration, error) {
+	if p.Timeout == "" {
+		return defaultTimeout, nil
+	}
+	d, err := time.ParseDuration(p.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("timeout(%v) err: %v", p.Timeout, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("timeout(%v) is not positive", p.Timeout)
+	}
+	return d, nil
+}
+
+//pattern 获取校验时SCAN的匹配模式
+func (p *paramConfig) pattern() (string, error) {
+	if p.Verify != nil && p.Verify.Pattern != "" {
+		return p.Verify.Pattern, nil
+	}
+	t, err := newKeyTemplate(p.Key)
+	if err != nil {
+		return "", err
+	}
+	return t.pattern(), nil
+}
+
+func (p *paramConfig) getBatchSize() int {
+	if p.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return p.BatchSize
+}
+
+func (p *paramConfig) getBatchTimeout() time.Duration {
+	if p.BatchTimeout.Duration == 0 {
+		return defaultBatchTimeout
+	}
+	return p.BatchTimeout.Duration
+}
+
+//dial 连接redis
+func (p *paramConfig) dial() (conn redis.Conn, err error) {
+	var timeout time.Duration
+	if timeout, err = p.timeout(); err != nil {
+		return
+	}
+	if conn, err = redis.Dial("tcp", p.Address,
+		redis.DialPassword(p.Password),
+		redis.DialDatabase(p.DB),
+		redis.DialConnectTimeout(timeout),
+		redis.DialReadTimeout(timeout),
+		redis.DialWriteTimeout(timeout)); err != nil {
+		return nil, fmt.Errorf("address(%v) err: %v", p.Address, err)
+	}
+	return
+}
@@ -0,0 +1,187 @@
+package redis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"address":"127.0.0.1:6379","key":"user:{id}","type":"zset","scoreColumn":"score","ttl":"1h","batchTimeout":"2s","verify":{"minCount":1}}`,
+		},
+		{
+			name: "2",
+			json: `{"address":"127.0.0.1:6379","key":"user:{id}","type":"hash","format":"text"}`,
+		},
+		{
+			name: "3",
+			json: `{"address":"127.0.0.1:6379","key":"user:{id}","format":"text","column":["name"]}`,
+		},
+		{
+			name:    "4",
+			json:    `{"key":"user:{id}"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"address":"127.0.0.1:6379","db":-1,"key":"user:{id}"}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"address":"127.0.0.1:6379","key":"user:{id}","timeout":"0s"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"address":"127.0.0.1:6379","key":"user:{id}","type":"set"}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			json:    `{"address":"127.0.0.1:6379","key":"user:{id}","type":"zset"}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			json:    `{"address":"127.0.0.1:6379"}`,
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			json:    `{"address":"127.0.0.1:6379","key":"user:{id}","column":[""]}`,
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			json:    `{"address":"127.0.0.1:6379","key":"user:{id}","format":"text"}`,
+			wantErr: true,
+		},
+		{
+			name:    "12",
+			json:    `{"address":"127.0.0.1:6379","key":"user:{id}","format":"xml"}`,
+			wantErr: true,
+		},
+		{
+			name:    "13",
+			json:    `{"address":"127.0.0.1:6379","key":"user:{id}","ttl":"-1s"}`,
+			wantErr: true,
+		},
+		{
+			name:    "14",
+			json:    `{"address":"127.0.0.1:6379","key":"user:{id}","batchTimeout":"-1s"}`,
+			wantErr: true,
+		},
+		{
+			name:    "15",
+			json:    `{"address":"127.0.0.1:6379","key":"user:{id}","timeout":"1x"}`,
+			wantErr: true,
+		},
+		{
+			name:    "16",
+			json:    `{"address":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_defaults(t *testing.T) {
+	p := &paramConfig{Key: "user:{id}"}
+	if got := p.typ(); got != TypeString {
+		t.Errorf("paramConfig.typ() = %v, want %v", got, TypeString)
+	}
+	if got := p.format(); got != FormatJSON {
+		t.Errorf("paramConfig.format() = %v, want %v", got, FormatJSON)
+	}
+	if got := p.layout(); got != time.RFC3339Nano {
+		t.Errorf("paramConfig.layout() = %v, want %v", got, time.RFC3339Nano)
+	}
+	if got, _ := p.timeout(); got != defaultTimeout {
+		t.Errorf("paramConfig.timeout() = %v, want %v", got, defaultTimeout)
+	}
+	if got, _ := p.pattern(); got != "user:*" {
+		t.Errorf("paramConfig.pattern() = %v, want user:*", got)
+	}
+	if got := p.getBatchSize(); got != defaultBatchSize {
+		t.Errorf("paramConfig.getBatchSize() = %v, want %v", got, defaultBatchSize)
+	}
+	if got := p.getBatchTimeout(); got != defaultBatchTimeout {
+		t.Errorf("paramConfig.getBatchTimeout() = %v, want %v", got, defaultBatchTimeout)
+	}
+
+	p = &paramConfig{Key: "user:{id}", Type: TypeList, Format: FormatText, DateFormat: "2006-01-02",
+		Timeout: "5s", BatchSize: 10, Verify: &verifyConfig{Pattern: "user:1*"}}
+	if got := p.typ(); got != TypeList {
+		t.Errorf("paramConfig.typ() = %v, want %v", got, TypeList)
+	}
+	if got := p.format(); got != FormatText {
+		t.Errorf("paramConfig.format() = %v, want %v", got, FormatText)
+	}
+	if got := p.layout(); got != "2006-01-02" {
+		t.Errorf("paramConfig.layout() = %v, want 2006-01-02", got)
+	}
+	if got, _ := p.timeout(); got != 5*time.Second {
+		t.Errorf("paramConfig.timeout() = %v, want 5s", got)
+	}
+	if got, _ := p.pattern(); got != "user:1*" {
+		t.Errorf("paramConfig.pattern() = %v, want user:1*", got)
+	}
+	if got := p.getBatchSize(); got != 10 {
+		t.Errorf("paramConfig.getBatchSize() = %v, want 10", got)
+	}
+
+	p = &paramConfig{Key: "{id"}
+	if _, err := p.pattern(); err == nil {
+		t.Errorf("paramConfig.pattern() error = nil, wantErr true")
+	}
+}
+
+func TestParamConfig_dial(t *testing.T) {
+	ts := newTestServer("pass")
+	defer ts.Close()
+	tests := []struct {
+		name    string
+		p       *paramConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			p:    &paramConfig{Address: ts.Addr(), Password: "pass", DB: 1},
+		},
+		{
+			name:    "2",
+			p:       &paramConfig{Address: ts.Addr(), Password: "wrong"},
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			p:       &paramConfig{Address: ts.Addr(), Timeout: "1x"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := tt.p.dial()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("paramConfig.dial() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				conn.Close()
+			}
+		})
+	}
+}
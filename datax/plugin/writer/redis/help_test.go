@@ -0,0 +1,283 @@
+package redis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockReceiver struct {
+	records []element.Record
+	err     error
+	sleep   time.Duration //每次获取记录前的等待时间
+}
+
+func (m *mockReceiver) GetFromReader() (element.Record, error) {
+	time.Sleep(m.sleep)
+	if len(m.records) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, exchange.ErrTerminate
+	}
+	r := m.records[0]
+	m.records = m.records[1:]
+	return r, nil
+}
+
+func (m *mockReceiver) Shutdown() error {
+	return nil
+}
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+//testJobConf 生成写入器参数为param的工作配置
+func testJobConf(param string) *config.JSON {
+	return testJSONFromString(`{
+		"job":{
+			"content":[
+				{
+					"writer": {
+						"name":"rediswriter",
+						"parameter":` + param + `
+					}
+				}
+			]
+		}
+	}`)
+}
+
+//testRecord 生成列为columns的记录
+func testRecord(columns ...element.Column) element.Record {
+	r := element.NewDefaultRecord()
+	for _, c := range columns {
+		r.Add(c)
+	}
+	return r
+}
+
+func testColumns() []element.Column {
+	i, _ := element.NewBigIntColumnValueFromString("123456789012345678901234567890")
+	d, _ := element.NewDecimalColumnValueFromString("0.10000000000000000001")
+	return []element.Column{
+		element.NewDefaultColumn(i, "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue(`a"<b>`), "name", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)), "time", 0),
+		element.NewDefaultColumn(element.NewBoolColumnValue(true), "bool", 0),
+		element.NewDefaultColumn(element.NewBytesColumnValue([]byte("xyz")), "bytes", 0),
+		element.NewDefaultColumn(d, "decimal", 0),
+		element.NewDefaultColumn(element.NewBoolColumnValue(false), "false", 0),
+	}
+}
+
+//mockCollector 收集脏记录的任务收集器
+type mockCollector struct {
+	records []element.Record
+	errs    []string
+}
+
+func (m *mockCollector) CollectDirtyRecordWithError(record element.Record, err error) {
+	m.records = append(m.records, record)
+	m.errs = append(m.errs, err.Error())
+}
+
+func (m *mockCollector) CollectDirtyRecordWithMsg(record element.Record, msgErr string) {}
+
+func (m *mockCollector) CollectDirtyRecord(record element.Record, err error, msgErr string) {}
+
+func (m *mockCollector) CollectMessage(key string, value string) {}
+
+//testServer redis协议的本地替身，支持测试用到的命令，以fail开头的键写入失败，
+//记录收到的写入命令
+type testServer struct {
+	listener net.Listener
+	password string
+
+	mu      sync.Mutex
+	cmds    []string            //收到的写入命令，参数以空格连接
+	strings map[string]string   //字符串
+	hashes  map[string][]string //哈希的字段和值
+	lists   map[string][]string //列表
+	zsets   map[string][]string //有序集合的分值和成员
+}
+
+func newTestServer(password string) *testServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	s := &testServer{
+		listener: l,
+		password: password,
+		strings:  make(map[string]string),
+		hashes:   make(map[string][]string),
+		lists:    make(map[string][]string),
+		zsets:    make(map[string][]string),
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+//Addr 获取地址
+func (s *testServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+//Close 关闭
+func (s *testServer) Close() {
+	s.listener.Close()
+}
+
+func (s *testServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		reply := s.do(args)
+		s.mu.Unlock()
+		w.WriteString(reply)
+		//管道中的命令一起读取后再一起回复
+		if r.Buffered() == 0 {
+			if err = w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+//readCommand 读取由多行字符串数组组成的命令
+func readCommand(r *bufio.Reader) (args []string, err error) {
+	var line string
+	if line, err = readLine(r); err != nil {
+		return
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("invalid command(%v)", line)
+	}
+	var n int
+	if n, err = strconv.Atoi(line[1:]); err != nil {
+		return
+	}
+	for i := 0; i < n; i++ {
+		if line, err = readLine(r); err != nil {
+			return
+		}
+		var size int
+		if size, err = strconv.Atoi(strings.TrimPrefix(line, "$")); err != nil {
+			return
+		}
+		b := make([]byte, size+2)
+		if _, err = io.ReadFull(r, b); err != nil {
+			return
+		}
+		args = append(args, string(b[:size]))
+	}
+	return
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
+
+func (s *testServer) do(args []string) string {
+	name := strings.ToUpper(args[0])
+	switch name {
+	case "AUTH":
+		if args[1] != s.password {
+			return "-ERR invalid password\r\n"
+		}
+		return "+OK\r\n"
+	case "SELECT":
+		return "+OK\r\n"
+	case "PING":
+		return "+PONG\r\n"
+	case "SCAN":
+		return s.scan(args[3])
+	case "LLEN":
+		return fmt.Sprintf(":%v\r\n", len(s.lists[args[1]]))
+	case "ZCARD":
+		return fmt.Sprintf(":%v\r\n", len(s.zsets[args[1]])/2)
+	}
+
+	if strings.HasPrefix(args[1], "fail") {
+		return "-ERR mock error\r\n"
+	}
+	s.cmds = append(s.cmds, strings.Join(args, " "))
+	key := args[1]
+	switch name {
+	case "SET":
+		s.strings[key] = args[2]
+		return "+OK\r\n"
+	case "HSET":
+		s.hashes[key] = append(s.hashes[key], args[2:]...)
+		return fmt.Sprintf(":%v\r\n", len(args[2:])/2)
+	case "RPUSH":
+		s.lists[key] = append(s.lists[key], args[2:]...)
+		return fmt.Sprintf(":%v\r\n", len(s.lists[key]))
+	case "ZADD":
+		s.zsets[key] = append(s.zsets[key], args[2:]...)
+		return fmt.Sprintf(":%v\r\n", len(args[2:])/2)
+	case "PEXPIRE":
+		return ":1\r\n"
+	}
+	return fmt.Sprintf("-ERR unknown command '%v'\r\n", args[0])
+}
+
+//scan 在一次SCAN中返回所有匹配pattern的键
+func (s *testServer) scan(pattern string) string {
+	var keys []string
+	for _, m := range []map[string][]string{s.hashes, s.lists, s.zsets} {
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	for k := range s.strings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	n := 0
+	for _, k := range keys {
+		if ok, _ := filepath.Match(pattern, k); ok {
+			fmt.Fprintf(&b, "$%v\r\n%v\r\n", len(k), k)
+			n++
+		}
+	}
+	return fmt.Sprintf("*2\r\n$1\r\n0\r\n*%v\r\n%v", n, b.String())
+}
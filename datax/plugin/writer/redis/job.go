@@ -0,0 +1,119 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/gomodule/redigo/redis"
+)
+
+//Job 工作
+type Job struct {
+	*plugin.BaseJob
+
+	param *paramConfig
+}
+
+//Init 初始化
+func (j *Job) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
+		return
+	}
+
+	j.param, err = newParamConfig(paramConf)
+	return
+}
+
+//Destroy 销毁
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//Split 切分成number个任务
+func (j *Job) Split(ctx context.Context, number int) (confs []*config.JSON, err error) {
+	if number <= 0 {
+		number = 1
+	}
+	for i := 0; i < number; i++ {
+		conf := j.PluginJobConf().CloneConfig()
+		if err = conf.Set(coreconst.DataxJobContentWriterParameter+".taskID", i); err != nil {
+			return nil, err
+		}
+		confs = append(confs, conf)
+	}
+	return
+}
+
+//Post 后置通知，设置了校验配置时通过SCAN统计匹配的键数，list和zset统计这些键中的元素总数，
+//计数小于minCount时报错
+func (j *Job) Post(ctx context.Context) (err error) {
+	if j.param.Verify == nil {
+		return
+	}
+
+	var pattern string
+	if pattern, err = j.param.pattern(); err != nil {
+		return
+	}
+
+	var conn redis.Conn
+	if conn, err = j.param.dial(); err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var count int64
+	if count, err = j.count(ctx, conn, pattern); err != nil {
+		return fmt.Errorf("verify pattern(%v) err: %v", pattern, err)
+	}
+	log.Infof("rediswriter verify pattern(%v) type(%v) count: %v", pattern, j.param.typ(), count)
+	if count < j.param.Verify.MinCount {
+		return fmt.Errorf("verify pattern(%v) count(%v) is less than minCount(%v)",
+			pattern, count, j.param.Verify.MinCount)
+	}
+	return
+}
+
+//count 统计匹配pattern的键数，list和zset统计这些键中的元素总数
+func (j *Job) count(ctx context.Context, conn redis.Conn, pattern string) (count int64, err error) {
+	cursor := "0"
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		default:
+		}
+
+		var reply []interface{}
+		if reply, err = redis.Values(conn.Do("SCAN", cursor, "MATCH", pattern, "COUNT", defaultScanCount)); err != nil {
+			return
+		}
+		var keys []string
+		if _, err = redis.Scan(reply, &cursor, &keys); err != nil {
+			return
+		}
+
+		for _, key := range keys {
+			var n int64
+			switch j.param.typ() {
+			case TypeList:
+				n, err = redis.Int64(conn.Do("LLEN", key))
+			case TypeZSet:
+				n, err = redis.Int64(conn.Do("ZCARD", key))
+			default:
+				n = 1
+			}
+			if err != nil {
+				return
+			}
+			count += n
+		}
+		if cursor == "0" {
+			return
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+)
+
+func testJob(t *testing.T, param string) *Job {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	j.SetPluginJobConf(testJobConf(param))
+	if err := j.Init(context.TODO()); err != nil {
+		t.Fatalf("Job.Init() error = %v", err)
+	}
+	return j
+}
+
+func TestJob_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJobConf(`{"address":"127.0.0.1:6379","key":"user:{id}"}`),
+		},
+		{
+			name:    "2",
+			jobConf: testJobConf(`{"address":"127.0.0.1:6379"}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJSONFromString(`{}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				BaseJob: plugin.NewBaseJob(),
+			}
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJob_Split(t *testing.T) {
+	tests := []struct {
+		name   string
+		number int
+		want   int
+	}{
+		{
+			name:   "1",
+			number: 3,
+			want:   3,
+		},
+		{
+			name:   "2",
+			number: 0,
+			want:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := testJob(t, `{"address":"127.0.0.1:6379","key":"user:{id}"}`)
+			confs, err := j.Split(context.TODO(), tt.number)
+			if err != nil {
+				t.Fatalf("Job.Split() error = %v", err)
+			}
+			if len(confs) != tt.want {
+				t.Fatalf("Job.Split() = %v, want %v confs", len(confs), tt.want)
+			}
+			for i, conf := range confs {
+				id, err := conf.GetInt64(coreconst.DataxJobContentWriterParameter + ".taskID")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if id != int64(i) {
+					t.Errorf("Job.Split() taskID = %v, want %v", id, i)
+				}
+			}
+		})
+	}
+}
+
+func TestJob_Post(t *testing.T) {
+	ts := newTestServer("")
+	defer ts.Close()
+	for _, args := range [][]string{
+		{"SET", "user:1", "a"},
+		{"SET", "user:2", "b"},
+		{"SET", "order:1", "c"},
+		{"RPUSH", "names", "a", "b", "c"},
+		{"ZADD", "scores", "1", "a", "2", "b"},
+	} {
+		ts.do(args)
+	}
+
+	tests := []struct {
+		name    string
+		param   string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			param: `"key":"user:{id}"`,
+		},
+		{
+			name:  "2",
+			param: `"key":"user:{id}","verify":{"minCount":2}`,
+		},
+		{
+			name:    "3",
+			param:   `"key":"user:{id}","verify":{"minCount":3}`,
+			wantErr: true,
+		},
+		{
+			name:  "4",
+			param: `"key":"user:{id}","verify":{"pattern":"*:1","minCount":2}`,
+		},
+		{
+			name:  "5",
+			param: `"key":"names","type":"list","verify":{"minCount":3}`,
+		},
+		{
+			name:    "6",
+			param:   `"key":"names","type":"list","verify":{"minCount":4}`,
+			wantErr: true,
+		},
+		{
+			name:  "7",
+			param: `"key":"scores","type":"zset","scoreColumn":"id","verify":{"minCount":2}`,
+		},
+		{
+			name:    "8",
+			param:   `"key":"scores","type":"zset","scoreColumn":"id","verify":{"minCount":3}`,
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			param:   `"key":"user:{id}","password":"pass","verify":{"minCount":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := testJob(t, `{"address":"`+ts.Addr()+`",`+tt.param+`}`)
+			if err := j.Post(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Post() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	j := testJob(t, `{"address":"`+ts.Addr()+`","key":"user:{id}","verify":{}}`)
+	if err := j.Post(ctx); err == nil {
+		t.Errorf("Job.Post() error = nil, wantErr true")
+	}
+}
+
+func TestJob_Destroy(t *testing.T) {
+	j := &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+	if err := j.Destroy(context.TODO()); err != nil {
+		t.Errorf("Job.Destroy() error = %v", err)
+	}
+}
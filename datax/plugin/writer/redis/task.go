@@ -0,0 +1,117 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/gomodule/redigo/redis"
+)
+
+//Task 任务
+type Task struct {
+	*writer.BaseTask
+
+	param     *paramConfig
+	conn      redis.Conn
+	commander *commander
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.param.TaskID != nil {
+		t.SetTaskID(*t.param.TaskID)
+	}
+	if t.commander, err = newCommander(t.param); err != nil {
+		return
+	}
+	if t.conn, err = t.param.dial(); err != nil {
+		return
+	}
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	if t.conn != nil {
+		err = t.conn.Close()
+	}
+	return
+}
+
+//StartWrite 开始写，记录数达到batchSize或者每隔batchTimeout发送一批记录，
+//收到终止记录后发送剩余的记录
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) error {
+	return plugin.BatchWrite(ctx, receiver, plugin.BatchOptions{
+		BatchSize:    t.param.getBatchSize(),
+		BatchTimeout: t.param.getBatchTimeout(),
+	}, nil, func(records []element.Record) error {
+		return t.send(records)
+	})
+}
+
+//send 将一批记录records转化为命令后通过管道一起发送，再依次读取所有命令的回复，
+//转化失败的记录作为脏记录处理，返回第一个执行失败的命令的错误
+func (t *Task) send(records []element.Record) (err error) {
+	var cmds []command
+	for _, r := range records {
+		var rc []command
+		if rc, err = t.commander.Commands(r); err != nil {
+			if err = t.dirty(r, err); err != nil {
+				return
+			}
+			continue
+		}
+		cmds = append(cmds, rc...)
+	}
+	if len(cmds) == 0 {
+		return
+	}
+
+	for _, c := range cmds {
+		if err = t.conn.Send(c.name, c.args...); err != nil {
+			return fmt.Errorf("command(%v) err: %v", c.name, err)
+		}
+	}
+	if err = t.conn.Flush(); err != nil {
+		return
+	}
+	log.Debugf("rediswriter task(%v) send %v records, %v commands", t.TaskID(), len(records), len(cmds))
+
+	//需要读取所有的回复，否则连接中会残留未读取的回复
+	for _, c := range cmds {
+		_, rerr := t.conn.Receive()
+		if rerr == nil {
+			continue
+		}
+		if _, ok := rerr.(redis.Error); !ok {
+			return fmt.Errorf("command(%v) err: %v", c.name, rerr)
+		}
+		if err == nil {
+			err = fmt.Errorf("command(%v %v) err: %v", c.name, c.args[0], rerr)
+		}
+	}
+	return
+}
+
+//dirty 处理脏记录record，设置了任务收集器时收集脏记录并继续写入，否则返回错误err
+func (t *Task) dirty(record element.Record, err error) error {
+	if collector := t.TaskCollector(); collector != nil {
+		collector.CollectDirtyRecordWithError(record, err)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,234 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testTask(t *testing.T, param string) *Task {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+//testDoc 生成id和name列的记录
+func testDoc(id int64, name string) element.Record {
+	return testRecord(
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(id), "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue(name), "name", 0),
+	)
+}
+
+func TestTask_Init(t *testing.T) {
+	ts := newTestServer("")
+	defer ts.Close()
+	tests := []struct {
+		name       string
+		param      string
+		wantTaskID int
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			param:      `{"address":"` + ts.Addr() + `","key":"user:{id}","taskID":3}`,
+			wantTaskID: 3,
+		},
+		{
+			name:  "2",
+			param: `{"address":"` + ts.Addr() + `","key":"user:{id}"}`,
+		},
+		{
+			name:    "3",
+			param:   `{"address":"` + ts.Addr() + `","key":"user:{id"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			param:   `{"address":"` + ts.Addr() + `","key":"user:{id}","password":"pass"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: writer.NewBaseTask(),
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			defer task.Destroy(context.TODO())
+			if err := task.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Fatalf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if task.TaskID() != tt.wantTaskID {
+				t.Errorf("Task.TaskID() = %v, want %v", task.TaskID(), tt.wantTaskID)
+			}
+		})
+	}
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	tests := []struct {
+		name      string
+		param     string
+		receiver  *mockReceiver
+		want      []string
+		wantDirty []string
+	}{
+		{
+			name:     "1",
+			param:    `"key":"user:{id}","batchSize":2`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a"), testDoc(2, "b"), testDoc(3, "c")}},
+			want: []string{
+				`SET user:1 {"id":1,"name":"a"}`,
+				`SET user:2 {"id":2,"name":"b"}`,
+				`SET user:3 {"id":3,"name":"c"}`,
+			},
+		},
+		{
+			name:     "2",
+			param:    `"key":"user:{id}","type":"hash","column":["name"],"ttl":"1s"`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a"), testDoc(2, "b")}},
+			want: []string{
+				`HSET user:1 name a`,
+				`PEXPIRE user:1 1000`,
+				`HSET user:2 name b`,
+				`PEXPIRE user:2 1000`,
+			},
+		},
+		{
+			name:     "3",
+			param:    `"key":"names","type":"list","format":"text","column":["name"]`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a"), testDoc(2, "b")}},
+			want: []string{
+				`RPUSH names a`,
+				`RPUSH names b`,
+			},
+		},
+		{
+			name:     "4",
+			param:    `"key":"names","type":"zset","scoreColumn":"id","format":"text","column":["name"]`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a"), testDoc(2, "b")}},
+			want: []string{
+				`ZADD names 1 a`,
+				`ZADD names 2 b`,
+			},
+		},
+		{
+			name:      "5",
+			param:     `"key":"user:{none}","batchTimeout":"5ms"`,
+			receiver:  &mockReceiver{records: []element.Record{testDoc(1, "a")}, sleep: 20 * time.Millisecond},
+			wantDirty: []string{"column does not exist"},
+		},
+		{
+			name:     "6",
+			param:    `"key":"user:{id}"`,
+			receiver: &mockReceiver{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer("")
+			defer ts.Close()
+			collector := &mockCollector{}
+			task := testTask(t, `{"address":"`+ts.Addr()+`",`+tt.param+`}`)
+			defer task.Destroy(context.TODO())
+			task.SetTaskCollector(collector)
+			if err := task.StartWrite(context.TODO(), tt.receiver); err != nil {
+				t.Fatalf("Task.StartWrite() error = %v", err)
+			}
+			if !reflect.DeepEqual(ts.cmds, tt.want) {
+				t.Errorf("Task.StartWrite() cmds = %v, want %v", ts.cmds, tt.want)
+			}
+			if !reflect.DeepEqual(collector.errs, tt.wantDirty) {
+				t.Errorf("Task.StartWrite() dirty = %v, want %v", collector.errs, tt.wantDirty)
+			}
+		})
+	}
+}
+
+func TestTask_StartWriteErr(t *testing.T) {
+	ts := newTestServer("")
+	defer ts.Close()
+	errMock := errors.New("mock error")
+	tests := []struct {
+		name     string
+		param    string
+		receiver *mockReceiver
+		want     []string
+	}{
+		{
+			name:     "1",
+			param:    `{"address":"` + ts.Addr() + `","key":"{name}:{id}"}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "fail"), testDoc(2, "a")}},
+			want:     []string{`SET a:2 {"id":2,"name":"a"}`},
+		},
+		{
+			name:     "2",
+			param:    `{"address":"` + ts.Addr() + `","key":"user:{id}"}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}, err: errMock},
+		},
+		{
+			name:     "3",
+			param:    `{"address":"` + ts.Addr() + `","key":"user:{id}","column":["none"],"batchTimeout":"5ms"}`,
+			receiver: &mockReceiver{records: []element.Record{testDoc(1, "a")}, sleep: 20 * time.Millisecond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts.cmds = nil
+			task := testTask(t, tt.param)
+			defer task.Destroy(context.TODO())
+			if err := task.StartWrite(context.TODO(), tt.receiver); err == nil {
+				t.Errorf("Task.StartWrite() error = nil, wantErr true")
+			}
+			if !reflect.DeepEqual(ts.cmds, tt.want) {
+				t.Errorf("Task.StartWrite() cmds = %v, want %v", ts.cmds, tt.want)
+			}
+		})
+	}
+
+	task := testTask(t, `{"address":"`+ts.Addr()+`","key":"user:{id}"}`)
+	task.conn.Close()
+	if err := task.StartWrite(context.TODO(), &mockReceiver{records: []element.Record{testDoc(1, "a")}}); err == nil {
+		t.Errorf("Task.StartWrite() error = nil, wantErr true")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	task = testTask(t, `{"address":"`+ts.Addr()+`","key":"user:{id}"}`)
+	defer task.Destroy(context.TODO())
+	if err := task.StartWrite(ctx, &mockReceiver{records: []element.Record{testDoc(1, "a")}}); err != nil {
+		t.Errorf("Task.StartWrite() error = %v", err)
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	ts := newTestServer("")
+	defer ts.Close()
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+	if err := testTask(t, `{"address":"`+ts.Addr()+`","key":"user:{id}"}`).Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package redis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestNewKeyTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []keyPart
+		wantErr bool
+	}{
+		{
+			name: "1",
+			s:    "test",
+			want: []keyPart{{text: "test"}},
+		},
+		{
+			name: "2",
+			s:    "user:{region}:{time|2006.01.02}",
+			want: []keyPart{{text: "user:"}, {column: "region"}, {text: ":"}, {column: "time", layout: "2006.01.02"}},
+		},
+		{
+			name: "3",
+			s:    "{region}{id}",
+			want: []keyPart{{column: "region"}, {column: "id"}},
+		},
+		{
+			name:    "4",
+			s:       "",
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			s:       "user:{region",
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			s:       "user:{re{gion}",
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			s:       "user:}",
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			s:       "user:{}",
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			s:       "user:{time|}",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newKeyTemplate(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newKeyTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got.parts, tt.want) {
+				t.Errorf("newKeyTemplate() = %+v, want %+v", got.parts, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyTemplate_render(t *testing.T) {
+	record := testRecord(
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue("cn"), "region", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)), "time", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+	)
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			s:    "test",
+			want: "test",
+		},
+		{
+			name: "2",
+			s:    "user:{region}:{time|2006.01.02}",
+			want: "user:cn:2021.01.02",
+		},
+		{
+			name: "3",
+			s:    "{id}",
+			want: "1",
+		},
+		{
+			name:    "4",
+			s:       "{none}",
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			s:       "{nil}",
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			s:       "{region|2006}",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := newKeyTemplate(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := key.render(record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("keyTemplate.render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("keyTemplate.render() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyTemplate_pattern(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "1",
+			s:    "user",
+			want: "user",
+		},
+		{
+			name: "2",
+			s:    "user:{region}:{time|2006.01.02}",
+			want: "user:*:*",
+		},
+		{
+			name: "3",
+			s:    `a*b?[c]\{id}`,
+			want: `a\*b\?\[c\]\\*`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := newKeyTemplate(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := key.pattern(); got != tt.want {
+				t.Errorf("keyTemplate.pattern() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+type paramConfig struct {
+	Print          bool   `json:"print"`          //是否将记录打印到标准输出，否则丢弃记录
+	FieldDelimiter string `json:"fieldDelimiter"` //打印时的列分隔符，默认为制表符
+	ReportInterval string `json:"reportInterval"` //打印吞吐量日志的间隔，使用go的时间间隔格式，为空时只在结束时打印
+	TaskID         *int   `json:"taskID"`         //由Job.Split生成的任务ID
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.reportInterval(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//fieldDelimiter 获取列分隔符，默认为制表符
+func (p *paramConfig) fieldDelimiter() string {
+	if p.FieldDelimiter == "" {
+		return "\t"
+	}
+	return p.FieldDelimiter
+}
+
+//reportInterval 获取打印吞吐量日志的间隔，为0时只在结束时打印
+func (p *paramConfig) reportInterval() (d time.Duration, err error) {
+	if p.ReportInterval == "" {
+		return
+	}
+	if d, err = time.ParseDuration(p.ReportInterval); err != nil {
+		return
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("reportInterval(%v) is not positive", p.ReportInterval)
+	}
+	return
+}
@@ -0,0 +1,64 @@
+package stream
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"print":true,"fieldDelimiter":",","reportInterval":"10s"}`,
+		},
+		{
+			name: "2",
+			json: `{}`,
+		},
+		{
+			name:    "3",
+			json:    `{"reportInterval":"1x"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"reportInterval":"-1s"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"print":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig(t *testing.T) {
+	p := &paramConfig{}
+	if got := p.fieldDelimiter(); got != "\t" {
+		t.Errorf("paramConfig.fieldDelimiter() = %q, want %q", got, "\t")
+	}
+	if got, _ := p.reportInterval(); got != 0 {
+		t.Errorf("paramConfig.reportInterval() = %v, want 0", got)
+	}
+
+	p = &paramConfig{FieldDelimiter: ",", ReportInterval: "1m"}
+	if got := p.fieldDelimiter(); got != "," {
+		t.Errorf("paramConfig.fieldDelimiter() = %q, want %q", got, ",")
+	}
+	if got, _ := p.reportInterval(); got != time.Minute {
+		t.Errorf("paramConfig.reportInterval() = %v, want %v", got, time.Minute)
+	}
+}
@@ -0,0 +1,143 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//Task 任务
+type Task struct {
+	*writer.BaseTask
+
+	param    *paramConfig
+	interval time.Duration
+	out      io.Writer
+
+	stats stats
+}
+
+//stats 写入的统计信息
+type stats struct {
+	records int64
+	bytes   int64
+	elapsed time.Duration
+}
+
+//Init 初始化
+func (t *Task) Init(ctx context.Context) (err error) {
+	var paramConf *config.JSON
+	if paramConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobContentWriterParameter); err != nil {
+		return
+	}
+
+	if t.param, err = newParamConfig(paramConf); err != nil {
+		return
+	}
+
+	if t.param.TaskID != nil {
+		t.SetTaskID(*t.param.TaskID)
+	}
+	if t.interval, err = t.param.reportInterval(); err != nil {
+		return
+	}
+	if t.out == nil {
+		t.out = os.Stdout
+	}
+	return
+}
+
+//Destroy 销毁
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+//StartWrite 开始写，收到终止记录或者ctx取消后打印吞吐量日志
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (err error) {
+	var w *bufio.Writer
+	if t.param.Print {
+		w = bufio.NewWriter(t.out)
+	}
+	start := time.Now()
+	last := start
+	defer func() {
+		if w != nil {
+			if ferr := w.Flush(); err == nil {
+				err = ferr
+			}
+		}
+		t.stats.elapsed = time.Since(start)
+		t.report("finished")
+	}()
+
+	var record element.Record
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		record, err = receiver.GetFromReader()
+		switch err {
+		case nil:
+		case exchange.ErrEmpty:
+			continue
+		case exchange.ErrTerminate:
+			return nil
+		default:
+			return
+		}
+
+		t.stats.records++
+		t.stats.bytes += record.ByteSize()
+		if w != nil {
+			if err = t.print(w, record); err != nil {
+				return
+			}
+		}
+
+		if t.interval > 0 && time.Since(last) >= t.interval {
+			last = time.Now()
+			t.stats.elapsed = last.Sub(start)
+			t.report("running")
+		}
+	}
+}
+
+//print 将记录record的列值以列分隔符连接后作为一行写入w
+func (t *Task) print(w *bufio.Writer, record element.Record) (err error) {
+	cols := make([]string, record.ColumnNumber())
+	for i := range cols {
+		var c element.Column
+		if c, err = record.GetByIndex(i); err != nil {
+			return
+		}
+		cols[i] = c.String()
+	}
+	if _, err = w.WriteString(strings.Join(cols, t.param.fieldDelimiter())); err != nil {
+		return
+	}
+	return w.WriteByte('\n')
+}
+
+//report 打印吞吐量日志
+func (t *Task) report(state string) {
+	seconds := t.stats.elapsed.Seconds()
+	if seconds <= 0 {
+		seconds = 1e-9
+	}
+	log.Infof("streamwriter task(%v) %v, records: %v, bytes: %v, elapsed: %v, speed: %.0f records/s, %.0f bytes/s",
+		t.TaskID(), state, t.stats.records, t.stats.bytes, t.stats.elapsed,
+		float64(t.stats.records)/seconds, float64(t.stats.bytes)/seconds)
+}
@@ -0,0 +1,156 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testTask(t *testing.T, param string, out *bytes.Buffer) *Task {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+		out:      out,
+	}
+	task.SetPluginJobConf(testJobConf(param))
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	return task
+}
+
+func testColumns() []element.Column {
+	return []element.Column{
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 8),
+		element.NewDefaultColumn(element.NewStringColumnValue("a"), "name", 1),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)), "time", 8),
+	}
+}
+
+func TestTask_Init(t *testing.T) {
+	tests := []struct {
+		name       string
+		param      string
+		wantTaskID int
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			param:      `{"taskID":3}`,
+			wantTaskID: 3,
+		},
+		{
+			name:  "2",
+			param: `{}`,
+		},
+		{
+			name:    "3",
+			param:   `{"reportInterval":"1x"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				BaseTask: writer.NewBaseTask(),
+			}
+			task.SetPluginJobConf(testJobConf(tt.param))
+			if err := task.Init(context.TODO()); (err != nil) != tt.wantErr {
+				t.Fatalf("Task.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if task.TaskID() != tt.wantTaskID {
+				t.Errorf("Task.TaskID() = %v, want %v", task.TaskID(), tt.wantTaskID)
+			}
+		})
+	}
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	task.SetPluginJobConf(testJSONFromString(`{}`))
+	if err := task.Init(context.TODO()); err == nil {
+		t.Errorf("Task.Init() error = nil, wantErr true")
+	}
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		records []element.Record
+		want    string
+	}{
+		{
+			name:    "1",
+			param:   `{"print":true}`,
+			records: []element.Record{testRecord(testColumns()...), testRecord(testColumns()[:2]...)},
+			want:    "1\ta\t<nil>\t2021-01-02T03:04:05Z\n1\ta\n",
+		},
+		{
+			name:    "2",
+			param:   `{"print":true,"fieldDelimiter":",","reportInterval":"1ns"}`,
+			records: []element.Record{testRecord(testColumns()[:2]...)},
+			want:    "1,a\n",
+		},
+		{
+			name:    "3",
+			param:   `{}`,
+			records: []element.Record{testRecord(testColumns()...), testRecord(testColumns()...)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			task := testTask(t, tt.param, out)
+			var wantBytes int64
+			for _, r := range tt.records {
+				wantBytes += r.ByteSize()
+			}
+			if err := task.StartWrite(context.TODO(), &mockReceiver{records: tt.records}); err != nil {
+				t.Fatalf("Task.StartWrite() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("Task.StartWrite() = %q, want %q", got, tt.want)
+			}
+			if task.stats.records != int64(len(tt.records)) || task.stats.bytes != wantBytes {
+				t.Errorf("Task.StartWrite() stats = %+v, want %v records %v bytes", task.stats, len(tt.records), wantBytes)
+			}
+		})
+	}
+}
+
+func TestTask_StartWriteErr(t *testing.T) {
+	errMock := errors.New("mock error")
+	task := testTask(t, `{"print":true}`, &bytes.Buffer{})
+	if err := task.StartWrite(context.TODO(), &mockReceiver{
+		records: []element.Record{testRecord(testColumns()...)},
+		err:     errMock,
+	}); err != errMock {
+		t.Errorf("Task.StartWrite() error = %v, want %v", err, errMock)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	task = testTask(t, `{}`, &bytes.Buffer{})
+	if err := task.StartWrite(ctx, &mockReceiver{
+		records: []element.Record{testRecord(testColumns()...)},
+	}); err != nil {
+		t.Errorf("Task.StartWrite() error = %v", err)
+	}
+	if task.stats.records != 0 {
+		t.Errorf("Task.StartWrite() records = %v, want 0", task.stats.records)
+	}
+}
+
+func TestTask_Destroy(t *testing.T) {
+	task := &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+	if err := task.Destroy(context.TODO()); err != nil {
+		t.Errorf("Task.Destroy() error = %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package txtfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/file"
+	"github.com/Breeze0806/go-etl/storage/stream/file/delimited"
+)
+
+type paramConfig struct {
+	file.Config
+
+	FieldDelimiter string `json:"fieldDelimiter"` //字段分隔符，默认为逗号
+	QuoteChar      string `json:"quoteChar"`      //引号，默认为双引号
+	EscapeChar     string `json:"escapeChar"`     //转义符，默认在引号内用两个连续的引号表示引号
+	Header         bool   `json:"header"`         //是否在每个文件首行写入列名
+	NullFormat     string `json:"nullFormat"`     //空值写入的字符串，默认为空字符串
+	DateFormat     string `json:"dateFormat"`     //时间格式，使用go的时间格式，默认为time.RFC3339Nano
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err = c.options(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//layout 获取时间格式，默认为time.RFC3339Nano
+func (p *paramConfig) layout() string {
+	if p.DateFormat == "" {
+		return time.RFC3339Nano
+	}
+	return p.DateFormat
+}
+
+//options 获取分隔文本选项，引号默认为双引号
+func (p *paramConfig) options() (opts delimited.Options, err error) {
+	if opts.Delimiter, err = toRune("fieldDelimiter", p.FieldDelimiter); err != nil {
+		return
+	}
+	if opts.Quote, err = toRune("quoteChar", p.QuoteChar); err != nil {
+		return
+	}
+	if opts.Quote == 0 {
+		opts.Quote = '"'
+	}
+	if opts.Escape, err = toRune("escapeChar", p.EscapeChar); err != nil {
+		return
+	}
+	return
+}
+
+//toRune 将只有一个字符的字符串s转化为字符，空字符串转化为0
+func toRune(name, s string) (rune, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if utf8.RuneCountInString(s) != 1 {
+		return 0, fmt.Errorf("%v(%v) is not a single character", name, s)
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return r, nil
+}
@@ -0,0 +1,121 @@
+package txtfile
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/storage/stream/file/delimited"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"path":"/tmp","fileName":"a","fieldDelimiter":"|","quoteChar":"'","escapeChar":"\\"}`,
+		},
+		{
+			name:    "2",
+			json:    `{"fileName":"a"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"path":"/tmp","fileName":"a","fieldDelimiter":"||"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"path":"/tmp","fileName":"a","quoteChar":"''"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"path":"/tmp","fileName":"a","escapeChar":"\\\\"}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"path":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_options(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        *paramConfig
+		wantOpts delimited.Options
+	}{
+		{
+			name: "1",
+			p:    &paramConfig{},
+			wantOpts: delimited.Options{
+				Quote: '"',
+			},
+		},
+		{
+			name: "2",
+			p: &paramConfig{
+				FieldDelimiter: "\t",
+				QuoteChar:      "'",
+				EscapeChar:     `\`,
+			},
+			wantOpts: delimited.Options{
+				Delimiter: '\t',
+				Quote:     '\'',
+				Escape:    '\\',
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOpts, err := tt.p.options()
+			if err != nil {
+				t.Fatalf("paramConfig.options() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotOpts, tt.wantOpts) {
+				t.Errorf("paramConfig.options() = %v, want %v", gotOpts, tt.wantOpts)
+			}
+		})
+	}
+}
+
+func TestParamConfig_layout(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *paramConfig
+		want string
+	}{
+		{
+			name: "1",
+			p:    &paramConfig{},
+			want: time.RFC3339Nano,
+		},
+		{
+			name: "2",
+			p:    &paramConfig{DateFormat: "2006-01-02"},
+			want: "2006-01-02",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.layout(); got != tt.want {
+				t.Errorf("paramConfig.layout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
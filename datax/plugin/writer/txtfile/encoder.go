@@ -0,0 +1,91 @@
+package txtfile
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file/delimited"
+)
+
+//encoder 分隔文本记录编码器
+type encoder struct {
+	param       *paramConfig
+	w           *delimited.Writer
+	decoder     element.TimeDecoder
+	writeHeader bool
+}
+
+func newEncoder(w io.Writer, isNew bool, param *paramConfig, opts delimited.Options) *encoder {
+	return &encoder{
+		param:       param,
+		w:           delimited.NewWriter(w, opts),
+		decoder:     element.NewStringTimeDecoder(param.layout()),
+		writeHeader: param.Header && isNew,
+	}
+}
+
+//Encode 将记录record编码成一行写入，新文件会先写入列名作为表头
+func (e *encoder) Encode(record element.Record) (n int, err error) {
+	var header, fields []string
+	if header, fields, err = e.toStrings(record); err != nil {
+		return
+	}
+
+	if e.writeHeader {
+		if n, err = e.w.Write(header); err != nil {
+			return
+		}
+		e.writeHeader = false
+	}
+
+	var m int
+	m, err = e.w.Write(fields)
+	return n + m, err
+}
+
+//toStrings 将记录record转化为列名和字段
+func (e *encoder) toStrings(record element.Record) (header, fields []string, err error) {
+	for i := 0; i < record.ColumnNumber(); i++ {
+		var c element.Column
+		if c, err = record.GetByIndex(i); err != nil {
+			return
+		}
+		header = append(header, c.Name())
+
+		var s string
+		if s, err = e.toString(c); err != nil {
+			return nil, nil, fmt.Errorf("column(%v) err: %v", c.Name(), err)
+		}
+		fields = append(fields, s)
+	}
+	return
+}
+
+//toString 将列c转化为字符串，空值转化为nullFormat，时间按照dateFormat格式化
+func (e *encoder) toString(c element.Column) (s string, err error) {
+	if c.IsNil() {
+		return e.param.NullFormat, nil
+	}
+
+	switch c.Type() {
+	case element.TypeTime:
+		var tm time.Time
+		if tm, err = c.AsTime(); err != nil {
+			return
+		}
+		var v interface{}
+		if v, err = e.decoder.TimeDecode(tm); err != nil {
+			return
+		}
+		return v.(string), nil
+	case element.TypeBytes:
+		var b []byte
+		if b, err = c.AsBytes(); err != nil {
+			return
+		}
+		return string(b), nil
+	}
+	return c.AsString()
+}
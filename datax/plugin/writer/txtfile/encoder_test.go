@@ -0,0 +1,81 @@
+package txtfile
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file/delimited"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   *paramConfig
+		isNew   bool
+		records []element.Record
+		want    string
+	}{
+		{
+			name:    "1",
+			param:   &paramConfig{Header: true, NullFormat: `\N`, DateFormat: "2006-01-02 15:04:05"},
+			isNew:   true,
+			records: []element.Record{testRecord(testColumns()...), testRecord(testColumns()...)},
+			want: "id,name,nil,time,bool,bytes,decimal\n" +
+				"1,\"a,b\",\\N,2021-01-02 03:04:05,true,xyz,1.5\n" +
+				"1,\"a,b\",\\N,2021-01-02 03:04:05,true,xyz,1.5\n",
+		},
+		{
+			name:    "2",
+			param:   &paramConfig{Header: true},
+			records: []element.Record{testRecord(testColumns()[3])},
+			want:    "2021-01-02T03:04:05Z\n",
+		},
+		{
+			name:    "3",
+			param:   &paramConfig{},
+			isNew:   true,
+			records: []element.Record{testRecord(testColumns()[:2]...)},
+			want:    "1,\"a,b\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			e := newEncoder(buf, tt.isNew, tt.param, delimited.Options{Quote: '"'})
+			n := 0
+			for _, v := range tt.records {
+				m, err := e.Encode(v)
+				if err != nil {
+					t.Fatalf("encoder.Encode() error = %v", err)
+				}
+				n += m
+			}
+			if buf.String() != tt.want {
+				t.Errorf("encoder.Encode() = %q, want %q", buf.String(), tt.want)
+			}
+			if n != len(tt.want) {
+				t.Errorf("encoder.Encode() n = %v, want %v", n, len(tt.want))
+			}
+		})
+	}
+}
+
+//mockTimeValue 类型为时间但是无法转化为时间的列值
+type mockTimeValue struct {
+	element.ColumnValue
+}
+
+func (m *mockTimeValue) Type() element.ColumnType {
+	return element.TypeTime
+}
+
+func TestEncoder_EncodeErr(t *testing.T) {
+	e := newEncoder(&bytes.Buffer{}, true, &paramConfig{}, delimited.Options{})
+	r := testRecord(element.NewDefaultColumn(&mockTimeValue{
+		ColumnValue: element.NewStringColumnValue("abc"),
+	}, "time", 0))
+	if _, err := e.Encode(r); err == nil {
+		t.Errorf("encoder.Encode() error = nil, wantErr true")
+	}
+}
@@ -0,0 +1,61 @@
+package xlsx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/file"
+)
+
+//maxSheetNameLength excel工作表名的最大长度
+const maxSheetNameLength = 31
+
+type paramConfig struct {
+	file.Config
+
+	Sheet      string `json:"sheet"`      //工作表名，达到excel的行数上限时新建的工作表名会加上序号后缀，默认为Sheet1
+	Header     bool   `json:"header"`     //是否在每个工作表的首行写入列名
+	DateFormat string `json:"dateFormat"` //时间单元格的excel数字格式，默认为yyyy-mm-dd hh:mm:ss
+}
+
+func newParamConfig(conf *config.JSON) (c *paramConfig, err error) {
+	c = &paramConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (p *paramConfig) validate() (err error) {
+	if err = p.ValidateBinary(); err != nil {
+		return
+	}
+
+	if utf8.RuneCountInString(p.Sheet) > maxSheetNameLength || strings.ContainsAny(p.Sheet, `:\/?*[]`) {
+		return fmt.Errorf("sheet(%v) is not valid", p.Sheet)
+	}
+	return
+}
+
+//sheet 获取工作表名，默认为Sheet1
+func (p *paramConfig) sheet() string {
+	if p.Sheet == "" {
+		return "Sheet1"
+	}
+	return p.Sheet
+}
+
+//dateFormat 获取时间单元格的excel数字格式，默认为yyyy-mm-dd hh:mm:ss
+func (p *paramConfig) dateFormat() string {
+	if p.DateFormat == "" {
+		return "yyyy-mm-dd hh:mm:ss"
+	}
+	return p.DateFormat
+}
@@ -0,0 +1,74 @@
+package xlsx
+
+import (
+	"testing"
+)
+
+func TestNewParamConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			json: `{"path":"/tmp","fileName":"a","sheet":"用户","header":true,"dateFormat":"yyyy-mm-dd"}`,
+		},
+		{
+			name:    "2",
+			json:    `{"fileName":"a"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			json:    `{"path":"/tmp","fileName":"a","writeMode":"append"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			json:    `{"path":"/tmp","fileName":"a","compress":"gzip"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			json:    `{"path":"/tmp","fileName":"a","sheet":"a/b"}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			json:    `{"path":"/tmp","fileName":"a","sheet":"abcdefghijklmnopqrstuvwxyz123456"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			json:    `{"path":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newParamConfig(testJSONFromString(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newParamConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamConfig_default(t *testing.T) {
+	p := &paramConfig{}
+	if got := p.sheet(); got != "Sheet1" {
+		t.Errorf("paramConfig.sheet() = %v, want %v", got, "Sheet1")
+	}
+	if got := p.dateFormat(); got != "yyyy-mm-dd hh:mm:ss" {
+		t.Errorf("paramConfig.dateFormat() = %v, want %v", got, "yyyy-mm-dd hh:mm:ss")
+	}
+
+	p = &paramConfig{Sheet: "a", DateFormat: "yyyy-mm-dd"}
+	if got := p.sheet(); got != "a" {
+		t.Errorf("paramConfig.sheet() = %v, want %v", got, "a")
+	}
+	if got := p.dateFormat(); got != "yyyy-mm-dd" {
+		t.Errorf("paramConfig.dateFormat() = %v, want %v", got, "yyyy-mm-dd")
+	}
+}
@@ -0,0 +1,176 @@
+package xlsx
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+//excelPrecision excel数值的有效位数，超过的整数和实数以字符串写入
+const excelPrecision = 15
+
+//encoder xlsx记录编码器，记录先写入工作簿，关闭时写入整个文件
+type encoder struct {
+	w       io.Writer
+	param   *paramConfig
+	f       *excelize.File
+	sw      *excelize.StreamWriter
+	style   int //时间单元格的样式
+	sheets  int //已创建的工作表数
+	row     int //当前工作表已写入的行数
+	maxRows int //每个工作表的最大行数
+	err     error
+}
+
+func newEncoder(w io.Writer, param *paramConfig) *encoder {
+	e := &encoder{
+		w:       w,
+		param:   param,
+		f:       excelize.NewFile(),
+		maxRows: excelize.TotalRows,
+	}
+	format := param.dateFormat()
+	if e.style, e.err = e.f.NewStyle(&excelize.Style{CustomNumFmt: &format}); e.err != nil {
+		return e
+	}
+	e.err = e.newSheet()
+	return e
+}
+
+//Encode 写入记录record，当前工作表达到行数上限时新建工作表，
+//由于数据在关闭时才写入，返回记录的字节数作为写入的字节数
+func (e *encoder) Encode(record element.Record) (n int, err error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+
+	if record.ColumnNumber() > excelize.MaxColumns {
+		return 0, fmt.Errorf("column number(%v) is more than %v", record.ColumnNumber(), excelize.MaxColumns)
+	}
+
+	if e.row >= e.maxRows {
+		if err = e.newSheet(); err != nil {
+			return
+		}
+	}
+
+	if e.row == 0 && e.param.Header {
+		header := make([]interface{}, record.ColumnNumber())
+		for i := range header {
+			c, _ := record.GetByIndex(i)
+			header[i] = c.Name()
+		}
+		if err = e.writeRow(header); err != nil {
+			return
+		}
+	}
+
+	values := make([]interface{}, record.ColumnNumber())
+	for i := range values {
+		c, _ := record.GetByIndex(i)
+		if values[i], err = e.cellValue(c); err != nil {
+			return 0, fmt.Errorf("column(%v) err: %v", c.Name(), err)
+		}
+	}
+	if err = e.writeRow(values); err != nil {
+		return
+	}
+	return int(record.ByteSize()), nil
+}
+
+//Close 写入工作簿
+func (e *encoder) Close() (err error) {
+	defer e.f.Close()
+	if e.err != nil {
+		return e.err
+	}
+
+	if err = e.sw.Flush(); err != nil {
+		return
+	}
+	return e.f.Write(e.w)
+}
+
+//newSheet 新建工作表，第一个工作表使用配置的名字，之后的工作表加上序号后缀，如Sheet1_2
+func (e *encoder) newSheet() (err error) {
+	if e.sw != nil {
+		if err = e.sw.Flush(); err != nil {
+			return
+		}
+	}
+
+	name := e.param.sheet()
+	if e.sheets == 0 {
+		err = e.f.SetSheetName(e.f.GetSheetName(0), name)
+	} else {
+		name = fmt.Sprintf("%v_%d", name, e.sheets+1)
+		_, err = e.f.NewSheet(name)
+	}
+	if err != nil {
+		return
+	}
+
+	if e.sw, err = e.f.NewStreamWriter(name); err != nil {
+		return
+	}
+	e.sheets++
+	e.row = 0
+	return
+}
+
+//writeRow 在当前工作表的下一行写入单元格values
+func (e *encoder) writeRow(values []interface{}) (err error) {
+	cell, _ := excelize.CoordinatesToCellName(1, e.row+1)
+	if err = e.sw.SetRow(cell, values); err != nil {
+		return
+	}
+	e.row++
+	return
+}
+
+//cellValue 将列c转化为单元格的值，超过excel有效位数的整数和实数转化为字符串以免丢失精度
+func (e *encoder) cellValue(c element.Column) (interface{}, error) {
+	if c.IsNil() {
+		return nil, nil
+	}
+
+	switch c.Type() {
+	case element.TypeBool:
+		return c.AsBool()
+	case element.TypeBigInt:
+		v, err := c.AsBigInt()
+		if err != nil {
+			return nil, err
+		}
+		if v.IsInt64() && digits(v.String()) <= excelPrecision {
+			return v.Int64(), nil
+		}
+		return v.String(), nil
+	case element.TypeDecimal:
+		v, err := c.AsDecimal()
+		if err != nil {
+			return nil, err
+		}
+		f, _ := v.Float64()
+		if digits(v.Coefficient().String()) <= excelPrecision && decimal.NewFromFloat(f).Equal(v) {
+			return f, nil
+		}
+		return v.String(), nil
+	case element.TypeTime:
+		v, err := c.AsTime()
+		if err != nil {
+			return nil, err
+		}
+		return excelize.Cell{StyleID: e.style, Value: v}, nil
+	}
+	return c.AsString()
+}
+
+//digits 获取整数字符串s的位数
+func digits(s string) int {
+	return len(strings.TrimPrefix(s, "-"))
+}
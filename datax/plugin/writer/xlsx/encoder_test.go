@@ -0,0 +1,173 @@
+package xlsx
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/xuri/excelize/v2"
+)
+
+type mockWriter struct {
+	err error
+}
+
+func (m *mockWriter) Write(p []byte) (int, error) {
+	return 0, m.err
+}
+
+//testReadXlsx 读取xlsx文件内容data，返回每行，每行为工作表名:单元格原始值，单元格之间用逗号分隔
+func testReadXlsx(t *testing.T, data []byte) (rows []string) {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	for _, sheet := range f.GetSheetList() {
+		cells, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range cells {
+			rows = append(rows, sheet+":"+strings.Join(c, ","))
+		}
+	}
+	return
+}
+
+func TestEncoder(t *testing.T) {
+	buf := &bytes.Buffer{}
+	e := newEncoder(buf, &paramConfig{Sheet: "a", Header: true})
+	e.maxRows = 3
+	for i := 0; i < 5; i++ {
+		r := testRecord(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(int64(i)), "id", 0))
+		n, err := e.Encode(r)
+		if err != nil {
+			t.Fatalf("encoder.Encode() error = %v", err)
+		}
+		if n != int(r.ByteSize()) {
+			t.Errorf("encoder.Encode() n = %v, want %v", n, r.ByteSize())
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatalf("encoder.Close() error = %v", err)
+	}
+	want := []string{
+		"a:id", "a:0", "a:1",
+		"a_2:id", "a_2:2", "a_2:3",
+		"a_3:id", "a_3:4",
+	}
+	if got := testReadXlsx(t, buf.Bytes()); !reflect.DeepEqual(got, want) {
+		t.Errorf("encoder.Close() = %v, want %v", got, want)
+	}
+}
+
+func TestEncoder_cellValue(t *testing.T) {
+	tm := time.Date(2021, 1, 2, 12, 0, 0, 0, time.UTC)
+	e := newEncoder(&bytes.Buffer{}, &paramConfig{})
+	defer e.Close()
+	newBigInt := func(s string) element.ColumnValue {
+		v, _ := element.NewBigIntColumnValueFromString(s)
+		return v
+	}
+	newDecimal := func(s string) element.ColumnValue {
+		v, _ := element.NewDecimalColumnValueFromString(s)
+		return v
+	}
+	tests := []struct {
+		name string
+		v    element.ColumnValue
+		want interface{}
+	}{
+		{
+			name: "1",
+			v:    element.NewNilBigIntColumnValue(),
+			want: nil,
+		},
+		{
+			name: "2",
+			v:    element.NewBoolColumnValue(true),
+			want: true,
+		},
+		{
+			name: "3",
+			v:    newBigInt("-123456789012345"),
+			want: int64(-123456789012345),
+		},
+		{
+			name: "4",
+			v:    newBigInt("1234567890123456"),
+			want: "1234567890123456",
+		},
+		{
+			name: "5",
+			v:    newDecimal("0.1"),
+			want: 0.1,
+		},
+		{
+			name: "6",
+			v:    newDecimal("0.1234567890123456"),
+			want: "0.1234567890123456",
+		},
+		{
+			name: "7",
+			v:    element.NewStringColumnValue("abc"),
+			want: "abc",
+		},
+		{
+			name: "8",
+			v:    element.NewBytesColumnValue([]byte("xyz")),
+			want: "xyz",
+		},
+		{
+			name: "9",
+			v:    element.NewTimeColumnValue(tm),
+			want: excelize.Cell{StyleID: e.style, Value: tm},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.cellValue(element.NewDefaultColumn(tt.v, "a", 0))
+			if err != nil {
+				t.Fatalf("encoder.cellValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encoder.cellValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncoderErr(t *testing.T) {
+	errMock := errors.New("mock error")
+	e := newEncoder(&mockWriter{err: errMock}, &paramConfig{})
+	if _, err := e.Encode(testRecord(testColumns()...)); err != nil {
+		t.Fatalf("encoder.Encode() error = %v", err)
+	}
+	if err := e.Close(); err != errMock {
+		t.Errorf("encoder.Close() error = %v, want %v", err, errMock)
+	}
+
+	e = newEncoder(&bytes.Buffer{}, &paramConfig{Sheet: "a/b"})
+	if _, err := e.Encode(testRecord(testColumns()...)); err == nil {
+		t.Errorf("encoder.Encode() error = nil, wantErr true")
+	}
+	if err := e.Close(); err == nil {
+		t.Errorf("encoder.Close() error = nil, wantErr true")
+	}
+
+	e = newEncoder(&bytes.Buffer{}, &paramConfig{})
+	defer e.Close()
+	r := element.NewDefaultRecord()
+	for i := 0; i <= excelize.MaxColumns; i++ {
+		r.Add(element.NewDefaultColumn(element.NewStringColumnValue("a"), strconv.Itoa(i), 0))
+	}
+	if _, err := e.Encode(r); err == nil {
+		t.Errorf("encoder.Encode() error = nil, wantErr true")
+	}
+}
@@ -7,6 +7,12 @@ type Transformer interface {
 	DoTransform(element.Record) (element.Record, error)
 }
 
+//SchemaTransformer 模式转化器，是Transformer的可选功能，
+//用于将读取器的记录模式转化为写入器收到的记录模式
+type SchemaTransformer interface {
+	TransformSchema(*element.Schema) (*element.Schema, error)
+}
+
 //NilTransformer 空转化器
 type NilTransformer struct{}
 
@@ -14,3 +20,8 @@ type NilTransformer struct{}
 func (n *NilTransformer) DoTransform(record element.Record) (element.Record, error) {
 	return record, nil
 }
+
+//TransformSchema 转化记录模式，直接返回记录模式schema
+func (n *NilTransformer) TransformSchema(schema *element.Schema) (*element.Schema, error) {
+	return schema, nil
+}
@@ -41,3 +41,43 @@ func TestNilTransformer_DoTransform(t *testing.T) {
 		})
 	}
 }
+
+func TestNilTransformer_TransformSchema(t *testing.T) {
+	s, _ := element.NewSchema([]element.ColumnSchema{
+		{
+			Name: "a",
+			Type: element.TypeString,
+		},
+	})
+	type args struct {
+		schema *element.Schema
+	}
+	tests := []struct {
+		name    string
+		n       *NilTransformer
+		args    args
+		want    *element.Schema
+		wantErr bool
+	}{
+		{
+			name: "1",
+			n:    &NilTransformer{},
+			args: args{
+				schema: s,
+			},
+			want: s,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.n.TransformSchema(tt.args.schema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NilTransformer.TransformSchema() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NilTransformer.TransformSchema() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
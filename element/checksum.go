@@ -0,0 +1,111 @@
+package element
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+)
+
+//Checksum 记录校验和，由记录数以及每条记录哈希值的和与异或组成，与记录的顺序无关，
+//可以用于比较发送到记录通道的记录和从记录通道收到的记录是否一致
+type Checksum struct {
+	count int64  //记录数
+	sum   uint64 //记录哈希值的和
+	xor   uint64 //记录哈希值的异或
+}
+
+//Add 将记录r加入校验和
+func (c *Checksum) Add(r Record) {
+	c.AddHash(RecordHash(r))
+}
+
+//AddHash 将通过RecordHash计算的记录哈希值h加入校验和
+func (c *Checksum) AddHash(h uint64) {
+	c.count++
+	c.sum += h
+	c.xor ^= h
+}
+
+//Merge 将校验和o合并到校验和中
+func (c *Checksum) Merge(o Checksum) {
+	c.count += o.count
+	c.sum += o.sum
+	c.xor ^= o.xor
+}
+
+//Count 记录数
+func (c Checksum) Count() int64 {
+	return c.count
+}
+
+func (c Checksum) String() string {
+	return fmt.Sprintf("count: %v sum: %016x xor: %016x", c.count, c.sum, c.xor)
+}
+
+//RecordHash 计算记录r的哈希值，由各列的列名和列值的规范形式计算，与列的顺序无关，
+//整数和实数按照数值比较，如12与12.0相同，字符串与字节流按照字节比较，时间按照时刻比较，与时区无关，
+//JSON按照规范形式比较，对象的键顺序以及空白不影响哈希值
+func RecordHash(r Record) uint64 {
+	var sum uint64
+	for i := 0; i < r.ColumnNumber(); i++ {
+		c, err := r.GetByIndex(i)
+		if err != nil {
+			break
+		}
+		sum += columnHash(c)
+	}
+	return mixHash(sum)
+}
+
+//columnHash 计算列c的哈希值，格式为列名 0 类型标记 列值的规范形式
+func columnHash(c Column) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(c.Name()))
+	h.Write([]byte{0})
+	h.Write(canonicalColumnValue(c))
+	return h.Sum64()
+}
+
+//canonicalColumnValue 列值的规范形式，首字节为类型标记，无法转化时使用列值的字符串
+func canonicalColumnValue(c Column) []byte {
+	if c.IsNil() {
+		return []byte{'n'}
+	}
+	switch c.Type() {
+	case TypeBool:
+		if v, err := c.AsBool(); err == nil {
+			return []byte{'b', byte(strconv.FormatBool(v)[0])}
+		}
+	case TypeBigInt, TypeDecimal:
+		if v, err := c.AsDecimal(); err == nil {
+			return append([]byte{'d'}, v.String()...)
+		}
+	case TypeString, TypeBytes:
+		if v, err := c.AsBytes(); err == nil {
+			return append([]byte{'s'}, v...)
+		}
+	case TypeTime:
+		if v, err := c.AsTime(); err == nil {
+			b := strconv.AppendInt([]byte{'t'}, v.Unix(), 10)
+			b = append(b, '.')
+			return strconv.AppendInt(b, int64(v.Nanosecond()), 10)
+		}
+	case TypeJSON:
+		if v, err := c.AsBytes(); err == nil {
+			if v, err = canonicalJSON(v); err == nil {
+				return append([]byte{'j'}, v...)
+			}
+		}
+	}
+	return append([]byte{'s'}, c.String()...)
+}
+
+//mixHash 打散哈希值h，避免不同记录之间交换列值时校验和不变
+func mixHash(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
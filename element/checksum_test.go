@@ -0,0 +1,171 @@
+package element
+
+import (
+	"testing"
+	"time"
+)
+
+func testChecksumRecord(values map[string]ColumnValue, names ...string) Record {
+	r := NewDefaultRecord()
+	for _, v := range names {
+		r.Add(NewDefaultColumn(values[v], v, 0))
+	}
+	return r
+}
+
+func TestRecordHash(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	tests := []struct {
+		name  string
+		left  Record
+		right Record
+		want  bool
+	}{
+		{
+			name: "1",
+			left: testChecksumRecord(map[string]ColumnValue{
+				"a": NewInt64ColumnValue(12),
+				"b": NewStringColumnValue("abc"),
+			}, "a", "b"),
+			right: testChecksumRecord(map[string]ColumnValue{
+				"a": testDecimalColumnValueFormString("12.00"),
+				"b": NewBytesColumnValue([]byte("abc")),
+			}, "b", "a"),
+			want: true,
+		},
+		{
+			name: "2",
+			left: testChecksumRecord(map[string]ColumnValue{
+				"a": NewTimeColumnValue(time.Date(2021, 1, 1, 8, 0, 0, 1, shanghai)),
+				"b": testJSONColumnValueFromString(`{"b":1.0, "a":[1]}`),
+				"c": NewFloat64ColumnValue(1.5),
+				"d": NewBoolColumnValue(true),
+				"e": NewNilStringColumnValue(),
+			}, "a", "b", "c", "d", "e"),
+			right: testChecksumRecord(map[string]ColumnValue{
+				"a": NewTimeColumnValue(time.Date(2021, 1, 1, 0, 0, 0, 1, time.UTC)),
+				"b": testJSONColumnValueFromString(`{"a":[1],"b":1}`),
+				"c": testDecimalColumnValueFormString("1.50"),
+				"d": NewBoolColumnValue(true),
+				"e": NewNilBigIntColumnValue(),
+			}, "a", "b", "c", "d", "e"),
+			want: true,
+		},
+		{
+			name: "3",
+			left: testChecksumRecord(map[string]ColumnValue{
+				"a": NewInt64ColumnValue(12),
+			}, "a"),
+			right: testChecksumRecord(map[string]ColumnValue{
+				"a": NewStringColumnValue("12"),
+			}, "a"),
+			want: false,
+		},
+		{
+			name: "4",
+			left: testChecksumRecord(map[string]ColumnValue{
+				"a": NewInt64ColumnValue(12),
+			}, "a"),
+			right: testChecksumRecord(map[string]ColumnValue{
+				"b": NewInt64ColumnValue(12),
+			}, "b"),
+			want: false,
+		},
+		{
+			name: "5",
+			left: testChecksumRecord(map[string]ColumnValue{
+				"a": NewStringColumnValue(""),
+			}, "a"),
+			right: testChecksumRecord(map[string]ColumnValue{
+				"a": NewNilStringColumnValue(),
+			}, "a"),
+			want: false,
+		},
+		{
+			name: "6",
+			left: testChecksumRecord(map[string]ColumnValue{
+				"a": NewBoolColumnValue(true),
+			}, "a"),
+			right: testChecksumRecord(map[string]ColumnValue{
+				"a": NewBoolColumnValue(false),
+			}, "a"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecordHash(tt.left) == RecordHash(tt.right); got != tt.want {
+				t.Errorf("RecordHash() equal = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	records := []Record{
+		testChecksumRecord(map[string]ColumnValue{
+			"a": NewInt64ColumnValue(1),
+			"b": NewStringColumnValue("x"),
+		}, "a", "b"),
+		testChecksumRecord(map[string]ColumnValue{
+			"a": NewInt64ColumnValue(2),
+			"b": NewStringColumnValue("y"),
+		}, "a", "b"),
+		testChecksumRecord(map[string]ColumnValue{
+			"a": NewInt64ColumnValue(3),
+			"b": NewStringColumnValue("z"),
+		}, "a", "b"),
+	}
+
+	var left Checksum
+	for _, v := range records {
+		left.Add(v)
+	}
+	if left.Count() != 3 {
+		t.Errorf("Count() = %v, want 3", left.Count())
+	}
+
+	var hashed Checksum
+	for _, v := range records {
+		hashed.AddHash(RecordHash(v))
+	}
+	if hashed != left {
+		t.Errorf("AddHash() = %v, want %v", hashed, left)
+	}
+
+	//顺序无关，可以分段合并
+	var right, part Checksum
+	right.Add(records[2])
+	part.Add(records[0])
+	part.Add(records[1])
+	right.Merge(part)
+	if left != right {
+		t.Errorf("Checksum = %v, want %v", right, left)
+	}
+
+	//不同记录间交换列值会改变校验和
+	var swapped Checksum
+	swapped.Add(testChecksumRecord(map[string]ColumnValue{
+		"a": NewInt64ColumnValue(1),
+		"b": NewStringColumnValue("y"),
+	}, "a", "b"))
+	swapped.Add(testChecksumRecord(map[string]ColumnValue{
+		"a": NewInt64ColumnValue(2),
+		"b": NewStringColumnValue("x"),
+	}, "a", "b"))
+	swapped.Add(records[2])
+	if left == swapped {
+		t.Errorf("Checksum = %v, want not equal", swapped)
+	}
+
+	//重复的记录不会抵消
+	var duplicated Checksum
+	duplicated.Add(records[0])
+	duplicated.Add(records[0])
+	duplicated.Add(records[2])
+	var single Checksum
+	single.Add(records[2])
+	if duplicated.String() == single.String() {
+		t.Errorf("Checksum = %v, want not equal", duplicated)
+	}
+}
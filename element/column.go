@@ -22,6 +22,7 @@ const (
 	TypeString  ColumnType = "string"  //字符串类型
 	TypeBytes   ColumnType = "bytes"   //字节流类型
 	TypeTime    ColumnType = "time"    //时间类型
+	TypeJSON    ColumnType = "json"    //JSON类型
 )
 
 //String 打印显示
@@ -54,6 +55,16 @@ type ColumnValueComparabale interface {
 	Cmp(ColumnValue) (int, error)
 }
 
+//ColumnValueInt64 可以直接转化为64位整数的列值，列转化为整数时不经过big.Int
+type ColumnValueInt64 interface {
+	AsInt64() (int64, error) //转化为64位整数
+}
+
+//ColumnValueFloat64 可以直接转化为64位实数的列值，列转化为实数时不经过decimal
+type ColumnValueFloat64 interface {
+	AsFloat64() (float64, error) //转化为64位实数
+}
+
 //Column 列
 type Column interface {
 	ColumnValue
@@ -124,6 +135,25 @@ func (n *nilColumnValue) String() string {
 	return "<nil>"
 }
 
+//NewNilColumnValue 生成类型为typ的空值，未知类型生成字符串空值
+func NewNilColumnValue(typ ColumnType) ColumnValue {
+	switch typ {
+	case TypeBool:
+		return NewNilBoolColumnValue()
+	case TypeBigInt:
+		return NewNilBigIntColumnValue()
+	case TypeDecimal:
+		return NewNilDecimalColumnValue()
+	case TypeBytes:
+		return NewNilBytesColumnValue()
+	case TypeTime:
+		return NewNilTimeColumnValue()
+	case TypeJSON:
+		return NewNilJSONColumnValue()
+	}
+	return NewNilStringColumnValue()
+}
+
 //DefaultColumn 默认值
 type DefaultColumn struct {
 	ColumnValue // 列值
@@ -184,67 +214,64 @@ func (d *DefaultColumn) MemorySize() int64 {
 
 //AsInt8 转化为8位整数
 func (d *DefaultColumn) AsInt8() (int8, error) {
-	bi, err := d.AsBigInt()
+	v, err := d.asInt64("int8")
 	if err != nil {
-		return 0, NewTransformErrorFormString(d.Type().String(), "int8", err)
+		return 0, err
 	}
-	if bi.IsInt64() {
-		v := bi.Int64()
-		if v > math.MaxInt8 || v < math.MinInt8 {
-			return 0, NewTransformErrorFormString(d.Type().String(), "int8", fmt.Errorf("%v %v", v, strconv.ErrRange))
-		}
-		return int8(bi.Int64()), nil
+	if v > math.MaxInt8 || v < math.MinInt8 {
+		return 0, NewTransformErrorFormString(d.Type().String(), "int8", fmt.Errorf("%v %v", v, strconv.ErrRange))
 	}
-
-	return 0, NewTransformErrorFormString(d.Type().String(), "int8", fmt.Errorf("%v %v", d.String(), ErrValueNotInt64))
+	return int8(v), nil
 }
 
 //AsInt16 转化为16位整数
 func (d *DefaultColumn) AsInt16() (int16, error) {
-	bi, err := d.AsBigInt()
+	v, err := d.asInt64("int16")
 	if err != nil {
-		return 0, NewTransformErrorFormString(d.Type().String(), "int16", err)
+		return 0, err
 	}
-	if bi.IsInt64() {
-		v := bi.Int64()
-		if v > math.MaxInt16 || v < math.MinInt16 {
-			return 0, NewTransformErrorFormString(d.Type().String(), "int16", fmt.Errorf("%v %v", v, strconv.ErrRange))
-		}
-		return int16(bi.Int64()), nil
+	if v > math.MaxInt16 || v < math.MinInt16 {
+		return 0, NewTransformErrorFormString(d.Type().String(), "int16", fmt.Errorf("%v %v", v, strconv.ErrRange))
 	}
-	return 0, NewTransformErrorFormString(d.Type().String(), "int16", fmt.Errorf("%v %v", d.String(), ErrValueNotInt64))
+	return int16(v), nil
 }
 
 //AsInt32 转化为32位整数
 func (d *DefaultColumn) AsInt32() (int32, error) {
-	bi, err := d.AsBigInt()
+	v, err := d.asInt64("int32")
 	if err != nil {
-		return 0, NewTransformErrorFormString(d.Type().String(), "int32", err)
+		return 0, err
 	}
-	if bi.IsInt64() {
-		v := bi.Int64()
-		if v > math.MaxInt32 || v < math.MinInt32 {
-			return 0, NewTransformErrorFormString(d.Type().String(), "int32", fmt.Errorf("%v %v", v, strconv.ErrRange))
-		}
-		return int32(bi.Int64()), nil
+	if v > math.MaxInt32 || v < math.MinInt32 {
+		return 0, NewTransformErrorFormString(d.Type().String(), "int32", fmt.Errorf("%v %v", v, strconv.ErrRange))
 	}
-	return 0, NewTransformErrorFormString(d.Type().String(), "int32", fmt.Errorf("%v %v", d.String(), ErrValueNotInt64))
+	return int32(v), nil
 }
 
 //AsInt64 转化为64位整数
 func (d *DefaultColumn) AsInt64() (int64, error) {
-	bi, err := d.AsBigInt()
-	if err != nil {
-		return 0, NewTransformErrorFormString(d.Type().String(), "int64", err)
-	}
-	if bi.IsInt64() {
-		return int64(bi.Int64()), nil
-	}
-	return 0, NewTransformErrorFormString(d.Type().String(), "int64", fmt.Errorf("%v %v", d.String(), ErrValueNotInt64))
+	return d.asInt64("int64")
 }
 
 //AsFloat32 转化为32位实数
 func (d *DefaultColumn) AsFloat32() (float32, error) {
+	if fv, ok := d.ColumnValue.(ColumnValueFloat64); ok {
+		f, err := fv.AsFloat64()
+		if err != nil {
+			return 0, NewTransformErrorFormString(d.Type().String(), "float32", err)
+		}
+		if math.Abs(f) <= math.MaxFloat32 {
+			return float32(f), nil
+		}
+		//超出范围时按照舍入后的结果判断
+		v, _ := big.NewFloat(f).Float32()
+		if math.IsInf(float64(v), 0) {
+			return 0, NewTransformErrorFormString(d.Type().String(), "float32",
+				fmt.Errorf("%v %v", d.String(), strconv.ErrRange))
+		}
+		return v, nil
+	}
+
 	dec, err := d.AsDecimal()
 	if err != nil {
 		return 0, NewTransformErrorFormString(d.Type().String(), "float32", err)
@@ -259,6 +286,14 @@ func (d *DefaultColumn) AsFloat32() (float32, error) {
 
 //AsFloat64 转化为64位实数
 func (d *DefaultColumn) AsFloat64() (float64, error) {
+	if fv, ok := d.ColumnValue.(ColumnValueFloat64); ok {
+		v, err := fv.AsFloat64()
+		if err != nil {
+			return 0, NewTransformErrorFormString(d.Type().String(), "float64", err)
+		}
+		return v, nil
+	}
+
 	dec, err := d.AsDecimal()
 	if err != nil {
 		return 0, NewTransformErrorFormString(d.Type().String(), "float64", err)
@@ -270,3 +305,23 @@ func (d *DefaultColumn) AsFloat64() (float64, error) {
 	}
 	return v, nil
 }
+
+//asInt64 转化为64位整数，列值可以直接转化为64位整数时不经过big.Int
+func (d *DefaultColumn) asInt64(typ string) (int64, error) {
+	if iv, ok := d.ColumnValue.(ColumnValueInt64); ok {
+		v, err := iv.AsInt64()
+		if err != nil {
+			return 0, NewTransformErrorFormString(d.Type().String(), typ, err)
+		}
+		return v, nil
+	}
+
+	bi, err := d.AsBigInt()
+	if err != nil {
+		return 0, NewTransformErrorFormString(d.Type().String(), typ, err)
+	}
+	if bi.IsInt64() {
+		return bi.Int64(), nil
+	}
+	return 0, NewTransformErrorFormString(d.Type().String(), typ, fmt.Errorf("%v %v", d.String(), ErrValueNotInt64))
+}
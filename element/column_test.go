@@ -257,6 +257,63 @@ func Test_nilColumnValue_String(t *testing.T) {
 	}
 }
 
+func TestNewNilColumnValue(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  ColumnType
+		want ColumnType
+	}{
+		{
+			name: "1",
+			typ:  TypeBool,
+			want: TypeBool,
+		},
+		{
+			name: "2",
+			typ:  TypeBigInt,
+			want: TypeBigInt,
+		},
+		{
+			name: "3",
+			typ:  TypeDecimal,
+			want: TypeDecimal,
+		},
+		{
+			name: "4",
+			typ:  TypeString,
+			want: TypeString,
+		},
+		{
+			name: "5",
+			typ:  TypeBytes,
+			want: TypeBytes,
+		},
+		{
+			name: "6",
+			typ:  TypeTime,
+			want: TypeTime,
+		},
+		{
+			name: "7",
+			typ:  TypeJSON,
+			want: TypeJSON,
+		},
+		{
+			name: "8",
+			typ:  TypeUnknown,
+			want: TypeString,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewNilColumnValue(tt.typ)
+			if got.Type() != tt.want || !got.IsNil() {
+				t.Errorf("NewNilColumnValue() = %v(%v), want nil %v", got, got.Type(), tt.want)
+			}
+		})
+	}
+}
+
 func TestDefaultColumn_Name(t *testing.T) {
 	tests := []struct {
 		name string
@@ -371,6 +428,24 @@ func TestDefaultColumn_AsInt8(t *testing.T) {
 			want:    0,
 			wantErr: true,
 		},
+		{
+			name:    "5",
+			d:       NewDefaultColumn(NewInt64ColumnValue(-128), "test", 0).(*DefaultColumn),
+			want:    -128,
+			wantErr: false,
+		},
+		{
+			name:    "6",
+			d:       NewDefaultColumn(NewUint64ColumnValue(128), "test", 0).(*DefaultColumn),
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			d:       NewDefaultColumn(NewFloat64ColumnValue(13.34), "test", 0).(*DefaultColumn),
+			want:    13,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -503,6 +578,24 @@ func TestDefaultColumn_AsInt64(t *testing.T) {
 			want:    0,
 			wantErr: true,
 		},
+		{
+			name:    "4",
+			d:       NewDefaultColumn(NewInt64ColumnValue(math.MinInt64), "test", 0).(*DefaultColumn),
+			want:    math.MinInt64,
+			wantErr: false,
+		},
+		{
+			name:    "5",
+			d:       NewDefaultColumn(NewUint64ColumnValue(math.MaxUint64), "test", 0).(*DefaultColumn),
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			d:       NewDefaultColumn(NewFloat64ColumnValue(math.Inf(1)), "test", 0).(*DefaultColumn),
+			want:    0,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -550,6 +643,24 @@ func TestDefaultColumn_AsFloat32(t *testing.T) {
 			want:    0,
 			wantErr: true,
 		},
+		{
+			name:    "5",
+			d:       NewDefaultColumn(NewFloat64ColumnValue(-1.5), "test", 0).(*DefaultColumn),
+			want:    -1.5,
+			wantErr: false,
+		},
+		{
+			name:    "6",
+			d:       NewDefaultColumn(NewFloat64ColumnValue(1e100), "test", 0).(*DefaultColumn),
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			d:       NewDefaultColumn(NewInt64ColumnValue(3), "test", 0).(*DefaultColumn),
+			want:    3,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -597,6 +708,18 @@ func TestDefaultColumn_AsFloat64(t *testing.T) {
 			want:    0,
 			wantErr: true,
 		},
+		{
+			name:    "5",
+			d:       NewDefaultColumn(NewFloat64ColumnValue(math.MaxFloat64), "test", 0).(*DefaultColumn),
+			want:    math.MaxFloat64,
+			wantErr: false,
+		},
+		{
+			name:    "6",
+			d:       NewDefaultColumn(NewUint64ColumnValue(math.MaxUint64), "test", 0).(*DefaultColumn),
+			want:    float64(math.MaxUint64),
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1,2 +1,2 @@
-//Package element 提供各个类型的列值，列，记录以及记录通道
+//Package element 提供各个类型的列值，列，记录，记录模式以及记录通道
 package element
@@ -17,6 +17,11 @@ var (
 	ErrNotColumnValueClonable   = errors.New("columnValue is not clonable")   //不是可克隆列值
 	ErrNotColumnValueComparable = errors.New("columnValue is not comparable") //不是可比较列值
 	ErrColumnNameNotEqual       = errors.New("column name is not equal")      //列名不同
+	ErrValueNotJSON             = errors.New("value is not json")             //不是JSON错误
+	ErrJSONPathNotExist         = errors.New("json path does not exist")      //JSON路径不存在错误
+
+	ErrRecordVersionNotSupported = errors.New("record codec version is not supported") //记录二进制格式版本不支持错误
+	ErrRecordDataInvalid         = errors.New("record data is invalid")                //记录二进制数据不合法错误
 )
 
 //TransformError 转化错误
@@ -0,0 +1,120 @@
+package element
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+//Float64ColumnValue 64位实数列值，列类型为高精度实数，转化时不经过decimal
+type Float64ColumnValue struct {
+	notNilColumnValue
+
+	val float64 //实数值
+}
+
+//NewFloat64ColumnValue 从float64 v中获取64位实数列值
+func NewFloat64ColumnValue(v float64) ColumnValue {
+	return &Float64ColumnValue{
+		val: v,
+	}
+}
+
+//Type 返回列类型
+func (f *Float64ColumnValue) Type() ColumnType {
+	return TypeDecimal
+}
+
+//AsBool 非0转化为true, 0转化为false
+func (f *Float64ColumnValue) AsBool() (bool, error) {
+	return f.val != 0, nil
+}
+
+//AsBigInt 对实数取整，如123.67转化为123 123.12转化为123，无穷大或者非数会报错
+func (f *Float64ColumnValue) AsBigInt() (*big.Int, error) {
+	d, err := f.decimal(TypeBigInt)
+	if err != nil {
+		return nil, err
+	}
+	return d.BigInt(), nil
+}
+
+//AsDecimal 转化为高精度实数，无穷大或者非数会报错
+func (f *Float64ColumnValue) AsDecimal() (decimal.Decimal, error) {
+	return f.decimal(TypeDecimal)
+}
+
+//AsString 转化为字符串， 如10.123 转化为10.123
+func (f *Float64ColumnValue) AsString() (string, error) {
+	return f.String(), nil
+}
+
+//AsBytes 转化为字节流， 如10.123 转化为10.123
+func (f *Float64ColumnValue) AsBytes() ([]byte, error) {
+	return strconv.AppendFloat(nil, f.val, 'f', -1, 64), nil
+}
+
+//AsTime 目前无法转化为时间
+func (f *Float64ColumnValue) AsTime() (time.Time, error) {
+	return time.Time{}, NewTransformErrorFormColumnTypes(f.Type(), TypeTime, fmt.Errorf(" val: %v", f.String()))
+}
+
+//AsInt64 对实数取整转化为64位整数，超出int64范围时会报错
+func (f *Float64ColumnValue) AsInt64() (int64, error) {
+	v := math.Trunc(f.val)
+	if v >= -math.MinInt64 || v < math.MinInt64 || math.IsNaN(v) {
+		return 0, NewTransformErrorFormString(f.Type().String(), "int64", fmt.Errorf("%v %v", f.String(), ErrValueNotInt64))
+	}
+	return int64(v), nil
+}
+
+//AsFloat64 转化为64位实数
+func (f *Float64ColumnValue) AsFloat64() (float64, error) {
+	return f.val, nil
+}
+
+func (f *Float64ColumnValue) String() string {
+	return strconv.FormatFloat(f.val, 'f', -1, 64)
+}
+
+//Clone 克隆64位实数列值
+func (f *Float64ColumnValue) Clone() ColumnValue {
+	return NewFloat64ColumnValue(f.val)
+}
+
+//Cmp  返回1代表大于， 0代表相等， -1代表小于，
+//右值为64位实数时直接比较，否则转化为高精度实数比较
+func (f *Float64ColumnValue) Cmp(right ColumnValue) (int, error) {
+	if r, ok := unwrapColumnValue(right).(*Float64ColumnValue); ok {
+		switch {
+		case f.val > r.val:
+			return 1, nil
+		case f.val < r.val:
+			return -1, nil
+		case f.val == r.val:
+			return 0, nil
+		}
+		return 0, NewTransformErrorFormColumnTypes(f.Type(), TypeDecimal, fmt.Errorf("val: %v %v", f.String(), r.String()))
+	}
+
+	left, err := f.decimal(TypeDecimal)
+	if err != nil {
+		return 0, err
+	}
+	rightValue, err := right.AsDecimal()
+	if err != nil {
+		return 0, err
+	}
+	return left.Cmp(rightValue), nil
+}
+
+func (f *Float64ColumnValue) decimal(typ ColumnType) (decimal.Decimal, error) {
+	if math.IsInf(f.val, 0) || math.IsNaN(f.val) {
+		return decimal.Decimal{}, NewTransformErrorFormColumnTypes(f.Type(), typ, fmt.Errorf("%v %v", f.String(), ErrValueInfinity))
+	}
+	return decimal.NewFromFloat(f.val), nil
+}
@@ -0,0 +1,295 @@
+package element
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFloat64ColumnValue_Type(t *testing.T) {
+	if got := NewFloat64ColumnValue(1).Type(); got != TypeDecimal {
+		t.Errorf("Float64ColumnValue.Type() = %v, want %v", got, TypeDecimal)
+	}
+}
+
+func TestFloat64ColumnValue_AsBool(t *testing.T) {
+	tests := []struct {
+		name string
+		f    ColumnValue
+		want bool
+	}{
+		{
+			name: "1",
+			f:    NewFloat64ColumnValue(0),
+			want: false,
+		},
+		{
+			name: "2",
+			f:    NewFloat64ColumnValue(0.1),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.AsBool()
+			if err != nil {
+				t.Errorf("Float64ColumnValue.AsBool() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Float64ColumnValue.AsBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFloat64ColumnValue_AsBigInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       ColumnValue
+		want    *big.Int
+		wantErr bool
+	}{
+		{
+			name: "1",
+			f:    NewFloat64ColumnValue(123.67),
+			want: big.NewInt(123),
+		},
+		{
+			name: "2",
+			f:    NewFloat64ColumnValue(-123.12),
+			want: big.NewInt(-123),
+		},
+		{
+			name: "3",
+			f:    NewFloat64ColumnValue(1e20),
+			want: testBigIntFromString("100000000000000000000"),
+		},
+		{
+			name:    "4",
+			f:       NewFloat64ColumnValue(math.Inf(1)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.AsBigInt()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Float64ColumnValue.AsBigInt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Cmp(tt.want) != 0 {
+				t.Errorf("Float64ColumnValue.AsBigInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFloat64ColumnValue_AsDecimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       ColumnValue
+		want    decimal.Decimal
+		wantErr bool
+	}{
+		{
+			name: "1",
+			f:    NewFloat64ColumnValue(10.123),
+			want: decimal.RequireFromString("10.123"),
+		},
+		{
+			name:    "2",
+			f:       NewFloat64ColumnValue(math.NaN()),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.AsDecimal()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Float64ColumnValue.AsDecimal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Float64ColumnValue.AsDecimal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFloat64ColumnValue_AsString(t *testing.T) {
+	tests := []struct {
+		name string
+		f    ColumnValue
+		want string
+	}{
+		{
+			name: "1",
+			f:    NewFloat64ColumnValue(10.123),
+			want: "10.123",
+		},
+		{
+			name: "2",
+			f:    NewFloat64ColumnValue(1e21),
+			want: "1000000000000000000000",
+		},
+		{
+			name: "3",
+			f:    NewFloat64ColumnValue(-0.000001),
+			want: "-0.000001",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.AsString()
+			if err != nil {
+				t.Errorf("Float64ColumnValue.AsString() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Float64ColumnValue.AsString() = %v, want %v", got, tt.want)
+			}
+			b, _ := tt.f.AsBytes()
+			if string(b) != tt.want {
+				t.Errorf("Float64ColumnValue.AsBytes() = %v, want %v", string(b), tt.want)
+			}
+			d, _ := tt.f.AsDecimal()
+			if d.String() != tt.want {
+				t.Errorf("Float64ColumnValue.AsDecimal() = %v, want %v", d.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFloat64ColumnValue_AsTime(t *testing.T) {
+	if _, err := NewFloat64ColumnValue(1).AsTime(); err == nil {
+		t.Errorf("Float64ColumnValue.AsTime() error = %v, wantErr true", err)
+	}
+}
+
+func TestFloat64ColumnValue_AsInt64(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       *Float64ColumnValue
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "1",
+			f:    NewFloat64ColumnValue(-13.9).(*Float64ColumnValue),
+			want: -13,
+		},
+		{
+			name:    "2",
+			f:       NewFloat64ColumnValue(1e19).(*Float64ColumnValue),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			f:       NewFloat64ColumnValue(math.NaN()).(*Float64ColumnValue),
+			wantErr: true,
+		},
+		{
+			name: "4",
+			f:    NewFloat64ColumnValue(math.MinInt64).(*Float64ColumnValue),
+			want: math.MinInt64,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.AsInt64()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Float64ColumnValue.AsInt64() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Float64ColumnValue.AsInt64() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFloat64ColumnValue_Clone(t *testing.T) {
+	f := NewFloat64ColumnValue(1.5).(*Float64ColumnValue)
+	c := f.Clone()
+	if c == ColumnValue(f) {
+		t.Errorf("Float64ColumnValue.Clone() = %p, want different from %p", c, f)
+	}
+	if !reflect.DeepEqual(c, ColumnValue(f)) {
+		t.Errorf("Float64ColumnValue.Clone() = %v, want %v", c, f)
+	}
+	if v, _ := f.AsFloat64(); v != 1.5 {
+		t.Errorf("Float64ColumnValue.AsFloat64() = %v, want 1.5", v)
+	}
+}
+
+func TestFloat64ColumnValue_Cmp(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       *Float64ColumnValue
+		right   ColumnValue
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			f:     NewFloat64ColumnValue(1.5).(*Float64ColumnValue),
+			right: NewFloat64ColumnValue(2.5),
+			want:  -1,
+		},
+		{
+			name:  "2",
+			f:     NewFloat64ColumnValue(1.5).(*Float64ColumnValue),
+			right: NewDefaultColumn(NewFloat64ColumnValue(1.5), "test", 0),
+			want:  0,
+		},
+		{
+			name:  "3",
+			f:     NewFloat64ColumnValue(0.1).(*Float64ColumnValue),
+			right: NewDecimalColumnValue(decimal.RequireFromString("0.1")),
+			want:  0,
+		},
+		{
+			name:  "4",
+			f:     NewFloat64ColumnValue(2).(*Float64ColumnValue),
+			right: NewInt64ColumnValue(1),
+			want:  1,
+		},
+		{
+			name:    "5",
+			f:       NewFloat64ColumnValue(math.NaN()).(*Float64ColumnValue),
+			right:   NewFloat64ColumnValue(1),
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			f:       NewFloat64ColumnValue(1).(*Float64ColumnValue),
+			right:   NewNilDecimalColumnValue(),
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			f:       NewFloat64ColumnValue(math.Inf(-1)).(*Float64ColumnValue),
+			right:   NewDecimalColumnValueFromFloat(1),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.Cmp(tt.right)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Float64ColumnValue.Cmp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Float64ColumnValue.Cmp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package element
+
+import (
+	"fmt"
+)
+
+//ColumnIndex 列名到列索引的映射，生成后不可修改，可以在同一个任务的所有记录间共享
+type ColumnIndex struct {
+	names   []string
+	indexes map[string]int
+}
+
+//NewColumnIndex 通过有序的列名names生成列名索引，列名重复时会报错
+func NewColumnIndex(names []string) (*ColumnIndex, error) {
+	c := &ColumnIndex{
+		names:   make([]string, len(names)),
+		indexes: make(map[string]int, len(names)),
+	}
+	copy(c.names, names)
+	for i, v := range c.names {
+		if _, ok := c.indexes[v]; ok {
+			return nil, fmt.Errorf("column(%v) err: %v", v, ErrColumnExist)
+		}
+		c.indexes[v] = i
+	}
+	return c, nil
+}
+
+//Index 获取列名为name的列索引
+func (c *ColumnIndex) Index(name string) (int, bool) {
+	i, ok := c.indexes[name]
+	return i, ok
+}
+
+//Name 获取第i列的列名
+func (c *ColumnIndex) Name(i int) string {
+	return c.names[i]
+}
+
+//Len 列数量
+func (c *ColumnIndex) Len() int {
+	return len(c.names)
+}
+
+//IndexRecord 索引记录，列通过数组存储，按照索引获取列时不需要计算哈希，
+//当按照列名索引的列顺序加入列时，通过列名索引获取列，否则通过遍历获取列
+type IndexRecord struct {
+	index      *ColumnIndex //列名索引，列的加入顺序与列名索引不同时为空
+	columns    []Column     //列数组
+	byteSize   int64        //字节流大小
+	memorySize int64        //内存大小
+}
+
+//NewIndexRecord 通过列名索引index创建索引记录，index可以为空
+func NewIndexRecord(index *ColumnIndex) *IndexRecord {
+	r := &IndexRecord{
+		index: index,
+	}
+	if index != nil {
+		r.columns = make([]Column, 0, index.Len())
+	}
+	return r
+}
+
+//Add 新增列c,若列c已经存在，就会报错
+func (r *IndexRecord) Add(c Column) error {
+	n := len(r.columns)
+	if r.index != nil && n < r.index.Len() && r.index.Name(n) == c.Name() {
+		r.columns = append(r.columns, c)
+		r.incSize(c)
+		return nil
+	}
+
+	if _, ok := r.find(c.Name()); ok {
+		return ErrColumnExist
+	}
+	r.index = nil
+	r.columns = append(r.columns, c)
+	r.incSize(c)
+	return nil
+}
+
+//GetByIndex 获取第i列,若索引i超出范围，就会报错
+func (r *IndexRecord) GetByIndex(i int) (Column, error) {
+	if i >= len(r.columns) || i < 0 {
+		return nil, ErrIndexOutOfRange
+	}
+	return r.columns[i], nil
+}
+
+//GetByName 获取列名为name的列,若列名为name的列不存在，就会报错
+func (r *IndexRecord) GetByName(name string) (Column, error) {
+	if i, ok := r.find(name); ok {
+		return r.columns[i], nil
+	}
+	return nil, ErrColumnNotExist
+}
+
+//Set 设置第i列,若索引i超出范围，就会报错
+func (r *IndexRecord) Set(i int, c Column) error {
+	if i >= len(r.columns) || i < 0 {
+		return ErrIndexOutOfRange
+	}
+	if r.index != nil && r.index.Name(i) != c.Name() {
+		r.index = nil
+	}
+	r.decSize(r.columns[i])
+	r.columns[i] = c
+	r.incSize(c)
+	return nil
+}
+
+//ColumnNumber 列数量
+func (r *IndexRecord) ColumnNumber() int {
+	return len(r.columns)
+}
+
+//ByteSize 字节流大小
+func (r *IndexRecord) ByteSize() int64 {
+	return r.byteSize
+}
+
+//MemorySize 内存大小
+func (r *IndexRecord) MemorySize() int64 {
+	return r.memorySize
+}
+
+//ColumnIndex 列名索引，列的加入顺序与列名索引不同时为空
+func (r *IndexRecord) ColumnIndex() *ColumnIndex {
+	return r.index
+}
+
+//Names 按照列顺序获取所有列名
+func (r *IndexRecord) Names() []string {
+	names := make([]string, len(r.columns))
+	for i, v := range r.columns {
+		names[i] = v.Name()
+	}
+	return names
+}
+
+//Reset 清空所有列并将列名索引设置为index，保留列数组的容量以便复用
+func (r *IndexRecord) Reset(index *ColumnIndex) {
+	for i := range r.columns {
+		r.columns[i] = nil
+	}
+	r.columns = r.columns[:0]
+	r.index = index
+	r.byteSize = 0
+	r.memorySize = 0
+}
+
+func (r *IndexRecord) find(name string) (int, bool) {
+	if r.index != nil {
+		if i, ok := r.index.Index(name); ok && i < len(r.columns) {
+			return i, true
+		}
+		return 0, false
+	}
+	for i, v := range r.columns {
+		if v.Name() == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (r *IndexRecord) incSize(c Column) {
+	r.byteSize += c.ByteSize()
+	r.memorySize += c.MemorySize()
+}
+
+func (r *IndexRecord) decSize(c Column) {
+	r.byteSize -= c.ByteSize()
+	r.memorySize -= c.MemorySize()
+}
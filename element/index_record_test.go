@@ -0,0 +1,249 @@
+package element
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testColumnIndex(names ...string) *ColumnIndex {
+	c, err := NewColumnIndex(names)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func TestNewColumnIndex(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			names: []string{"a", "b", "c"},
+		},
+		{
+			name: "2",
+		},
+		{
+			name:    "3",
+			names:   []string{"a", "b", "a"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewColumnIndex(tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewColumnIndex() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Len() != len(tt.names) {
+				t.Errorf("NewColumnIndex() Len() = %v, want %v", got.Len(), len(tt.names))
+			}
+			for i, v := range tt.names {
+				if got.Name(i) != v {
+					t.Errorf("NewColumnIndex() Name(%v) = %v, want %v", i, got.Name(i), v)
+				}
+				if j, ok := got.Index(v); !ok || j != i {
+					t.Errorf("NewColumnIndex() Index(%v) = %v %v, want %v true", v, j, ok, i)
+				}
+			}
+			if _, ok := got.Index("d"); ok {
+				t.Errorf("NewColumnIndex() Index(d) = true, want false")
+			}
+		})
+	}
+}
+
+func TestIndexRecord_Add(t *testing.T) {
+	tests := []struct {
+		name      string
+		r         *IndexRecord
+		columns   []Column
+		wantErr   bool
+		wantIndex bool
+		wantNames []string
+	}{
+		{
+			name: "1",
+			r:    NewIndexRecord(testColumnIndex("a", "b")),
+			columns: []Column{
+				NewDefaultColumn(NewBigIntColumnValueFromInt64(1), "a", 1),
+				NewDefaultColumn(NewStringColumnValue("x"), "b", 1),
+			},
+			wantIndex: true,
+			wantNames: []string{"a", "b"},
+		},
+		{
+			name: "2",
+			r:    NewIndexRecord(testColumnIndex("a", "b")),
+			columns: []Column{
+				NewDefaultColumn(NewStringColumnValue("x"), "b", 1),
+				NewDefaultColumn(NewBigIntColumnValueFromInt64(1), "a", 1),
+			},
+			wantNames: []string{"b", "a"},
+		},
+		{
+			name: "3",
+			r:    NewIndexRecord(nil),
+			columns: []Column{
+				NewDefaultColumn(NewBigIntColumnValueFromInt64(1), "a", 1),
+				NewDefaultColumn(NewStringColumnValue("x"), "b", 1),
+			},
+			wantNames: []string{"a", "b"},
+		},
+		{
+			name: "4",
+			r:    NewIndexRecord(testColumnIndex("a", "b")),
+			columns: []Column{
+				NewDefaultColumn(NewBigIntColumnValueFromInt64(1), "a", 1),
+				NewDefaultColumn(NewStringColumnValue("x"), "a", 1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "5",
+			r:    NewIndexRecord(nil),
+			columns: []Column{
+				NewDefaultColumn(NewBigIntColumnValueFromInt64(1), "a", 1),
+				NewDefaultColumn(NewStringColumnValue("x"), "a", 1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "6",
+			r:    NewIndexRecord(testColumnIndex("a")),
+			columns: []Column{
+				NewDefaultColumn(NewBigIntColumnValueFromInt64(1), "a", 1),
+				NewDefaultColumn(NewStringColumnValue("x"), "b", 1),
+			},
+			wantNames: []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			for _, c := range tt.columns {
+				if err = tt.r.Add(c); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IndexRecord.Add() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if (tt.r.ColumnIndex() != nil) != tt.wantIndex {
+				t.Errorf("IndexRecord.ColumnIndex() = %v, wantIndex %v", tt.r.ColumnIndex(), tt.wantIndex)
+			}
+			if got := tt.r.Names(); !reflect.DeepEqual(got, tt.wantNames) {
+				t.Errorf("IndexRecord.Names() = %v, want %v", got, tt.wantNames)
+			}
+			for i, v := range tt.wantNames {
+				c, err := tt.r.GetByName(v)
+				if err != nil {
+					t.Errorf("IndexRecord.GetByName(%v) error = %v", v, err)
+					continue
+				}
+				want, _ := tt.r.GetByIndex(i)
+				if c != want {
+					t.Errorf("IndexRecord.GetByName(%v) = %v, want %v", v, c, want)
+				}
+			}
+			if _, err = tt.r.GetByName("c"); err != ErrColumnNotExist {
+				t.Errorf("IndexRecord.GetByName(c) error = %v, want %v", err, ErrColumnNotExist)
+			}
+		})
+	}
+}
+
+func TestIndexRecord(t *testing.T) {
+	r := NewIndexRecord(testColumnIndex("test", "test2"))
+	if err := r.Add(NewDefaultColumn(NewNilBigIntColumnValue(), "test", 0)); err != nil {
+		t.Errorf("IndexRecord.Add() error = %v", err)
+		return
+	}
+
+	if _, err := r.GetByName("test2"); err != ErrColumnNotExist {
+		t.Errorf("IndexRecord.GetByName() error = %v, want %v", err, ErrColumnNotExist)
+		return
+	}
+
+	if _, err := r.GetByIndex(1); err != ErrIndexOutOfRange {
+		t.Errorf("IndexRecord.GetByIndex() error = %v, want %v", err, ErrIndexOutOfRange)
+		return
+	}
+
+	if _, err := r.GetByIndex(-1); err != ErrIndexOutOfRange {
+		t.Errorf("IndexRecord.GetByIndex() error = %v, want %v", err, ErrIndexOutOfRange)
+		return
+	}
+
+	if s := r.ByteSize(); s != 0 {
+		t.Errorf("IndexRecord.ByteSize() = %v, want 0", s)
+		return
+	}
+
+	if s := r.MemorySize(); s != 8 {
+		t.Errorf("IndexRecord.MemorySize() = %v, want 8", s)
+		return
+	}
+
+	if n := r.ColumnNumber(); n != 1 {
+		t.Errorf("IndexRecord.ColumnNumber() = %v, want 1", n)
+		return
+	}
+
+	if err := r.Set(0, NewDefaultColumn(NewBoolColumnValue(true), "test", 10)); err != nil {
+		t.Errorf("IndexRecord.Set() error = %v", err)
+		return
+	}
+
+	if s := r.ByteSize(); s != 10 {
+		t.Errorf("IndexRecord.ByteSize() = %v, want 10", s)
+		return
+	}
+
+	if r.ColumnIndex() == nil {
+		t.Errorf("IndexRecord.ColumnIndex() = nil, want not nil")
+		return
+	}
+
+	if err := r.Set(1, NewDefaultColumn(NewNilBoolColumnValue(), "test", 10)); err != ErrIndexOutOfRange {
+		t.Errorf("IndexRecord.Set() error = %v, want %v", err, ErrIndexOutOfRange)
+		return
+	}
+
+	if err := r.Set(0, NewDefaultColumn(NewNilBoolColumnValue(), "other", 10)); err != nil {
+		t.Errorf("IndexRecord.Set() error = %v", err)
+		return
+	}
+
+	if r.ColumnIndex() != nil {
+		t.Errorf("IndexRecord.ColumnIndex() = %v, want nil", r.ColumnIndex())
+		return
+	}
+
+	if _, err := r.GetByName("other"); err != nil {
+		t.Errorf("IndexRecord.GetByName() error = %v", err)
+		return
+	}
+
+	r.Reset(nil)
+	if n := r.ColumnNumber(); n != 0 {
+		t.Errorf("IndexRecord.ColumnNumber() = %v, want 0", n)
+		return
+	}
+
+	if s := r.MemorySize(); s != 0 {
+		t.Errorf("IndexRecord.MemorySize() = %v, want 0", s)
+		return
+	}
+}
@@ -0,0 +1,116 @@
+package element
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+//Int64ColumnValue 64位整数列值，列类型为整数，转化时不经过big.Int
+type Int64ColumnValue struct {
+	notNilColumnValue
+
+	val int64 //整数值
+}
+
+//NewInt64ColumnValue 从int64 v中获取64位整数列值
+func NewInt64ColumnValue(v int64) ColumnValue {
+	return &Int64ColumnValue{
+		val: v,
+	}
+}
+
+//Type 返回列类型
+func (i *Int64ColumnValue) Type() ColumnType {
+	return TypeBigInt
+}
+
+//AsBool 转化成布尔值，不是0的转化为true,是0的转化成false
+func (i *Int64ColumnValue) AsBool() (bool, error) {
+	return i.val != 0, nil
+}
+
+//AsBigInt 转化成整数
+func (i *Int64ColumnValue) AsBigInt() (*big.Int, error) {
+	return big.NewInt(i.val), nil
+}
+
+//AsDecimal 转化成高精度实数
+func (i *Int64ColumnValue) AsDecimal() (decimal.Decimal, error) {
+	return decimal.New(i.val, 0), nil
+}
+
+//AsString 转化成字符串，如1234556790转化为1234556790
+func (i *Int64ColumnValue) AsString() (string, error) {
+	return i.String(), nil
+}
+
+//AsBytes 转化成字节流，如1234556790转化为1234556790
+func (i *Int64ColumnValue) AsBytes() ([]byte, error) {
+	return strconv.AppendInt(nil, i.val, 10), nil
+}
+
+//AsTime 目前整数无法转化成时间
+func (i *Int64ColumnValue) AsTime() (time.Time, error) {
+	return time.Time{}, NewTransformErrorFormColumnTypes(i.Type(), TypeTime, fmt.Errorf(" val: %v", i.String()))
+}
+
+//AsInt64 转化成64位整数
+func (i *Int64ColumnValue) AsInt64() (int64, error) {
+	return i.val, nil
+}
+
+//AsFloat64 转化成64位实数
+func (i *Int64ColumnValue) AsFloat64() (float64, error) {
+	return float64(i.val), nil
+}
+
+func (i *Int64ColumnValue) String() string {
+	return strconv.FormatInt(i.val, 10)
+}
+
+//Clone 克隆64位整数列值
+func (i *Int64ColumnValue) Clone() ColumnValue {
+	return NewInt64ColumnValue(i.val)
+}
+
+//Cmp  返回1代表大于， 0代表相等， -1代表小于，
+//右值为64位整数或者64位非负整数时直接比较，否则转化为整数比较
+func (i *Int64ColumnValue) Cmp(right ColumnValue) (int, error) {
+	switch r := unwrapColumnValue(right).(type) {
+	case *Int64ColumnValue:
+		return cmpInt64(i.val, r.val), nil
+	case *Uint64ColumnValue:
+		if r.val > math.MaxInt64 {
+			return -1, nil
+		}
+		return cmpInt64(i.val, int64(r.val)), nil
+	}
+	rightValue, err := right.AsBigInt()
+	if err != nil {
+		return 0, err
+	}
+	return big.NewInt(i.val).Cmp(rightValue), nil
+}
+
+//unwrapColumnValue 获取列v中的列值，方便按照具体的列值类型比较
+func unwrapColumnValue(v ColumnValue) ColumnValue {
+	if c, ok := v.(*DefaultColumn); ok {
+		return c.ColumnValue
+	}
+	return v
+}
+
+func cmpInt64(left, right int64) int {
+	switch {
+	case left > right:
+		return 1
+	case left < right:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,239 @@
+package element
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestInt64ColumnValue_Type(t *testing.T) {
+	if got := NewInt64ColumnValue(1).Type(); got != TypeBigInt {
+		t.Errorf("Int64ColumnValue.Type() = %v, want %v", got, TypeBigInt)
+	}
+}
+
+func TestInt64ColumnValue_AsBool(t *testing.T) {
+	tests := []struct {
+		name string
+		i    ColumnValue
+		want bool
+	}{
+		{
+			name: "1",
+			i:    NewInt64ColumnValue(0),
+			want: false,
+		},
+		{
+			name: "2",
+			i:    NewInt64ColumnValue(-1),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.i.AsBool()
+			if err != nil {
+				t.Errorf("Int64ColumnValue.AsBool() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Int64ColumnValue.AsBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInt64ColumnValue_AsBigInt(t *testing.T) {
+	tests := []struct {
+		name string
+		i    ColumnValue
+		want *big.Int
+	}{
+		{
+			name: "1",
+			i:    NewInt64ColumnValue(math.MaxInt64),
+			want: big.NewInt(math.MaxInt64),
+		},
+		{
+			name: "2",
+			i:    NewInt64ColumnValue(math.MinInt64),
+			want: big.NewInt(math.MinInt64),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.i.AsBigInt()
+			if err != nil {
+				t.Errorf("Int64ColumnValue.AsBigInt() error = %v", err)
+				return
+			}
+			if got.Cmp(tt.want) != 0 {
+				t.Errorf("Int64ColumnValue.AsBigInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInt64ColumnValue_AsDecimal(t *testing.T) {
+	tests := []struct {
+		name string
+		i    ColumnValue
+		want decimal.Decimal
+	}{
+		{
+			name: "1",
+			i:    NewInt64ColumnValue(0),
+			want: decimal.Zero,
+		},
+		{
+			name: "2",
+			i:    NewInt64ColumnValue(-123456789),
+			want: decimal.New(-123456789, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.i.AsDecimal()
+			if err != nil {
+				t.Errorf("Int64ColumnValue.AsDecimal() error = %v", err)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Int64ColumnValue.AsDecimal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInt64ColumnValue_AsString(t *testing.T) {
+	tests := []struct {
+		name string
+		i    ColumnValue
+		want string
+	}{
+		{
+			name: "1",
+			i:    NewInt64ColumnValue(math.MinInt64),
+			want: "-9223372036854775808",
+		},
+		{
+			name: "2",
+			i:    NewInt64ColumnValue(1234556790),
+			want: "1234556790",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.i.AsString()
+			if err != nil {
+				t.Errorf("Int64ColumnValue.AsString() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Int64ColumnValue.AsString() = %v, want %v", got, tt.want)
+			}
+			b, _ := tt.i.AsBytes()
+			if string(b) != tt.want {
+				t.Errorf("Int64ColumnValue.AsBytes() = %v, want %v", string(b), tt.want)
+			}
+			if tt.i.String() != tt.want {
+				t.Errorf("Int64ColumnValue.String() = %v, want %v", tt.i.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestInt64ColumnValue_AsTime(t *testing.T) {
+	if _, err := NewInt64ColumnValue(1).AsTime(); err == nil {
+		t.Errorf("Int64ColumnValue.AsTime() error = %v, wantErr true", err)
+	}
+}
+
+func TestInt64ColumnValue_AsInt64(t *testing.T) {
+	i := NewInt64ColumnValue(math.MinInt64).(*Int64ColumnValue)
+	if got, err := i.AsInt64(); err != nil || got != math.MinInt64 {
+		t.Errorf("Int64ColumnValue.AsInt64() = %v %v, want %v", got, err, int64(math.MinInt64))
+	}
+	if got, err := i.AsFloat64(); err != nil || got != float64(math.MinInt64) {
+		t.Errorf("Int64ColumnValue.AsFloat64() = %v %v, want %v", got, err, float64(math.MinInt64))
+	}
+}
+
+func TestInt64ColumnValue_Clone(t *testing.T) {
+	i := NewInt64ColumnValue(1).(*Int64ColumnValue)
+	c := i.Clone()
+	if c == ColumnValue(i) {
+		t.Errorf("Int64ColumnValue.Clone() = %p, want different from %p", c, i)
+	}
+	if !reflect.DeepEqual(c, ColumnValue(i)) {
+		t.Errorf("Int64ColumnValue.Clone() = %v, want %v", c, i)
+	}
+}
+
+func TestInt64ColumnValue_Cmp(t *testing.T) {
+	tests := []struct {
+		name    string
+		i       *Int64ColumnValue
+		right   ColumnValue
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			i:     NewInt64ColumnValue(1).(*Int64ColumnValue),
+			right: NewInt64ColumnValue(2),
+			want:  -1,
+		},
+		{
+			name:  "2",
+			i:     NewInt64ColumnValue(math.MaxInt64).(*Int64ColumnValue),
+			right: NewDefaultColumn(NewInt64ColumnValue(math.MaxInt64-1), "test", 0),
+			want:  1,
+		},
+		{
+			name:  "3",
+			i:     NewInt64ColumnValue(math.MaxInt64).(*Int64ColumnValue),
+			right: NewUint64ColumnValue(math.MaxUint64),
+			want:  -1,
+		},
+		{
+			name:  "4",
+			i:     NewInt64ColumnValue(5).(*Int64ColumnValue),
+			right: NewUint64ColumnValue(5),
+			want:  0,
+		},
+		{
+			name:  "5",
+			i:     NewInt64ColumnValue(5).(*Int64ColumnValue),
+			right: NewBigIntColumnValueFromInt64(6),
+			want:  -1,
+		},
+		{
+			name:  "6",
+			i:     NewInt64ColumnValue(5).(*Int64ColumnValue),
+			right: NewStringColumnValue("4"),
+			want:  1,
+		},
+		{
+			name:    "7",
+			i:       NewInt64ColumnValue(5).(*Int64ColumnValue),
+			right:   NewNilBigIntColumnValue(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.i.Cmp(tt.right)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Int64ColumnValue.Cmp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Int64ColumnValue.Cmp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
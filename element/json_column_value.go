@@ -0,0 +1,338 @@
+package element
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+//NilJSONColumnValue 空值JSON列值
+type NilJSONColumnValue struct {
+	*nilColumnValue
+}
+
+//NewNilJSONColumnValue 创建空值JSON列值
+func NewNilJSONColumnValue() ColumnValue {
+	return &NilJSONColumnValue{
+		nilColumnValue: &nilColumnValue{},
+	}
+}
+
+//Type 返回列类型
+func (n *NilJSONColumnValue) Type() ColumnType {
+	return TypeJSON
+}
+
+//Clone 克隆空值JSON列值
+func (n *NilJSONColumnValue) Clone() ColumnValue {
+	return NewNilJSONColumnValue()
+}
+
+//JSONColumnValue JSON列值，保存经过校验的原始JSON文本
+type JSONColumnValue struct {
+	notNilColumnValue
+
+	val []byte //原始JSON文本
+}
+
+//NewJSONColumnValue 从JSON文本v生成JSON列值，v不是合法的JSON时会报错
+func NewJSONColumnValue(v []byte) (ColumnValue, error) {
+	if !json.Valid(v) {
+		return nil, NewSetError(v, TypeJSON, ErrValueNotJSON)
+	}
+	return &JSONColumnValue{
+		val: v,
+	}, nil
+}
+
+//NewJSONColumnValueFromString 从JSON字符串s生成JSON列值，s不是合法的JSON时会报错
+func NewJSONColumnValueFromString(s string) (ColumnValue, error) {
+	if !json.Valid([]byte(s)) {
+		return nil, NewSetError(s, TypeJSON, ErrValueNotJSON)
+	}
+	return &JSONColumnValue{
+		val: []byte(s),
+	}, nil
+}
+
+//Type 返回列类型
+func (j *JSONColumnValue) Type() ColumnType {
+	return TypeJSON
+}
+
+//AsBool JSON的true和false转化为布尔值，其他JSON会报错
+func (j *JSONColumnValue) AsBool() (bool, error) {
+	v, err := j.decode()
+	if err != nil {
+		return false, NewTransformErrorFormColumnTypes(j.Type(), TypeBool, err)
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, NewTransformErrorFormColumnTypes(j.Type(), TypeBool, fmt.Errorf("val: %v", j.String()))
+	}
+	return b, nil
+}
+
+//AsBigInt JSON的数值转化为整数，实数会被取整，其他JSON会报错
+func (j *JSONColumnValue) AsBigInt() (*big.Int, error) {
+	d, err := j.number()
+	if err != nil {
+		return nil, NewTransformErrorFormColumnTypes(j.Type(), TypeBigInt, err)
+	}
+	return d.BigInt(), nil
+}
+
+//AsDecimal JSON的数值转化为高精度实数，其他JSON会报错
+func (j *JSONColumnValue) AsDecimal() (decimal.Decimal, error) {
+	d, err := j.number()
+	if err != nil {
+		return decimal.Decimal{}, NewTransformErrorFormColumnTypes(j.Type(), TypeDecimal, err)
+	}
+	return d, nil
+}
+
+//AsString 转化为原始JSON文本
+func (j *JSONColumnValue) AsString() (string, error) {
+	return j.String(), nil
+}
+
+//AsBytes 转化为原始JSON文本的字节流
+func (j *JSONColumnValue) AsBytes() ([]byte, error) {
+	v := make([]byte, len(j.val))
+	copy(v, j.val)
+	return v, nil
+}
+
+//AsTime 无法转化为时间
+func (j *JSONColumnValue) AsTime() (time.Time, error) {
+	return time.Time{}, NewTransformErrorFormColumnTypes(j.Type(), TypeTime, fmt.Errorf("val: %v", j.String()))
+}
+
+func (j *JSONColumnValue) String() string {
+	return string(j.val)
+}
+
+//Clone 克隆JSON列值
+func (j *JSONColumnValue) Clone() ColumnValue {
+	v := make([]byte, len(j.val))
+	copy(v, j.val)
+	return &JSONColumnValue{
+		val: v,
+	}
+}
+
+//Cmp 按照规范形式比较，对象的键顺序，空白以及数值的写法不影响比较结果，
+//如{"b":1.0, "a":[1]}和{"a":[1],"b":1}相等，右值不是JSON列值时按照JSON文本处理，
+//返回1代表大于， 0代表相等， -1代表小于
+func (j *JSONColumnValue) Cmp(right ColumnValue) (int, error) {
+	var rightValue []byte
+	if rj, ok := unwrapColumnValue(right).(*JSONColumnValue); ok {
+		rightValue = rj.val
+	} else {
+		var err error
+		if rightValue, err = right.AsBytes(); err != nil {
+			return 0, err
+		}
+	}
+
+	left, err := canonicalJSON(j.val)
+	if err != nil {
+		return 0, err
+	}
+	r, err := canonicalJSON(rightValue)
+	if err != nil {
+		return 0, err
+	}
+	return bytes.Compare(left, r), nil
+}
+
+//Extract 获取路径path对应的JSON列值，路径由点号分隔的键以及方括号包围的数组下标组成，
+//可以以$开头，如$.a.b[0].c，空路径或者$代表整个JSON，路径不存在时会报错
+func (j *JSONColumnValue) Extract(path string) (ColumnValue, error) {
+	v, err := j.extract(path)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONColumnValue{
+		val: b,
+	}, nil
+}
+
+//ExtractValue 获取路径path对应的列值，路径格式与Extract一致，
+//字符串转化为字符串列值，数值转化为高精度实数列值，布尔值转化为布尔列值，
+//null转化为空值JSON列值，对象和数组转化为JSON列值
+func (j *JSONColumnValue) ExtractValue(path string) (ColumnValue, error) {
+	v, err := j.extract(path)
+	if err != nil {
+		return nil, err
+	}
+	switch data := v.(type) {
+	case nil:
+		return NewNilJSONColumnValue(), nil
+	case bool:
+		return NewBoolColumnValue(data), nil
+	case string:
+		return NewStringColumnValue(data), nil
+	case json.Number:
+		return NewDecimalColumnValueFromString(data.String())
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONColumnValue{
+		val: b,
+	}, nil
+}
+
+func (j *JSONColumnValue) extract(path string) (interface{}, error) {
+	keys, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := j.decode()
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		switch data := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = data[k.key]; !ok || k.isIndex {
+				return nil, fmt.Errorf("path(%v) err: %v", path, ErrJSONPathNotExist)
+			}
+		case []interface{}:
+			if !k.isIndex || k.index >= len(data) {
+				return nil, fmt.Errorf("path(%v) err: %v", path, ErrJSONPathNotExist)
+			}
+			v = data[k.index]
+		default:
+			return nil, fmt.Errorf("path(%v) err: %v", path, ErrJSONPathNotExist)
+		}
+	}
+	return v, nil
+}
+
+func (j *JSONColumnValue) number() (decimal.Decimal, error) {
+	v, err := j.decode()
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	n, ok := v.(json.Number)
+	if !ok {
+		return decimal.Decimal{}, fmt.Errorf("val: %v", j.String())
+	}
+	return decimal.NewFromString(n.String())
+}
+
+func (j *JSONColumnValue) decode() (interface{}, error) {
+	return decodeJSON(j.val)
+}
+
+//jsonPathKey JSON路径中的一段，isIndex为true时为数组下标
+type jsonPathKey struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+//parseJSONPath 解析JSON路径path，如$.a.b[0].c
+func parseJSONPath(path string) (keys []jsonPathKey, err error) {
+	p := strings.TrimPrefix(path, "$")
+	for len(p) > 0 {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+			i := strings.IndexAny(p, ".[")
+			if i < 0 {
+				i = len(p)
+			}
+			if i == 0 {
+				return nil, fmt.Errorf("path(%v) err: empty key", path)
+			}
+			keys = append(keys, jsonPathKey{key: p[:i]})
+			p = p[i:]
+		case '[':
+			i := strings.IndexByte(p, ']')
+			if i < 0 {
+				return nil, fmt.Errorf("path(%v) err: ] is missing", path)
+			}
+			var index int
+			if index, err = strconv.Atoi(p[1:i]); err != nil || index < 0 {
+				return nil, fmt.Errorf("path(%v) err: index(%v) is invalid", path, p[1:i])
+			}
+			keys = append(keys, jsonPathKey{key: p[1:i], index: index, isIndex: true})
+			p = p[i+1:]
+		default:
+			if len(keys) > 0 || len(p) != len(path) {
+				return nil, fmt.Errorf("path(%v) err: . or [ is missing", path)
+			}
+			//允许省略开头的点号，如a.b
+			p = "." + p
+		}
+	}
+	return
+}
+
+//decodeJSON 解析JSON文本data，数值保存为json.Number，避免丢失精度
+func decodeJSON(data []byte) (v interface{}, err error) {
+	if !json.Valid(data) {
+		return nil, ErrValueNotJSON
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//canonicalJSON 生成JSON文本data的规范形式，对象的键排序，去除空白，数值按照高精度实数规范化
+func canonicalJSON(data []byte) ([]byte, error) {
+	v, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	if v, err = canonicalJSONValue(v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+func canonicalJSONValue(v interface{}) (interface{}, error) {
+	switch data := v.(type) {
+	case json.Number:
+		d, err := decimal.NewFromString(data.String())
+		if err != nil {
+			return nil, err
+		}
+		return json.Number(d.String()), nil
+	case map[string]interface{}:
+		for k, e := range data {
+			c, err := canonicalJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			data[k] = c
+		}
+	case []interface{}:
+		for i, e := range data {
+			c, err := canonicalJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			data[i] = c
+		}
+	}
+	return v, nil
+}
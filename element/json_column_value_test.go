@@ -0,0 +1,407 @@
+package element
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func testJSONColumnValue(s string) *JSONColumnValue {
+	cv, err := NewJSONColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return cv.(*JSONColumnValue)
+}
+
+func TestNilJSONColumnValue(t *testing.T) {
+	n := NewNilJSONColumnValue()
+	if n.Type() != TypeJSON {
+		t.Errorf("NilJSONColumnValue.Type() = %v, want %v", n.Type(), TypeJSON)
+	}
+	if !n.IsNil() {
+		t.Errorf("NilJSONColumnValue.IsNil() = false, want true")
+	}
+	if c := n.(ColumnValueClonable).Clone(); !reflect.DeepEqual(c, n) {
+		t.Errorf("NilJSONColumnValue.Clone() = %v, want %v", c, n)
+	}
+}
+
+func TestNewJSONColumnValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       []byte
+		wantErr bool
+	}{
+		{
+			name: "1",
+			v:    []byte(`{"a":1,"b":[true,null,"x"]}`),
+		},
+		{
+			name: "2",
+			v:    []byte(`1.5`),
+		},
+		{
+			name:    "3",
+			v:       []byte(`{"a":1`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			v:       []byte(`1 2`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewJSONColumnValue(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewJSONColumnValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrValueNotJSON) {
+				t.Errorf("NewJSONColumnValue() error = %v, want %v", err, ErrValueNotJSON)
+			}
+			_, err = NewJSONColumnValueFromString(string(tt.v))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewJSONColumnValueFromString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJSONColumnValue_AsBool(t *testing.T) {
+	tests := []struct {
+		name    string
+		j       ColumnValue
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "1",
+			j:    testJSONColumnValue(`true`),
+			want: true,
+		},
+		{
+			name: "2",
+			j:    testJSONColumnValue(` false `),
+			want: false,
+		},
+		{
+			name:    "3",
+			j:       testJSONColumnValue(`"true"`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.j.AsBool()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSONColumnValue.AsBool() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("JSONColumnValue.AsBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONColumnValue_AsBigInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		j       ColumnValue
+		want    *big.Int
+		wantErr bool
+	}{
+		{
+			name: "1",
+			j:    testJSONColumnValue(`123456789012345678901234567890`),
+			want: testBigIntFromString("123456789012345678901234567890"),
+		},
+		{
+			name: "2",
+			j:    testJSONColumnValue(`-12.7`),
+			want: big.NewInt(-12),
+		},
+		{
+			name:    "3",
+			j:       testJSONColumnValue(`[1]`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.j.AsBigInt()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSONColumnValue.AsBigInt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Cmp(tt.want) != 0 {
+				t.Errorf("JSONColumnValue.AsBigInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONColumnValue_AsDecimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		j       ColumnValue
+		want    decimal.Decimal
+		wantErr bool
+	}{
+		{
+			name: "1",
+			j:    testJSONColumnValue(`1.25e2`),
+			want: decimal.New(125, 0),
+		},
+		{
+			name:    "2",
+			j:       testJSONColumnValue(`"1.25"`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.j.AsDecimal()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSONColumnValue.AsDecimal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("JSONColumnValue.AsDecimal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONColumnValue_AsString(t *testing.T) {
+	s := `{"b": 1, "a": "x"}`
+	j := testJSONColumnValue(s)
+	if got, err := j.AsString(); err != nil || got != s {
+		t.Errorf("JSONColumnValue.AsString() = %v %v, want %v", got, err, s)
+	}
+	if got, err := j.AsBytes(); err != nil || string(got) != s {
+		t.Errorf("JSONColumnValue.AsBytes() = %v %v, want %v", string(got), err, s)
+	}
+	if got := j.String(); got != s {
+		t.Errorf("JSONColumnValue.String() = %v, want %v", got, s)
+	}
+	if _, err := j.AsTime(); err == nil {
+		t.Errorf("JSONColumnValue.AsTime() error = %v, wantErr true", err)
+	}
+	if j.Type() != TypeJSON {
+		t.Errorf("JSONColumnValue.Type() = %v, want %v", j.Type(), TypeJSON)
+	}
+}
+
+func TestJSONColumnValue_Clone(t *testing.T) {
+	j := testJSONColumnValue(`[1,2]`)
+	c := j.Clone()
+	if c == ColumnValue(j) {
+		t.Errorf("JSONColumnValue.Clone() = %p, want different from %p", c, j)
+	}
+	if !reflect.DeepEqual(c, ColumnValue(j)) {
+		t.Errorf("JSONColumnValue.Clone() = %v, want %v", c, j)
+	}
+}
+
+func TestJSONColumnValue_Cmp(t *testing.T) {
+	tests := []struct {
+		name    string
+		j       *JSONColumnValue
+		right   ColumnValue
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			j:     testJSONColumnValue(`{"b":1.0, "a":[1]}`),
+			right: testJSONColumnValue(`{"a":[1],"b":1}`),
+			want:  0,
+		},
+		{
+			name:  "2",
+			j:     testJSONColumnValue(`{"a":[1,2]}`),
+			right: NewDefaultColumn(testJSONColumnValue(`{ "a" : [ 1 , 2 ] }`), "test", 0),
+			want:  0,
+		},
+		{
+			name:  "3",
+			j:     testJSONColumnValue(`[1,2]`),
+			right: testJSONColumnValue(`[1,3]`),
+			want:  -1,
+		},
+		{
+			name:  "4",
+			j:     testJSONColumnValue(`{"a":"y"}`),
+			right: NewStringColumnValue(`{"a":"x"}`),
+			want:  1,
+		},
+		{
+			name:    "5",
+			j:       testJSONColumnValue(`{"a":"y"}`),
+			right:   NewStringColumnValue(`{"a":`),
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			j:       testJSONColumnValue(`{"a":"y"}`),
+			right:   NewNilJSONColumnValue(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.j.Cmp(tt.right)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSONColumnValue.Cmp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("JSONColumnValue.Cmp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONColumnValue_Extract(t *testing.T) {
+	j := testJSONColumnValue(`{"a":{"b":[{"c":"x"},2.50,true,null]},"d":"y"}`)
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			path: "$.a.b[0].c",
+			want: `"x"`,
+		},
+		{
+			name: "2",
+			path: "a.b[1]",
+			want: `2.50`,
+		},
+		{
+			name: "3",
+			path: "$",
+			want: `{"a":{"b":[{"c":"x"},2.50,true,null]},"d":"y"}`,
+		},
+		{
+			name: "4",
+			path: "$.a.b",
+			want: `[{"c":"x"},2.50,true,null]`,
+		},
+		{
+			name:    "5",
+			path:    "$.a.b[4]",
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			path:    "$.d.e",
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			path:    "$.a[0]",
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			path:    "$.a.b[x]",
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			path:    "$..a",
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			path:    "$a",
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			path:    "$.a.b[0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := j.Extract(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSONColumnValue.Extract() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("JSONColumnValue.Extract() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONColumnValue_ExtractValue(t *testing.T) {
+	j := testJSONColumnValue(`{"a":{"b":[{"c":"x"},2.50,true,null]}}`)
+	tests := []struct {
+		name    string
+		path    string
+		want    ColumnValue
+		wantErr bool
+	}{
+		{
+			name: "1",
+			path: "$.a.b[0].c",
+			want: NewStringColumnValue("x"),
+		},
+		{
+			name: "2",
+			path: "$.a.b[1]",
+			want: NewDecimalColumnValue(decimal.RequireFromString("2.50")),
+		},
+		{
+			name: "3",
+			path: "$.a.b[2]",
+			want: NewBoolColumnValue(true),
+		},
+		{
+			name: "4",
+			path: "$.a.b[3]",
+			want: NewNilJSONColumnValue(),
+		},
+		{
+			name: "5",
+			path: "$.a.b[0]",
+			want: testJSONColumnValue(`{"c":"x"}`),
+		},
+		{
+			name:    "6",
+			path:    "$.b",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := j.ExtractValue(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSONColumnValue.ExtractValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JSONColumnValue.ExtractValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,601 @@
+package element
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+//RecordCodecVersion 记录二进制格式的版本号，格式不兼容地变化时递增
+const RecordCodecVersion byte = 1
+
+//列值的二进制类型，空值时最高位为1
+const (
+	codecKindUnknown byte = iota
+	codecKindBool
+	codecKindBigInt
+	codecKindInt64
+	codecKindUint64
+	codecKindDecimal
+	codecKindFloat64
+	codecKindString
+	codecKindBytes
+	codecKindTime
+	codecKindJSON
+
+	codecNilFlag byte = 0x80
+)
+
+//时间编码器和时间解码器的二进制类型
+const (
+	codecTimeDefault   byte = iota //默认的时间编码器或者时间解码器
+	codecTimeString                //字符串时间编码器或者时间解码器
+	codecTimeWallClock             //时钟读数时间解码器
+)
+
+//EncodeRecord 将记录r编码为二进制，格式为
+//
+//	版本号(1字节) 列数(uvarint) 列...
+//
+//每列的格式为
+//
+//	列名(uvarint长度+字节) 字节流大小(varint) 列值类型(1字节) 列值
+//
+//列名，列类型，是否为空，列值，时间的时区以及字符串和字节流的时间编码器，时间的时间解码器都会被保留，
+//时间编码器和时间解码器中的时区需要能通过时区名加载，否则会报错，
+//element以外实现的时间编码器和时间解码器不会被保留，解码后为默认值
+func EncodeRecord(r Record) ([]byte, error) {
+	return AppendRecord(nil, r)
+}
+
+//AppendRecord 将记录r编码为二进制并追加到dst后，返回追加后的字节流
+func AppendRecord(dst []byte, r Record) ([]byte, error) {
+	dst = append(dst, RecordCodecVersion)
+	dst = appendUvarint(dst, uint64(r.ColumnNumber()))
+	for i := 0; i < r.ColumnNumber(); i++ {
+		c, err := r.GetByIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		dst = appendCodecBytes(dst, []byte(c.Name()))
+		dst = appendVarint(dst, c.ByteSize())
+		if dst, err = appendColumnValue(dst, c); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", c.Name(), err)
+		}
+	}
+	return dst, nil
+}
+
+//DecodeRecord 从EncodeRecord生成的二进制data中解码出记录，
+//版本号不支持或者数据不完整时会报错
+func DecodeRecord(data []byte) (Record, error) {
+	r := NewDefaultRecord()
+	if err := DecodeRecordTo(r, data); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+//DecodeRecordTo 从EncodeRecord生成的二进制data中解码出列并加入到记录r中，
+//版本号不支持或者数据不完整时会报错
+func DecodeRecordTo(r Record, data []byte) (err error) {
+	d := &codecDecoder{data: data}
+	var version byte
+	if version, err = d.byte(); err != nil {
+		return
+	}
+	if version != RecordCodecVersion {
+		return fmt.Errorf("version(%v) err: %v", version, ErrRecordVersionNotSupported)
+	}
+
+	var n uint64
+	if n, err = d.uvarint(); err != nil {
+		return
+	}
+	for i := uint64(0); i < n; i++ {
+		var name []byte
+		if name, err = d.bytes(); err != nil {
+			return
+		}
+		var byteSize int64
+		if byteSize, err = d.varint(); err != nil {
+			return
+		}
+		var v ColumnValue
+		if v, err = d.columnValue(); err != nil {
+			return fmt.Errorf("column(%v) err: %v", string(name), err)
+		}
+		if err = r.Add(NewDefaultColumn(v, string(name), int(byteSize))); err != nil {
+			return
+		}
+	}
+	if len(d.data) != 0 {
+		return ErrRecordDataInvalid
+	}
+	return
+}
+
+func appendColumnValue(dst []byte, c Column) ([]byte, error) {
+	if c.IsNil() {
+		kind, err := codecKindOf(c.Type())
+		if err != nil {
+			return nil, err
+		}
+		return append(dst, kind|codecNilFlag), nil
+	}
+
+	switch v := unwrapColumnValue(c).(type) {
+	case *Int64ColumnValue:
+		return appendVarint(append(dst, codecKindInt64), v.val), nil
+	case *Uint64ColumnValue:
+		return appendUvarint(append(dst, codecKindUint64), v.val), nil
+	case *Float64ColumnValue:
+		return appendFloat64(append(dst, codecKindFloat64), v.val), nil
+	case *JSONColumnValue:
+		return appendCodecBytes(append(dst, codecKindJSON), v.val), nil
+	}
+
+	kind, err := codecKindOf(c.Type())
+	if err != nil {
+		return nil, err
+	}
+	dst = append(dst, kind)
+	switch kind {
+	case codecKindBool:
+		var v bool
+		if v, err = c.AsBool(); err != nil {
+			return nil, err
+		}
+		if v {
+			return append(dst, 1), nil
+		}
+		return append(dst, 0), nil
+	case codecKindBigInt:
+		var v *big.Int
+		if v, err = c.AsBigInt(); err != nil {
+			return nil, err
+		}
+		return appendBigInt(dst, v), nil
+	case codecKindDecimal:
+		var v decimal.Decimal
+		if v, err = c.AsDecimal(); err != nil {
+			return nil, err
+		}
+		return appendBigInt(appendVarint(dst, int64(v.Exponent())), v.Coefficient()), nil
+	case codecKindString:
+		var v string
+		if v, err = c.AsString(); err != nil {
+			return nil, err
+		}
+		return appendTimeEncoder(appendCodecBytes(dst, []byte(v)), unwrapColumnValue(c))
+	case codecKindBytes:
+		var v []byte
+		if v, err = c.AsBytes(); err != nil {
+			return nil, err
+		}
+		return appendTimeEncoder(appendCodecBytes(dst, v), unwrapColumnValue(c))
+	case codecKindJSON:
+		var v []byte
+		if v, err = c.AsBytes(); err != nil {
+			return nil, err
+		}
+		return appendCodecBytes(dst, v), nil
+	case codecKindTime:
+		var v time.Time
+		if v, err = c.AsTime(); err != nil {
+			return nil, err
+		}
+		var d TimeDecoder
+		if tv, ok := unwrapColumnValue(c).(*TimeColumnValue); ok {
+			d = tv.TimeDecoder
+		}
+		return appendTimeDecoder(appendTime(dst, v), d)
+	}
+	return nil, fmt.Errorf("column type(%v) is not supported", c.Type())
+}
+
+//codecKindOf 获取列类型typ对应的列值二进制类型
+func codecKindOf(typ ColumnType) (byte, error) {
+	switch typ {
+	case TypeBool:
+		return codecKindBool, nil
+	case TypeBigInt:
+		return codecKindBigInt, nil
+	case TypeDecimal:
+		return codecKindDecimal, nil
+	case TypeString:
+		return codecKindString, nil
+	case TypeBytes:
+		return codecKindBytes, nil
+	case TypeTime:
+		return codecKindTime, nil
+	case TypeJSON:
+		return codecKindJSON, nil
+	}
+	return codecKindUnknown, fmt.Errorf("column type(%v) is not supported", typ)
+}
+
+func appendUvarint(dst []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(dst, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+func appendVarint(dst []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(dst, buf[:binary.PutVarint(buf[:], v)]...)
+}
+
+func appendFloat64(dst []byte, v float64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], math.Float64bits(v))
+	return append(dst, buf[:]...)
+}
+
+func appendCodecBytes(dst []byte, v []byte) []byte {
+	return append(appendUvarint(dst, uint64(len(v))), v...)
+}
+
+//appendBigInt 整数的格式为符号(1字节，-1，0，1) 绝对值(uvarint长度+大端字节)
+func appendBigInt(dst []byte, v *big.Int) []byte {
+	return appendCodecBytes(append(dst, byte(int8(v.Sign()))), v.Bytes())
+}
+
+//appendTime 时间的格式为秒(varint) 纳秒(uvarint) 时区名 时区缩写 时区偏移秒数(varint)，
+//时区名和时区缩写均为uvarint长度+字节
+func appendTime(dst []byte, t time.Time) []byte {
+	dst = appendVarint(dst, t.Unix())
+	dst = appendUvarint(dst, uint64(t.Nanosecond()))
+	zone, offset := t.Zone()
+	dst = appendCodecBytes(dst, []byte(t.Location().String()))
+	dst = appendCodecBytes(dst, []byte(zone))
+	return appendVarint(dst, int64(offset))
+}
+
+//appendTimeEncoder 追加列值v中的时间编码器，格式为类型(1字节) 时间格式 时区名，
+//时间格式和时区名均为uvarint长度+字节，默认的以及element以外实现的时间编码器只有类型
+func appendTimeEncoder(dst []byte, v ColumnValue) ([]byte, error) {
+	var e TimeEncoder
+	switch v := v.(type) {
+	case *StringColumnValue:
+		e = v.TimeEncoder
+	case *BytesColumnValue:
+		e = v.TimeEncoder
+	}
+	if v, ok := e.(*StringTimeEncoder); ok && (v.layout != time.RFC3339Nano || v.loc != nil) {
+		return appendTimeLayout(append(dst, codecTimeString), v.layout, v.loc)
+	}
+	return append(dst, codecTimeDefault), nil
+}
+
+//appendTimeDecoder 追加时间解码器d，格式与appendTimeEncoder相同
+func appendTimeDecoder(dst []byte, d TimeDecoder) ([]byte, error) {
+	switch v := d.(type) {
+	case *StringTimeDecoder:
+		if v.layout != time.RFC3339Nano || v.loc != nil {
+			return appendTimeLayout(append(dst, codecTimeString), v.layout, v.loc)
+		}
+	case *wallClockTimeDecoder:
+		return appendTimeLayout(append(dst, codecTimeWallClock), v.layout, v.loc)
+	}
+	return append(dst, codecTimeDefault), nil
+}
+
+//appendTimeLayout 追加时间格式layout和时区loc的时区名，时区为空时时区名为空，
+//时区无法通过时区名加载时会报错
+func appendTimeLayout(dst []byte, layout string, loc *time.Location) ([]byte, error) {
+	dst = appendCodecBytes(dst, []byte(layout))
+	if loc == nil {
+		return appendCodecBytes(dst, nil), nil
+	}
+	if loadCodecLocation(loc.String()) == nil {
+		return nil, fmt.Errorf("time location(%v) can not be loaded by name", loc)
+	}
+	return appendCodecBytes(dst, []byte(loc.String())), nil
+}
+
+//codecDecoder 二进制解码器，每次读取后data都会去掉已读取的部分
+type codecDecoder struct {
+	data []byte
+}
+
+func (d *codecDecoder) byte() (byte, error) {
+	if len(d.data) < 1 {
+		return 0, ErrRecordDataInvalid
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b, nil
+}
+
+func (d *codecDecoder) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		return 0, ErrRecordDataInvalid
+	}
+	d.data = d.data[n:]
+	return v, nil
+}
+
+func (d *codecDecoder) varint() (int64, error) {
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		return 0, ErrRecordDataInvalid
+	}
+	d.data = d.data[n:]
+	return v, nil
+}
+
+func (d *codecDecoder) float64() (float64, error) {
+	if len(d.data) < 8 {
+		return 0, ErrRecordDataInvalid
+	}
+	v := math.Float64frombits(binary.BigEndian.Uint64(d.data))
+	d.data = d.data[8:]
+	return v, nil
+}
+
+//bytes 读取uvarint长度+字节，返回的字节流会被复制
+func (d *codecDecoder) bytes() ([]byte, error) {
+	n, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(d.data)) < n {
+		return nil, ErrRecordDataInvalid
+	}
+	v := make([]byte, n)
+	copy(v, d.data)
+	d.data = d.data[n:]
+	return v, nil
+}
+
+func (d *codecDecoder) bigInt() (*big.Int, error) {
+	sign, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	var abs []byte
+	if abs, err = d.bytes(); err != nil {
+		return nil, err
+	}
+	v := new(big.Int).SetBytes(abs)
+	switch int8(sign) {
+	case -1:
+		return v.Neg(v), nil
+	case 0, 1:
+		return v, nil
+	}
+	return nil, ErrRecordDataInvalid
+}
+
+func (d *codecDecoder) time() (time.Time, error) {
+	sec, err := d.varint()
+	if err != nil {
+		return time.Time{}, err
+	}
+	var nsec uint64
+	if nsec, err = d.uvarint(); err != nil {
+		return time.Time{}, err
+	}
+	var name, zone []byte
+	if name, err = d.bytes(); err != nil {
+		return time.Time{}, err
+	}
+	if zone, err = d.bytes(); err != nil {
+		return time.Time{}, err
+	}
+	var offset int64
+	if offset, err = d.varint(); err != nil {
+		return time.Time{}, err
+	}
+	t := time.Unix(sec, int64(nsec))
+	return t.In(codecLocation(t, string(name), string(zone), int(offset))), nil
+}
+
+//timeLayout 读取appendTimeLayout追加的时间格式和时区
+func (d *codecDecoder) timeLayout() (layout string, loc *time.Location, err error) {
+	var l, name []byte
+	if l, err = d.bytes(); err != nil {
+		return
+	}
+	if name, err = d.bytes(); err != nil {
+		return
+	}
+	if len(name) > 0 {
+		if loc = loadCodecLocation(string(name)); loc == nil {
+			return "", nil, ErrRecordDataInvalid
+		}
+	}
+	return string(l), loc, nil
+}
+
+func (d *codecDecoder) timeEncoder() (TimeEncoder, error) {
+	kind, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case codecTimeDefault:
+		return NewStringTimeEncoder(time.RFC3339Nano), nil
+	case codecTimeString:
+		layout, loc, err := d.timeLayout()
+		if err != nil {
+			return nil, err
+		}
+		return NewStringTimeEncoderWithLocation(layout, loc), nil
+	}
+	return nil, ErrRecordDataInvalid
+}
+
+func (d *codecDecoder) timeDecoder() (TimeDecoder, error) {
+	kind, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case codecTimeDefault:
+		return NewStringTimeDecoder(time.RFC3339Nano), nil
+	case codecTimeString, codecTimeWallClock:
+		layout, loc, err := d.timeLayout()
+		if err != nil {
+			return nil, err
+		}
+		if kind == codecTimeWallClock {
+			return &wallClockTimeDecoder{
+				layout: layout,
+				loc:    loc,
+			}, nil
+		}
+		return NewStringTimeDecoderWithLocation(layout, loc), nil
+	}
+	return nil, ErrRecordDataInvalid
+}
+
+//codecLocations 时区名对应的时区，时区不存在时为空，避免每次解码时间都加载时区
+var codecLocations sync.Map
+
+//loadCodecLocation 获取时区名name对应的时区，时区不存在时返回空
+func loadCodecLocation(name string) *time.Location {
+	switch name {
+	case "UTC":
+		return time.UTC
+	case "Local":
+		return time.Local
+	}
+	if v, ok := codecLocations.Load(name); ok {
+		return v.(*time.Location)
+	}
+	loc, _ := time.LoadLocation(name)
+	codecLocations.Store(name, loc)
+	return loc
+}
+
+//codecLocation 优先使用时区名name对应的时区，时区不存在或者在时间t时的偏移不一致时
+//使用时区缩写zone和偏移offset生成固定时区
+func codecLocation(t time.Time, name, zone string, offset int) *time.Location {
+	if loc := loadCodecLocation(name); loc != nil {
+		if z, o := t.In(loc).Zone(); z == zone && o == offset {
+			return loc
+		}
+	}
+	return time.FixedZone(zone, offset)
+}
+
+func (d *codecDecoder) columnValue() (ColumnValue, error) {
+	kind, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	if kind&codecNilFlag != 0 {
+		return nilCodecColumnValue(kind &^ codecNilFlag)
+	}
+
+	switch kind {
+	case codecKindBool:
+		var b byte
+		if b, err = d.byte(); err != nil {
+			return nil, err
+		}
+		return NewBoolColumnValue(b != 0), nil
+	case codecKindBigInt:
+		var v *big.Int
+		if v, err = d.bigInt(); err != nil {
+			return nil, err
+		}
+		return NewBigIntColumnValue(v), nil
+	case codecKindInt64:
+		var v int64
+		if v, err = d.varint(); err != nil {
+			return nil, err
+		}
+		return NewInt64ColumnValue(v), nil
+	case codecKindUint64:
+		var v uint64
+		if v, err = d.uvarint(); err != nil {
+			return nil, err
+		}
+		return NewUint64ColumnValue(v), nil
+	case codecKindDecimal:
+		var exp int64
+		if exp, err = d.varint(); err != nil {
+			return nil, err
+		}
+		if exp < math.MinInt32 || exp > math.MaxInt32 {
+			return nil, ErrRecordDataInvalid
+		}
+		var v *big.Int
+		if v, err = d.bigInt(); err != nil {
+			return nil, err
+		}
+		return NewDecimalColumnValue(decimal.NewFromBigInt(v, int32(exp))), nil
+	case codecKindFloat64:
+		var v float64
+		if v, err = d.float64(); err != nil {
+			return nil, err
+		}
+		return NewFloat64ColumnValue(v), nil
+	case codecKindString:
+		var v []byte
+		if v, err = d.bytes(); err != nil {
+			return nil, err
+		}
+		var e TimeEncoder
+		if e, err = d.timeEncoder(); err != nil {
+			return nil, err
+		}
+		return NewStringColumnValueWithEncoder(string(v), e), nil
+	case codecKindBytes:
+		var v []byte
+		if v, err = d.bytes(); err != nil {
+			return nil, err
+		}
+		var e TimeEncoder
+		if e, err = d.timeEncoder(); err != nil {
+			return nil, err
+		}
+		return NewBytesColumnValueWithEncoder(v, e), nil
+	case codecKindTime:
+		var v time.Time
+		if v, err = d.time(); err != nil {
+			return nil, err
+		}
+		var dec TimeDecoder
+		if dec, err = d.timeDecoder(); err != nil {
+			return nil, err
+		}
+		return NewTimeColumnValueWithDecoder(v, dec), nil
+	case codecKindJSON:
+		var v []byte
+		if v, err = d.bytes(); err != nil {
+			return nil, err
+		}
+		return NewJSONColumnValue(v)
+	}
+	return nil, ErrRecordDataInvalid
+}
+
+//nilCodecColumnValue 获取列值二进制类型kind对应的空值列值
+func nilCodecColumnValue(kind byte) (ColumnValue, error) {
+	switch kind {
+	case codecKindBool:
+		return NewNilBoolColumnValue(), nil
+	case codecKindBigInt:
+		return NewNilBigIntColumnValue(), nil
+	case codecKindDecimal:
+		return NewNilDecimalColumnValue(), nil
+	case codecKindString:
+		return NewNilStringColumnValue(), nil
+	case codecKindBytes:
+		return NewNilBytesColumnValue(), nil
+	case codecKindTime:
+		return NewNilTimeColumnValue(), nil
+	case codecKindJSON:
+		return NewNilJSONColumnValue(), nil
+	}
+	return nil, ErrRecordDataInvalid
+}
@@ -0,0 +1,295 @@
+package element
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testJSONColumnValueFromString(s string) ColumnValue {
+	v, err := NewJSONColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func testCodecRecord(values ...ColumnValue) Record {
+	r := NewDefaultRecord()
+	for i, v := range values {
+		r.Add(NewDefaultColumn(v, string(rune('a'+i)), i*10))
+	}
+	return r
+}
+
+func testRecordEqual(t *testing.T, got, want Record) {
+	if got.ColumnNumber() != want.ColumnNumber() {
+		t.Fatalf("ColumnNumber() = %v, want %v", got.ColumnNumber(), want.ColumnNumber())
+	}
+	for i := 0; i < want.ColumnNumber(); i++ {
+		g, _ := got.GetByIndex(i)
+		w, _ := want.GetByIndex(i)
+		if g.Name() != w.Name() || g.ByteSize() != w.ByteSize() {
+			t.Errorf("column %v = (%v, %v), want (%v, %v)", i, g.Name(), g.ByteSize(), w.Name(), w.ByteSize())
+		}
+		gv, wv := unwrapColumnValue(g), unwrapColumnValue(w)
+		if reflect.TypeOf(gv) != reflect.TypeOf(wv) {
+			t.Errorf("column %v type = %T, want %T", i, gv, wv)
+			continue
+		}
+		if g.String() != w.String() {
+			t.Errorf("column %v = %v, want %v", i, g.String(), w.String())
+		}
+		if tv, ok := wv.(*TimeColumnValue); ok {
+			gt := gv.(*TimeColumnValue).val
+			gotZone, gotOffset := gt.Zone()
+			wantZone, wantOffset := tv.val.Zone()
+			if !gt.Equal(tv.val) || gotZone != wantZone || gotOffset != wantOffset ||
+				gt.Location().String() != tv.val.Location().String() {
+				t.Errorf("column %v = %v(%v), want %v(%v)", i, gt, gt.Location(), tv.val, tv.val.Location())
+			}
+		}
+		//时间解码器和时间编码器也需要被保留
+		gs, gerr := g.AsString()
+		ws, werr := w.AsString()
+		if gs != ws || (gerr != nil) != (werr != nil) {
+			t.Errorf("column %v AsString() = %v %v, want %v %v", i, gs, gerr, ws, werr)
+		}
+		gt, gerr := g.AsTime()
+		wt, werr := w.AsTime()
+		if !gt.Equal(wt) || (gerr != nil) != (werr != nil) {
+			t.Errorf("column %v AsTime() = %v %v, want %v %v", i, gt, gerr, wt, werr)
+		}
+	}
+}
+
+func TestEncodeRecord(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skipf("LoadLocation() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		r       Record
+		wantErr bool
+	}{
+		{
+			name: "1",
+			r:    NewDefaultRecord(),
+		},
+		{
+			name: "2",
+			r: testCodecRecord(
+				NewBoolColumnValue(true),
+				NewBoolColumnValue(false),
+				testBigIntColumnValueFromString("-123456789012345678901234567890"),
+				NewBigIntColumnValueFromInt64(0),
+				NewInt64ColumnValue(math.MinInt64),
+				NewUint64ColumnValue(math.MaxUint64),
+				testDecimalColumnValueFormString("-12345678901234567890.0123456789"),
+				testDecimalColumnValueFormString("1e20"),
+				NewFloat64ColumnValue(-1.5e-300),
+				NewStringColumnValue("中文\x00abc"),
+				NewStringColumnValue(""),
+				NewBytesColumnValue([]byte{0, 1, 255}),
+				testJSONColumnValueFromString(`{"b":1.0, "a":[1]}`),
+			),
+		},
+		{
+			name: "3",
+			r: testCodecRecord(
+				NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC)),
+				NewTimeColumnValue(time.Date(2021, 7, 2, 3, 4, 5, 6, shanghai)),
+				NewTimeColumnValue(time.Date(1900, 1, 2, 3, 4, 5, 6, time.FixedZone("XYZ", -3*3600-1800))),
+				NewTimeColumnValue(time.Time{}),
+				NewTimeColumnValue(time.Now()),
+			),
+		},
+		{
+			name: "4",
+			r: testCodecRecord(
+				NewNilBoolColumnValue(),
+				NewNilBigIntColumnValue(),
+				NewNilDecimalColumnValue(),
+				NewNilStringColumnValue(),
+				NewNilBytesColumnValue(),
+				NewNilTimeColumnValue(),
+				NewNilJSONColumnValue(),
+			),
+		},
+		{
+			name:    "5",
+			r:       testCodecRecord(newMockColumnValue()),
+			wantErr: true,
+		},
+		{
+			name: "6",
+			r: testCodecRecord(
+				NewTimeColumnValueWithDecoder(time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC),
+					NewStringTimeDecoder("2006-01-02 15:04:05")),
+				NewTimeColumnValueWithDecoder(time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC),
+					NewStringTimeDecoderWithLocation("2006/01/02 15:04", shanghai)),
+				NewTimeColumnValueWithDecoder(time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC),
+					&wallClockTimeDecoder{layout: "2006-01-02 15:04:05", loc: shanghai}),
+				NewStringColumnValueWithEncoder("2021-01-02 03:04", NewStringTimeEncoder("2006-01-02 15:04")),
+				NewBytesColumnValueWithEncoder([]byte("2021/01/02"),
+					NewStringTimeEncoderWithLocation("2006/01/02", shanghai)),
+			),
+		},
+		{
+			name: "7",
+			r: testCodecRecord(
+				NewTimeColumnValueWithDecoder(time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC),
+					NewStringTimeDecoderWithLocation(time.RFC3339, time.FixedZone("XYZ", 3600))),
+			),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncodeRecord(tt.r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EncodeRecord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := DecodeRecord(data)
+			if err != nil {
+				t.Fatalf("DecodeRecord() error = %v", err)
+			}
+			testRecordEqual(t, got, tt.r)
+		})
+	}
+}
+
+func TestAppendRecord(t *testing.T) {
+	r := testCodecRecord(NewStringColumnValue("abc"), NewInt64ColumnValue(1))
+	want, err := EncodeRecord(r)
+	if err != nil {
+		t.Fatalf("EncodeRecord() error = %v", err)
+	}
+	got, err := AppendRecord([]byte("prefix"), r)
+	if err != nil {
+		t.Fatalf("AppendRecord() error = %v", err)
+	}
+	if string(got) != "prefix"+string(want) {
+		t.Errorf("AppendRecord() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeRecordTo(t *testing.T) {
+	data, err := EncodeRecord(testCodecRecord(NewStringColumnValue("abc"), NewInt64ColumnValue(1)))
+	if err != nil {
+		t.Fatalf("EncodeRecord() error = %v", err)
+	}
+	index, _ := NewColumnIndex([]string{"a", "b"})
+	r := NewIndexRecord(index)
+	if err = DecodeRecordTo(r, data); err != nil {
+		t.Fatalf("DecodeRecordTo() error = %v", err)
+	}
+	testRecordEqual(t, r, testCodecRecord(NewStringColumnValue("abc"), NewInt64ColumnValue(1)))
+}
+
+func TestDecodeRecord(t *testing.T) {
+	data, err := EncodeRecord(testCodecRecord(
+		testBigIntColumnValueFromString("-123"),
+		testDecimalColumnValueFormString("1.5"),
+		NewFloat64ColumnValue(1.5),
+		NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC)),
+		testJSONColumnValueFromString(`[1]`),
+		NewNilStringColumnValue(),
+	))
+	if err != nil {
+		t.Fatalf("EncodeRecord() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{
+			name: "1",
+			data: data,
+		},
+		{
+			name:    "2",
+			data:    nil,
+			wantErr: ErrRecordDataInvalid,
+		},
+		{
+			name:    "3",
+			data:    append([]byte{RecordCodecVersion + 1}, data[1:]...),
+			wantErr: ErrRecordVersionNotSupported,
+		},
+		{
+			name:    "4",
+			data:    append(append([]byte{}, data...), 0),
+			wantErr: ErrRecordDataInvalid,
+		},
+		{
+			name:    "5",
+			data:    []byte{RecordCodecVersion, 1, 1, 'a', 0, 0x7f},
+			wantErr: ErrRecordDataInvalid,
+		},
+		{
+			name:    "6",
+			data:    []byte{RecordCodecVersion, 1, 1, 'a', 0, codecKindUnknown | codecNilFlag},
+			wantErr: ErrRecordDataInvalid,
+		},
+		{
+			name:    "7",
+			data:    []byte{RecordCodecVersion, 1, 1, 'a', 0, codecKindBigInt, 2, 1, 1},
+			wantErr: ErrRecordDataInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeRecord(tt.data)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("DecodeRecord() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasSuffix(err.Error(), tt.wantErr.Error()) {
+				t.Errorf("DecodeRecord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	//任何被截断的数据都会报错
+	for i := 0; i < len(data); i++ {
+		if _, err := DecodeRecord(data[:i]); err == nil {
+			t.Errorf("DecodeRecord() data[:%v] error = nil", i)
+		}
+	}
+}
+
+func TestLoadCodecLocation(t *testing.T) {
+	if got := loadCodecLocation("UTC"); got != time.UTC {
+		t.Errorf("loadCodecLocation() = %v, want %v", got, time.UTC)
+	}
+	if got := loadCodecLocation("Local"); got != time.Local {
+		t.Errorf("loadCodecLocation() = %v, want %v", got, time.Local)
+	}
+
+	//同名的时区只加载一次
+	loc := loadCodecLocation("Asia/Shanghai")
+	if loc == nil || loc.String() != "Asia/Shanghai" {
+		t.Fatalf("loadCodecLocation() = %v, want Asia/Shanghai", loc)
+	}
+	if got := loadCodecLocation("Asia/Shanghai"); got != loc {
+		t.Errorf("loadCodecLocation() = %p, want %p", got, loc)
+	}
+
+	//不存在的时区也会缓存
+	if got := loadCodecLocation("Mars/Olympus"); got != nil {
+		t.Errorf("loadCodecLocation() = %v, want nil", got)
+	}
+	if _, ok := codecLocations.Load("Mars/Olympus"); !ok {
+		t.Errorf("codecLocations does not cache Mars/Olympus")
+	}
+}
@@ -0,0 +1,76 @@
+package element
+
+import (
+	"sync"
+)
+
+//RecordPool 索引记录池，复用索引记录的列数组，
+//第一条放回的带列的记录的列名会作为之后取出记录的列名索引
+type RecordPool struct {
+	pool sync.Pool
+
+	mu    sync.RWMutex
+	index *ColumnIndex
+}
+
+//NewRecordPool 创建索引记录池
+func NewRecordPool() *RecordPool {
+	return &RecordPool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return NewIndexRecord(nil)
+			},
+		},
+	}
+}
+
+//Get 从记录池中取出索引记录
+func (p *RecordPool) Get() *IndexRecord {
+	r := p.pool.Get().(*IndexRecord)
+	r.Reset(p.ColumnIndex())
+	return r
+}
+
+//Put 将记录r放回记录池，放回后不能再使用该记录，不是索引记录时会被忽略
+func (p *RecordPool) Put(r Record) {
+	ir, ok := r.(*IndexRecord)
+	if !ok {
+		return
+	}
+
+	if ir.ColumnNumber() > 0 && p.ColumnIndex() == nil {
+		p.learn(ir)
+	}
+	ir.Reset(nil)
+	p.pool.Put(ir)
+}
+
+//ColumnIndex 获取列名索引，没有放回过带列的记录时为空
+func (p *RecordPool) ColumnIndex() *ColumnIndex {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.index
+}
+
+//SetColumnIndex 设置之后取出记录的列名索引，如读取器的记录模式的列名索引
+func (p *RecordPool) SetColumnIndex(index *ColumnIndex) {
+	p.mu.Lock()
+	p.index = index
+	p.mu.Unlock()
+}
+
+func (p *RecordPool) learn(r *IndexRecord) {
+	index := r.ColumnIndex()
+	if index == nil {
+		var err error
+		if index, err = NewColumnIndex(r.Names()); err != nil {
+			return
+		}
+	}
+
+	p.mu.Lock()
+	if p.index == nil {
+		p.index = index
+	}
+	p.mu.Unlock()
+}
@@ -0,0 +1,71 @@
+package element
+
+import (
+	"testing"
+)
+
+func TestRecordPool(t *testing.T) {
+	p := NewRecordPool()
+
+	r := p.Get()
+	if r.ColumnIndex() != nil {
+		t.Errorf("RecordPool.Get() ColumnIndex() = %v, want nil", r.ColumnIndex())
+		return
+	}
+	p.Put(r)
+
+	if p.ColumnIndex() != nil {
+		t.Errorf("RecordPool.ColumnIndex() = %v, want nil", p.ColumnIndex())
+		return
+	}
+
+	r = p.Get()
+	r.Add(NewDefaultColumn(NewBigIntColumnValueFromInt64(1), "a", 1))
+	r.Add(NewDefaultColumn(NewStringColumnValue("x"), "b", 1))
+	p.Put(r)
+
+	if r.ColumnNumber() != 0 {
+		t.Errorf("RecordPool.Put() ColumnNumber() = %v, want 0", r.ColumnNumber())
+		return
+	}
+
+	index := p.ColumnIndex()
+	if index == nil || index.Len() != 2 || index.Name(0) != "a" || index.Name(1) != "b" {
+		t.Errorf("RecordPool.ColumnIndex() = %v, want [a b]", index)
+		return
+	}
+
+	r = p.Get()
+	if r.ColumnIndex() != index {
+		t.Errorf("RecordPool.Get() ColumnIndex() = %v, want %v", r.ColumnIndex(), index)
+		return
+	}
+	r.Add(NewDefaultColumn(NewStringColumnValue("x"), "b", 1))
+	p.Put(r)
+
+	if p.ColumnIndex() != index {
+		t.Errorf("RecordPool.ColumnIndex() = %v, want %v", p.ColumnIndex(), index)
+		return
+	}
+
+	p.Put(NewDefaultRecord())
+	p.Put(GetTerminateRecord())
+}
+
+func TestRecordPool_SetColumnIndex(t *testing.T) {
+	p := NewRecordPool()
+	index := testColumnIndex("a", "b")
+	p.SetColumnIndex(index)
+
+	r := p.Get()
+	if r.ColumnIndex() != index {
+		t.Errorf("RecordPool.Get() ColumnIndex() = %v, want %v", r.ColumnIndex(), index)
+		return
+	}
+	r.Add(NewDefaultColumn(NewStringColumnValue("x"), "c", 1))
+	p.Put(r)
+
+	if p.ColumnIndex() != index {
+		t.Errorf("RecordPool.ColumnIndex() = %v, want %v", p.ColumnIndex(), index)
+	}
+}
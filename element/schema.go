@@ -0,0 +1,87 @@
+package element
+
+import (
+	"fmt"
+)
+
+//ColumnSchema 列模式
+type ColumnSchema struct {
+	Name      string     //列名
+	Type      ColumnType //列类型，无法确定时为unknown
+	Nullable  bool       //是否可以为空
+	Precision int64      //精度，没有精度时为0
+	Scale     int64      //小数位数
+	DBType    string     //源数据库的类型名，如VARCHAR
+}
+
+//Schema 记录模式，由读取器生成，经过转化器传递给写入器，生成后不可修改
+type Schema struct {
+	columns []ColumnSchema
+	index   *ColumnIndex
+}
+
+//NewSchema 通过有序的列模式columns生成记录模式，列名重复时会报错
+func NewSchema(columns []ColumnSchema) (*Schema, error) {
+	names := make([]string, len(columns))
+	for i, v := range columns {
+		names[i] = v.Name
+	}
+	index, err := NewColumnIndex(names)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{
+		columns: make([]ColumnSchema, len(columns)),
+		index:   index,
+	}
+	copy(s.columns, columns)
+	return s, nil
+}
+
+//Len 列数量
+func (s *Schema) Len() int {
+	return len(s.columns)
+}
+
+//Column 获取第i列的列模式
+func (s *Schema) Column(i int) ColumnSchema {
+	return s.columns[i]
+}
+
+//ColumnByName 获取列名为name的列模式
+func (s *Schema) ColumnByName(name string) (ColumnSchema, bool) {
+	if i, ok := s.index.Index(name); ok {
+		return s.columns[i], true
+	}
+	return ColumnSchema{}, false
+}
+
+//Names 按照列顺序获取所有列名
+func (s *Schema) Names() []string {
+	names := make([]string, len(s.columns))
+	for i, v := range s.columns {
+		names[i] = v.Name
+	}
+	return names
+}
+
+//ColumnIndex 列名索引，可以用于生成索引记录
+func (s *Schema) ColumnIndex() *ColumnIndex {
+	return s.index
+}
+
+func (s *Schema) String() string {
+	return fmt.Sprintf("%v", s.columns)
+}
+
+//Convertible 类型为from的列值是否可能转化为类型为to的列值，
+//返回false时该类型的任何列值都无法转化，任意一个类型为unknown时返回true
+func Convertible(from, to ColumnType) bool {
+	switch {
+	case from == TypeTime:
+		return to != TypeBool && to != TypeBigInt && to != TypeDecimal && to != TypeJSON
+	case to == TypeTime:
+		return from != TypeBool && from != TypeBigInt && from != TypeDecimal && from != TypeJSON
+	}
+	return true
+}
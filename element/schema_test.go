@@ -0,0 +1,155 @@
+package element
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []ColumnSchema
+		wantErr bool
+	}{
+		{
+			name: "1",
+			columns: []ColumnSchema{
+				{
+					Name:     "a",
+					Type:     TypeBigInt,
+					DBType:   "INT",
+					Nullable: true,
+				},
+				{
+					Name:      "b",
+					Type:      TypeDecimal,
+					DBType:    "DECIMAL",
+					Precision: 10,
+					Scale:     2,
+				},
+			},
+		},
+		{
+			name: "2",
+		},
+		{
+			name: "3",
+			columns: []ColumnSchema{
+				{
+					Name: "a",
+				},
+				{
+					Name: "a",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSchema(tt.columns)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSchema() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Len() != len(tt.columns) {
+				t.Errorf("NewSchema() Len() = %v, want %v", got.Len(), len(tt.columns))
+				return
+			}
+			for i, v := range tt.columns {
+				if !reflect.DeepEqual(got.Column(i), v) {
+					t.Errorf("NewSchema() Column(%v) = %v, want %v", i, got.Column(i), v)
+				}
+				c, ok := got.ColumnByName(v.Name)
+				if !ok || !reflect.DeepEqual(c, v) {
+					t.Errorf("NewSchema() ColumnByName(%v) = %v %v, want %v", v.Name, c, ok, v)
+				}
+				if got.Names()[i] != v.Name {
+					t.Errorf("NewSchema() Names()[%v] = %v, want %v", i, got.Names()[i], v.Name)
+				}
+				if j, ok := got.ColumnIndex().Index(v.Name); !ok || j != i {
+					t.Errorf("NewSchema() ColumnIndex().Index(%v) = %v %v, want %v", v.Name, j, ok, i)
+				}
+			}
+			if _, ok := got.ColumnByName("c"); ok {
+				t.Errorf("NewSchema() ColumnByName(c) = true, want false")
+			}
+		})
+	}
+}
+
+func TestConvertible(t *testing.T) {
+	tests := []struct {
+		name string
+		from ColumnType
+		to   ColumnType
+		want bool
+	}{
+		{
+			name: "1",
+			from: TypeTime,
+			to:   TypeBigInt,
+		},
+		{
+			name: "2",
+			from: TypeDecimal,
+			to:   TypeTime,
+		},
+		{
+			name: "3",
+			from: TypeBool,
+			to:   TypeTime,
+		},
+		{
+			name: "4",
+			from: TypeTime,
+			to:   TypeString,
+			want: true,
+		},
+		{
+			name: "5",
+			from: TypeString,
+			to:   TypeTime,
+			want: true,
+		},
+		{
+			name: "6",
+			from: TypeBigInt,
+			to:   TypeDecimal,
+			want: true,
+		},
+		{
+			name: "7",
+			from: TypeUnknown,
+			to:   TypeTime,
+			want: true,
+		},
+		{
+			name: "8",
+			from: TypeTime,
+			to:   TypeTime,
+			want: true,
+		},
+		{
+			name: "9",
+			from: TypeJSON,
+			to:   TypeTime,
+		},
+		{
+			name: "10",
+			from: TypeJSON,
+			to:   TypeString,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Convertible(tt.from, tt.to); got != tt.want {
+				t.Errorf("Convertible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,400 @@
+package element
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+const defaultSpillSegmentByte = 64 * 1024 * 1024
+
+//段文件中每条记录的类型
+const (
+	spillEntryRecord    byte = iota //普通记录，后接uvarint长度+记录二进制
+	spillEntryTerminate             //终止记录
+)
+
+//SpillConfig 溢出记录通道配置
+type SpillConfig struct {
+	Dir         string //段文件所在目录，为空时使用系统临时目录
+	MemoryByte  int64  //内存中记录的最大内存大小，超过后记录会写入段文件
+	SegmentByte int64  //单个段文件的最大字节数，为0时使用默认值64MB
+}
+
+func (s *SpillConfig) getSegmentByte() int64 {
+	if s.SegmentByte <= 0 {
+		return defaultSpillSegmentByte
+	}
+	return s.SegmentByte
+}
+
+//SpillRecordChan 溢出记录通道，内存中记录的内存大小超过阈值时，
+//之后的记录会按顺序追加到临时段文件中，弹出时先弹出内存中的记录再按顺序读取段文件，
+//读取完的段文件会被删除，读取器因此可以尽快读完并释放源数据库的连接，
+//段文件读写失败后，通道不再接受记录，弹出时直接返回没有值，错误可以通过Err获取
+type SpillRecordChan struct {
+	//加锁顺序为rlock，wlock，lock，记录的编解码和段文件读写都不持有lock
+	rlock sync.Mutex //读取段文件的锁，保护reading
+	wlock sync.Mutex //写入段文件的锁，保护writing
+	lock  sync.Mutex //保护除段文件读写以外的状态
+	cond  *sync.Cond
+
+	conf     SpillConfig
+	data     []Record
+	memBytes int64 //内存中记录的内存大小
+
+	spilling int             //正在写入段文件的记录数量
+	spilled  int             //段文件中记录的数量
+	writing  *spillSegment   //正在写入的段文件
+	sealed   []*spillSegment //写入完成等待读取的段文件
+	reading  *spillSegment   //正在读取的段文件
+
+	waits  int
+	closed bool
+	err    error
+}
+
+//spillSegment 段文件
+type spillSegment struct {
+	file *os.File
+	w    *bufio.Writer
+	r    *bufio.Reader
+	size int64 //已写入的字节数
+	n    int   //未读取的记录数
+}
+
+//NewSpillRecordChan 根据溢出配置conf创建溢出记录通道
+func NewSpillRecordChan(conf SpillConfig) *SpillRecordChan {
+	ch := &SpillRecordChan{
+		conf: conf,
+	}
+	ch.cond = sync.NewCond(&ch.lock)
+	return ch
+}
+
+//Close 关闭，关闭后仍然可以弹出剩余的记录
+func (c *SpillRecordChan) Close() {
+	c.lock.Lock()
+	if !c.closed {
+		c.closed = true
+		c.cond.Broadcast()
+	}
+	c.lock.Unlock()
+}
+
+//Buffered 记录通道内的元素数量，包括段文件中的记录
+func (c *SpillRecordChan) Buffered() int {
+	c.lock.Lock()
+	n := len(c.data) + c.spilled
+	c.lock.Unlock()
+	return n
+}
+
+//Spilled 段文件中记录的数量
+func (c *SpillRecordChan) Spilled() int {
+	c.lock.Lock()
+	n := c.spilled
+	c.lock.Unlock()
+	return n
+}
+
+//Err 段文件读写的错误
+func (c *SpillRecordChan) Err() error {
+	c.lock.Lock()
+	err := c.err
+	c.lock.Unlock()
+	return err
+}
+
+//PushBack 在尾部追加记录r，并且返回队列大小
+func (c *SpillRecordChan) PushBack(r Record) int {
+	c.lock.Lock()
+	n := c.lockedPushBack(r)
+	c.lock.Unlock()
+	return n
+}
+
+//PopFront 在头部弹出记录r，并且返回是否还有值
+func (c *SpillRecordChan) PopFront() (Record, bool) {
+	c.lock.Lock()
+	r, ok := c.lockedPopFront()
+	c.lock.Unlock()
+	return r, ok
+}
+
+//PushBackAll 通过函数fetchRecord获取多个记录，在尾部追加
+func (c *SpillRecordChan) PushBackAll(fetchRecord func() (Record, error)) error {
+	for {
+		r, err := fetchRecord()
+		if err != nil {
+			return err
+		}
+		c.PushBack(r)
+	}
+}
+
+//PopFrontAll 通过函数onRecord从头部弹出所有记录
+func (c *SpillRecordChan) PopFrontAll(onRecord func(Record) error) error {
+	for {
+		r, ok := c.PopFront()
+		if ok {
+			if err := onRecord(r); err != nil {
+				return err
+			}
+		} else {
+			return nil
+		}
+	}
+}
+
+//Destroy 删除所有段文件，段文件中未读取的记录会被丢弃
+func (c *SpillRecordChan) Destroy() (err error) {
+	c.rlock.Lock()
+	defer c.rlock.Unlock()
+	c.wlock.Lock()
+	defer c.wlock.Unlock()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	segments := append(c.sealed, c.writing, c.reading)
+	for _, v := range segments {
+		if v == nil {
+			continue
+		}
+		if rerr := v.remove(); rerr != nil && err == nil {
+			err = rerr
+		}
+	}
+	c.sealed, c.writing, c.reading = nil, nil, nil
+	c.spilled = 0
+	return
+}
+
+//lockedPushBack 追加记录r，调用时持有lock，写入段文件时会释放lock
+func (c *SpillRecordChan) lockedPushBack(r Record) int {
+	if c.closed {
+		panic("send on closed chan")
+	}
+	if c.err != nil {
+		return len(c.data) + c.spilled
+	}
+	if c.waits != 0 {
+		c.cond.Signal()
+	}
+
+	size := r.MemorySize()
+	//段文件中有记录时，为了保证顺序之后的记录都需要写入段文件
+	if c.spilled == 0 && c.spilling == 0 && (len(c.data) == 0 || c.memBytes+size <= c.conf.MemoryByte) {
+		c.data = append(c.data, r)
+		c.memBytes += size
+		return len(c.data)
+	}
+
+	c.spilling++
+	c.lock.Unlock()
+	err := c.spill(r)
+	c.lock.Lock()
+	c.spilling--
+	if err != nil && c.err == nil {
+		c.err = err
+	}
+	//唤醒等待的读取者重新检查状态
+	c.cond.Broadcast()
+	return len(c.data) + c.spilled
+}
+
+//lockedPopFront 弹出记录，调用时持有lock，读取段文件时会释放lock
+func (c *SpillRecordChan) lockedPopFront() (Record, bool) {
+	for {
+		if c.err != nil {
+			return nil, false
+		}
+		if len(c.data) > 0 {
+			r := c.data[0]
+			c.data, c.data[0] = c.data[1:], nil
+			c.memBytes -= r.MemorySize()
+			return r, true
+		}
+		if c.spilled > 0 {
+			if r, ok := c.lockedPopSpilled(); ok {
+				return r, true
+			}
+			continue
+		}
+		if c.closed && c.spilling == 0 {
+			return nil, false
+		}
+		c.waits++
+		c.cond.Wait()
+		c.waits--
+	}
+}
+
+//spill 将记录r编码后追加到正在写入的段文件中，段文件过大时不再写入
+func (c *SpillRecordChan) spill(r Record) (err error) {
+	var entry []byte
+	if _, ok := r.(*TerminateRecord); ok {
+		entry = []byte{spillEntryTerminate}
+	} else {
+		var data []byte
+		if data, err = EncodeRecord(r); err != nil {
+			return
+		}
+		entry = appendCodecBytes([]byte{spillEntryRecord}, data)
+	}
+
+	c.wlock.Lock()
+	defer c.wlock.Unlock()
+	if c.writing == nil {
+		var f *os.File
+		if f, err = ioutil.TempFile(c.conf.Dir, "go-etl-spill-*"); err != nil {
+			return
+		}
+		c.writing = &spillSegment{
+			file: f,
+			w:    bufio.NewWriter(f),
+		}
+	}
+
+	if _, err = c.writing.w.Write(entry); err != nil {
+		return
+	}
+	c.writing.size += int64(len(entry))
+	c.writing.n++
+	//在释放wlock之前计数，读取者看到的记录一定已经写入段文件
+	c.lock.Lock()
+	c.spilled++
+	c.lock.Unlock()
+
+	if c.writing.size >= c.conf.getSegmentByte() {
+		s := c.writing
+		c.writing = nil
+		err = s.seal()
+		c.lock.Lock()
+		c.sealed = append(c.sealed, s)
+		c.lock.Unlock()
+	}
+	return
+}
+
+//lockedPopSpilled 从段文件中弹出记录，调用时持有lock，读取和解码时会释放lock，
+//记录已经被其他读取者弹出或者出错时返回没有值
+func (c *SpillRecordChan) lockedPopSpilled() (r Record, ok bool) {
+	c.lock.Unlock()
+	c.rlock.Lock()
+	c.lock.Lock()
+	if c.spilled == 0 || c.err != nil {
+		c.rlock.Unlock()
+		return nil, false
+	}
+	c.lock.Unlock()
+
+	kind, data, err := c.readSpilled()
+	c.lock.Lock()
+	if err == nil {
+		c.spilled--
+	}
+	c.rlock.Unlock()
+	if err != nil {
+		c.err = err
+		c.cond.Broadcast()
+		return nil, false
+	}
+
+	c.lock.Unlock()
+	r, err = decodeSpillEntry(kind, data)
+	c.lock.Lock()
+	if err != nil {
+		if c.err == nil {
+			c.err = err
+		}
+		c.cond.Broadcast()
+		return nil, false
+	}
+	return r, true
+}
+
+//readSpilled 按顺序从段文件中读取一条记录，调用时持有rlock，读取完的段文件会被删除
+func (c *SpillRecordChan) readSpilled() (kind byte, data []byte, err error) {
+	if c.reading == nil {
+		if c.reading, err = c.nextSegment(); err != nil {
+			return
+		}
+	}
+
+	s := c.reading
+	if kind, err = s.r.ReadByte(); err != nil {
+		return
+	}
+	if kind == spillEntryRecord {
+		var n uint64
+		if n, err = binary.ReadUvarint(s.r); err != nil {
+			return
+		}
+		data = make([]byte, n)
+		if _, err = io.ReadFull(s.r, data); err != nil {
+			return
+		}
+	}
+
+	s.n--
+	if s.n == 0 {
+		c.reading = nil
+		//记录已经读取，删除失败不影响之后的记录
+		s.remove()
+	}
+	return
+}
+
+//nextSegment 获取下一个等待读取的段文件，没有时结束写入正在写入的段文件
+func (c *SpillRecordChan) nextSegment() (*spillSegment, error) {
+	c.wlock.Lock()
+	defer c.wlock.Unlock()
+	c.lock.Lock()
+	if len(c.sealed) > 0 {
+		s := c.sealed[0]
+		c.sealed = c.sealed[1:]
+		c.lock.Unlock()
+		return s, nil
+	}
+	c.lock.Unlock()
+
+	s := c.writing
+	c.writing = nil
+	return s, s.seal()
+}
+
+//decodeSpillEntry 通过段文件中记录的类型kind和记录二进制data解码记录
+func decodeSpillEntry(kind byte, data []byte) (Record, error) {
+	switch kind {
+	case spillEntryTerminate:
+		return GetTerminateRecord(), nil
+	case spillEntryRecord:
+		return DecodeRecord(data)
+	}
+	return nil, ErrRecordDataInvalid
+}
+
+//seal 结束写入段文件，等待读取
+func (s *spillSegment) seal() error {
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	s.w = nil
+	s.r = bufio.NewReader(s.file)
+	return nil
+}
+
+//remove 关闭并删除段文件
+func (s *spillSegment) remove() error {
+	if err := s.file.Close(); err != nil {
+		os.Remove(s.file.Name())
+		return err
+	}
+	return os.Remove(s.file.Name())
+}
@@ -0,0 +1,300 @@
+package element
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testSpillRecord(i int) Record {
+	r := NewDefaultRecord()
+	r.Add(NewDefaultColumn(NewInt64ColumnValue(int64(i)), "i", 8))
+	r.Add(NewDefaultColumn(NewStringColumnValue(strconv.Itoa(i)), "s", 8))
+	return r
+}
+
+func testSpillRecordIndex(t *testing.T, r Record) int {
+	c, err := r.GetByName("i")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	i, err := c.AsInt64()
+	if err != nil {
+		t.Fatalf("AsInt64() error = %v", err)
+	}
+	return int(i)
+}
+
+func testSpillFiles(t *testing.T, dir string) int {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	return len(files)
+}
+
+func TestSpillRecordChan_PushBackPopFront(t *testing.T) {
+	dir := t.TempDir()
+	c := NewSpillRecordChan(SpillConfig{
+		Dir:         dir,
+		MemoryByte:  testSpillRecord(0).MemorySize() * 10,
+		SegmentByte: 256,
+	})
+	defer c.Destroy()
+
+	for i := 0; i < 100; i++ {
+		if n := c.PushBack(testSpillRecord(i)); n != i+1 {
+			t.Fatalf("PushBack() = %v, want %v", n, i+1)
+		}
+	}
+	c.PushBack(GetTerminateRecord())
+	c.Close()
+
+	if n := c.Spilled(); n != 91 {
+		t.Errorf("Spilled() = %v, want 91", n)
+	}
+	if n := testSpillFiles(t, dir); n < 2 {
+		t.Errorf("segment files = %v, want more than 1", n)
+	}
+
+	for i := 0; i < 100; i++ {
+		r, ok := c.PopFront()
+		if !ok {
+			t.Fatalf("PopFront() %v = %v, want true", i, ok)
+		}
+		if got := testSpillRecordIndex(t, r); got != i {
+			t.Fatalf("PopFront() = %v, want %v", got, i)
+		}
+	}
+	if r, ok := c.PopFront(); !ok || r != GetTerminateRecord() {
+		t.Errorf("PopFront() = %v %v, want terminate record", r, ok)
+	}
+	if _, ok := c.PopFront(); ok {
+		t.Errorf("PopFront() = %v, want false", ok)
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("Err() = %v", err)
+	}
+	if n := c.Buffered(); n != 0 {
+		t.Errorf("Buffered() = %v, want 0", n)
+	}
+	if n := testSpillFiles(t, dir); n != 0 {
+		t.Errorf("segment files = %v, want 0", n)
+	}
+}
+
+func TestSpillRecordChan_PushBackAllPopFrontAll(t *testing.T) {
+	c := NewSpillRecordChan(SpillConfig{
+		Dir:         t.TempDir(),
+		MemoryByte:  1,
+		SegmentByte: 1024,
+	})
+	defer c.Destroy()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		i := 0
+		c.PushBackAll(func() (Record, error) {
+			if i == 10000 {
+				return nil, errors.New("mock error")
+			}
+			i++
+			return testSpillRecord(i - 1), nil
+		})
+		c.Close()
+	}()
+
+	i := 0
+	if err := c.PopFrontAll(func(r Record) error {
+		if got := testSpillRecordIndex(t, r); got != i {
+			t.Fatalf("PopFrontAll() = %v, want %v", got, i)
+		}
+		i++
+		return nil
+	}); err != nil {
+		t.Errorf("PopFrontAll() error = %v", err)
+	}
+	wg.Wait()
+	if i != 10000 {
+		t.Errorf("PopFrontAll() count = %v, want 10000", i)
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("Err() = %v", err)
+	}
+}
+
+func TestSpillRecordChan_Err(t *testing.T) {
+	c := NewSpillRecordChan(SpillConfig{
+		Dir:        filepath.Join(t.TempDir(), "not_exist"),
+		MemoryByte: 1,
+	})
+	defer c.Destroy()
+
+	c.PushBack(testSpillRecord(0))
+	c.PushBack(testSpillRecord(1))
+	if c.Err() == nil {
+		t.Fatalf("Err() = nil, want error")
+	}
+	c.PushBack(testSpillRecord(2))
+	if _, ok := c.PopFront(); ok {
+		t.Errorf("PopFront() = %v, want false", ok)
+	}
+}
+
+func TestSpillRecordChan_Destroy(t *testing.T) {
+	dir := t.TempDir()
+	c := NewSpillRecordChan(SpillConfig{
+		Dir:         dir,
+		MemoryByte:  1,
+		SegmentByte: 64,
+	})
+	for i := 0; i < 10; i++ {
+		c.PushBack(testSpillRecord(i))
+	}
+	if _, ok := c.PopFront(); !ok {
+		t.Fatalf("PopFront() = %v, want true", ok)
+	}
+	if _, ok := c.PopFront(); !ok {
+		t.Fatalf("PopFront() = %v, want true", ok)
+	}
+	if err := c.Destroy(); err != nil {
+		t.Errorf("Destroy() error = %v", err)
+	}
+	if n := testSpillFiles(t, dir); n != 0 {
+		t.Errorf("segment files = %v, want 0", n)
+	}
+	if n := c.Buffered(); n != 0 {
+		t.Errorf("Buffered() = %v, want 0", n)
+	}
+
+	c.PushBack(testSpillRecord(10))
+	c.PushBack(testSpillRecord(11))
+	c.Close()
+	for _, want := range []int{10, 11} {
+		r, ok := c.PopFront()
+		if !ok {
+			t.Fatalf("PopFront() = %v, want true", ok)
+		}
+		if got := testSpillRecordIndex(t, r); got != want {
+			t.Errorf("PopFront() = %v, want %v", got, want)
+		}
+	}
+	if err := c.Destroy(); err != nil {
+		t.Errorf("Destroy() error = %v", err)
+	}
+}
+
+func TestSpillRecordChan_Concurrent(t *testing.T) {
+	tests := []struct {
+		name    string
+		readers int
+	}{
+		{
+			name:    "1",
+			readers: 1,
+		},
+		{
+			name:    "2",
+			readers: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			c := NewSpillRecordChan(SpillConfig{
+				Dir:         dir,
+				MemoryByte:  256,
+				SegmentByte: 128,
+			})
+			defer c.Destroy()
+			n := 1000
+			go func() {
+				for i := 0; i < n; i++ {
+					c.PushBack(testSpillRecord(i))
+				}
+				c.Close()
+			}()
+
+			var mu sync.Mutex
+			got := make([]int, 0, n)
+			var wg sync.WaitGroup
+			for j := 0; j < tt.readers; j++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						r, ok := c.PopFront()
+						if !ok {
+							return
+						}
+						mu.Lock()
+						got = append(got, testSpillRecordIndex(t, r))
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			if err := c.Err(); err != nil {
+				t.Fatalf("Err() = %v", err)
+			}
+			if len(got) != n {
+				t.Fatalf("PopFront() count = %v, want %v", len(got), n)
+			}
+			//只有一个读取者时记录保持顺序
+			seen := make(map[int]bool)
+			for i, v := range got {
+				if tt.readers == 1 && v != i {
+					t.Fatalf("PopFront() = %v, want %v", v, i)
+				}
+				seen[v] = true
+			}
+			if len(seen) != n {
+				t.Errorf("PopFront() distinct = %v, want %v", len(seen), n)
+			}
+			if n := testSpillFiles(t, dir); n != 0 {
+				t.Errorf("segment files = %v, want 0", n)
+			}
+		})
+	}
+}
+
+func TestSpillRecordChan_TimeLayout(t *testing.T) {
+	c := NewSpillRecordChan(SpillConfig{
+		Dir:        t.TempDir(),
+		MemoryByte: 1,
+	})
+	defer c.Destroy()
+
+	tm := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		r := NewDefaultRecord()
+		r.Add(NewDefaultColumn(NewTimeColumnValueWithDecoder(tm, NewStringTimeDecoder("2006/01/02 15:04")), "t", 8))
+		c.PushBack(r)
+	}
+	c.Close()
+	if n := c.Spilled(); n != 1 {
+		t.Fatalf("Spilled() = %v, want 1", n)
+	}
+
+	//溢出到段文件的记录与内存中的记录转化为字符串的结果一致
+	for i := 0; i < 2; i++ {
+		r, ok := c.PopFront()
+		if !ok {
+			t.Fatalf("PopFront() = %v, want true", ok)
+		}
+		col, err := r.GetByName("t")
+		if err != nil {
+			t.Fatalf("GetByName() error = %v", err)
+		}
+		if s, err := col.AsString(); err != nil || s != "2021/01/02 03:04" {
+			t.Errorf("AsString() = %v %v, want 2021/01/02 03:04", s, err)
+		}
+	}
+}
@@ -19,7 +19,8 @@ type TimeEncoder interface {
 
 //StringTimeEncoder 字符串时间编码器
 type StringTimeEncoder struct {
-	layout string //go时间格式
+	layout string         //go时间格式
+	loc    *time.Location //没有时区信息的字符串所在的时区，为空时为UTC
 }
 
 //NewStringTimeEncoder 根据go时间格式layout的字符串时间编码器
@@ -29,18 +30,31 @@ func NewStringTimeEncoder(layout string) TimeEncoder {
 	}
 }
 
+//NewStringTimeEncoderWithLocation 根据go时间格式layout和时区loc的字符串时间编码器，
+//没有时区信息的字符串会按照时区loc解析
+func NewStringTimeEncoderWithLocation(layout string, loc *time.Location) TimeEncoder {
+	return &StringTimeEncoder{
+		layout: layout,
+		loc:    loc,
+	}
+}
+
 //TimeEncode 编码成时间，若i不是string或者不是layout格式，会报错
 func (e *StringTimeEncoder) TimeEncode(i interface{}) (time.Time, error) {
 	s, ok := i.(string)
 	if !ok {
 		return time.Time{}, fmt.Errorf("%v is %T, not string", i, i)
 	}
+	if e.loc != nil {
+		return time.ParseInLocation(e.layout, s, e.loc)
+	}
 	return time.Parse(e.layout, s)
 }
 
 //StringTimeDecoder 字符串时间编码器
 type StringTimeDecoder struct {
-	layout string //go时间格式
+	layout string         //go时间格式
+	loc    *time.Location //生成字符串时使用的时区，为空时使用时间本身的时区
 }
 
 //NewStringTimeDecoder 根据go时间格式layout的字符串时间编码器
@@ -50,7 +64,67 @@ func NewStringTimeDecoder(layout string) TimeDecoder {
 	}
 }
 
+//NewStringTimeDecoderWithLocation 根据go时间格式layout和时区loc的字符串时间编码器，
+//时间会先转化到时区loc再生成字符串
+func NewStringTimeDecoderWithLocation(layout string, loc *time.Location) TimeDecoder {
+	return &StringTimeDecoder{
+		layout: layout,
+		loc:    loc,
+	}
+}
+
 //TimeDecode 根据go时间格式layout的字符串时间编码成string
 func (d *StringTimeDecoder) TimeDecode(t time.Time) (interface{}, error) {
+	if d.loc != nil {
+		t = t.In(d.loc)
+	}
 	return t.Format(d.layout), nil
 }
+
+//TimeConfig 时间配置，用于设置时间所在的时区以及时间转化为字符串的格式
+type TimeConfig struct {
+	Layout   string `json:"layout"`   //时间格式，使用go的时间格式，默认为time.RFC3339Nano
+	Location string `json:"location"` //时区，如Asia/Shanghai，为空时不改变时区
+}
+
+//Merge 用列配置column覆盖时间配置c，column中为空的项使用c中的配置
+func (c TimeConfig) Merge(column TimeConfig) TimeConfig {
+	if column.Layout != "" {
+		c.Layout = column.Layout
+	}
+	if column.Location != "" {
+		c.Location = column.Location
+	}
+	return c
+}
+
+//IsEmpty 是否没有任何配置
+func (c TimeConfig) IsEmpty() bool {
+	return c.Layout == "" && c.Location == ""
+}
+
+//GetLayout 获取时间格式，默认为time.RFC3339Nano
+func (c TimeConfig) GetLayout() string {
+	if c.Layout == "" {
+		return defaultTimeFormat
+	}
+	return c.Layout
+}
+
+//GetLocation 获取时区，没有设置时区时为空，时区不存在时会报错
+func (c TimeConfig) GetLocation() (*time.Location, error) {
+	if c.Location == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(c.Location)
+	if err != nil {
+		return nil, fmt.Errorf("location(%v) err: %v", c.Location, err)
+	}
+	return loc, nil
+}
+
+//InLocation 将时间t的时钟读数解释为时区loc中的时间，用于没有时区的数据库时间类型，
+//如时区loc为Asia/Shanghai时，2021-01-01 08:00:00 UTC会变成2021-01-01 08:00:00 +0800
+func InLocation(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
@@ -103,7 +103,8 @@ func (t *TimeColumnValue) String() string {
 //Clone 克隆时间列值
 func (t *TimeColumnValue) Clone() ColumnValue {
 	return &TimeColumnValue{
-		val: t.val,
+		TimeDecoder: t.TimeDecoder,
+		val:         t.val,
 	}
 }
 
@@ -255,12 +255,25 @@ func TestTimeColumnValue_Clone(t *testing.T) {
 			t:    NewTimeColumnValue(time.Date(2020, 12, 17, 22, 49, 56, 69-999-999, time.Local)).(*TimeColumnValue),
 			want: NewTimeColumnValue(time.Date(2020, 12, 17, 22, 49, 56, 69-999-999, time.Local)),
 		},
+		{
+			name: "2",
+			t: NewTimeColumnValueWithDecoder(time.Date(2020, 12, 17, 22, 49, 56, 0, time.UTC),
+				NewStringTimeDecoder("2006-01-02")).(*TimeColumnValue),
+			want: NewTimeColumnValueWithDecoder(time.Date(2020, 12, 17, 22, 49, 56, 0, time.UTC),
+				NewStringTimeDecoder("2006-01-02")),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.t.Clone(); !reflect.DeepEqual(got.String(), tt.want.String()) {
+			got := tt.t.Clone()
+			if !reflect.DeepEqual(got.String(), tt.want.String()) {
 				t.Errorf("TimeColumnValue.Clone() = %v, want %v", got, tt.want)
 			}
+			gotString, _ := got.AsString()
+			wantString, _ := tt.want.AsString()
+			if gotString != wantString {
+				t.Errorf("TimeColumnValue.Clone() AsString() = %v, want %v", gotString, wantString)
+			}
 		})
 	}
 }
@@ -0,0 +1,125 @@
+package element
+
+import (
+	"fmt"
+	"time"
+)
+
+//TimeConverter 时间列转化器，按照列名对应的时间配置转化记录中的时间列，
+//转化后的时间列按照配置的时间格式转化为字符串
+type TimeConverter struct {
+	def     *timeConversion
+	columns map[string]*timeConversion
+	source  bool
+}
+
+type timeConversion struct {
+	loc     *time.Location
+	bind    *time.Location
+	decoder TimeDecoder
+}
+
+//NewSourceTimeConverter 生成源时间列转化器，用于读取器，通过时间配置conf和列的时间配置columns生成，
+//时间的时钟读数会被解释为配置时区中的时间，如源数据库中没有时区的时间，
+//时区不存在时会报错，没有任何配置时返回空
+func NewSourceTimeConverter(conf TimeConfig, columns map[string]TimeConfig) (*TimeConverter, error) {
+	return newTimeConverter(conf, columns, true, nil)
+}
+
+//NewTargetTimeConverter 生成目标时间列转化器，用于写入器，通过时间配置conf和列的时间配置columns生成，
+//时间会被转化到配置的时区，时区不存在时会报错，没有任何配置时返回空，
+//bind为驱动写入时间时使用的时区，如mysql驱动会把时间转化到url中loc参数的时区后写入时钟读数，
+//不为空时转化后的时钟读数会被解释为bind中的时间，使驱动写入配置时区中的时钟读数
+func NewTargetTimeConverter(conf TimeConfig, columns map[string]TimeConfig, bind *time.Location) (*TimeConverter, error) {
+	return newTimeConverter(conf, columns, false, bind)
+}
+
+func newTimeConverter(conf TimeConfig, columns map[string]TimeConfig, source bool, bind *time.Location) (t *TimeConverter, err error) {
+	if conf.IsEmpty() && len(columns) == 0 {
+		return nil, nil
+	}
+
+	t = &TimeConverter{
+		columns: make(map[string]*timeConversion),
+		source:  source,
+	}
+	if t.def, err = newTimeConversion(conf, bind); err != nil {
+		return nil, err
+	}
+	for name, v := range columns {
+		if t.columns[name], err = newTimeConversion(conf.Merge(v), bind); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", name, err)
+		}
+	}
+	return
+}
+
+func newTimeConversion(conf TimeConfig, bind *time.Location) (*timeConversion, error) {
+	loc, err := conf.GetLocation()
+	if err != nil {
+		return nil, err
+	}
+	if loc != nil && bind != nil {
+		return &timeConversion{
+			loc:  loc,
+			bind: bind,
+			decoder: &wallClockTimeDecoder{
+				layout: conf.GetLayout(),
+				loc:    loc,
+			},
+		}, nil
+	}
+	return &timeConversion{
+		loc:     loc,
+		decoder: NewStringTimeDecoderWithLocation(conf.GetLayout(), loc),
+	}, nil
+}
+
+//Convert 转化记录r中的非空时间列
+func (t *TimeConverter) Convert(r Record) error {
+	for i := 0; i < r.ColumnNumber(); i++ {
+		c, err := r.GetByIndex(i)
+		if err != nil {
+			return err
+		}
+		if c.Type() != TypeTime || c.IsNil() {
+			continue
+		}
+
+		conv, ok := t.columns[c.Name()]
+		if !ok {
+			conv = t.def
+		}
+
+		var tm time.Time
+		if tm, err = c.AsTime(); err != nil {
+			return err
+		}
+		if conv.loc != nil {
+			if t.source {
+				tm = InLocation(tm, conv.loc)
+			} else {
+				tm = tm.In(conv.loc)
+				if conv.bind != nil {
+					tm = InLocation(tm, conv.bind)
+				}
+			}
+		}
+		if err = r.Set(i, NewDefaultColumn(NewTimeColumnValueWithDecoder(tm, conv.decoder),
+			c.Name(), int(c.ByteSize()))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//wallClockTimeDecoder 将时间的时钟读数解释为时区loc中的时间后按照layout生成字符串，
+//用于时钟读数已经被解释为驱动写入时区中时间的目标时间
+type wallClockTimeDecoder struct {
+	layout string
+	loc    *time.Location
+}
+
+func (d *wallClockTimeDecoder) TimeDecode(t time.Time) (interface{}, error) {
+	return InLocation(t, d.loc).Format(d.layout), nil
+}
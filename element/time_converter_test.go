@@ -0,0 +1,179 @@
+package element
+
+import (
+	"testing"
+	"time"
+)
+
+func testTimeRecord(columns ...Column) Record {
+	r := NewDefaultRecord()
+	for _, c := range columns {
+		if err := r.Add(c); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func TestNewTimeConverter(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    TimeConfig
+		columns map[string]TimeConfig
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			wantNil: true,
+		},
+		{
+			name: "2",
+			conf: TimeConfig{
+				Location: "Asia/Shanghai",
+			},
+		},
+		{
+			name: "3",
+			columns: map[string]TimeConfig{
+				"a": {
+					Layout: "2006-01-02",
+				},
+			},
+		},
+		{
+			name: "4",
+			conf: TimeConfig{
+				Location: "Mars/Olympus",
+			},
+			wantErr: true,
+		},
+		{
+			name: "5",
+			columns: map[string]TimeConfig{
+				"a": {
+					Location: "Mars/Olympus",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSourceTimeConverter(tt.conf, tt.columns)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSourceTimeConverter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got == nil) != tt.wantNil {
+				t.Errorf("NewSourceTimeConverter() = %v, wantNil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestTimeConverter_Convert(t *testing.T) {
+	wall := time.Date(2021, 1, 1, 8, 0, 0, 0, time.UTC)
+	conf := TimeConfig{
+		Layout:   "2006-01-02 15:04:05",
+		Location: "Asia/Shanghai",
+	}
+	columns := map[string]TimeConfig{
+		"b": {
+			Layout:   "2006-01-02 15:04:05Z07:00",
+			Location: "UTC",
+		},
+	}
+	tests := []struct {
+		name     string
+		source   bool
+		bind     *time.Location
+		r        Record
+		want     map[string]string
+		wantTime map[string]string
+		wantErr  bool
+	}{
+		{
+			name:   "1",
+			source: true,
+			r: testTimeRecord(
+				NewDefaultColumn(NewTimeColumnValue(wall), "a", 0),
+				NewDefaultColumn(NewTimeColumnValue(wall), "b", 0),
+				NewDefaultColumn(NewNilTimeColumnValue(), "c", 0),
+				NewDefaultColumn(NewStringColumnValue("x"), "d", 0),
+			),
+			want: map[string]string{
+				"a": "2021-01-01 08:00:00",
+				"b": "2021-01-01 08:00:00Z",
+				"d": "x",
+			},
+		},
+		{
+			name: "2",
+			r: testTimeRecord(
+				NewDefaultColumn(NewTimeColumnValue(wall), "a", 0),
+				NewDefaultColumn(NewTimeColumnValue(wall), "b", 0),
+			),
+			want: map[string]string{
+				"a": "2021-01-01 16:00:00",
+				"b": "2021-01-01 08:00:00Z",
+			},
+		},
+		{
+			name: "3",
+			bind: time.FixedZone("EST", -5*3600),
+			r: testTimeRecord(
+				NewDefaultColumn(NewTimeColumnValue(wall), "a", 0),
+				NewDefaultColumn(NewTimeColumnValue(wall), "b", 0),
+			),
+			want: map[string]string{
+				"a": "2021-01-01 16:00:00",
+				"b": "2021-01-01 08:00:00Z",
+			},
+			wantTime: map[string]string{
+				"a": "2021-01-01 16:00:00 -0500",
+				"b": "2021-01-01 08:00:00 -0500",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c *TimeConverter
+			var err error
+			if tt.source {
+				c, err = NewSourceTimeConverter(conf, columns)
+			} else {
+				c, err = NewTargetTimeConverter(conf, columns, tt.bind)
+			}
+			if err != nil {
+				t.Fatalf("NewTimeConverter() error = %v", err)
+			}
+			if err = c.Convert(tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("TimeConverter.Convert() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for name, want := range tt.want {
+				col, err := tt.r.GetByName(name)
+				if err != nil {
+					t.Errorf("GetByName(%v) error = %v", name, err)
+					continue
+				}
+				if got, _ := col.AsString(); got != want {
+					t.Errorf("TimeConverter.Convert() %v = %v, want %v", name, got, want)
+				}
+			}
+			for name, want := range tt.wantTime {
+				col, _ := tt.r.GetByName(name)
+				if got, _ := col.AsTime(); got.Format("2006-01-02 15:04:05 -0700") != want {
+					t.Errorf("TimeConverter.Convert() %v = %v, want %v", name, got, want)
+				}
+			}
+			if col, _ := tt.r.GetByName("c"); col != nil && !col.IsNil() {
+				t.Errorf("TimeConverter.Convert() c = %v, want nil", col)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 )
 
+var testShanghai = time.FixedZone("CST", 8*3600)
+
 func TestStringTimeEncoder_TimeEncode(t *testing.T) {
 	type args struct {
 		i interface{}
@@ -25,6 +27,22 @@ func TestStringTimeEncoder_TimeEncode(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "2",
+			e:    NewStringTimeEncoder("2006-01-02 15:04:05").(*StringTimeEncoder),
+			args: args{
+				i: "2021-01-01 08:00:00",
+			},
+			want: time.Date(2021, 1, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "3",
+			e:    NewStringTimeEncoderWithLocation("2006-01-02 15:04:05", testShanghai).(*StringTimeEncoder),
+			args: args{
+				i: "2021-01-01 08:00:00",
+			},
+			want: time.Date(2021, 1, 1, 8, 0, 0, 0, testShanghai),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -39,3 +57,119 @@ func TestStringTimeEncoder_TimeEncode(t *testing.T) {
 		})
 	}
 }
+
+func TestStringTimeDecoder_TimeDecode(t *testing.T) {
+	tm := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		d    TimeDecoder
+		want interface{}
+	}{
+		{
+			name: "1",
+			d:    NewStringTimeDecoder("2006-01-02 15:04:05"),
+			want: "2021-01-01 00:00:00",
+		},
+		{
+			name: "2",
+			d:    NewStringTimeDecoderWithLocation("2006-01-02 15:04:05", testShanghai),
+			want: "2021-01-01 08:00:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.TimeDecode(tm)
+			if err != nil {
+				t.Errorf("StringTimeDecoder.TimeDecode() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("StringTimeDecoder.TimeDecode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeConfig_Merge(t *testing.T) {
+	tests := []struct {
+		name   string
+		c      TimeConfig
+		column TimeConfig
+		want   TimeConfig
+	}{
+		{
+			name: "1",
+			c: TimeConfig{
+				Layout:   "2006-01-02",
+				Location: "UTC",
+			},
+			column: TimeConfig{
+				Location: "Asia/Shanghai",
+			},
+			want: TimeConfig{
+				Layout:   "2006-01-02",
+				Location: "Asia/Shanghai",
+			},
+		},
+		{
+			name: "2",
+			c: TimeConfig{
+				Location: "UTC",
+			},
+			column: TimeConfig{
+				Layout: "2006-01-02",
+			},
+			want: TimeConfig{
+				Layout:   "2006-01-02",
+				Location: "UTC",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Merge(tt.column); got != tt.want {
+				t.Errorf("TimeConfig.Merge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeConfig(t *testing.T) {
+	c := TimeConfig{}
+	if !c.IsEmpty() {
+		t.Errorf("TimeConfig.IsEmpty() = false, want true")
+	}
+	if got := c.GetLayout(); got != time.RFC3339Nano {
+		t.Errorf("TimeConfig.GetLayout() = %v, want %v", got, time.RFC3339Nano)
+	}
+	if loc, err := c.GetLocation(); loc != nil || err != nil {
+		t.Errorf("TimeConfig.GetLocation() = %v %v, want nil", loc, err)
+	}
+
+	c = TimeConfig{
+		Layout:   "2006-01-02",
+		Location: "UTC",
+	}
+	if c.IsEmpty() {
+		t.Errorf("TimeConfig.IsEmpty() = true, want false")
+	}
+	if got := c.GetLayout(); got != "2006-01-02" {
+		t.Errorf("TimeConfig.GetLayout() = %v, want 2006-01-02", got)
+	}
+	if loc, err := c.GetLocation(); loc != time.UTC || err != nil {
+		t.Errorf("TimeConfig.GetLocation() = %v %v, want UTC", loc, err)
+	}
+
+	c.Location = "Mars/Olympus"
+	if _, err := c.GetLocation(); err == nil {
+		t.Errorf("TimeConfig.GetLocation() error = nil, want not nil")
+	}
+}
+
+func TestInLocation(t *testing.T) {
+	got := InLocation(time.Date(2021, 1, 1, 8, 0, 0, 1, time.UTC), testShanghai)
+	want := time.Date(2021, 1, 1, 0, 0, 0, 1, time.UTC)
+	if !got.Equal(want) || got.Location() != testShanghai {
+		t.Errorf("InLocation() = %v, want %v", got, want)
+	}
+}
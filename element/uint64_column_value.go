@@ -0,0 +1,110 @@
+package element
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+//Uint64ColumnValue 64位非负整数列值，列类型为整数，用于超出int64范围的无符号整数
+type Uint64ColumnValue struct {
+	notNilColumnValue
+
+	val uint64 //整数值
+}
+
+//NewUint64ColumnValue 从uint64 v中获取64位非负整数列值
+func NewUint64ColumnValue(v uint64) ColumnValue {
+	return &Uint64ColumnValue{
+		val: v,
+	}
+}
+
+//Type 返回列类型
+func (u *Uint64ColumnValue) Type() ColumnType {
+	return TypeBigInt
+}
+
+//AsBool 转化成布尔值，不是0的转化为true,是0的转化成false
+func (u *Uint64ColumnValue) AsBool() (bool, error) {
+	return u.val != 0, nil
+}
+
+//AsBigInt 转化成整数
+func (u *Uint64ColumnValue) AsBigInt() (*big.Int, error) {
+	return new(big.Int).SetUint64(u.val), nil
+}
+
+//AsDecimal 转化成高精度实数
+func (u *Uint64ColumnValue) AsDecimal() (decimal.Decimal, error) {
+	if u.val <= math.MaxInt64 {
+		return decimal.New(int64(u.val), 0), nil
+	}
+	return decimal.NewFromBigInt(new(big.Int).SetUint64(u.val), 0), nil
+}
+
+//AsString 转化成字符串，如1234556790转化为1234556790
+func (u *Uint64ColumnValue) AsString() (string, error) {
+	return u.String(), nil
+}
+
+//AsBytes 转化成字节流，如1234556790转化为1234556790
+func (u *Uint64ColumnValue) AsBytes() ([]byte, error) {
+	return strconv.AppendUint(nil, u.val, 10), nil
+}
+
+//AsTime 目前整数无法转化成时间
+func (u *Uint64ColumnValue) AsTime() (time.Time, error) {
+	return time.Time{}, NewTransformErrorFormColumnTypes(u.Type(), TypeTime, fmt.Errorf(" val: %v", u.String()))
+}
+
+//AsInt64 转化成64位整数，超出int64范围时会报错
+func (u *Uint64ColumnValue) AsInt64() (int64, error) {
+	if u.val > math.MaxInt64 {
+		return 0, NewTransformErrorFormString(u.Type().String(), "int64", fmt.Errorf("%v %v", u.String(), ErrValueNotInt64))
+	}
+	return int64(u.val), nil
+}
+
+//AsFloat64 转化成64位实数
+func (u *Uint64ColumnValue) AsFloat64() (float64, error) {
+	return float64(u.val), nil
+}
+
+func (u *Uint64ColumnValue) String() string {
+	return strconv.FormatUint(u.val, 10)
+}
+
+//Clone 克隆64位非负整数列值
+func (u *Uint64ColumnValue) Clone() ColumnValue {
+	return NewUint64ColumnValue(u.val)
+}
+
+//Cmp  返回1代表大于， 0代表相等， -1代表小于，
+//右值为64位整数或者64位非负整数时直接比较，否则转化为整数比较
+func (u *Uint64ColumnValue) Cmp(right ColumnValue) (int, error) {
+	switch r := unwrapColumnValue(right).(type) {
+	case *Uint64ColumnValue:
+		switch {
+		case u.val > r.val:
+			return 1, nil
+		case u.val < r.val:
+			return -1, nil
+		}
+		return 0, nil
+	case *Int64ColumnValue:
+		if u.val > math.MaxInt64 {
+			return 1, nil
+		}
+		return cmpInt64(int64(u.val), r.val), nil
+	}
+	rightValue, err := right.AsBigInt()
+	if err != nil {
+		return 0, err
+	}
+	return new(big.Int).SetUint64(u.val).Cmp(rightValue), nil
+}
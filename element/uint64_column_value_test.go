@@ -0,0 +1,234 @@
+package element
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestUint64ColumnValue_Type(t *testing.T) {
+	if got := NewUint64ColumnValue(1).Type(); got != TypeBigInt {
+		t.Errorf("Uint64ColumnValue.Type() = %v, want %v", got, TypeBigInt)
+	}
+}
+
+func TestUint64ColumnValue_AsBool(t *testing.T) {
+	tests := []struct {
+		name string
+		u    ColumnValue
+		want bool
+	}{
+		{
+			name: "1",
+			u:    NewUint64ColumnValue(0),
+			want: false,
+		},
+		{
+			name: "2",
+			u:    NewUint64ColumnValue(math.MaxUint64),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.u.AsBool()
+			if err != nil {
+				t.Errorf("Uint64ColumnValue.AsBool() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Uint64ColumnValue.AsBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUint64ColumnValue_AsBigInt(t *testing.T) {
+	tests := []struct {
+		name string
+		u    ColumnValue
+		want *big.Int
+	}{
+		{
+			name: "1",
+			u:    NewUint64ColumnValue(math.MaxUint64),
+			want: testBigIntFromString("18446744073709551615"),
+		},
+		{
+			name: "2",
+			u:    NewUint64ColumnValue(1),
+			want: big.NewInt(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.u.AsBigInt()
+			if err != nil {
+				t.Errorf("Uint64ColumnValue.AsBigInt() error = %v", err)
+				return
+			}
+			if got.Cmp(tt.want) != 0 {
+				t.Errorf("Uint64ColumnValue.AsBigInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUint64ColumnValue_AsDecimal(t *testing.T) {
+	tests := []struct {
+		name string
+		u    ColumnValue
+		want decimal.Decimal
+	}{
+		{
+			name: "1",
+			u:    NewUint64ColumnValue(math.MaxUint64),
+			want: decimal.RequireFromString("18446744073709551615"),
+		},
+		{
+			name: "2",
+			u:    NewUint64ColumnValue(12),
+			want: decimal.New(12, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.u.AsDecimal()
+			if err != nil {
+				t.Errorf("Uint64ColumnValue.AsDecimal() error = %v", err)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Uint64ColumnValue.AsDecimal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUint64ColumnValue_AsString(t *testing.T) {
+	u := NewUint64ColumnValue(math.MaxUint64)
+	want := "18446744073709551615"
+	if got, err := u.AsString(); err != nil || got != want {
+		t.Errorf("Uint64ColumnValue.AsString() = %v %v, want %v", got, err, want)
+	}
+	if got, err := u.AsBytes(); err != nil || string(got) != want {
+		t.Errorf("Uint64ColumnValue.AsBytes() = %v %v, want %v", string(got), err, want)
+	}
+	if _, err := u.AsTime(); err == nil {
+		t.Errorf("Uint64ColumnValue.AsTime() error = %v, wantErr true", err)
+	}
+}
+
+func TestUint64ColumnValue_AsInt64(t *testing.T) {
+	tests := []struct {
+		name    string
+		u       *Uint64ColumnValue
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "1",
+			u:    NewUint64ColumnValue(math.MaxInt64).(*Uint64ColumnValue),
+			want: math.MaxInt64,
+		},
+		{
+			name:    "2",
+			u:       NewUint64ColumnValue(math.MaxInt64 + 1).(*Uint64ColumnValue),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.u.AsInt64()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Uint64ColumnValue.AsInt64() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Uint64ColumnValue.AsInt64() = %v, want %v", got, tt.want)
+			}
+			if f, _ := tt.u.AsFloat64(); f != float64(tt.u.val) {
+				t.Errorf("Uint64ColumnValue.AsFloat64() = %v, want %v", f, float64(tt.u.val))
+			}
+		})
+	}
+}
+
+func TestUint64ColumnValue_Clone(t *testing.T) {
+	u := NewUint64ColumnValue(1).(*Uint64ColumnValue)
+	c := u.Clone()
+	if c == ColumnValue(u) {
+		t.Errorf("Uint64ColumnValue.Clone() = %p, want different from %p", c, u)
+	}
+	if !reflect.DeepEqual(c, ColumnValue(u)) {
+		t.Errorf("Uint64ColumnValue.Clone() = %v, want %v", c, u)
+	}
+}
+
+func TestUint64ColumnValue_Cmp(t *testing.T) {
+	tests := []struct {
+		name    string
+		u       *Uint64ColumnValue
+		right   ColumnValue
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			u:     NewUint64ColumnValue(math.MaxUint64).(*Uint64ColumnValue),
+			right: NewUint64ColumnValue(math.MaxUint64 - 1),
+			want:  1,
+		},
+		{
+			name:  "2",
+			u:     NewUint64ColumnValue(1).(*Uint64ColumnValue),
+			right: NewDefaultColumn(NewUint64ColumnValue(2), "test", 0),
+			want:  -1,
+		},
+		{
+			name:  "3",
+			u:     NewUint64ColumnValue(2).(*Uint64ColumnValue),
+			right: NewUint64ColumnValue(2),
+			want:  0,
+		},
+		{
+			name:  "4",
+			u:     NewUint64ColumnValue(math.MaxUint64).(*Uint64ColumnValue),
+			right: NewInt64ColumnValue(math.MaxInt64),
+			want:  1,
+		},
+		{
+			name:  "5",
+			u:     NewUint64ColumnValue(1).(*Uint64ColumnValue),
+			right: NewInt64ColumnValue(-1),
+			want:  1,
+		},
+		{
+			name:  "6",
+			u:     NewUint64ColumnValue(math.MaxUint64).(*Uint64ColumnValue),
+			right: NewBigIntColumnValue(testBigIntFromString("18446744073709551616")),
+			want:  -1,
+		},
+		{
+			name:    "7",
+			u:       NewUint64ColumnValue(1).(*Uint64ColumnValue),
+			right:   NewNilBigIntColumnValue(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.u.Cmp(tt.right)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Uint64ColumnValue.Cmp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Uint64ColumnValue.Cmp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/shopspring/decimal"
+)
+
+//CastPolicy 类型转化策略，决定列类型与字段的golang类型不一致时如何转化列值
+type CastPolicy string
+
+//类型转化策略枚举
+const (
+	CastStrict      CastPolicy = "strict"      //严格转化，转化会丢失精度时报错
+	CastLenient     CastPolicy = "lenient"     //宽松转化，去除字符串首尾空白，空字符串作为空值，多余的小数位会被截断
+	CastTruncate    CastPolicy = "truncate"    //截断转化，多余的小数位会被截断
+	CastRound       CastPolicy = "round"       //舍入转化，多余的小数位会被四舍五入
+	CastNullOnError CastPolicy = "nullOnError" //严格转化，转化失败时作为空值
+)
+
+//类型转化相关错误
+var (
+	ErrCastPolicyNotSupported = errors.New("cast policy is not supported") //不支持的类型转化策略
+	ErrFieldNotExist          = errors.New("field does not exist")         //字段不存在
+)
+
+//Validate 检查类型转化策略是否支持
+func (p CastPolicy) Validate() error {
+	switch p {
+	case CastStrict, CastLenient, CastTruncate, CastRound, CastNullOnError:
+		return nil
+	}
+	return fmt.Errorf("cast policy(%v) err: %v", p, ErrCastPolicyNotSupported)
+}
+
+//Caster 类型转化器，将记录中的列转化为表中同名字段的列类型，
+//字段类型实现ColumnTyper时使用其列类型，否则使用ValuerGoType的golang类型对应的列类型，
+//如mysql的DECIMAL写入时使用字符串，但是列类型为高精度实数，列类型一致时不做转化
+type Caster struct {
+	fields map[string]*fieldCaster
+}
+
+type fieldCaster struct {
+	policy   CastPolicy
+	typ      element.ColumnType
+	scale    int32 //小数位数
+	hasScale bool  //是否有小数位数，整数类型的小数位数为0
+}
+
+//NewCaster 通过表table，默认的类型转化策略policy和字段名对应的类型转化策略columns
+//生成类型转化器，没有任何策略时返回空，策略不支持或者字段不存在时会报错
+func NewCaster(table Table, policy CastPolicy, columns map[string]CastPolicy) (*Caster, error) {
+	if policy == "" && len(columns) == 0 {
+		return nil, nil
+	}
+	if policy != "" {
+		if err := policy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	for name, v := range columns {
+		if err := v.Validate(); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", name, err)
+		}
+	}
+
+	c := &Caster{
+		fields: make(map[string]*fieldCaster),
+	}
+	for _, f := range table.Fields() {
+		p, ok := columns[f.Name()]
+		if !ok {
+			p = policy
+		}
+		typ := fieldColumnType(f.Type())
+		if p == "" || typ == element.TypeUnknown {
+			continue
+		}
+		c.fields[f.Name()] = newFieldCaster(p, typ, f.Type())
+	}
+	for name := range columns {
+		if _, ok := c.fields[name]; !ok {
+			return nil, fmt.Errorf("column(%v) err: %v", name, ErrFieldNotExist)
+		}
+	}
+	return c, nil
+}
+
+//fieldColumnType 获取字段类型typ对应的列类型，优先使用ColumnTyper，
+//其次使用ValuerGoType的golang类型，都没有实现时为unknown
+func fieldColumnType(typ FieldType) element.ColumnType {
+	if ct, ok := typ.(ColumnTyper); ok {
+		if t := ct.ColumnType(); t != element.TypeUnknown {
+			return t
+		}
+	}
+	if gt, ok := typ.(ValuerGoType); ok {
+		return gt.GoType().ColumnType()
+	}
+	return element.TypeUnknown
+}
+
+func newFieldCaster(policy CastPolicy, columnType element.ColumnType, typ FieldType) *fieldCaster {
+	f := &fieldCaster{
+		policy: policy,
+		typ:    columnType,
+	}
+	switch columnType {
+	case element.TypeBigInt:
+		f.hasScale = true
+	case element.TypeDecimal:
+		//浮点数没有固定的小数位数时驱动可能返回很大的值，如mysql的FLOAT
+		if _, scale, ok := typ.DecimalSize(); ok && scale >= 0 && scale <= math.MaxInt32 {
+			f.scale, f.hasScale = int32(scale), true
+		}
+	}
+	return f
+}
+
+//Cast 转化记录r中有类型转化策略的非空列
+func (c *Caster) Cast(r element.Record) error {
+	for i := 0; i < r.ColumnNumber(); i++ {
+		col, err := r.GetByIndex(i)
+		if err != nil {
+			return err
+		}
+		f, ok := c.fields[col.Name()]
+		if !ok || col.IsNil() || col.Type() == f.typ {
+			continue
+		}
+
+		var v element.ColumnValue
+		if v, err = f.cast(col); err != nil {
+			return fmt.Errorf("field(%v) err: %v", col.Name(), err)
+		}
+		if err = r.Set(i, element.NewDefaultColumn(v, col.Name(), int(col.ByteSize()))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fieldCaster) cast(c element.Column) (element.ColumnValue, error) {
+	v, err := f.convert(c)
+	if err != nil && f.policy == CastNullOnError {
+		return element.NewNilColumnValue(f.typ), nil
+	}
+	return v, err
+}
+
+func (f *fieldCaster) convert(c element.Column) (element.ColumnValue, error) {
+	if f.policy == CastLenient && c.Type() == element.TypeString && f.typ != element.TypeString {
+		s, err := c.AsString()
+		if err != nil {
+			return nil, err
+		}
+		if s = strings.TrimSpace(s); s == "" {
+			return element.NewNilColumnValue(f.typ), nil
+		}
+		//时间需要保留字符串列值的时间编码器
+		if f.typ != element.TypeTime {
+			c = element.NewDefaultColumn(element.NewStringColumnValue(s), c.Name(), len(s))
+		}
+	}
+
+	switch f.typ {
+	case element.TypeBool:
+		v, err := c.AsBool()
+		if err != nil {
+			return nil, err
+		}
+		return element.NewBoolColumnValue(v), nil
+	case element.TypeBigInt:
+		d, err := c.AsDecimal()
+		if err != nil {
+			return nil, err
+		}
+		if d, err = f.adjustScale(c, d); err != nil {
+			return nil, err
+		}
+		return element.NewBigIntColumnValue(d.BigInt()), nil
+	case element.TypeDecimal:
+		d, err := c.AsDecimal()
+		if err != nil {
+			return nil, err
+		}
+		if d, err = f.adjustScale(c, d); err != nil {
+			return nil, err
+		}
+		return element.NewDecimalColumnValue(d), nil
+	case element.TypeString:
+		v, err := c.AsString()
+		if err != nil {
+			return nil, err
+		}
+		return element.NewStringColumnValue(v), nil
+	case element.TypeBytes:
+		v, err := c.AsBytes()
+		if err != nil {
+			return nil, err
+		}
+		return element.NewBytesColumnValue(v), nil
+	case element.TypeTime:
+		v, err := c.AsTime()
+		if err != nil {
+			return nil, err
+		}
+		return element.NewTimeColumnValue(v), nil
+	case element.TypeJSON:
+		v, err := c.AsBytes()
+		if err != nil {
+			return nil, err
+		}
+		return element.NewJSONColumnValue(v)
+	}
+	return nil, fmt.Errorf("column type(%v) is not supported", f.typ)
+}
+
+//adjustScale 按照类型转化策略处理实数d多余的小数位
+func (f *fieldCaster) adjustScale(c element.Column, d decimal.Decimal) (decimal.Decimal, error) {
+	if !f.hasScale || d.Equal(d.Truncate(f.scale)) {
+		return d, nil
+	}
+	switch f.policy {
+	case CastLenient, CastTruncate:
+		return d.Truncate(f.scale), nil
+	case CastRound:
+		return d.Round(f.scale), nil
+	}
+	return decimal.Decimal{}, element.NewTransformErrorFormColumnTypes(c.Type(), f.typ,
+		fmt.Errorf("val: %v has more than %v decimal places", d, f.scale))
+}
@@ -0,0 +1,353 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockDecimalFieldType struct {
+	*mockFieldType
+	scale int64
+}
+
+func (m *mockDecimalFieldType) DecimalSize() (precision, scale int64, ok bool) {
+	return 10, m.scale, true
+}
+
+func testCastTable() *mockTable {
+	table := newMockTable(NewBaseTable("db", "schema", "table"))
+	table.AppendField(newMockField(NewBaseField("i", nil), newMockFieldType(GoTypeInt64)))
+	table.AppendField(newMockField(NewBaseField("d", nil),
+		&mockDecimalFieldType{mockFieldType: newMockFieldType(GoTypeFloat64), scale: 2}))
+	table.AppendField(newMockField(NewBaseField("f", nil), newMockFieldType(GoTypeFloat64)))
+	table.AppendField(newMockField(NewBaseField("b", nil), newMockFieldType(GoTypeBool)))
+	table.AppendField(newMockField(NewBaseField("s", nil), newMockFieldType(GoTypeString)))
+	table.AppendField(newMockField(NewBaseField("t", nil), newMockFieldType(GoTypeTime)))
+	table.AppendField(newMockField(NewBaseField("u", nil), &BaseFieldType{}))
+	return table
+}
+
+func TestCastPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       CastPolicy
+		wantErr bool
+	}{
+		{
+			name: "1",
+			p:    CastStrict,
+		},
+		{
+			name: "2",
+			p:    CastNullOnError,
+		},
+		{
+			name:    "3",
+			p:       "",
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			p:       "ceil",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.p.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("CastPolicy.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewCaster(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  CastPolicy
+		columns map[string]CastPolicy
+		wantNil bool
+		want    map[string]CastPolicy
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			wantNil: true,
+		},
+		{
+			name:   "2",
+			policy: CastRound,
+			columns: map[string]CastPolicy{
+				"s": CastNullOnError,
+			},
+			want: map[string]CastPolicy{
+				"i": CastRound,
+				"d": CastRound,
+				"f": CastRound,
+				"b": CastRound,
+				"s": CastNullOnError,
+				"t": CastRound,
+			},
+		},
+		{
+			name: "3",
+			columns: map[string]CastPolicy{
+				"i": CastTruncate,
+			},
+			want: map[string]CastPolicy{
+				"i": CastTruncate,
+			},
+		},
+		{
+			name:    "4",
+			policy:  "ceil",
+			wantErr: true,
+		},
+		{
+			name: "5",
+			columns: map[string]CastPolicy{
+				"i": "ceil",
+			},
+			wantErr: true,
+		},
+		{
+			name: "6",
+			columns: map[string]CastPolicy{
+				"x": CastStrict,
+			},
+			wantErr: true,
+		},
+		{
+			name: "7",
+			columns: map[string]CastPolicy{
+				"u": CastStrict,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCaster(testCastTable(), tt.policy, tt.columns)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCaster() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got == nil) != tt.wantNil {
+				t.Errorf("NewCaster() = %v, wantNil %v", got, tt.wantNil)
+				return
+			}
+			if tt.wantNil {
+				return
+			}
+			if len(got.fields) != len(tt.want) {
+				t.Errorf("NewCaster() fields = %v, want %v", len(got.fields), len(tt.want))
+				return
+			}
+			for k, v := range tt.want {
+				if f, ok := got.fields[k]; !ok || f.policy != v {
+					t.Errorf("NewCaster() field(%v) = %v, want %v", k, f, v)
+				}
+			}
+		})
+	}
+}
+
+func TestCaster_Cast(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   CastPolicy
+		column   string
+		v        element.ColumnValue
+		wantType element.ColumnType
+		want     string
+		wantNil  bool
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			policy:   CastStrict,
+			column:   "d",
+			v:        element.NewStringColumnValue("12.50"),
+			wantType: element.TypeDecimal,
+			want:     "12.5",
+		},
+		{
+			name:    "2",
+			policy:  CastStrict,
+			column:  "d",
+			v:       element.NewStringColumnValue("12.505"),
+			wantErr: true,
+		},
+		{
+			name:     "3",
+			policy:   CastTruncate,
+			column:   "d",
+			v:        element.NewStringColumnValue("-12.505"),
+			wantType: element.TypeDecimal,
+			want:     "-12.5",
+		},
+		{
+			name:     "4",
+			policy:   CastRound,
+			column:   "d",
+			v:        element.NewStringColumnValue("-12.505"),
+			wantType: element.TypeDecimal,
+			want:     "-12.51",
+		},
+		{
+			name:     "5",
+			policy:   CastRound,
+			column:   "i",
+			v:        element.NewStringColumnValue("12.5"),
+			wantType: element.TypeBigInt,
+			want:     "13",
+		},
+		{
+			name:     "6",
+			policy:   CastStrict,
+			column:   "i",
+			v:        element.NewDecimalColumnValueFromFloat(12.0),
+			wantType: element.TypeBigInt,
+			want:     "12",
+		},
+		{
+			name:    "7",
+			policy:  CastStrict,
+			column:  "i",
+			v:       element.NewDecimalColumnValueFromFloat(12.3),
+			wantErr: true,
+		},
+		{
+			name:     "8",
+			policy:   CastRound,
+			column:   "f",
+			v:        element.NewStringColumnValue("1.23456"),
+			wantType: element.TypeDecimal,
+			want:     "1.23456",
+		},
+		{
+			name:    "9",
+			policy:  CastStrict,
+			column:  "i",
+			v:       element.NewStringColumnValue(" 12 "),
+			wantErr: true,
+		},
+		{
+			name:     "10",
+			policy:   CastLenient,
+			column:   "i",
+			v:        element.NewStringColumnValue(" 12.9 "),
+			wantType: element.TypeBigInt,
+			want:     "12",
+		},
+		{
+			name:     "11",
+			policy:   CastLenient,
+			column:   "d",
+			v:        element.NewStringColumnValue("  "),
+			wantType: element.TypeDecimal,
+			wantNil:  true,
+		},
+		{
+			name:     "12",
+			policy:   CastLenient,
+			column:   "b",
+			v:        element.NewStringColumnValue(" true"),
+			wantType: element.TypeBool,
+			want:     "true",
+		},
+		{
+			name:     "13",
+			policy:   CastNullOnError,
+			column:   "d",
+			v:        element.NewStringColumnValue("abc"),
+			wantType: element.TypeDecimal,
+			wantNil:  true,
+		},
+		{
+			name:     "14",
+			policy:   CastNullOnError,
+			column:   "i",
+			v:        element.NewDecimalColumnValueFromFloat(1.5),
+			wantType: element.TypeBigInt,
+			wantNil:  true,
+		},
+		{
+			name:     "15",
+			policy:   CastStrict,
+			column:   "s",
+			v:        element.NewBigIntColumnValueFromInt64(12),
+			wantType: element.TypeString,
+			want:     "12",
+		},
+		{
+			name:     "16",
+			policy:   CastStrict,
+			column:   "t",
+			v:        element.NewStringColumnValue("2021-01-02T03:04:05Z"),
+			wantType: element.TypeTime,
+			want:     "2021-01-02T03:04:05Z",
+		},
+		{
+			name:     "17",
+			policy:   CastStrict,
+			column:   "s",
+			v:        element.NewStringColumnValue(" a "),
+			wantType: element.TypeString,
+			want:     " a ",
+		},
+		{
+			name:     "18",
+			policy:   CastStrict,
+			column:   "d",
+			v:        element.NewNilStringColumnValue(),
+			wantType: element.TypeString,
+			wantNil:  true,
+		},
+		{
+			name:     "19",
+			policy:   CastStrict,
+			column:   "u",
+			v:        element.NewStringColumnValue("abc"),
+			wantType: element.TypeString,
+			want:     "abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCaster(testCastTable(), tt.policy, nil)
+			if err != nil {
+				t.Fatalf("NewCaster() error = %v", err)
+			}
+			r := element.NewDefaultRecord()
+			r.Add(element.NewDefaultColumn(element.NewStringColumnValue("x"), "x", 0))
+			r.Add(element.NewDefaultColumn(tt.v, tt.column, 0))
+			err = c.Cast(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Caster.Cast() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, _ := r.GetByName(tt.column)
+			if got.Type() != tt.wantType {
+				t.Errorf("Caster.Cast() type = %v, want %v", got.Type(), tt.wantType)
+			}
+			if got.IsNil() != tt.wantNil {
+				t.Errorf("Caster.Cast() IsNil = %v, want %v", got.IsNil(), tt.wantNil)
+				return
+			}
+			if tt.wantNil {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("Caster.Cast() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
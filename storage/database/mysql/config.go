@@ -2,11 +2,27 @@ package mysql
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/go-sql-driver/mysql"
 )
 
+//ZeroDatePolicy 零值日期处理策略，零值日期如0000-00-00，
+//通过url中的zeroDate参数设置，如tcp(127.0.0.1:3306)/db?zeroDate=epoch
+type ZeroDatePolicy string
+
+//零值日期处理策略枚举
+const (
+	ZeroDateZero  ZeroDatePolicy = "zero"  //作为go的零值时间即0001-01-01 00:00:00 UTC处理，默认策略
+	ZeroDateNull  ZeroDatePolicy = "null"  //作为空值处理
+	ZeroDateError ZeroDatePolicy = "error" //报错
+	ZeroDateEpoch ZeroDatePolicy = "epoch" //作为1970-01-01 00:00:00 UTC处理
+)
+
+const zeroDateParam = "zeroDate"
+
 //Config mysql配置，读入的时间都需要解析即parseTime=true
 type Config struct {
 	URL      string `json:"url"`      //数据库url，包含数据库地址，数据库其他参数
@@ -33,6 +49,35 @@ func (c *Config) FormatDSN() (dsn string, err error) {
 	mysqlConf.User = c.Username
 	mysqlConf.Passwd = c.Password
 	mysqlConf.ParseTime = true
+	//zeroDate不是mysql的系统变量，不能传给数据库
+	delete(mysqlConf.Params, zeroDateParam)
 	dsn = mysqlConf.FormatDSN()
 	return
 }
+
+//Location 获取url中的loc参数，驱动读取时间时使用该时区，写入时间时会先转化到该时区，
+//没有设置时为UTC，url有错会报错
+func (c *Config) Location() (loc *time.Location, err error) {
+	var mysqlConf *mysql.Config
+	if mysqlConf, err = mysql.ParseDSN(c.URL); err != nil {
+		return
+	}
+	return mysqlConf.Loc, nil
+}
+
+//ZeroDatePolicy 获取url中的零值日期处理策略，没有设置时为zero，url或者策略有错会报错
+func (c *Config) ZeroDatePolicy() (policy ZeroDatePolicy, err error) {
+	var mysqlConf *mysql.Config
+	if mysqlConf, err = mysql.ParseDSN(c.URL); err != nil {
+		return
+	}
+	policy = ZeroDatePolicy(mysqlConf.Params[zeroDateParam])
+	switch policy {
+	case "":
+		return ZeroDateZero, nil
+	case ZeroDateZero, ZeroDateNull, ZeroDateError, ZeroDateEpoch:
+		return
+	}
+	return "", fmt.Errorf("%v(%v) err: %v is not in [%v %v %v %v]",
+		zeroDateParam, policy, policy, ZeroDateZero, ZeroDateNull, ZeroDateError, ZeroDateEpoch)
+}
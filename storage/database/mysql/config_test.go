@@ -83,6 +83,15 @@ func TestConfig_FormatDSN(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "3",
+			c: &Config{
+				URL:      "tcp(192.168.1.1:3306)/db?zeroDate=error&charset=utf8",
+				Username: "user",
+				Password: "passwd",
+			},
+			wantDsn: "user:passwd@tcp(192.168.1.1:3306)/db?parseTime=true&charset=utf8",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -97,3 +106,117 @@ func TestConfig_FormatDSN(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_ZeroDatePolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		c          *Config
+		wantPolicy ZeroDatePolicy
+		wantErr    bool
+	}{
+		{
+			name: "1",
+			c: &Config{
+				URL: "tcp(192.168.1.1:3306)/db",
+			},
+			wantPolicy: ZeroDateZero,
+		},
+		{
+			name: "2",
+			c: &Config{
+				URL: "tcp(192.168.1.1:3306)/db?zeroDate=null",
+			},
+			wantPolicy: ZeroDateNull,
+		},
+		{
+			name: "3",
+			c: &Config{
+				URL: "tcp(192.168.1.1:3306)/db?zeroDate=error",
+			},
+			wantPolicy: ZeroDateError,
+		},
+		{
+			name: "4",
+			c: &Config{
+				URL: "tcp(192.168.1.1:3306)/db?zeroDate=epoch",
+			},
+			wantPolicy: ZeroDateEpoch,
+		},
+		{
+			name: "5",
+			c: &Config{
+				URL: "tcp(192.168.1.1:3306)/db?zeroDate=round",
+			},
+			wantErr: true,
+		},
+		{
+			name: "6",
+			c: &Config{
+				URL: "tcp(192.168.1.1:3306/db",
+			},
+			wantErr: true,
+		},
+		{
+			name: "7",
+			c: &Config{
+				URL: "tcp(192.168.1.1:3306)/db?zeroDate=zero",
+			},
+			wantPolicy: ZeroDateZero,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPolicy, err := tt.c.ZeroDatePolicy()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.ZeroDatePolicy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotPolicy != tt.wantPolicy {
+				t.Errorf("Config.ZeroDatePolicy() = %v, want %v", gotPolicy, tt.wantPolicy)
+			}
+		})
+	}
+}
+
+func TestConfig_Location(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *Config
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c: &Config{
+				URL: "tcp(192.168.1.1:3306)/db",
+			},
+			want: "UTC",
+		},
+		{
+			name: "2",
+			c: &Config{
+				URL: "tcp(192.168.1.1:3306)/db?loc=Asia%2FShanghai",
+			},
+			want: "Asia/Shanghai",
+		},
+		{
+			name: "3",
+			c: &Config{
+				URL: "tcp(192.168.1.1:3306)/db?loc=Mars%2FOlympus",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.Location()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.Location() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Config.Location() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,8 @@ Package mysql 实现了mysql的数据库方言Dialect，支持mysql 5.6+ 对应
 驱动为github.com/go-sql-driver/mysql
 
 数据源Source使用BaseSource来简化实现, 对github.com/go-sql-driver/mysql
-驱动进行包装.对于数据库配置，需要和Config一致
+驱动进行包装.对于数据库配置，需要和Config一致，零值日期的处理策略通过url中的
+zeroDate参数设置
 
 表Table使用BaseTable来简化实现,也是基于github.com/go-sql-driver/mysql的
 封装,Table实现了FieldAdder的方式去获取列,在ExecParameter中实现写入模式为
@@ -13,8 +14,8 @@ replace的repalce into批量数据处理模式,写入模式为insert的插入模
 列Field使用BaseField来简化实现,其中FieldType采用了原来的sql.ColumnType，
 并实现了ValuerGoType
 
-扫描器Scanner使用BaseScanner来简化实现
+扫描器Scanner使用BaseScanner来简化实现，各个mysql类型的处理方式见Scan
 
-赋值器Valuer 使用了GoValuer的实现方式
+赋值器Valuer 使用了GoValuer的实现方式，BIT使用BitValuer按照无符号64位整数写入
 */
 package mysql
@@ -0,0 +1,110 @@
+package mysql
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"testing"
+)
+
+//testServer mysql服务器的本地替身，只支持mysql_native_password认证和文本协议的执行语句，
+//每条执行语句都返回成功，用于检查驱动实际发送的sql语句，需要在url中设置interpolateParams=true
+type testServer struct {
+	net.Listener
+
+	mu      sync.Mutex
+	queries []string
+}
+
+func newTestServer(t *testing.T) *testServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testServer{Listener: l}
+	t.Cleanup(func() {
+		l.Close()
+	})
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+//Queries 服务器收到的所有执行语句
+func (s *testServer) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+func (s *testServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	//握手包：协议版本10，服务器版本，连接ID，认证数据以及支持的能力
+	handshake := []byte{10}
+	handshake = append(handshake, "5.7.0\x00"...)
+	handshake = append(handshake, 1, 0, 0, 0)
+	handshake = append(handshake, "01234567\x00"...)
+	//CLIENT_LONG_PASSWORD|CLIENT_PROTOCOL_41|CLIENT_TRANSACTIONS|CLIENT_SECURE_CONNECTION
+	handshake = append(handshake, 0x01, 0xa2)
+	handshake = append(handshake, 33, 0x02, 0x00)
+	//CLIENT_PLUGIN_AUTH
+	handshake = append(handshake, 0x08, 0x00, 21)
+	handshake = append(handshake, make([]byte, 10)...)
+	handshake = append(handshake, "890123456789\x00"...)
+	handshake = append(handshake, "mysql_native_password\x00"...)
+	if err := writeTestPacket(conn, 0, handshake); err != nil {
+		return
+	}
+	if _, _, err := readTestPacket(conn); err != nil {
+		return
+	}
+	ok := []byte{0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}
+	if err := writeTestPacket(conn, 2, ok); err != nil {
+		return
+	}
+
+	for {
+		seq, data, err := readTestPacket(conn)
+		if err != nil || len(data) == 0 {
+			return
+		}
+		switch data[0] {
+		case 0x01: //COM_QUIT
+			return
+		case 0x03: //COM_QUERY
+			s.mu.Lock()
+			s.queries = append(s.queries, string(data[1:]))
+			s.mu.Unlock()
+		}
+		if err = writeTestPacket(conn, seq+1, ok); err != nil {
+			return
+		}
+	}
+}
+
+func readTestPacket(r io.Reader) (seq byte, data []byte, err error) {
+	var header [4]byte
+	if _, err = io.ReadFull(r, header[:]); err != nil {
+		return
+	}
+	data = make([]byte, int(header[0])|int(header[1])<<8|int(header[2])<<16)
+	_, err = io.ReadFull(r, data)
+	return header[3], data, err
+}
+
+func writeTestPacket(w io.Writer, seq byte, data []byte) error {
+	var header [4]byte
+	binary.LittleEndian.PutUint32(header[:], uint32(len(data)))
+	header[3] = seq
+	_, err := w.Write(append(header[:], data...))
+	return err
+}
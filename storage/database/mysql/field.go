@@ -1,7 +1,9 @@
 package mysql
 
 import (
+	"database/sql/driver"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/Breeze0806/go-etl/element"
@@ -11,6 +13,8 @@ import (
 //Field 字段
 type Field struct {
 	*database.BaseField
+
+	zeroDate ZeroDatePolicy
 }
 
 //NewField 通过基本列属性生成字段
@@ -45,11 +49,41 @@ func (f *Field) Scanner() database.Scanner {
 	return NewScanner(f)
 }
 
-//Valuer 赋值器，采用GoValuer处理数据
+//Valuer 赋值器，BIT采用BitValuer处理数据，其他类型采用GoValuer处理数据
 func (f *Field) Valuer(c element.Column) database.Valuer {
+	if f.Type().DatabaseTypeName() == "BIT" {
+		return NewBitValuer(c)
+	}
 	return database.NewGoValuer(f, c)
 }
 
+//BitValuer BIT赋值器，BIT(64)的值可能超出int64的范围，所以按照无符号64位整数写入
+type BitValuer struct {
+	c element.Column
+}
+
+//NewBitValuer 根据列c生成BIT赋值器
+func NewBitValuer(c element.Column) *BitValuer {
+	return &BitValuer{
+		c: c,
+	}
+}
+
+//Value 将列值转化为无符号64位整数，列值为负数或者超出uint64范围时会报错
+func (b *BitValuer) Value() (driver.Value, error) {
+	if b.c.IsNil() {
+		return nil, nil
+	}
+	v, err := b.c.AsBigInt()
+	if err != nil {
+		return nil, err
+	}
+	if v.Sign() < 0 || v.BitLen() > 64 {
+		return nil, fmt.Errorf("column(%v) value(%v) is out of range of BIT", b.c.Name(), v)
+	}
+	return v.Uint64(), nil
+}
+
 //FieldType 字段类型
 type FieldType struct {
 	*database.BaseFieldType
@@ -72,9 +106,15 @@ func (f *FieldType) GoType() database.GoType {
 	case "MEDIUMINT", "INT", "BIGINT", "SMALLINT", "TINYINT",
 		"TEXT", "LONGTEXT", "MEDIUMTEXT", "TINYTEXT", "CHAR", "VARCHAR",
 		"TIME", "YEAR",
-		"DECIMAL":
+		"DECIMAL",
+		"JSON", "ENUM", "SET", "NULL":
 		return database.GoTypeString
-	case "BLOB", "LONGBLOB", "MEDIUMBLOB", "BINARY", "TINYBLOB", "VARBINARY":
+	//BIT使用字符串时会按照字节写入，如'1'会写成0x31，写入时通过BitValuer按照uint64写入
+	case "BIT":
+		return database.GoTypeInt64
+	//GEOMETRY使用mysql内部格式，即4字节SRID加WKB
+	case "BLOB", "LONGBLOB", "MEDIUMBLOB", "BINARY", "TINYBLOB", "VARBINARY",
+		"GEOMETRY":
 		return database.GoTypeBytes
 	case "DOUBLE", "FLOAT":
 		return database.GoTypeFloat64
@@ -84,6 +124,27 @@ func (f *FieldType) GoType() database.GoType {
 	return database.GoTypeUnknow
 }
 
+//ColumnType 返回扫描器生成的列值的类型，与Scanner的处理方式一致
+func (f *FieldType) ColumnType() element.ColumnType {
+	switch f.DatabaseTypeName() {
+	case "MEDIUMINT", "INT", "BIGINT", "SMALLINT", "TINYINT", "YEAR", "BIT":
+		return element.TypeBigInt
+	case "BLOB", "LONGBLOB", "MEDIUMBLOB", "BINARY", "TINYBLOB", "VARBINARY",
+		"GEOMETRY":
+		return element.TypeBytes
+	case "DATE", "DATETIME", "TIMESTAMP":
+		return element.TypeTime
+	case "TEXT", "LONGTEXT", "MEDIUMTEXT", "TINYTEXT", "CHAR", "VARCHAR", "TIME",
+		"ENUM", "SET", "NULL":
+		return element.TypeString
+	case "DOUBLE", "FLOAT", "DECIMAL":
+		return element.TypeDecimal
+	case "JSON":
+		return element.TypeJSON
+	}
+	return element.TypeUnknown
+}
+
 //Scanner 扫描器
 type Scanner struct {
 	f *Field
@@ -98,11 +159,15 @@ func NewScanner(f *Field) *Scanner {
 }
 
 //Scan 根据列类型读取数据
-//"MEDIUMINT", "INT", "BIGINT", "SMALLINT", "TINYINT", "YEAR"作为整形处理
-//"DOUBLE", "FLOAT", "DECIMAL"作为高精度实数处理
-//"DATE", "DATETIME", "TIMESTAMP" 作为时间处理
-//"TEXT", "LONGTEXT", "MEDIUMTEXT", "TINYTEXT", "CHAR", "VARCHAR", "TIME"作为字符串处理
-//"BLOB", "LONGBLOB", "MEDIUMBLOB", "BINARY", "TINYBLOB", "VARBINARY"作为字节流处理
+//"MEDIUMINT", "INT", "BIGINT", "SMALLINT", "TINYINT", "YEAR"作为整形处理，优先生成64位整形列值
+//"BIT"作为无符号64位整形处理
+//"DOUBLE"作为64位浮点数处理
+//"FLOAT", "DECIMAL"作为高精度实数处理
+//"DATE", "DATETIME", "TIMESTAMP" 作为时间处理，零值日期按照零值日期处理策略处理
+//"TEXT", "LONGTEXT", "MEDIUMTEXT", "TINYTEXT", "CHAR", "VARCHAR", "TIME", "ENUM", "SET", "NULL"作为字符串处理
+//"BLOB", "LONGBLOB", "MEDIUMBLOB", "BINARY", "TINYBLOB", "VARBINARY", "GEOMETRY"作为字节流处理
+//"JSON"作为JSON处理
+//其他类型会报错
 func (s *Scanner) Scan(src interface{}) (err error) {
 	var cv element.ColumnValue
 	//todo: byteSize is 0, fix it
@@ -114,13 +179,30 @@ func (s *Scanner) Scan(src interface{}) (err error) {
 		case nil:
 			cv = element.NewNilBigIntColumnValue()
 		case []byte:
-			if cv, err = element.NewBigIntColumnValueFromString(string(data)); err != nil {
+			if cv, err = newIntegerColumnValue(string(data)); err != nil {
 				return
 			}
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeBigInt)
 		}
-	case "BLOB", "LONGBLOB", "MEDIUMBLOB", "BINARY", "TINYBLOB", "VARBINARY":
+	case "BIT":
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilBigIntColumnValue()
+		case []byte:
+			if len(data) > 8 {
+				return fmt.Errorf("src is %v(%T), but its length is more than 8", src, src)
+			}
+			var v uint64
+			for _, b := range data {
+				v = v<<8 | uint64(b)
+			}
+			cv = element.NewUint64ColumnValue(v)
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeBigInt)
+		}
+	case "BLOB", "LONGBLOB", "MEDIUMBLOB", "BINARY", "TINYBLOB", "VARBINARY",
+		"GEOMETRY":
 		switch data := src.(type) {
 		case nil:
 			cv = element.NewNilBytesColumnValue()
@@ -134,11 +216,14 @@ func (s *Scanner) Scan(src interface{}) (err error) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValue(data)
+			if cv, err = s.timeColumnValue(data); err != nil {
+				return
+			}
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
-	case "TEXT", "LONGTEXT", "MEDIUMTEXT", "TINYTEXT", "CHAR", "VARCHAR", "TIME":
+	case "TEXT", "LONGTEXT", "MEDIUMTEXT", "TINYTEXT", "CHAR", "VARCHAR", "TIME",
+		"ENUM", "SET", "NULL":
 		switch data := src.(type) {
 		case nil:
 			cv = element.NewNilStringColumnValue()
@@ -147,7 +232,20 @@ func (s *Scanner) Scan(src interface{}) (err error) {
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeString)
 		}
-	case "DOUBLE", "FLOAT", "DECIMAL":
+	case "DOUBLE":
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilDecimalColumnValue()
+		case []byte:
+			var f float64
+			if f, err = strconv.ParseFloat(string(data), 64); err != nil {
+				return
+			}
+			cv = element.NewFloat64ColumnValue(f)
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeDecimal)
+		}
+	case "FLOAT", "DECIMAL":
 		switch data := src.(type) {
 		case nil:
 			cv = element.NewNilDecimalColumnValue()
@@ -158,7 +256,48 @@ func (s *Scanner) Scan(src interface{}) (err error) {
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeDecimal)
 		}
+	case "JSON":
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilJSONColumnValue()
+		case []byte:
+			//data属于驱动的缓冲区，需要复制
+			if cv, err = element.NewJSONColumnValueFromString(string(data)); err != nil {
+				return
+			}
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeJSON)
+		}
+	default:
+		return fmt.Errorf("database type(%v) is not supported", s.f.Type().DatabaseTypeName())
 	}
 	s.SetColumn(element.NewDefaultColumn(cv, s.f.Name(), byteSize))
 	return
 }
+
+//timeColumnValue 将时间t转化为列值，驱动会将零值日期解析成time.Time{}
+func (s *Scanner) timeColumnValue(t time.Time) (element.ColumnValue, error) {
+	if !t.IsZero() {
+		return element.NewTimeColumnValue(t), nil
+	}
+	switch s.f.zeroDate {
+	case ZeroDateNull:
+		return element.NewNilTimeColumnValue(), nil
+	case ZeroDateError:
+		return nil, fmt.Errorf("field(%v) err: zero date is not allowed", s.f.Name())
+	case ZeroDateEpoch:
+		return element.NewTimeColumnValue(time.Unix(0, 0).UTC()), nil
+	}
+	return element.NewTimeColumnValue(t), nil
+}
+
+//newIntegerColumnValue 将整形字符串s转化为列值，依次尝试int64，uint64和大整数
+func newIntegerColumnValue(s string) (element.ColumnValue, error) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return element.NewInt64ColumnValue(i), nil
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return element.NewUint64ColumnValue(u), nil
+	}
+	return element.NewBigIntColumnValueFromString(s)
+}
@@ -2,6 +2,9 @@ package mysql
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"math"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -182,6 +185,14 @@ func TestField_Valuer(t *testing.T) {
 			},
 			want: database.NewGoValuer(NewField(database.NewBaseField("f1", NewFieldType(&sql.ColumnType{}))), element.NewDefaultColumn(nil, "", 0)),
 		},
+		{
+			name: "2",
+			f:    NewField(database.NewBaseField("f1", newMockFieldType("BIT"))),
+			args: args{
+				c: element.NewDefaultColumn(nil, "", 0),
+			},
+			want: NewBitValuer(element.NewDefaultColumn(nil, "", 0)),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -192,6 +203,92 @@ func TestField_Valuer(t *testing.T) {
 	}
 }
 
+func TestBitValuer_Value(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       element.Column
+		want    driver.Value
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    element.NewDefaultColumn(element.NewNilBigIntColumnValue(), "a", 0),
+			want: nil,
+		},
+		{
+			name: "2",
+			c:    element.NewDefaultColumn(element.NewUint64ColumnValue(0xFFFFFFFFFFFFFFFF), "a", 0),
+			want: uint64(0xFFFFFFFFFFFFFFFF),
+		},
+		{
+			name: "3",
+			c:    element.NewDefaultColumn(element.NewStringColumnValue("5"), "a", 0),
+			want: uint64(5),
+		},
+		{
+			name:    "4",
+			c:       element.NewDefaultColumn(element.NewInt64ColumnValue(-1), "a", 0),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			c:       element.NewDefaultColumn(element.NewBigIntColumnValue(new(big.Int).Lsh(big.NewInt(1), 64)), "a", 0),
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			c:       element.NewDefaultColumn(element.NewStringColumnValue("abc"), "a", 0),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBitValuer(tt.c).Value()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BitValuer.Value() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BitValuer.Value() = %v(%T), want %v(%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestBitValuer_ValueDriver(t *testing.T) {
+	server := newTestServer(t)
+	s, err := NewSource(database.NewBaseSource(testJSONFromString(`{
+		"url" : "tcp(` + server.Addr().String() + `)/db?interpolateParams=true",
+		"username" : "user",
+		"password": "passwd"
+	}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open(s.DriverName(), s.ConnectName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	//mysql到mysql复制BIT(64)时，扫描器生成的列值超出int64的范围
+	scanner := NewScanner(NewField(database.NewBaseField("a", newMockFieldType("BIT"))))
+	if err = scanner.Scan([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}); err != nil {
+		t.Fatal(err)
+	}
+	v, err := NewField(database.NewBaseField("a", newMockFieldType("BIT"))).Valuer(scanner.Column()).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = db.Exec("insert into t values (?)", v); err != nil {
+		t.Fatal(err)
+	}
+	queries := server.Queries()
+	if got, want := queries[len(queries)-1], "insert into t values (18446744073709551615)"; got != want {
+		t.Errorf("BitValuer.Value() query = %v, want %v", got, want)
+	}
+}
+
 func TestFieldType_GoType(t *testing.T) {
 	tests := []struct {
 		name string
@@ -272,6 +369,11 @@ func TestFieldType_GoType(t *testing.T) {
 			f:    NewFieldType(newMockFieldType("DECIMAL")),
 			want: database.GoTypeString,
 		},
+		{
+			name: "JSON",
+			f:    NewFieldType(newMockFieldType("JSON")),
+			want: database.GoTypeString,
+		},
 		//"BLOB", "LONGBLOB", "MEDIUMBLOB", "BINARY", "TINYBLOB", "VARBINARY"
 		{
 			name: "BLOB",
@@ -330,6 +432,31 @@ func TestFieldType_GoType(t *testing.T) {
 			f:    NewFieldType(newMockFieldType("TIMESTAMP")),
 			want: database.GoTypeTime,
 		},
+		{
+			name: "ENUM",
+			f:    NewFieldType(newMockFieldType("ENUM")),
+			want: database.GoTypeString,
+		},
+		{
+			name: "SET",
+			f:    NewFieldType(newMockFieldType("SET")),
+			want: database.GoTypeString,
+		},
+		{
+			name: "NULL",
+			f:    NewFieldType(newMockFieldType("NULL")),
+			want: database.GoTypeString,
+		},
+		{
+			name: "BIT",
+			f:    NewFieldType(newMockFieldType("BIT")),
+			want: database.GoTypeInt64,
+		},
+		{
+			name: "GEOMETRY",
+			f:    NewFieldType(newMockFieldType("GEOMETRY")),
+			want: database.GoTypeBytes,
+		},
 		{
 			name: "NEWDATE",
 			f:    NewFieldType(newMockFieldType("NEWDATE")),
@@ -345,6 +472,212 @@ func TestFieldType_GoType(t *testing.T) {
 	}
 }
 
+func TestFieldType_ColumnType(t *testing.T) {
+	tests := []struct {
+		name string
+		f    *FieldType
+		want element.ColumnType
+	}{
+		{
+			name: "MEDIUMINT",
+			f:    NewFieldType(newMockFieldType("MEDIUMINT")),
+			want: element.TypeBigInt,
+		},
+		{
+			name: "INT",
+			f:    NewFieldType(newMockFieldType("INT")),
+			want: element.TypeBigInt,
+		},
+		{
+			name: "BIGINT",
+			f:    NewFieldType(newMockFieldType("BIGINT")),
+			want: element.TypeBigInt,
+		},
+		{
+			name: "SMALLINT",
+			f:    NewFieldType(newMockFieldType("SMALLINT")),
+			want: element.TypeBigInt,
+		},
+		{
+			name: "TINYINT",
+			f:    NewFieldType(newMockFieldType("TINYINT")),
+			want: element.TypeBigInt,
+		},
+		{
+			name: "YEAR",
+			f:    NewFieldType(newMockFieldType("YEAR")),
+			want: element.TypeBigInt,
+		},
+		{
+			name: "BLOB",
+			f:    NewFieldType(newMockFieldType("BLOB")),
+			want: element.TypeBytes,
+		},
+		{
+			name: "LONGBLOB",
+			f:    NewFieldType(newMockFieldType("LONGBLOB")),
+			want: element.TypeBytes,
+		},
+		{
+			name: "MEDIUMBLOB",
+			f:    NewFieldType(newMockFieldType("MEDIUMBLOB")),
+			want: element.TypeBytes,
+		},
+		{
+			name: "BINARY",
+			f:    NewFieldType(newMockFieldType("BINARY")),
+			want: element.TypeBytes,
+		},
+		{
+			name: "TINYBLOB",
+			f:    NewFieldType(newMockFieldType("TINYBLOB")),
+			want: element.TypeBytes,
+		},
+		{
+			name: "VARBINARY",
+			f:    NewFieldType(newMockFieldType("VARBINARY")),
+			want: element.TypeBytes,
+		},
+		{
+			name: "DATE",
+			f:    NewFieldType(newMockFieldType("DATE")),
+			want: element.TypeTime,
+		},
+		{
+			name: "DATETIME",
+			f:    NewFieldType(newMockFieldType("DATETIME")),
+			want: element.TypeTime,
+		},
+		{
+			name: "TIMESTAMP",
+			f:    NewFieldType(newMockFieldType("TIMESTAMP")),
+			want: element.TypeTime,
+		},
+		{
+			name: "TEXT",
+			f:    NewFieldType(newMockFieldType("TEXT")),
+			want: element.TypeString,
+		},
+		{
+			name: "LONGTEXT",
+			f:    NewFieldType(newMockFieldType("LONGTEXT")),
+			want: element.TypeString,
+		},
+		{
+			name: "MEDIUMTEXT",
+			f:    NewFieldType(newMockFieldType("MEDIUMTEXT")),
+			want: element.TypeString,
+		},
+		{
+			name: "TINYTEXT",
+			f:    NewFieldType(newMockFieldType("TINYTEXT")),
+			want: element.TypeString,
+		},
+		{
+			name: "CHAR",
+			f:    NewFieldType(newMockFieldType("CHAR")),
+			want: element.TypeString,
+		},
+		{
+			name: "VARCHAR",
+			f:    NewFieldType(newMockFieldType("VARCHAR")),
+			want: element.TypeString,
+		},
+		{
+			name: "TIME",
+			f:    NewFieldType(newMockFieldType("TIME")),
+			want: element.TypeString,
+		},
+		{
+			name: "DOUBLE",
+			f:    NewFieldType(newMockFieldType("DOUBLE")),
+			want: element.TypeDecimal,
+		},
+		{
+			name: "FLOAT",
+			f:    NewFieldType(newMockFieldType("FLOAT")),
+			want: element.TypeDecimal,
+		},
+		{
+			name: "DECIMAL",
+			f:    NewFieldType(newMockFieldType("DECIMAL")),
+			want: element.TypeDecimal,
+		},
+		{
+			name: "JSON",
+			f:    NewFieldType(newMockFieldType("JSON")),
+			want: element.TypeJSON,
+		},
+		{
+			name: "BIT",
+			f:    NewFieldType(newMockFieldType("BIT")),
+			want: element.TypeBigInt,
+		},
+		{
+			name: "ENUM",
+			f:    NewFieldType(newMockFieldType("ENUM")),
+			want: element.TypeString,
+		},
+		{
+			name: "SET",
+			f:    NewFieldType(newMockFieldType("SET")),
+			want: element.TypeString,
+		},
+		{
+			name: "NULL",
+			f:    NewFieldType(newMockFieldType("NULL")),
+			want: element.TypeString,
+		},
+		{
+			name: "GEOMETRY",
+			f:    NewFieldType(newMockFieldType("GEOMETRY")),
+			want: element.TypeBytes,
+		},
+		{
+			name: "NEWDATE",
+			f:    NewFieldType(newMockFieldType("NEWDATE")),
+			want: element.TypeUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.ColumnType(); got != tt.want {
+				t.Errorf("FieldType.ColumnType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func testBigIntColumnValue(s string) element.ColumnValue {
+	cv, err := element.NewBigIntColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return cv
+}
+
+func testDecimalColumnValue(s string) element.ColumnValue {
+	cv, err := element.NewDecimalColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return cv
+}
+
+func testZeroDateField(typ string, policy ZeroDatePolicy) *Field {
+	f := NewField(database.NewBaseField("test", newMockFieldType(typ)))
+	f.zeroDate = policy
+	return f
+}
+
+func testJSONColumnValue(s string) element.ColumnValue {
+	cv, err := element.NewJSONColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return cv
+}
+
 func TestScanner_Scan(t *testing.T) {
 	type args struct {
 		src interface{}
@@ -363,7 +696,23 @@ func TestScanner_Scan(t *testing.T) {
 			args: args{
 				src: []byte("123123456789"),
 			},
-			want: element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(123123456789), "test", 0),
+			want: element.NewDefaultColumn(element.NewInt64ColumnValue(123123456789), "test", 0),
+		},
+		{
+			name: "BIGINT",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("BIGINT")))),
+			args: args{
+				src: []byte("18446744073709551615"),
+			},
+			want: element.NewDefaultColumn(element.NewUint64ColumnValue(18446744073709551615), "test", 0),
+		},
+		{
+			name: "INT",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("INT")))),
+			args: args{
+				src: []byte("-18446744073709551616"),
+			},
+			want: element.NewDefaultColumn(testBigIntColumnValue("-18446744073709551616"), "test", 0),
 		},
 		{
 			name: "MEDIUMINT",
@@ -439,6 +788,54 @@ func TestScanner_Scan(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "DATE",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("DATE")))),
+			args: args{
+				src: time.Time{},
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValue(time.Time{}), "test", 0),
+		},
+		{
+			name: "DATE",
+			s:    NewScanner(testZeroDateField("DATE", ZeroDateZero)),
+			args: args{
+				src: time.Time{},
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValue(time.Time{}), "test", 0),
+		},
+		{
+			name: "DATE",
+			s:    NewScanner(testZeroDateField("DATE", ZeroDateNull)),
+			args: args{
+				src: time.Time{},
+			},
+			want: element.NewDefaultColumn(element.NewNilTimeColumnValue(), "test", 0),
+		},
+		{
+			name: "DATETIME",
+			s:    NewScanner(testZeroDateField("DATETIME", ZeroDateEpoch)),
+			args: args{
+				src: time.Time{},
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValue(time.Unix(0, 0).UTC()), "test", 0),
+		},
+		{
+			name: "TIMESTAMP",
+			s:    NewScanner(testZeroDateField("TIMESTAMP", ZeroDateError)),
+			args: args{
+				src: time.Time{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "TIMESTAMP",
+			s:    NewScanner(testZeroDateField("TIMESTAMP", ZeroDateError)),
+			args: args{
+				src: time.Date(2021, 1, 13, 18, 43, 12, 0, time.UTC),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 13, 18, 43, 12, 0, time.UTC)), "test", 0),
+		},
 		//"TEXT", "LONGTEXT", "MEDIUMTEXT", "TINYTEXT", "CHAR", "VARCHAR", "TIME"
 		{
 			name: "TEXT",
@@ -471,7 +868,15 @@ func TestScanner_Scan(t *testing.T) {
 			args: args{
 				src: []byte("123456.7123456"),
 			},
-			want: element.NewDefaultColumn(element.NewDecimalColumnValueFromFloat(123456.7123456), "test", 0),
+			want: element.NewDefaultColumn(element.NewFloat64ColumnValue(123456.7123456), "test", 0),
+		},
+		{
+			name: "DECIMAL",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("DECIMAL")))),
+			args: args{
+				src: []byte("123456.7123456"),
+			},
+			want: element.NewDefaultColumn(testDecimalColumnValue("123456.7123456"), "test", 0),
 		},
 		{
 			name: "DOUBLE",
@@ -497,6 +902,123 @@ func TestScanner_Scan(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		//"BIT"
+		{
+			name: "BIT",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("BIT")))),
+			args: args{
+				src: []byte{0x01, 0x02},
+			},
+			want: element.NewDefaultColumn(element.NewUint64ColumnValue(258), "test", 0),
+		},
+		{
+			name: "BIT",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("BIT")))),
+			args: args{
+				src: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			},
+			want: element.NewDefaultColumn(element.NewUint64ColumnValue(18446744073709551615), "test", 0),
+		},
+		{
+			name: "BIT",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("BIT")))),
+			args: args{
+				src: nil,
+			},
+			want: element.NewDefaultColumn(element.NewNilBigIntColumnValue(), "test", 0),
+		},
+		{
+			name: "BIT",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("BIT")))),
+			args: args{
+				src: make([]byte, 9),
+			},
+			wantErr: true,
+		},
+		{
+			name: "BIT",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("BIT")))),
+			args: args{
+				src: int64(1),
+			},
+			wantErr: true,
+		},
+		//"ENUM", "SET", "NULL"
+		{
+			name: "ENUM",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("ENUM")))),
+			args: args{
+				src: []byte("small"),
+			},
+			want: element.NewDefaultColumn(element.NewStringColumnValue("small"), "test", 0),
+		},
+		{
+			name: "SET",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("SET")))),
+			args: args{
+				src: []byte("a,b"),
+			},
+			want: element.NewDefaultColumn(element.NewStringColumnValue("a,b"), "test", 0),
+		},
+		{
+			name: "NULL",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("NULL")))),
+			args: args{
+				src: nil,
+			},
+			want: element.NewDefaultColumn(element.NewNilStringColumnValue(), "test", 0),
+		},
+		//"GEOMETRY"
+		{
+			name: "GEOMETRY",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("GEOMETRY")))),
+			args: args{
+				src: []byte{0x00, 0x00, 0x00, 0x00, 0x01, 0x01},
+			},
+			want: element.NewDefaultColumn(element.NewBytesColumnValue([]byte{0x00, 0x00, 0x00, 0x00, 0x01, 0x01}), "test", 0),
+		},
+		//其他类型
+		{
+			name: "NEWDATE",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("NEWDATE")))),
+			args: args{
+				src: nil,
+			},
+			wantErr: true,
+		},
+		//"JSON"
+		{
+			name: "JSON",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("JSON")))),
+			args: args{
+				src: []byte(`{"a": [1, 2]}`),
+			},
+			want: element.NewDefaultColumn(testJSONColumnValue(`{"a": [1, 2]}`), "test", 0),
+		},
+		{
+			name: "JSON",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("JSON")))),
+			args: args{
+				src: nil,
+			},
+			want: element.NewDefaultColumn(element.NewNilJSONColumnValue(), "test", 0),
+		},
+		{
+			name: "JSON",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("JSON")))),
+			args: args{
+				src: []byte(`{"a": [1, 2]`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "JSON",
+			s:    NewScanner(NewField(database.NewBaseField("test", newMockFieldType("JSON")))),
+			args: args{
+				src: "{}",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -510,3 +1032,132 @@ func TestScanner_Scan(t *testing.T) {
 		})
 	}
 }
+
+//mockDecimalFieldType 有小数位数的字段类型
+type mockDecimalFieldType struct {
+	*mockFieldType
+	precision int64
+	scale     int64
+}
+
+func (m *mockDecimalFieldType) DecimalSize() (precision, scale int64, ok bool) {
+	return m.precision, m.scale, true
+}
+
+func TestFieldType_Cast(t *testing.T) {
+	table := NewTable(database.NewBaseTable("db", "", "table"))
+	table.AddField(database.NewBaseField("d", &mockDecimalFieldType{
+		mockFieldType: newMockFieldType("DECIMAL"),
+		precision:     10,
+		scale:         2,
+	}))
+	table.AddField(database.NewBaseField("i", newMockFieldType("INT")))
+	table.AddField(database.NewBaseField("f", &mockDecimalFieldType{
+		mockFieldType: newMockFieldType("FLOAT"),
+		precision:     math.MaxInt64,
+		scale:         math.MaxInt64,
+	}))
+	table.AddField(database.NewBaseField("j", newMockFieldType("JSON")))
+	table.AddField(database.NewBaseField("s", newMockFieldType("VARCHAR")))
+
+	tests := []struct {
+		name     string
+		policy   database.CastPolicy
+		column   string
+		v        string
+		want     string
+		wantType element.ColumnType
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			policy:   database.CastRound,
+			column:   "d",
+			v:        "12.345",
+			want:     "12.35",
+			wantType: element.TypeDecimal,
+		},
+		{
+			name:    "2",
+			policy:  database.CastStrict,
+			column:  "d",
+			v:       "12.345",
+			wantErr: true,
+		},
+		{
+			name:     "3",
+			policy:   database.CastLenient,
+			column:   "i",
+			v:        " 7 ",
+			want:     "7",
+			wantType: element.TypeBigInt,
+		},
+		{
+			name:    "4",
+			policy:  database.CastStrict,
+			column:  "i",
+			v:       "7.5",
+			wantErr: true,
+		},
+		{
+			name:     "5",
+			policy:   database.CastNullOnError,
+			column:   "i",
+			v:        "abc",
+			want:     "<nil>",
+			wantType: element.TypeBigInt,
+		},
+		{
+			name:     "6",
+			policy:   database.CastStrict,
+			column:   "f",
+			v:        "1.125",
+			want:     "1.125",
+			wantType: element.TypeDecimal,
+		},
+		{
+			name:     "7",
+			policy:   database.CastStrict,
+			column:   "j",
+			v:        `{"a":1}`,
+			want:     `{"a":1}`,
+			wantType: element.TypeJSON,
+		},
+		{
+			name:    "8",
+			policy:  database.CastStrict,
+			column:  "j",
+			v:       `{"a":`,
+			wantErr: true,
+		},
+		{
+			name:     "9",
+			policy:   database.CastStrict,
+			column:   "s",
+			v:        "x",
+			want:     "x",
+			wantType: element.TypeString,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := database.NewCaster(table, "", map[string]database.CastPolicy{tt.column: tt.policy})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := element.NewDefaultRecord()
+			r.Add(element.NewDefaultColumn(element.NewStringColumnValue(tt.v), tt.column, 0))
+			err = c.Cast(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Caster.Cast() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			col, _ := r.GetByIndex(0)
+			if col.Type() != tt.wantType || col.String() != tt.want {
+				t.Errorf("Caster.Cast() = %v %v, want %v %v", col.Type(), col.String(), tt.wantType, tt.want)
+			}
+		})
+	}
+}
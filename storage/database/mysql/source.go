@@ -1,6 +1,8 @@
 package mysql
 
 import (
+	"time"
+
 	"github.com/Breeze0806/go-etl/storage/database"
 )
 
@@ -26,7 +28,9 @@ func (d Dialect) Name() string {
 type Source struct {
 	*database.BaseSource //基础数据源
 
-	dsn string
+	dsn      string
+	zeroDate ZeroDatePolicy
+	loc      *time.Location
 }
 
 //NewSource 生成mysql数据源，在配置文件错误时会报错
@@ -42,6 +46,14 @@ func NewSource(bs *database.BaseSource) (s database.Source, err error) {
 	if source.dsn, err = c.FormatDSN(); err != nil {
 		return
 	}
+
+	if source.zeroDate, err = c.ZeroDatePolicy(); err != nil {
+		return
+	}
+
+	if source.loc, err = c.Location(); err != nil {
+		return
+	}
 	return source, nil
 }
 
@@ -62,7 +74,10 @@ func (s *Source) Key() string {
 
 //Table 生成mysql的表
 func (s *Source) Table(b *database.BaseTable) database.Table {
-	return NewTable(b)
+	t := NewTable(b)
+	t.zeroDate = s.zeroDate
+	t.loc = s.loc
+	return t
 }
 
 //Quoted mysql应用函数
@@ -3,6 +3,7 @@ package mysql
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/storage/database"
@@ -43,7 +44,9 @@ func TestDialect_Source(t *testing.T) {
 					"username" : "user",
 					"password": "passwd"
 				}`)),
-				dsn: "user:passwd@tcp(192.168.1.1:3306)/db?parseTime=true",
+				dsn:      "user:passwd@tcp(192.168.1.1:3306)/db?parseTime=true",
+				zeroDate: ZeroDateZero,
+				loc:      time.UTC,
 			},
 		},
 	}
@@ -86,7 +89,9 @@ func TestNewSource(t *testing.T) {
 					"username" : "user",
 					"password": "passwd"
 				}`)),
-				dsn: "user:passwd@tcp(192.168.1.1:3306)/db?parseTime=true",
+				dsn:      "user:passwd@tcp(192.168.1.1:3306)/db?parseTime=true",
+				zeroDate: ZeroDateZero,
+				loc:      time.UTC,
 			},
 		},
 
@@ -113,6 +118,37 @@ func TestNewSource(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "3",
+			args: args{
+				bs: database.NewBaseSource(testJSONFromString(`{
+					"url" : "tcp(192.168.1.1:3306)/db?zeroDate=epoch",
+					"username" : "user",
+					"password": "passwd"
+				}`)),
+			},
+			wantS: &Source{
+				BaseSource: database.NewBaseSource(testJSONFromString(`{
+					"url" : "tcp(192.168.1.1:3306)/db?zeroDate=epoch",
+					"username" : "user",
+					"password": "passwd"
+				}`)),
+				dsn:      "user:passwd@tcp(192.168.1.1:3306)/db?parseTime=true",
+				zeroDate: ZeroDateEpoch,
+				loc:      time.UTC,
+			},
+		},
+		{
+			name: "4",
+			args: args{
+				bs: database.NewBaseSource(testJSONFromString(`{
+					"url" : "tcp(192.168.1.1:3306)/db?zeroDate=round",
+					"username" : "user",
+					"password": "passwd"
+				}`)),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -192,6 +228,22 @@ func TestSource_Table(t *testing.T) {
 			},
 			want: NewTable(database.NewBaseTable("db", "schema", "table")),
 		},
+		{
+			name: "2",
+			s: &Source{
+				dsn:      "11111xxx",
+				zeroDate: ZeroDateError,
+				loc:      time.UTC,
+			},
+			args: args{
+				b: database.NewBaseTable("db", "schema", "table"),
+			},
+			want: &Table{
+				BaseTable: database.NewBaseTable("db", "schema", "table"),
+				zeroDate:  ZeroDateError,
+				loc:       time.UTC,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"time"
 
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
@@ -13,6 +14,9 @@ import (
 //Table mysql表
 type Table struct {
 	*database.BaseTable
+
+	zeroDate ZeroDatePolicy
+	loc      *time.Location
 }
 
 //NewTable 创建mysql表，注意此时BaseTable中的schema参数为空，instance为数据库名，而name是表明
@@ -31,9 +35,16 @@ func (t *Table) String() string {
 	return t.Quoted()
 }
 
+//TimeLocation 驱动写入时间时使用的时区，即url中的loc参数，驱动会把时间转化到该时区后写入时钟读数
+func (t *Table) TimeLocation() *time.Location {
+	return t.loc
+}
+
 //AddField 新增列
 func (t *Table) AddField(baseField *database.BaseField) {
-	t.AppendField(NewField(baseField))
+	f := NewField(baseField)
+	f.zeroDate = t.zeroDate
+	t.AppendField(f)
 }
 
 //ExecParam 获取执行参数，其中replace into的参数方式以及被注册
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
@@ -102,6 +103,15 @@ func TestTable_AddField(t *testing.T) {
 			t.Errorf("run %v Table.Fields() = %v want: %v", tt.name, tt.t.Fields(), tt.want)
 		}
 	}
+
+	table = &Table{
+		BaseTable: database.NewBaseTable("db", "schema", "table"),
+		zeroDate:  ZeroDateEpoch,
+	}
+	table.AddField(database.NewBaseField("f1", &sql.ColumnType{}))
+	if f := table.Fields()[0].(*Field); f.zeroDate != ZeroDateEpoch {
+		t.Errorf("Table.AddField() zeroDate = %v want: %v", f.zeroDate, ZeroDateEpoch)
+	}
 }
 
 func TestTable_ExecParam(t *testing.T) {
@@ -368,3 +378,74 @@ func TestReplaceParam_Agrs(t *testing.T) {
 		})
 	}
 }
+
+func TestTable_TimeLocation(t *testing.T) {
+	server := newTestServer(t)
+	wall := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		loc  string
+		bind bool
+		want string
+	}{
+		{
+			name: "1",
+			bind: true,
+			want: "insert into t values ('2021-01-01 08:00:00')",
+		},
+		{
+			name: "2",
+			loc:  "&loc=America%2FNew_York",
+			bind: true,
+			want: "insert into t values ('2021-01-01 08:00:00')",
+		},
+		{
+			name: "3",
+			loc:  "&loc=America%2FNew_York",
+			want: "insert into t values ('2020-12-31 19:00:00')",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSource(database.NewBaseSource(testJSONFromString(`{
+				"url" : "tcp(` + server.Addr().String() + `)/db?interpolateParams=true` + tt.loc + `",
+				"username" : "user",
+				"password": "passwd"
+			}`)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var bind *time.Location
+			if tt.bind {
+				bind = s.Table(database.NewBaseTable("db", "", "t")).(database.TimeLocationer).TimeLocation()
+			}
+			conv, err := element.NewTargetTimeConverter(element.TimeConfig{Location: "Asia/Shanghai"}, nil, bind)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := element.NewDefaultRecord()
+			r.Add(element.NewDefaultColumn(element.NewTimeColumnValue(wall), "a", 0))
+			if err = conv.Convert(r); err != nil {
+				t.Fatal(err)
+			}
+			c, _ := r.GetByIndex(0)
+			v, err := NewField(database.NewBaseField("a", newMockFieldType("DATETIME"))).Valuer(c).Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			db, err := sql.Open(s.DriverName(), s.ConnectName())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			if _, err = db.Exec("insert into t values (?)", v); err != nil {
+				t.Fatal(err)
+			}
+			queries := server.Queries()
+			if got := queries[len(queries)-1]; got != tt.want {
+				t.Errorf("Table.TimeLocation() query = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
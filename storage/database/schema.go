@@ -0,0 +1,77 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//ColumnTyper 用于生成记录模式的列类型判定,是FieldType的可选功能，
+//就是返回扫描器生成的列值的类型
+type ColumnTyper interface {
+	ColumnType() element.ColumnType
+}
+
+//NewSchema 通过字段fields生成记录模式，字段类型没有实现ColumnTyper时列类型为unknown
+func NewSchema(fields []Field) (*element.Schema, error) {
+	columns := make([]element.ColumnSchema, len(fields))
+	for i, f := range fields {
+		typ := f.Type()
+		columns[i] = element.ColumnSchema{
+			Name:     f.Name(),
+			Type:     element.TypeUnknown,
+			Nullable: true,
+			DBType:   typ.DatabaseTypeName(),
+		}
+		if ct, ok := typ.(ColumnTyper); ok {
+			columns[i].Type = ct.ColumnType()
+		}
+		if nullable, ok := typ.Nullable(); ok {
+			columns[i].Nullable = nullable
+		}
+		if precision, scale, ok := typ.DecimalSize(); ok {
+			columns[i].Precision = precision
+			columns[i].Scale = scale
+		}
+	}
+	return element.NewSchema(columns)
+}
+
+//CheckSchema 检查记录模式schema能否写入表table，表的每个字段都需要有同名的列，
+//并且字段类型实现ValuerGoType时，列类型需要能够转化为对应的golang类型
+func CheckSchema(table Table, schema *element.Schema) error {
+	for _, f := range table.Fields() {
+		c, ok := schema.ColumnByName(f.Name())
+		if !ok {
+			return fmt.Errorf("field(%v) err: %v", f.Name(), element.ErrColumnNotExist)
+		}
+		typ, ok := f.Type().(ValuerGoType)
+		if !ok {
+			continue
+		}
+		if !element.Convertible(c.Type, typ.GoType().ColumnType()) {
+			return fmt.Errorf("field(%v) err: column type(%v) can not convert to %v",
+				f.Name(), c.Type, typ.GoType())
+		}
+	}
+	return nil
+}
+
+//ColumnType golang的类型对应的列类型
+func (t GoType) ColumnType() element.ColumnType {
+	switch t {
+	case GoTypeBool:
+		return element.TypeBool
+	case GoTypeInt8, GoTypeInt16, GoTypeInt32, GoTypeInt64:
+		return element.TypeBigInt
+	case GoTypeFloat32, GoTypeFloat64:
+		return element.TypeDecimal
+	case GoTypeString:
+		return element.TypeString
+	case GoTypeBytes:
+		return element.TypeBytes
+	case GoTypeTime:
+		return element.TypeTime
+	}
+	return element.TypeUnknown
+}
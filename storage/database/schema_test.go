@@ -0,0 +1,202 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockColumnTyperFieldType struct {
+	*mockFieldType
+}
+
+func (m *mockColumnTyperFieldType) ColumnType() element.ColumnType {
+	return m.GoType().ColumnType()
+}
+
+func TestNewSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []Field
+		want    []element.ColumnSchema
+		wantErr bool
+	}{
+		{
+			name: "1",
+			fields: []Field{
+				newMockField(NewBaseField("f1", newMockFieldType(GoTypeInt64)),
+					&mockColumnTyperFieldType{newMockFieldType(GoTypeInt64)}),
+				newMockField(NewBaseField("f2", newMockFieldType(GoTypeString)),
+					newMockFieldType(GoTypeString)),
+			},
+			want: []element.ColumnSchema{
+				{
+					Name:     "f1",
+					Type:     element.TypeBigInt,
+					Nullable: true,
+					DBType:   "5",
+				},
+				{
+					Name:     "f2",
+					Type:     element.TypeUnknown,
+					Nullable: true,
+					DBType:   "8",
+				},
+			},
+		},
+		{
+			name: "2",
+			fields: []Field{
+				newMockField(NewBaseField("f1", newMockFieldType(GoTypeInt64)), newMockFieldType(GoTypeInt64)),
+				newMockField(NewBaseField("f1", newMockFieldType(GoTypeInt64)), newMockFieldType(GoTypeInt64)),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSchema(tt.fields)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSchema() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			var columns []element.ColumnSchema
+			for i := 0; i < got.Len(); i++ {
+				columns = append(columns, got.Column(i))
+			}
+			if !reflect.DeepEqual(columns, tt.want) {
+				t.Errorf("NewSchema() = %v, want %v", columns, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSchema(t *testing.T) {
+	table := newMockTable(NewBaseTable("db", "schema", "table"))
+	table.AppendField(newMockField(NewBaseField("f1", newMockFieldType(GoTypeInt64)), newMockFieldType(GoTypeInt64)))
+	table.AppendField(newMockField(NewBaseField("f2", newMockFieldType(GoTypeTime)), newMockFieldType(GoTypeTime)))
+	table.AppendField(newMockField(NewBaseField("f3", nil), &BaseFieldType{}))
+	tests := []struct {
+		name    string
+		columns []element.ColumnSchema
+		wantErr bool
+	}{
+		{
+			name: "1",
+			columns: []element.ColumnSchema{
+				{
+					Name: "f1",
+					Type: element.TypeString,
+				},
+				{
+					Name: "f2",
+					Type: element.TypeTime,
+				},
+				{
+					Name: "f3",
+					Type: element.TypeTime,
+				},
+			},
+		},
+		{
+			name: "2",
+			columns: []element.ColumnSchema{
+				{
+					Name: "f1",
+					Type: element.TypeTime,
+				},
+				{
+					Name: "f2",
+					Type: element.TypeTime,
+				},
+				{
+					Name: "f3",
+					Type: element.TypeTime,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "3",
+			columns: []element.ColumnSchema{
+				{
+					Name: "f1",
+					Type: element.TypeBigInt,
+				},
+				{
+					Name: "f2",
+					Type: element.TypeTime,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, _ := element.NewSchema(tt.columns)
+			if err := CheckSchema(table, schema); (err != nil) != tt.wantErr {
+				t.Errorf("CheckSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGoType_ColumnType(t *testing.T) {
+	tests := []struct {
+		name string
+		t    GoType
+		want element.ColumnType
+	}{
+		{
+			name: "1",
+			t:    GoTypeBool,
+			want: element.TypeBool,
+		},
+		{
+			name: "2",
+			t:    GoTypeInt8,
+			want: element.TypeBigInt,
+		},
+		{
+			name: "3",
+			t:    GoTypeInt64,
+			want: element.TypeBigInt,
+		},
+		{
+			name: "4",
+			t:    GoTypeFloat32,
+			want: element.TypeDecimal,
+		},
+		{
+			name: "5",
+			t:    GoTypeString,
+			want: element.TypeString,
+		},
+		{
+			name: "6",
+			t:    GoTypeBytes,
+			want: element.TypeBytes,
+		},
+		{
+			name: "7",
+			t:    GoTypeTime,
+			want: element.TypeTime,
+		},
+		{
+			name: "8",
+			t:    GoTypeUnknow,
+			want: element.TypeUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.ColumnType(); got != tt.want {
+				t.Errorf("GoType.ColumnType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"time"
 
 	"github.com/Breeze0806/go-etl/element"
 )
@@ -63,6 +64,12 @@ type ExecParameter interface {
 	ExecParam(string, *sql.TxOptions) (Parameter, bool)
 }
 
+//TimeLocationer Table的补充方法，用于获取驱动写入时间时使用的时区，
+//驱动会把时间转化到该时区后只写入时钟读数，如mysql驱动使用url中的loc参数
+type TimeLocationer interface {
+	TimeLocation() *time.Location
+}
+
 //BaseTable 基本表，用于嵌入各种数据库Table的实现
 type BaseTable struct {
 	instance string
@@ -0,0 +1,95 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *Config
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    &Config{URI: "mongodb://127.0.0.1:27017", Database: "db", Collection: "c", Timeout: "5s"},
+		},
+		{
+			name:    "2",
+			c:       &Config{Database: "db", Collection: "c"},
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			c:       &Config{URI: "mongodb://127.0.0.1:27017", Collection: "c"},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			c:       &Config{URI: "mongodb://127.0.0.1:27017", Database: "db"},
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			c:       &Config{URI: "mongodb://127.0.0.1:27017", Database: "db", Collection: "c", Timeout: "0s"},
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			c:       &Config{URI: "mongodb://127.0.0.1:27017", Database: "db", Collection: "c", Timeout: "1x"},
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			c:       &Config{URI: "http://127.0.0.1:27017", Database: "db", Collection: "c"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_timeout(t *testing.T) {
+	c := &Config{}
+	if got, _ := c.timeout(); got != DefaultTimeout {
+		t.Errorf("Config.timeout() = %v, want %v", got, DefaultTimeout)
+	}
+	c = &Config{Timeout: "5s"}
+	if got, _ := c.timeout(); got != 5*time.Second {
+		t.Errorf("Config.timeout() = %v, want 5s", got)
+	}
+}
+
+func TestConfig_Connect(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *Config
+	}{
+		{
+			name: "1",
+			c:    &Config{URI: "mongodb://127.0.0.1:1/?connect=direct", Timeout: "100ms"},
+		},
+		{
+			name: "2",
+			c:    &Config{URI: "http://127.0.0.1:1"},
+		},
+		{
+			name: "3",
+			c:    &Config{URI: "mongodb://127.0.0.1:1", Timeout: "0s"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.c.Connect(context.TODO()); err == nil {
+				t.Errorf("Config.Connect() error = nil, wantErr true")
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+// Package mongodb 对mongodb的连接配置和BSON值与列值之间的转化进行封装，
+// ObjectId转化为十六进制字符串，Decimal128转化为高精度实数，Date转化为时间，
+// 嵌套的文档和数组转化为扩展JSON字符串，例如
+//
+//	cv, err := mongodb.NewColumnValue(doc.Lookup("user", "name"))
+//	if err != nil {
+//		fmt.Println(err)
+//		return
+//	}
+//	c := &mongodb.Column{Name: "_id", Type: mongodb.TypeObjectID}
+//	v, err := c.Value(element.NewDefaultColumn(element.NewStringColumnValue("5f1d7e2a9b1e8a3c4d5e6f70"), "_id", 0))
+//	fmt.Println(cv, v, err)
+package mongodb
@@ -0,0 +1,219 @@
+package mongodb
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//BSON类型，用于将列值转化为指定类型的BSON值
+const (
+	TypeAuto       = ""           //按照列值的类型转化，默认的类型
+	TypeObjectID   = "objectId"   //由十六进制字符串转化为ObjectId
+	TypeDecimal128 = "decimal128" //转化为Decimal128，不会丢失精度
+	TypeDouble     = "double"     //转化为64位浮点数
+	TypeLong       = "long"       //转化为64位整数
+	TypeString     = "string"     //转化为字符串
+	TypeBool       = "bool"       //转化为布尔值
+	TypeDate       = "date"       //转化为Date
+	TypeJSON       = "json"       //由扩展JSON字符串转化为嵌套的文档或者数组
+)
+
+//Column 列定义
+type Column struct {
+	Name string `json:"name"` //列名，也是文档中的字段名，嵌套的字段使用.分隔，如a.b
+	Type string `json:"type"` //写入的BSON类型，默认按照列值的类型转化
+}
+
+//Validate 校验列定义
+func (c *Column) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("column name is empty")
+	}
+	switch c.Type {
+	case TypeAuto, TypeObjectID, TypeDecimal128, TypeDouble, TypeLong,
+		TypeString, TypeBool, TypeDate, TypeJSON:
+		return nil
+	}
+	return fmt.Errorf("column(%v) type(%v) is not supported", c.Name, c.Type)
+}
+
+//Lookup 在文档doc中查找名为name的字段，嵌套的字段使用.分隔，不存在时返回零值
+func Lookup(doc bson.Raw, name string) bson.RawValue {
+	v, err := doc.LookupErr(strings.Split(name, ".")...)
+	if err != nil {
+		return bson.RawValue{}
+	}
+	return v
+}
+
+//NewColumnValue 将BSON值v转化为列值，不存在的字段，null和undefined转化为空值，
+//32位和64位整数转化为整数，64位浮点数和Decimal128转化为高精度实数，Date和Timestamp转化为时间，
+//二进制转化为字节流，ObjectId转化为十六进制字符串，嵌套的文档，数组和其他类型转化为扩展JSON字符串
+func NewColumnValue(v bson.RawValue) (element.ColumnValue, error) {
+	switch v.Type {
+	case 0, bsontype.Null, bsontype.Undefined:
+		return element.NewNilStringColumnValue(), nil
+	case bsontype.String:
+		return element.NewStringColumnValue(v.StringValue()), nil
+	case bsontype.Int32:
+		return element.NewBigIntColumnValueFromInt64(int64(v.Int32())), nil
+	case bsontype.Int64:
+		return element.NewBigIntColumnValueFromInt64(v.Int64()), nil
+	case bsontype.Double:
+		return element.NewDecimalColumnValueFromFloat(v.Double()), nil
+	case bsontype.Decimal128:
+		d := v.Decimal128()
+		if d.IsNaN() || d.IsInf() != 0 {
+			return nil, fmt.Errorf("decimal128(%v) is not a number", d)
+		}
+		return element.NewDecimalColumnValueFromString(d.String())
+	case bsontype.Boolean:
+		return element.NewBoolColumnValue(v.Boolean()), nil
+	case bsontype.DateTime:
+		return element.NewTimeColumnValue(v.Time().UTC()), nil
+	case bsontype.Timestamp:
+		t, _ := v.Timestamp()
+		return element.NewTimeColumnValue(time.Unix(int64(t), 0).UTC()), nil
+	case bsontype.Binary:
+		_, data := v.Binary()
+		return element.NewBytesColumnValue(data), nil
+	case bsontype.ObjectID:
+		return element.NewStringColumnValue(v.ObjectID().Hex()), nil
+	}
+
+	s, err := extJSON(v)
+	if err != nil {
+		return nil, err
+	}
+	return element.NewStringColumnValue(s), nil
+}
+
+//Value 将列col转化为BSON值，空值转化为null
+func (c *Column) Value(col element.Column) (v interface{}, err error) {
+	if col.IsNil() {
+		return nil, nil
+	}
+
+	switch c.Type {
+	case TypeObjectID:
+		var s string
+		if s, err = col.AsString(); err != nil {
+			return
+		}
+		return primitive.ObjectIDFromHex(s)
+	case TypeDecimal128:
+		return decimal128(col)
+	case TypeDouble:
+		return double(col)
+	case TypeLong:
+		return long(col)
+	case TypeString:
+		return col.AsString()
+	case TypeBool:
+		return col.AsBool()
+	case TypeDate:
+		return date(col)
+	case TypeJSON:
+		var s string
+		if s, err = col.AsString(); err != nil {
+			return
+		}
+		return parseExtJSON(s)
+	}
+
+	switch col.Type() {
+	case element.TypeBool:
+		return col.AsBool()
+	case element.TypeBigInt:
+		var bi *big.Int
+		if bi, err = col.AsBigInt(); err != nil {
+			return
+		}
+		if bi.IsInt64() {
+			return bi.Int64(), nil
+		}
+		return decimal128(col)
+	case element.TypeDecimal:
+		return double(col)
+	case element.TypeTime:
+		return date(col)
+	case element.TypeBytes:
+		var b []byte
+		if b, err = col.AsBytes(); err != nil {
+			return
+		}
+		return primitive.Binary{Data: b}, nil
+	}
+	return col.AsString()
+}
+
+//decimal128 将列col转化为Decimal128
+func decimal128(col element.Column) (interface{}, error) {
+	d, err := col.AsDecimal()
+	if err != nil {
+		return nil, err
+	}
+	v, err := primitive.ParseDecimal128(d.String())
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+//double 将列col转化为64位浮点数
+func double(col element.Column) (interface{}, error) {
+	d, err := col.AsDecimal()
+	if err != nil {
+		return nil, err
+	}
+	f, _ := d.Float64()
+	return f, nil
+}
+
+//long 将列col转化为64位整数
+func long(col element.Column) (interface{}, error) {
+	bi, err := col.AsBigInt()
+	if err != nil {
+		return nil, err
+	}
+	if !bi.IsInt64() {
+		return nil, fmt.Errorf("%v is out of range of long", bi)
+	}
+	return bi.Int64(), nil
+}
+
+//date 将列col转化为Date
+func date(col element.Column) (interface{}, error) {
+	t, err := col.AsTime()
+	if err != nil {
+		return nil, err
+	}
+	return primitive.NewDateTimeFromTime(t), nil
+}
+
+//extJSON 将BSON值v转化为宽松模式的扩展JSON字符串
+func extJSON(v bson.RawValue) (string, error) {
+	b, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: v}}, false, false)
+	if err != nil {
+		return "", err
+	}
+	b = bytes.TrimPrefix(b, []byte(`{"v":`))
+	return string(bytes.TrimSuffix(b, []byte("}"))), nil
+}
+
+//parseExtJSON 将扩展JSON字符串s转化为BSON值
+func parseExtJSON(s string) (v bson.RawValue, err error) {
+	var doc bson.Raw
+	if err = bson.UnmarshalExtJSON([]byte(`{"v":`+s+`}`), false, &doc); err != nil {
+		return v, fmt.Errorf("json(%v) err: %v", s, err)
+	}
+	return doc.Lookup("v"), nil
+}
@@ -0,0 +1,360 @@
+package mongodb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testDoc() bson.Raw {
+	id, _ := primitive.ObjectIDFromHex("5f1d7e2a9b1e8a3c4d5e6f70")
+	d, _ := primitive.ParseDecimal128("123456789012345678.000000001")
+	b, err := bson.Marshal(bson.D{
+		{Key: "_id", Value: id},
+		{Key: "str", Value: "a"},
+		{Key: "int32", Value: int32(1)},
+		{Key: "int64", Value: int64(1) << 40},
+		{Key: "double", Value: 1.5},
+		{Key: "decimal", Value: d},
+		{Key: "bool", Value: true},
+		{Key: "date", Value: primitive.NewDateTimeFromTime(time.Date(2021, 1, 2, 3, 4, 5, 6000000, time.UTC))},
+		{Key: "timestamp", Value: primitive.Timestamp{T: 1609556645, I: 1}},
+		{Key: "binary", Value: primitive.Binary{Data: []byte("xyz")}},
+		{Key: "null", Value: nil},
+		{Key: "doc", Value: bson.D{{Key: "a", Value: int32(1)}, {Key: "b", Value: bson.D{{Key: "c", Value: "x"}}}}},
+		{Key: "array", Value: bson.A{int32(1), "x"}},
+		{Key: "regex", Value: primitive.Regex{Pattern: "^a", Options: "i"}},
+		{Key: "nan", Value: primitive.NewDecimal128(0x7c00000000000000, 0)},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestNewColumnValue(t *testing.T) {
+	doc := testDoc()
+	tests := []struct {
+		name    string
+		field   string
+		want    string
+		wantTyp element.ColumnType
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			field:   "_id",
+			want:    "5f1d7e2a9b1e8a3c4d5e6f70",
+			wantTyp: element.TypeString,
+		},
+		{
+			name:    "2",
+			field:   "str",
+			want:    "a",
+			wantTyp: element.TypeString,
+		},
+		{
+			name:    "3",
+			field:   "int32",
+			want:    "1",
+			wantTyp: element.TypeBigInt,
+		},
+		{
+			name:    "4",
+			field:   "int64",
+			want:    "1099511627776",
+			wantTyp: element.TypeBigInt,
+		},
+		{
+			name:    "5",
+			field:   "double",
+			want:    "1.5",
+			wantTyp: element.TypeDecimal,
+		},
+		{
+			name:    "6",
+			field:   "decimal",
+			want:    "123456789012345678.000000001",
+			wantTyp: element.TypeDecimal,
+		},
+		{
+			name:    "7",
+			field:   "bool",
+			want:    "true",
+			wantTyp: element.TypeBool,
+		},
+		{
+			name:    "8",
+			field:   "date",
+			want:    "2021-01-02T03:04:05.006Z",
+			wantTyp: element.TypeTime,
+		},
+		{
+			name:    "9",
+			field:   "timestamp",
+			want:    "2021-01-02T03:04:05Z",
+			wantTyp: element.TypeTime,
+		},
+		{
+			name:    "10",
+			field:   "binary",
+			want:    "xyz",
+			wantTyp: element.TypeBytes,
+		},
+		{
+			name:    "11",
+			field:   "null",
+			want:    "<nil>",
+			wantTyp: element.TypeString,
+		},
+		{
+			name:    "12",
+			field:   "none",
+			want:    "<nil>",
+			wantTyp: element.TypeString,
+		},
+		{
+			name:    "13",
+			field:   "doc",
+			want:    `{"a":1,"b":{"c":"x"}}`,
+			wantTyp: element.TypeString,
+		},
+		{
+			name:    "14",
+			field:   "doc.b.c",
+			want:    "x",
+			wantTyp: element.TypeString,
+		},
+		{
+			name:    "15",
+			field:   "array",
+			want:    `[1,"x"]`,
+			wantTyp: element.TypeString,
+		},
+		{
+			name:    "16",
+			field:   "regex",
+			want:    `{"$regularExpression":{"pattern":"^a","options":"i"}}`,
+			wantTyp: element.TypeString,
+		},
+		{
+			name:    "17",
+			field:   "nan",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewColumnValue(Lookup(doc, tt.field))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewColumnValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("NewColumnValue() = %v, want %v", got.String(), tt.want)
+			}
+			if got.Type() != tt.wantTyp {
+				t.Errorf("NewColumnValue() type = %v, want %v", got.Type(), tt.wantTyp)
+			}
+		})
+	}
+}
+
+func TestColumn_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *Column
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    &Column{Name: "a"},
+		},
+		{
+			name: "2",
+			c:    &Column{Name: "a", Type: TypeDecimal128},
+		},
+		{
+			name:    "3",
+			c:       &Column{Type: TypeJSON},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			c:       &Column{Name: "a", Type: "int"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Column.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestColumn_Value(t *testing.T) {
+	bi, _ := element.NewBigIntColumnValueFromString("123456789012345678901234567890")
+	dv, _ := element.NewDecimalColumnValueFromString("0.10000000000000000001")
+	tm := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	id, _ := primitive.ObjectIDFromHex("5f1d7e2a9b1e8a3c4d5e6f70")
+	d1, _ := primitive.ParseDecimal128("0.10000000000000000001")
+	d2, _ := primitive.ParseDecimal128("123456789012345678901234567890")
+	jv, _ := bson.Marshal(bson.D{{Key: "v", Value: bson.D{{Key: "a", Value: bson.A{int32(1), id}}}}})
+	tests := []struct {
+		name    string
+		typ     string
+		cv      element.ColumnValue
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "1",
+			cv:   element.NewNilStringColumnValue(),
+		},
+		{
+			name: "2",
+			cv:   element.NewBoolColumnValue(true),
+			want: true,
+		},
+		{
+			name: "3",
+			cv:   element.NewBigIntColumnValueFromInt64(1),
+			want: int64(1),
+		},
+		{
+			name: "4",
+			cv:   bi,
+			want: d2,
+		},
+		{
+			name: "5",
+			cv:   dv,
+			want: 0.1,
+		},
+		{
+			name: "6",
+			cv:   element.NewTimeColumnValue(tm),
+			want: primitive.NewDateTimeFromTime(tm),
+		},
+		{
+			name: "7",
+			cv:   element.NewBytesColumnValue([]byte("xyz")),
+			want: primitive.Binary{Data: []byte("xyz")},
+		},
+		{
+			name: "8",
+			cv:   element.NewStringColumnValue("a"),
+			want: "a",
+		},
+		{
+			name: "9",
+			typ:  TypeObjectID,
+			cv:   element.NewStringColumnValue("5f1d7e2a9b1e8a3c4d5e6f70"),
+			want: id,
+		},
+		{
+			name: "10",
+			typ:  TypeDecimal128,
+			cv:   dv,
+			want: d1,
+		},
+		{
+			name: "11",
+			typ:  TypeDouble,
+			cv:   element.NewStringColumnValue("1.5"),
+			want: 1.5,
+		},
+		{
+			name: "12",
+			typ:  TypeLong,
+			cv:   element.NewStringColumnValue("10"),
+			want: int64(10),
+		},
+		{
+			name: "13",
+			typ:  TypeString,
+			cv:   element.NewBigIntColumnValueFromInt64(10),
+			want: "10",
+		},
+		{
+			name: "14",
+			typ:  TypeBool,
+			cv:   element.NewStringColumnValue("true"),
+			want: true,
+		},
+		{
+			name: "15",
+			typ:  TypeDate,
+			cv:   element.NewTimeColumnValue(tm),
+			want: primitive.NewDateTimeFromTime(tm),
+		},
+		{
+			name: "16",
+			typ:  TypeJSON,
+			cv:   element.NewStringColumnValue(`{"a":[1,{"$oid":"5f1d7e2a9b1e8a3c4d5e6f70"}]}`),
+			want: bson.Raw(jv).Lookup("v"),
+		},
+		{
+			name:    "17",
+			typ:     TypeObjectID,
+			cv:      element.NewStringColumnValue("xyz"),
+			wantErr: true,
+		},
+		{
+			name:    "18",
+			typ:     TypeLong,
+			cv:      bi,
+			wantErr: true,
+		},
+		{
+			name:    "19",
+			typ:     TypeJSON,
+			cv:      element.NewStringColumnValue(`{"a":`),
+			wantErr: true,
+		},
+		{
+			name:    "20",
+			typ:     TypeDecimal128,
+			cv:      element.NewStringColumnValue("abc"),
+			wantErr: true,
+		},
+		{
+			name:    "21",
+			typ:     TypeDouble,
+			cv:      element.NewStringColumnValue("abc"),
+			wantErr: true,
+		},
+		{
+			name:    "22",
+			typ:     TypeDate,
+			cv:      element.NewBoolColumnValue(true),
+			wantErr: true,
+		},
+		{
+			name:    "23",
+			typ:     TypeLong,
+			cv:      element.NewStringColumnValue("abc"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Column{Name: "a", Type: tt.typ}
+			got, err := c.Value(element.NewDefaultColumn(tt.cv, "a", 0))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Column.Value() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Column.Value() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
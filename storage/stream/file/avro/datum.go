@@ -0,0 +1,99 @@
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/linkedin/goavro/v2"
+)
+
+//DatumCodec 单条记录的avro二进制编解码器，不包含对象容器文件的文件头和数据块，
+//用于消息队列等每条消息为一条记录的场景，编解码双方需要使用相同的列定义
+type DatumCodec struct {
+	columns []Column
+	codec   *goavro.Codec
+	read    []*readColumn
+}
+
+//NewDatumCodec 生成记录名为name，列定义为columns的单条记录编解码器，name为空时使用Record
+func NewDatumCodec(name string, columns []Column) (d *DatumCodec, err error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("columns are empty")
+	}
+	for i := range columns {
+		if err = columns[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	d = &DatumCodec{
+		columns: columns,
+	}
+	var s string
+	if s, err = schema(name, columns); err != nil {
+		return nil, err
+	}
+	if d.codec, err = goavro.NewCodec(s); err != nil {
+		return nil, err
+	}
+
+	var root map[string]interface{}
+	if err = json.Unmarshal([]byte(s), &root); err != nil {
+		return nil, err
+	}
+	p := &schemaParser{
+		named: make(map[string]interface{}),
+	}
+	p.parseRecord(root, p.define(root, ""), "", nil)
+	for _, v := range p.fields {
+		if v.err != nil {
+			return nil, v.err
+		}
+		d.read = append(d.read, v.column)
+	}
+	return
+}
+
+//Schema avro模式
+func (d *DatumCodec) Schema() string {
+	return d.codec.Schema()
+}
+
+//Encode 将列cols按照顺序编码为avro二进制
+func (d *DatumCodec) Encode(cols []element.Column) (b []byte, err error) {
+	if len(cols) != len(d.columns) {
+		return nil, fmt.Errorf("%v columns, want %v", len(cols), len(d.columns))
+	}
+	var v map[string]interface{}
+	if v, err = datum(d.columns, cols); err != nil {
+		return
+	}
+	return d.codec.BinaryFromNative(nil, v)
+}
+
+//Decode 将avro二进制b解码为列
+func (d *DatumCodec) Decode(b []byte) (columns []element.Column, err error) {
+	var datum interface{}
+	var rest []byte
+	if datum, rest, err = d.codec.NativeFromBinary(b); err != nil {
+		return
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("%v bytes remain after decoding", len(rest))
+	}
+
+	columns = make([]element.Column, len(d.read))
+	for i, c := range d.read {
+		var v interface{}
+		if v, err = c.get(datum); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", c.name, err)
+		}
+		var cv element.ColumnValue
+		if cv, err = c.value(v); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", c.name, err)
+		}
+		columns[i] = element.NewDefaultColumn(cv, c.name, byteSize(v))
+	}
+	return
+}
@@ -0,0 +1,95 @@
+package avro
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/linkedin/goavro/v2"
+)
+
+func TestDatumCodec(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: element.TypeBigInt},
+		{Name: "name", Type: element.TypeString},
+		{Name: "nil", Type: element.TypeString},
+		{Name: "time", Type: element.TypeTime},
+		{Name: "bool", Type: element.TypeBool},
+		{Name: "bytes", Type: element.TypeBytes},
+		{Name: "decimal", Type: element.TypeDecimal},
+	}
+	d, err := NewDatumCodec("", columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = goavro.NewCodec(d.Schema()); err != nil {
+		t.Fatalf("DatumCodec.Schema() error = %v", err)
+	}
+
+	b, err := d.Encode(testColumns())
+	if err != nil {
+		t.Fatalf("DatumCodec.Encode() error = %v", err)
+	}
+	got, err := d.Decode(b)
+	if err != nil {
+		t.Fatalf("DatumCodec.Decode() error = %v", err)
+	}
+	var row []string
+	for _, c := range got {
+		row = append(row, fmt.Sprintf("%v:%v:%v", c.Name(), c.Type(), c.String()))
+	}
+	want := []string{"id:bigInt:1", "name:string:a", "nil:string:<nil>",
+		"time:time:2021-01-02T03:04:05.123Z", "bool:bool:true", "bytes:bytes:xyz",
+		"decimal:decimal:-12345678901234567890.123456789"}
+	if !reflect.DeepEqual(row, want) {
+		t.Errorf("DatumCodec.Decode() = %v, want %v", row, want)
+	}
+
+	if _, err = d.Encode(testColumns()[:1]); err == nil {
+		t.Errorf("DatumCodec.Encode() error = nil, wantErr true")
+	}
+	if _, err = d.Encode(append(testColumns()[1:], testColumns()[0])); err == nil {
+		t.Errorf("DatumCodec.Encode() error = nil, wantErr true")
+	}
+	if _, err = d.Decode(b[:len(b)-1]); err == nil {
+		t.Errorf("DatumCodec.Decode() error = nil, wantErr true")
+	}
+	if _, err = d.Decode(append(b, 0)); err == nil {
+		t.Errorf("DatumCodec.Decode() error = nil, wantErr true")
+	}
+}
+
+func TestNewDatumCodec(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			columns: []Column{{Name: "a", Type: element.TypeTime, LogicalType: LogicalTypeDate}},
+		},
+		{
+			name:    "2",
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			columns: []Column{{Name: "a", Type: "none"}},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			columns: []Column{{Name: "a-b", Type: element.TypeString}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewDatumCodec("", tt.columns); (err != nil) != tt.wantErr {
+				t.Errorf("NewDatumCodec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
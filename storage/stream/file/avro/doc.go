@@ -0,0 +1,24 @@
+// Package avro 对avro对象容器文件(object container file)的读写进行封装，
+// 将avro的基本类型，与null的联合(union)以及逻辑类型与element中的列值互相转化
+//
+// 读取时按照文件中的记录逐条读取，嵌套的记录会展开，列名以.连接，例如
+//
+//	r, err := avro.NewReader(file.Local, "/data/a.avro", nil)
+//	if err != nil {
+//		fmt.Println(err)
+//		return
+//	}
+//	defer r.Close()
+//	for {
+//		columns, err := r.Read()
+//		if err == io.EOF {
+//			break
+//		}
+//		fmt.Println(columns, err)
+//	}
+//
+// 写入时通过列定义生成avro模式，所有列都是与null的联合，
+// 没有声明列定义时通过写入的第一条记录推断
+//
+// DatumCodec 按照列定义对单条记录进行avro二进制编解码，不包含文件头，用于消息队列等场景
+package avro
@@ -0,0 +1,110 @@
+package avro
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+func testTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "avro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+//testRecord 生成列为columns的记录
+func testRecord(columns ...element.Column) element.Record {
+	r := element.NewDefaultRecord()
+	for _, c := range columns {
+		r.Add(c)
+	}
+	return r
+}
+
+func testDecimal(s string) element.ColumnValue {
+	v, err := element.NewDecimalColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func testBigInt(s string) element.ColumnValue {
+	v, err := element.NewBigIntColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+//testColumns 生成包含所有类型的列
+func testColumns() []element.Column {
+	return []element.Column{
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue("a"), "name", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 123456789, time.UTC)), "time", 0),
+		element.NewDefaultColumn(element.NewBoolColumnValue(true), "bool", 0),
+		element.NewDefaultColumn(element.NewBytesColumnValue([]byte("xyz")), "bytes", 0),
+		element.NewDefaultColumn(testDecimal("-12345678901234567890.123456789"), "decimal", 0),
+	}
+}
+
+//testWrite 向dir中的文件name写入记录records，返回文件路径
+func testWrite(t *testing.T, dir, name string, columns []Column, opts Options, records ...element.Record) string {
+	filename := filepath.Join(dir, name)
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w, err := NewWriter(f, columns, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		if err = w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+//testRead 读取文件filename中列名为names的列，每行转化为name:type:value的形式
+func testRead(t *testing.T, filename string, names []string) (rows [][]string) {
+	r, err := NewReader(file.Local, filename, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	for {
+		columns, err := r.Read()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var row []string
+		for _, c := range columns {
+			row = append(row, fmt.Sprintf("%v:%v:%v", c.Name(), c.Type(), c.String()))
+		}
+		rows = append(rows, row)
+	}
+}
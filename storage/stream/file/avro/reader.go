@@ -0,0 +1,304 @@
+package avro
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/linkedin/goavro/v2"
+)
+
+//step 从记录中获取列值的一步
+type step struct {
+	name  string //字段名
+	union bool   //字段是否为与null的联合
+}
+
+//readColumn 读取的列
+type readColumn struct {
+	name  string    //列名，嵌套的列以.连接
+	steps []step    //从记录中获取列值的路径
+	value valueFunc //转化函数
+}
+
+//get 从记录datum中获取列值，路径上的嵌套记录为空时返回nil
+func (c *readColumn) get(datum interface{}) (v interface{}, err error) {
+	v = datum
+	for _, s := range c.steps {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v(%T) is not record", v, v)
+		}
+		if v = m[s.name]; v == nil {
+			return nil, nil
+		}
+		if s.union {
+			if m, ok = v.(map[string]interface{}); !ok || len(m) != 1 {
+				return nil, fmt.Errorf("%v(%T) is not union", v, v)
+			}
+			for _, u := range m {
+				v = u
+			}
+		}
+	}
+	return
+}
+
+//Reader avro对象容器文件读取器，只支持基本类型，与null的联合，嵌套的记录以及
+//date，timestamp-millis，timestamp-micros，decimal和uuid逻辑类型
+type Reader struct {
+	filename string
+	f        file.File
+	ocfr     *goavro.OCFReader
+	columns  []*readColumn
+}
+
+//NewReader 打开文件系统fs中的avro文件filename，读取列名为names的列，names为空时读取所有列，
+//嵌套的列名以.连接，如a.b
+func NewReader(fs file.FileSystem, filename string, names []string) (r *Reader, err error) {
+	r = &Reader{
+		filename: filename,
+	}
+	if r.f, err = fs.Open(filename); err != nil {
+		return nil, err
+	}
+	if r.ocfr, err = goavro.NewOCFReader(bufio.NewReader(r.f)); err != nil {
+		r.f.Close()
+		return nil, fmt.Errorf("avro file(%v) err: %v", filename, err)
+	}
+	if err = r.initColumns(names); err != nil {
+		r.f.Close()
+		return nil, fmt.Errorf("avro file(%v) err: %v", filename, err)
+	}
+	return
+}
+
+//initColumns 通过文件中的模式初始化列名为names的列
+func (r *Reader) initColumns(names []string) (err error) {
+	var s interface{}
+	if err = json.Unmarshal([]byte(r.ocfr.Codec().Schema()), &s); err != nil {
+		return
+	}
+	root, ok := s.(map[string]interface{})
+	if !ok || root["type"] != "record" {
+		return fmt.Errorf("schema(%v) is not record", r.ocfr.Codec().Schema())
+	}
+
+	p := &schemaParser{
+		named: make(map[string]interface{}),
+	}
+	p.parseRecord(root, p.define(root, ""), "", nil)
+
+	if len(names) == 0 {
+		for _, v := range p.fields {
+			if v.err != nil {
+				return v.err
+			}
+			r.columns = append(r.columns, v.column)
+		}
+		return
+	}
+
+	all := make(map[string]*parsedField)
+	for _, v := range p.fields {
+		all[v.column.name] = v
+	}
+	for _, v := range names {
+		f, ok := all[v]
+		if !ok {
+			return fmt.Errorf("column(%v) does not exist", v)
+		}
+		if f.err != nil {
+			return f.err
+		}
+		r.columns = append(r.columns, f.column)
+	}
+	return
+}
+
+//Columns 读取的列名
+func (r *Reader) Columns() (names []string) {
+	for _, v := range r.columns {
+		names = append(names, v.name)
+	}
+	return
+}
+
+//Read 读取下一条记录的列，读取完毕时返回io.EOF
+func (r *Reader) Read() (columns []element.Column, err error) {
+	if !r.ocfr.Scan() {
+		if err = r.ocfr.Err(); err != nil {
+			return nil, fmt.Errorf("avro file(%v) err: %v", r.filename, err)
+		}
+		return nil, io.EOF
+	}
+
+	var datum interface{}
+	if datum, err = r.ocfr.Read(); err != nil {
+		return nil, fmt.Errorf("avro file(%v) err: %v", r.filename, err)
+	}
+
+	columns = make([]element.Column, len(r.columns))
+	for i, c := range r.columns {
+		var v interface{}
+		if v, err = c.get(datum); err != nil {
+			return nil, fmt.Errorf("avro file(%v) column(%v) err: %v", r.filename, c.name, err)
+		}
+		var cv element.ColumnValue
+		if cv, err = c.value(v); err != nil {
+			return nil, fmt.Errorf("avro file(%v) column(%v) err: %v", r.filename, c.name, err)
+		}
+		columns[i] = element.NewDefaultColumn(cv, c.name, byteSize(v))
+	}
+	return
+}
+
+//Close 关闭文件
+func (r *Reader) Close() error {
+	return r.f.Close()
+}
+
+//parsedField 解析模式得到的列，不支持的列err不为空
+type parsedField struct {
+	column *readColumn
+	err    error
+}
+
+//schemaParser avro模式解析器，将记录中的字段展开为列
+type schemaParser struct {
+	named  map[string]interface{} //命名类型(record，enum，fixed)的定义
+	fields []*parsedField
+}
+
+//define 记录命名类型的定义，可以通过名字或者全名引用，未设置命名空间时沿用外层的命名空间ns，
+//返回该类型的命名空间
+func (p *schemaParser) define(m map[string]interface{}, ns string) string {
+	name, _ := m["name"].(string)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		ns, name = name[:i], name[i+1:]
+	} else if v, _ := m["namespace"].(string); v != "" {
+		ns = v
+	}
+	if name == "" {
+		return ns
+	}
+	p.named[name] = m
+	if ns != "" {
+		p.named[ns+"."+name] = m
+	}
+	return ns
+}
+
+//resolve 将命名空间ns中对命名类型的引用typ替换为其定义
+func (p *schemaParser) resolve(ns string, typ interface{}) interface{} {
+	if s, ok := typ.(string); ok {
+		if m, ok := p.named[s]; ok {
+			return m
+		}
+		if m, ok := p.named[ns+"."+s]; ok {
+			return m
+		}
+	}
+	return typ
+}
+
+//parseRecord 解析命名空间为ns的记录中的字段，列名以prefix开头，从记录中获取值的路径以steps开头
+func (p *schemaParser) parseRecord(record map[string]interface{}, ns, prefix string, steps []step) {
+	fields, _ := record["fields"].([]interface{})
+	for _, v := range fields {
+		field, _ := v.(map[string]interface{})
+		name, _ := field["name"].(string)
+		p.parseField(ns, prefix+name, append(steps[:len(steps):len(steps)], step{name: name}), field["type"])
+	}
+}
+
+//parseField 解析命名空间ns中类型为typ的字段，与null的联合会被展开，嵌套的记录会展开为多列
+func (p *schemaParser) parseField(ns, name string, steps []step, typ interface{}) {
+	if u, ok := typ.([]interface{}); ok {
+		var types []interface{}
+		for _, t := range u {
+			if t != "null" {
+				types = append(types, t)
+			}
+		}
+		if len(types) != 1 {
+			p.fields = append(p.fields, &parsedField{
+				column: &readColumn{name: name},
+				err:    fmt.Errorf("column(%v) union(%v) is not supported", name, u),
+			})
+			return
+		}
+		typ = types[0]
+		steps[len(steps)-1].union = true
+	}
+
+	typ = p.resolve(ns, typ)
+	if m, ok := typ.(map[string]interface{}); ok && m["type"] == "record" {
+		p.parseRecord(m, p.define(m, ns), name+".", steps)
+		return
+	}
+
+	f, err := p.valueFunc(ns, typ)
+	if err != nil {
+		err = fmt.Errorf("column(%v) err: %v", name, err)
+	}
+	p.fields = append(p.fields, &parsedField{
+		column: &readColumn{name: name, steps: steps, value: f},
+		err:    err,
+	})
+}
+
+//valueFunc 根据命名空间ns中的avro类型typ生成转化函数，整数转化为整数列值，浮点数和decimal转化为高精度实数列值，
+//date和timestamp转化为时间列值，string，enum和uuid转化为字符串列值，bytes和fixed转化为字节流列值
+func (p *schemaParser) valueFunc(ns string, typ interface{}) (valueFunc, error) {
+	switch t := typ.(type) {
+	case string:
+		switch t {
+		case "boolean":
+			return withNil(element.NewNilBoolColumnValue, boolValue), nil
+		case "int", "long":
+			return withNil(element.NewNilBigIntColumnValue, intValue), nil
+		case "float", "double":
+			return withNil(element.NewNilDecimalColumnValue, floatValue), nil
+		case "string":
+			return withNil(element.NewNilStringColumnValue, stringValue), nil
+		case "bytes":
+			return withNil(element.NewNilBytesColumnValue, bytesValue), nil
+		case "null":
+			return withNil(element.NewNilStringColumnValue, stringValue), nil
+		}
+	case map[string]interface{}:
+		lt, _ := t["logicalType"].(string)
+		switch lt {
+		case LogicalTypeDecimal:
+			scale, _ := t["scale"].(float64)
+			if t["type"] == "fixed" {
+				p.define(t, ns)
+			}
+			return withNil(element.NewNilDecimalColumnValue, decimalValue(int(scale))), nil
+		case LogicalTypeDate, LogicalTypeTimestampMillis, LogicalTypeTimestampMicros:
+			return withNil(element.NewNilTimeColumnValue, timeValue), nil
+		case LogicalTypeUUID:
+			return withNil(element.NewNilStringColumnValue, stringValue), nil
+		case "time-millis", "time-micros":
+			return nil, fmt.Errorf("logicalType(%v) is not supported", lt)
+		}
+
+		switch t["type"] {
+		case "enum":
+			p.define(t, ns)
+			return withNil(element.NewNilStringColumnValue, stringValue), nil
+		case "fixed":
+			p.define(t, ns)
+			return withNil(element.NewNilBytesColumnValue, bytesValue), nil
+		case "array", "map", "record":
+			return nil, fmt.Errorf("type(%v) is not supported", t["type"])
+		}
+		return p.valueFunc(ns, p.resolve(ns, t["type"]))
+	}
+	return nil, fmt.Errorf("type(%v) is not supported", typ)
+}
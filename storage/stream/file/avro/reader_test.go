@@ -0,0 +1,194 @@
+package avro
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/linkedin/goavro/v2"
+)
+
+//testNestedSchema 包含嵌套记录，命名类型引用以及不支持类型的avro模式
+const testNestedSchema = `{"type":"record","name":"Nested","namespace":"test","fields":[
+	{"name":"id","type":"int"},
+	{"name":"user","type":["null",{"type":"record","name":"User","fields":[
+		{"name":"name","type":["null","string"]},
+		{"name":"kind","type":{"type":"enum","name":"Kind","symbols":["A","B"]}}]}]},
+	{"name":"other","type":["null","test.User"]},
+	{"name":"hash","type":{"type":"fixed","name":"Hash","size":2}},
+	{"name":"amount","type":{"type":"fixed","name":"Amount","size":4,"logicalType":"decimal","precision":8,"scale":2}},
+	{"name":"score","type":"float"},
+	{"name":"multi","type":["null","int","string"]},
+	{"name":"clock","type":{"type":"int","logicalType":"time-millis"}}]}`
+
+func testWriteNested(t *testing.T, filename string) {
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:      f,
+		Schema: testNestedSchema,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Append([]interface{}{
+		map[string]interface{}{
+			"id": int32(1),
+			"user": goavro.Union("test.User", map[string]interface{}{
+				"name": goavro.Union("string", "a"),
+				"kind": "B",
+			}),
+			"other":  nil,
+			"hash":   []byte("xy"),
+			"amount": big.NewRat(12345, 100),
+			"score":  float32(1.5),
+			"multi":  goavro.Union("int", int32(1)),
+			"clock":  time.Second,
+		},
+		map[string]interface{}{
+			"id":     int32(2),
+			"user":   nil,
+			"other":  nil,
+			"hash":   []byte("zz"),
+			"amount": big.NewRat(-1, 1),
+			"score":  float32(0),
+			"multi":  nil,
+			"clock":  time.Duration(0),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReader(t *testing.T) {
+	dir := testTempDir(t)
+	nested := filepath.Join(dir, "nested.avro")
+	testWriteNested(t, nested)
+	filename := testWrite(t, dir, "a.avro", nil, Options{}, testRecord(testColumns()...))
+
+	tests := []struct {
+		name     string
+		filename string
+		names    []string
+		want     [][]string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			filename: filename,
+			names:    []string{"decimal", "id"},
+			want:     [][]string{{"decimal:decimal:-12345678901234567890.123456789", "id:bigInt:1"}},
+		},
+		{
+			name:     "2",
+			filename: nested,
+			names:    []string{"user.name", "user.kind", "other.kind", "id", "hash", "amount", "score"},
+			want: [][]string{
+				{"user.name:string:a", "user.kind:string:B", "other.kind:string:<nil>", "id:bigInt:1",
+					"hash:bytes:xy", "amount:decimal:123.45", "score:decimal:1.5"},
+				{"user.name:string:<nil>", "user.kind:string:<nil>", "other.kind:string:<nil>", "id:bigInt:2",
+					"hash:bytes:zz", "amount:decimal:-1", "score:decimal:0"},
+			},
+		},
+		{
+			name:     "3",
+			filename: nested,
+			wantErr:  true,
+		},
+		{
+			name:     "4",
+			filename: nested,
+			names:    []string{"multi"},
+			wantErr:  true,
+		},
+		{
+			name:     "5",
+			filename: nested,
+			names:    []string{"clock"},
+			wantErr:  true,
+		},
+		{
+			name:     "6",
+			filename: filename,
+			names:    []string{"none"},
+			wantErr:  true,
+		},
+		{
+			name:     "7",
+			filename: filepath.Join(dir, "none.avro"),
+			wantErr:  true,
+		},
+		{
+			name:     "8",
+			filename: testWriteFile(t, dir, "b.avro", "abc"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReader(file.Local, tt.filename, tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			r.Close()
+			if got := testRead(t, tt.filename, tt.names); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reader = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func testWriteFile(t *testing.T, dir, name, data string) string {
+	filename := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(filename, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestReader_NotRecord(t *testing.T) {
+	dir := testTempDir(t)
+	filename := filepath.Join(dir, "a.avro")
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:      f,
+		Schema: `"string"`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Append([]interface{}{"a"}); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if _, err = NewReader(file.Local, filename, nil); err == nil {
+		t.Errorf("NewReader() error = nil, wantErr true")
+	}
+}
+
+func TestReader_Columns(t *testing.T) {
+	dir := testTempDir(t)
+	filename := testWrite(t, dir, "a.avro", nil, Options{}, testRecord(testColumns()[:2]...))
+	r, err := NewReader(file.Local, filename, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if got := r.Columns(); !reflect.DeepEqual(got, []string{"id", "name"}) {
+		t.Errorf("Reader.Columns() = %v, want %v", got, []string{"id", "name"})
+	}
+}
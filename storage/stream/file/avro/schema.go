@@ -0,0 +1,223 @@
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/linkedin/goavro/v2"
+)
+
+//逻辑类型枚举
+const (
+	LogicalTypeDate            = "date"             //日期，以int存储
+	LogicalTypeTimestampMillis = "timestamp-millis" //毫秒精度的时间戳，以long存储，时间的默认逻辑类型
+	LogicalTypeTimestampMicros = "timestamp-micros" //微秒精度的时间戳，以long存储
+	LogicalTypeUUID            = "uuid"             //uuid，以string存储
+	LogicalTypeDecimal         = "decimal"          //高精度实数，以bytes存储
+)
+
+//高精度实数的默认精度和标度
+const (
+	DefaultPrecision = 38
+	DefaultScale     = 18
+)
+
+//DefaultName 默认的记录名
+const DefaultName = "Record"
+
+//Column 写入的列定义，所有列都是与null的联合
+type Column struct {
+	Name        string             `json:"name"`        //列名
+	Type        element.ColumnType `json:"type"`        //列类型，支持bool，bigInt，decimal，string，bytes，time
+	LogicalType string             `json:"logicalType"` //时间支持date，timestamp-millis和timestamp-micros，默认为timestamp-millis，字符串支持uuid
+	Precision   int                `json:"precision"`   //高精度实数的精度，默认为38
+	Scale       int                `json:"scale"`       //高精度实数的标度，默认为18
+}
+
+//InferColumn 通过列c推断列定义，时间推断为timestamp-millis，高精度实数推断为decimal(38,18)
+func InferColumn(c element.Column) (col Column, err error) {
+	col.Name = c.Name()
+	col.Type = c.Type()
+	switch c.Type() {
+	case element.TypeBool, element.TypeBigInt, element.TypeDecimal,
+		element.TypeString, element.TypeBytes, element.TypeTime:
+	default:
+		return col, fmt.Errorf("column(%v) type(%v) can not be inferred", c.Name(), c.Type())
+	}
+	return
+}
+
+//Validate 校验列定义
+func (c *Column) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("column name is empty")
+	}
+	switch c.Type {
+	case element.TypeBool, element.TypeBigInt, element.TypeBytes:
+		if c.LogicalType != "" {
+			return fmt.Errorf("column(%v) type(%v) does not support logicalType(%v)", c.Name, c.Type, c.LogicalType)
+		}
+	case element.TypeString:
+		switch c.LogicalType {
+		case "", LogicalTypeUUID:
+		default:
+			return fmt.Errorf("column(%v) type(%v) does not support logicalType(%v)", c.Name, c.Type, c.LogicalType)
+		}
+	case element.TypeTime:
+		switch c.LogicalType {
+		case "", LogicalTypeDate, LogicalTypeTimestampMillis, LogicalTypeTimestampMicros:
+		default:
+			return fmt.Errorf("column(%v) type(%v) does not support logicalType(%v)", c.Name, c.Type, c.LogicalType)
+		}
+	case element.TypeDecimal:
+		switch c.LogicalType {
+		case "", LogicalTypeDecimal:
+		default:
+			return fmt.Errorf("column(%v) type(%v) does not support logicalType(%v)", c.Name, c.Type, c.LogicalType)
+		}
+		if p := c.precision(); p <= 0 || p > DefaultPrecision {
+			return fmt.Errorf("column(%v) precision(%v) is out of range [1, %v]", c.Name, p, DefaultPrecision)
+		}
+		if s := c.scale(); s < 0 || s > c.precision() {
+			return fmt.Errorf("column(%v) scale(%v) is out of range [0, %v]", c.Name, s, c.precision())
+		}
+	default:
+		return fmt.Errorf("column(%v) type(%v) is not supported", c.Name, c.Type)
+	}
+	return nil
+}
+
+//precision 高精度实数的精度，默认为38
+func (c *Column) precision() int {
+	if c.Precision == 0 {
+		return DefaultPrecision
+	}
+	return c.Precision
+}
+
+//scale 高精度实数的标度，精度和标度都未设置时默认为18
+func (c *Column) scale() int {
+	if c.Precision == 0 && c.Scale == 0 {
+		return DefaultScale
+	}
+	return c.Scale
+}
+
+//logicalType 获取逻辑类型，时间默认为timestamp-millis，高精度实数为decimal
+func (c *Column) logicalType() string {
+	switch c.Type {
+	case element.TypeTime:
+		if c.LogicalType == "" {
+			return LogicalTypeTimestampMillis
+		}
+	case element.TypeDecimal:
+		return LogicalTypeDecimal
+	}
+	return c.LogicalType
+}
+
+//avroType 获取列对应的avro类型
+func (c *Column) avroType() interface{} {
+	switch c.Type {
+	case element.TypeBool:
+		return "boolean"
+	case element.TypeBigInt:
+		return "long"
+	case element.TypeDecimal:
+		return map[string]interface{}{
+			"type":        "bytes",
+			"logicalType": LogicalTypeDecimal,
+			"precision":   c.precision(),
+			"scale":       c.scale(),
+		}
+	case element.TypeBytes:
+		return "bytes"
+	case element.TypeTime:
+		typ := "long"
+		if c.logicalType() == LogicalTypeDate {
+			typ = "int"
+		}
+		return map[string]interface{}{
+			"type":        typ,
+			"logicalType": c.logicalType(),
+		}
+	}
+	if c.LogicalType == LogicalTypeUUID {
+		return map[string]interface{}{
+			"type":        "string",
+			"logicalType": LogicalTypeUUID,
+		}
+	}
+	return "string"
+}
+
+//unionName 获取与null联合时非空值的类型名
+func (c *Column) unionName() string {
+	switch c.Type {
+	case element.TypeBool:
+		return "boolean"
+	case element.TypeBigInt:
+		return "long"
+	case element.TypeDecimal:
+		return "bytes.decimal"
+	case element.TypeBytes:
+		return "bytes"
+	case element.TypeTime:
+		if c.logicalType() == LogicalTypeDate {
+			return "int.date"
+		}
+		return "long." + c.logicalType()
+	}
+	return "string"
+}
+
+//schema 生成记录名为name，列定义为columns的avro模式，name为空时使用Record
+func schema(name string, columns []Column) (string, error) {
+	if name == "" {
+		name = DefaultName
+	}
+	fields := make([]interface{}, len(columns))
+	for i := range columns {
+		fields[i] = map[string]interface{}{
+			"name":    columns[i].Name,
+			"type":    []interface{}{"null", columns[i].avroType()},
+			"default": nil,
+		}
+	}
+	s, err := json.Marshal(map[string]interface{}{
+		"type":   "record",
+		"name":   name,
+		"fields": fields,
+	})
+	return string(s), err
+}
+
+//Codec 写入的压缩格式
+type Codec string
+
+//压缩格式枚举
+const (
+	CodecNone    Codec = "none"    //不压缩
+	CodecDeflate Codec = "deflate" //deflate压缩
+	CodecSnappy  Codec = "snappy"  //snappy压缩，默认的压缩格式
+)
+
+//IsValid 是否为支持的压缩格式
+func (c Codec) IsValid() bool {
+	_, ok := c.compressionName()
+	return ok
+}
+
+//compressionName 获取对应的avro压缩格式名，默认为snappy
+func (c Codec) compressionName() (string, bool) {
+	switch c {
+	case CodecNone:
+		return goavro.CompressionNullLabel, true
+	case "", CodecSnappy:
+		return goavro.CompressionSnappyLabel, true
+	case CodecDeflate:
+		return goavro.CompressionDeflateLabel, true
+	}
+	return "", false
+}
@@ -0,0 +1,210 @@
+package avro
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/linkedin/goavro/v2"
+)
+
+type mockColumnValue struct {
+	element.ColumnValue
+}
+
+func (m *mockColumnValue) Type() element.ColumnType {
+	return "mock"
+}
+
+func TestInferColumn(t *testing.T) {
+	for _, c := range testColumns() {
+		got, err := InferColumn(c)
+		if err != nil {
+			t.Fatalf("InferColumn() error = %v", err)
+		}
+		if got.Name != c.Name() || got.Type != c.Type() {
+			t.Errorf("InferColumn() = %v, want %v %v", got, c.Name(), c.Type())
+		}
+	}
+
+	if _, err := InferColumn(element.NewDefaultColumn(&mockColumnValue{
+		ColumnValue: element.NewNilStringColumnValue(),
+	}, "mock", 0)); err == nil {
+		t.Errorf("InferColumn() error = nil, wantErr true")
+	}
+}
+
+func TestColumn_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       Column
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    Column{Name: "a", Type: element.TypeTime, LogicalType: LogicalTypeDate},
+		},
+		{
+			name: "2",
+			c:    Column{Name: "a", Type: element.TypeDecimal, Precision: 10, Scale: 2},
+		},
+		{
+			name: "3",
+			c:    Column{Name: "a", Type: element.TypeString, LogicalType: LogicalTypeUUID},
+		},
+		{
+			name:    "4",
+			c:       Column{Type: element.TypeBigInt},
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			c:       Column{Name: "a", Type: "mock"},
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			c:       Column{Name: "a", Type: element.TypeBigInt, LogicalType: LogicalTypeDate},
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			c:       Column{Name: "a", Type: element.TypeString, LogicalType: LogicalTypeDate},
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			c:       Column{Name: "a", Type: element.TypeTime, LogicalType: LogicalTypeUUID},
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			c:       Column{Name: "a", Type: element.TypeDecimal, LogicalType: LogicalTypeUUID},
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			c:       Column{Name: "a", Type: element.TypeDecimal, Precision: 39},
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			c:       Column{Name: "a", Type: element.TypeDecimal, Precision: 10, Scale: 11},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Column.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestColumn_precisionScale(t *testing.T) {
+	tests := []struct {
+		name          string
+		c             Column
+		wantPrecision int
+		wantScale     int
+	}{
+		{
+			name:          "1",
+			c:             Column{},
+			wantPrecision: DefaultPrecision,
+			wantScale:     DefaultScale,
+		},
+		{
+			name:          "2",
+			c:             Column{Precision: 10},
+			wantPrecision: 10,
+			wantScale:     0,
+		},
+		{
+			name:          "3",
+			c:             Column{Scale: 2},
+			wantPrecision: DefaultPrecision,
+			wantScale:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.precision(); got != tt.wantPrecision {
+				t.Errorf("Column.precision() = %v, want %v", got, tt.wantPrecision)
+			}
+			if got := tt.c.scale(); got != tt.wantScale {
+				t.Errorf("Column.scale() = %v, want %v", got, tt.wantScale)
+			}
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	s, err := schema("", []Column{
+		{Name: "a", Type: element.TypeTime, LogicalType: LogicalTypeDate},
+		{Name: "b", Type: element.TypeDecimal, Precision: 10, Scale: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = goavro.NewCodec(s); err != nil {
+		t.Fatalf("goavro.NewCodec() error = %v", err)
+	}
+
+	var got interface{}
+	if err = json.Unmarshal([]byte(s), &got); err != nil {
+		t.Fatal(err)
+	}
+	var want interface{}
+	if err = json.Unmarshal([]byte(`{"type":"record","name":"Record","fields":[
+		{"name":"a","type":["null",{"type":"int","logicalType":"date"}],"default":null},
+		{"name":"b","type":["null",{"type":"bytes","logicalType":"decimal","precision":10,"scale":2}],"default":null}]}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("schema() = %v, want %v", got, want)
+	}
+}
+
+func TestCodec_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Codec
+		want bool
+	}{
+		{
+			name: "1",
+			c:    "",
+			want: true,
+		},
+		{
+			name: "2",
+			c:    CodecNone,
+			want: true,
+		},
+		{
+			name: "3",
+			c:    CodecDeflate,
+			want: true,
+		},
+		{
+			name: "4",
+			c:    CodecSnappy,
+			want: true,
+		},
+		{
+			name: "5",
+			c:    "zstd",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.IsValid(); got != tt.want {
+				t.Errorf("Codec.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package avro
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/linkedin/goavro/v2"
+	"github.com/shopspring/decimal"
+)
+
+//value 将列col转化为列定义对应的avro值，空值转化为nil，非空值包装为联合中对应类型的值
+func (c *Column) value(col element.Column) (v interface{}, err error) {
+	if col.IsNil() {
+		return nil, nil
+	}
+
+	switch c.Type {
+	case element.TypeBool:
+		v, err = col.AsBool()
+	case element.TypeBigInt:
+		var bi *big.Int
+		if bi, err = col.AsBigInt(); err != nil {
+			return
+		}
+		if !bi.IsInt64() {
+			return nil, fmt.Errorf("%v overflows long", bi)
+		}
+		v = bi.Int64()
+	case element.TypeDecimal:
+		var d decimal.Decimal
+		if d, err = col.AsDecimal(); err != nil {
+			return
+		}
+		unscaled := d.Shift(int32(c.scale())).Round(0).BigInt()
+		if len(new(big.Int).Abs(unscaled).String()) > c.precision() {
+			return nil, fmt.Errorf("%v overflows decimal(%v,%v)", d, c.precision(), c.scale())
+		}
+		v = new(big.Rat).SetFrac(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(c.scale())), nil))
+	case element.TypeString:
+		v, err = col.AsString()
+	case element.TypeBytes:
+		v, err = col.AsBytes()
+	case element.TypeTime:
+		var t time.Time
+		if t, err = col.AsTime(); err != nil {
+			return
+		}
+		if c.logicalType() == LogicalTypeDate {
+			y, m, d := t.Date()
+			t = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		}
+		v = t
+	default:
+		return nil, fmt.Errorf("type(%v) is not supported", c.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return goavro.Union(c.unionName(), v), nil
+}
+
+//valueFunc 将avro值转化为列值的函数
+type valueFunc func(v interface{}) (element.ColumnValue, error)
+
+//withNil 在转化函数f的基础上将nil转化为newNil生成的空值
+func withNil(newNil func() element.ColumnValue, f valueFunc) valueFunc {
+	return func(v interface{}) (element.ColumnValue, error) {
+		if v == nil {
+			return newNil(), nil
+		}
+		return f(v)
+	}
+}
+
+func boolValue(v interface{}) (element.ColumnValue, error) {
+	if b, ok := v.(bool); ok {
+		return element.NewBoolColumnValue(b), nil
+	}
+	return nil, fmt.Errorf("%v(%T) is not boolean", v, v)
+}
+
+func intValue(v interface{}) (element.ColumnValue, error) {
+	switch i := v.(type) {
+	case int32:
+		return element.NewBigIntColumnValueFromInt64(int64(i)), nil
+	case int64:
+		return element.NewBigIntColumnValueFromInt64(i), nil
+	}
+	return nil, fmt.Errorf("%v(%T) is not integer", v, v)
+}
+
+func floatValue(v interface{}) (element.ColumnValue, error) {
+	switch f := v.(type) {
+	case float32:
+		return element.NewDecimalColumnValue(decimal.NewFromFloat32(f)), nil
+	case float64:
+		return element.NewDecimalColumnValueFromFloat(f), nil
+	}
+	return nil, fmt.Errorf("%v(%T) is not float", v, v)
+}
+
+func stringValue(v interface{}) (element.ColumnValue, error) {
+	if s, ok := v.(string); ok {
+		return element.NewStringColumnValue(s), nil
+	}
+	return nil, fmt.Errorf("%v(%T) is not string", v, v)
+}
+
+func bytesValue(v interface{}) (element.ColumnValue, error) {
+	if b, ok := v.([]byte); ok {
+		return element.NewBytesColumnValue(b), nil
+	}
+	return nil, fmt.Errorf("%v(%T) is not bytes", v, v)
+}
+
+func timeValue(v interface{}) (element.ColumnValue, error) {
+	if t, ok := v.(time.Time); ok {
+		return element.NewTimeColumnValue(t.UTC()), nil
+	}
+	return nil, fmt.Errorf("%v(%T) is not time", v, v)
+}
+
+//decimalValue 将有理数转化为标度为scale的高精度实数
+func decimalValue(scale int) valueFunc {
+	return func(v interface{}) (element.ColumnValue, error) {
+		r, ok := v.(*big.Rat)
+		if !ok {
+			return nil, fmt.Errorf("%v(%T) is not decimal", v, v)
+		}
+		unscaled := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+		unscaled.Mul(unscaled, r.Num()).Quo(unscaled, r.Denom())
+		return element.NewDecimalColumnValue(decimal.NewFromBigInt(unscaled, -int32(scale))), nil
+	}
+}
+
+//byteSize avro值v的字节数
+func byteSize(v interface{}) int {
+	switch v := v.(type) {
+	case bool:
+		return 1
+	case int32, float32:
+		return 4
+	case int64, float64:
+		return 8
+	case string:
+		return len(v)
+	case []byte:
+		return len(v)
+	}
+	return 0
+}
@@ -0,0 +1,173 @@
+package avro
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestColumn_valueErr(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Column
+		col  element.Column
+	}{
+		{
+			name: "1",
+			c:    Column{Name: "a", Type: element.TypeBigInt},
+			col:  element.NewDefaultColumn(testBigInt("123456789012345678901234567890"), "a", 0),
+		},
+		{
+			name: "2",
+			c:    Column{Name: "a", Type: element.TypeDecimal, Precision: 3, Scale: 1},
+			col:  element.NewDefaultColumn(testDecimal("100"), "a", 0),
+		},
+		{
+			name: "3",
+			c:    Column{Name: "a", Type: element.TypeBool},
+			col:  element.NewDefaultColumn(element.NewStringColumnValue("abc"), "a", 0),
+		},
+		{
+			name: "4",
+			c:    Column{Name: "a", Type: element.TypeTime},
+			col:  element.NewDefaultColumn(element.NewBoolColumnValue(true), "a", 0),
+		},
+		{
+			name: "5",
+			c:    Column{Name: "a", Type: "mock"},
+			col:  element.NewDefaultColumn(element.NewBoolColumnValue(true), "a", 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.c.value(tt.col); err == nil {
+				t.Errorf("Column.value() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func TestColumn_valueDate(t *testing.T) {
+	c := Column{Name: "a", Type: element.TypeTime, LogicalType: LogicalTypeDate}
+	loc := time.FixedZone("UTC+8", 8*3600)
+	v, err := c.value(element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 0, loc)), "a", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := v.(map[string]interface{})["int.date"]
+	if want := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC); !got.(time.Time).Equal(want) {
+		t.Errorf("Column.value() = %v, want %v", got, want)
+	}
+}
+
+func TestValueFunc(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       valueFunc
+		v       interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			f:    withNil(element.NewNilBigIntColumnValue, intValue),
+			v:    nil,
+			want: "<nil>",
+		},
+		{
+			name: "2",
+			f:    intValue,
+			v:    int32(-1),
+			want: "-1",
+		},
+		{
+			name: "3",
+			f:    floatValue,
+			v:    float32(1.5),
+			want: "1.5",
+		},
+		{
+			name: "4",
+			f:    floatValue,
+			v:    0.25,
+			want: "0.25",
+		},
+		{
+			name: "5",
+			f:    decimalValue(2),
+			v:    big.NewRat(-12345, 100),
+			want: "-123.45",
+		},
+		{
+			name: "6",
+			f:    timeValue,
+			v:    time.Date(2021, 1, 2, 3, 4, 5, 0, time.FixedZone("UTC+8", 8*3600)),
+			want: "2021-01-01T19:04:05Z",
+		},
+		{
+			name:    "7",
+			f:       boolValue,
+			v:       "true",
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			f:       intValue,
+			v:       "1",
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			f:       floatValue,
+			v:       "1",
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			f:       stringValue,
+			v:       1,
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			f:       bytesValue,
+			v:       1,
+			wantErr: true,
+		},
+		{
+			name:    "12",
+			f:       timeValue,
+			v:       1,
+			wantErr: true,
+		},
+		{
+			name:    "13",
+			f:       decimalValue(2),
+			v:       1.5,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("valueFunc() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("valueFunc() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestByteSize(t *testing.T) {
+	values := []interface{}{true, int32(1), int64(1), float32(1), 1.0, "ab", []byte("abc"), nil}
+	want := []int{1, 4, 8, 4, 8, 2, 3, 0}
+	for i, v := range values {
+		if got := byteSize(v); got != want[i] {
+			t.Errorf("byteSize(%v) = %v, want %v", v, got, want[i])
+		}
+	}
+}
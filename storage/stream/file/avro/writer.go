@@ -0,0 +1,157 @@
+package avro
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/linkedin/goavro/v2"
+)
+
+//DefaultBlockSize 默认的每个数据块的记录数
+const DefaultBlockSize = 1000
+
+//Options 写入选项
+type Options struct {
+	Name      string //记录名，默认为Record
+	Codec     Codec  //压缩格式，默认为snappy
+	BlockSize int    //每个数据块的记录数，小于等于0时为默认的1000
+}
+
+//Writer avro对象容器文件写入器
+type Writer struct {
+	w       io.Writer
+	columns []Column
+	opts    Options
+
+	ocfw   *goavro.OCFWriter
+	block  []interface{}
+	closed bool
+}
+
+//NewWriter 生成向w写入，列定义为columns，写入选项为opts的avro文件写入器，
+//columns为空时通过写入的第一条记录推断列定义
+func NewWriter(w io.Writer, columns []Column, opts Options) (*Writer, error) {
+	for i := range columns {
+		if err := columns[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if !opts.Codec.IsValid() {
+		return nil, fmt.Errorf("codec(%v) is not supported", opts.Codec)
+	}
+	if opts.BlockSize <= 0 {
+		opts.BlockSize = DefaultBlockSize
+	}
+	return &Writer{
+		w:       w,
+		columns: columns,
+		opts:    opts,
+	}, nil
+}
+
+//Write 写入记录record，记录中的列按照顺序与列定义对应，每满一个数据块写入一次
+func (w *Writer) Write(record element.Record) (err error) {
+	if w.closed {
+		return fmt.Errorf("writer is closed")
+	}
+	if w.ocfw == nil {
+		if len(w.columns) == 0 {
+			if w.columns, err = inferColumns(record); err != nil {
+				return
+			}
+		}
+		if err = w.open(); err != nil {
+			return
+		}
+	}
+
+	if record.ColumnNumber() != len(w.columns) {
+		return fmt.Errorf("record has %v columns, want %v", record.ColumnNumber(), len(w.columns))
+	}
+
+	cols := make([]element.Column, len(w.columns))
+	for i := range cols {
+		if cols[i], err = record.GetByIndex(i); err != nil {
+			return
+		}
+	}
+	var d map[string]interface{}
+	if d, err = datum(w.columns, cols); err != nil {
+		return
+	}
+
+	w.block = append(w.block, d)
+	if len(w.block) >= w.opts.BlockSize {
+		return w.flush()
+	}
+	return
+}
+
+//open 按照列定义写入文件头
+func (w *Writer) open() (err error) {
+	var s string
+	if s, err = schema(w.opts.Name, w.columns); err != nil {
+		return
+	}
+	name, _ := w.opts.Codec.compressionName()
+	w.ocfw, err = goavro.NewOCFWriter(goavro.OCFConfig{
+		W:               w.w,
+		Schema:          s,
+		CompressionName: name,
+	})
+	return
+}
+
+//flush 将缓存的记录写入一个数据块
+func (w *Writer) flush() (err error) {
+	if len(w.block) == 0 {
+		return
+	}
+	err = w.ocfw.Append(w.block)
+	w.block = w.block[:0]
+	return
+}
+
+//Close 写入剩余的记录，在声明了列定义时没有写入过记录也会生成只有文件头的文件，
+//不会关闭w，重复调用时不做处理
+func (w *Writer) Close() (err error) {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.ocfw == nil {
+		if len(w.columns) == 0 {
+			return nil
+		}
+		return w.open()
+	}
+	return w.flush()
+}
+
+//inferColumns 通过记录record推断列定义
+func inferColumns(record element.Record) (columns []Column, err error) {
+	for i := 0; i < record.ColumnNumber(); i++ {
+		var c element.Column
+		if c, err = record.GetByIndex(i); err != nil {
+			return
+		}
+		var col Column
+		if col, err = InferColumn(c); err != nil {
+			return
+		}
+		columns = append(columns, col)
+	}
+	return
+}
+
+//datum 将列cols按照顺序转化为列定义为columns的avro记录
+func datum(columns []Column, cols []element.Column) (d map[string]interface{}, err error) {
+	d = make(map[string]interface{}, len(columns))
+	for i := range columns {
+		if d[columns[i].Name], err = columns[i].value(cols[i]); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", columns[i].Name, err)
+		}
+	}
+	return
+}
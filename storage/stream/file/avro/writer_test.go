@@ -0,0 +1,168 @@
+package avro
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/linkedin/goavro/v2"
+)
+
+func TestWriter(t *testing.T) {
+	dir := testTempDir(t)
+	declared := []Column{
+		{Name: "id", Type: element.TypeBigInt},
+		{Name: "name", Type: element.TypeString, LogicalType: LogicalTypeUUID},
+		{Name: "nil", Type: element.TypeBytes},
+		{Name: "time", Type: element.TypeTime, LogicalType: LogicalTypeTimestampMicros},
+		{Name: "bool", Type: element.TypeBool},
+		{Name: "bytes", Type: element.TypeString},
+		{Name: "decimal", Type: element.TypeDecimal, Precision: 25, Scale: 2},
+	}
+	tests := []struct {
+		name    string
+		columns []Column
+		opts    Options
+		records []element.Record
+		want    [][]string
+	}{
+		{
+			name:    "1",
+			records: []element.Record{testRecord(testColumns()...)},
+			want: [][]string{{"id:bigInt:1", "name:string:a", "nil:string:<nil>",
+				"time:time:2021-01-02T03:04:05.123Z", "bool:bool:true", "bytes:bytes:xyz",
+				"decimal:decimal:-12345678901234567890.123456789"}},
+		},
+		{
+			name:    "2",
+			columns: declared,
+			opts:    Options{Codec: CodecDeflate},
+			records: []element.Record{testRecord(testColumns()...)},
+			want: [][]string{{"id:bigInt:1", "name:string:a", "nil:bytes:<nil>",
+				"time:time:2021-01-02T03:04:05.123456Z", "bool:bool:true", "bytes:string:xyz",
+				"decimal:decimal:-12345678901234567890.12"}},
+		},
+		{
+			name:    "3",
+			columns: declared,
+			opts:    Options{Codec: CodecNone},
+		},
+		{
+			name: "4",
+			columns: []Column{
+				{Name: "date", Type: element.TypeTime, LogicalType: LogicalTypeDate},
+				{Name: "int64", Type: element.TypeBigInt},
+			},
+			opts: Options{Name: "test"},
+			records: []element.Record{testRecord(
+				testColumns()[3],
+				element.NewDefaultColumn(testBigInt("-9223372036854775808"), "int64", 0),
+			)},
+			want: [][]string{{"date:time:2021-01-02T00:00:00Z", "int64:bigInt:-9223372036854775808"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := testWrite(t, dir, tt.name+".avro", tt.columns, tt.opts, tt.records...)
+			if got := testRead(t, filename, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Writer = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter_Block(t *testing.T) {
+	dir := testTempDir(t)
+	var records []element.Record
+	var want [][]string
+	for i := 0; i < 25; i++ {
+		s := strconv.Itoa(i)
+		records = append(records, testRecord(element.NewDefaultColumn(element.NewStringColumnValue(s), "s", 0)))
+		want = append(want, []string{"s:string:" + s})
+	}
+	filename := testWrite(t, dir, "a.avro", nil, Options{BlockSize: 10}, records...)
+	if got := testRead(t, filename, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("Writer = %v, want %v", got, want)
+	}
+}
+
+func TestWriter_Empty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, _ := NewWriter(buf, nil, Options{})
+	if err := w.Close(); err != nil {
+		t.Fatalf("Writer.Close() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Writer.Close() wrote %v bytes, want 0", buf.Len())
+	}
+
+	w, _ = NewWriter(buf, []Column{{Name: "a", Type: element.TypeString}}, Options{})
+	if err := w.Close(); err != nil {
+		t.Fatalf("Writer.Close() error = %v", err)
+	}
+	r, err := goavro.NewOCFReader(buf)
+	if err != nil {
+		t.Fatalf("goavro.NewOCFReader() error = %v", err)
+	}
+	if r.Scan() {
+		t.Errorf("OCFReader.Scan() = true, want false")
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, []Column{{Name: "a"}}, Options{}); err == nil {
+		t.Errorf("NewWriter() error = nil, wantErr true")
+	}
+	if _, err := NewWriter(&bytes.Buffer{}, nil, Options{Codec: "lzo"}); err == nil {
+		t.Errorf("NewWriter() error = nil, wantErr true")
+	}
+}
+
+func TestWriter_WriteErr(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		record  element.Record
+	}{
+		{
+			name:    "1",
+			columns: []Column{{Name: "a", Type: element.TypeBigInt}},
+			record:  testRecord(testColumns()[:2]...),
+		},
+		{
+			name:    "2",
+			columns: []Column{{Name: "a", Type: element.TypeBigInt}},
+			record:  testRecord(testColumns()[1]),
+		},
+		{
+			name: "3",
+			record: testRecord(element.NewDefaultColumn(&mockColumnValue{
+				ColumnValue: element.NewNilStringColumnValue(),
+			}, "mock", 0)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewWriter(&bytes.Buffer{}, tt.columns, Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = w.Write(tt.record); err == nil {
+				t.Errorf("Writer.Write() error = nil, wantErr true")
+			}
+		})
+	}
+
+	w, _ := NewWriter(&bytes.Buffer{}, nil, Options{})
+	if err := w.Close(); err != nil {
+		t.Errorf("Writer.Close() error = %v", err)
+	}
+	if err := w.Write(testRecord(testColumns()...)); err == nil {
+		t.Errorf("Writer.Write() error = nil, wantErr true")
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Writer.Close() error = %v", err)
+	}
+}
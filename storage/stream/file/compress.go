@@ -0,0 +1,137 @@
+package file
+
+import (
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+)
+
+//Compress 压缩格式
+type Compress string
+
+//压缩格式枚举
+const (
+	CompressNone  Compress = ""      //不压缩
+	CompressGzip  Compress = "gzip"  //gzip压缩
+	CompressBzip2 Compress = "bzip2" //bzip2压缩
+	CompressZip   Compress = "zip"   //zip压缩，会依次读取压缩包中所有的文件
+)
+
+//IsValid 是否为支持的压缩格式
+func (c Compress) IsValid() bool {
+	switch c {
+	case CompressNone, CompressGzip, CompressBzip2, CompressZip:
+		return true
+	}
+	return false
+}
+
+//Open 以压缩格式c打开文件系统fs中的文件filename，返回解压后的数据流
+//当文件不存在或者压缩格式不支持时会报错
+func Open(fs FileSystem, filename string, c Compress) (rc io.ReadCloser, err error) {
+	switch c {
+	case CompressNone:
+		return fs.Open(filename)
+	case CompressGzip:
+		var f File
+		if f, err = fs.Open(filename); err != nil {
+			return nil, err
+		}
+		var gr *gzip.Reader
+		if gr, err = gzip.NewReader(f); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("gzip.NewReader(%v) err: %v", filename, err)
+		}
+		return &readCloser{Reader: gr, closers: []io.Closer{gr, f}}, nil
+	case CompressBzip2:
+		var f File
+		if f, err = fs.Open(filename); err != nil {
+			return nil, err
+		}
+		return &readCloser{Reader: bzip2.NewReader(f), closers: []io.Closer{f}}, nil
+	case CompressZip:
+		var fi os.FileInfo
+		if fi, err = fs.Stat(filename); err != nil {
+			return nil, err
+		}
+		var f File
+		if f, err = fs.Open(filename); err != nil {
+			return nil, err
+		}
+		var zr *zip.Reader
+		if zr, err = zip.NewReader(f, fi.Size()); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("zip.NewReader(%v) err: %v", filename, err)
+		}
+		return &zipReader{zr: zr, f: f}, nil
+	}
+	return nil, fmt.Errorf("compress %v is not supported", c)
+}
+
+type readCloser struct {
+	io.Reader
+
+	closers []io.Closer
+}
+
+func (r *readCloser) Close() (err error) {
+	for _, c := range r.closers {
+		if cerr := c.Close(); cerr != nil {
+			err = cerr
+		}
+	}
+	return
+}
+
+//zipReader 依次读取zip压缩包中的所有文件
+type zipReader struct {
+	zr    *zip.Reader
+	f     File
+	index int
+	cur   io.ReadCloser
+}
+
+func (z *zipReader) Read(p []byte) (n int, err error) {
+	for {
+		if z.cur == nil {
+			if err = z.next(); err != nil {
+				return 0, err
+			}
+		}
+		n, err = z.cur.Read(p)
+		if err == io.EOF {
+			z.cur.Close()
+			z.cur = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return
+	}
+}
+
+func (z *zipReader) next() (err error) {
+	for ; z.index < len(z.zr.File); z.index++ {
+		f := z.zr.File[z.index]
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		z.index++
+		if z.cur, err = f.Open(); err != nil {
+			return fmt.Errorf("open %v in zip err: %v", f.Name, err)
+		}
+		return nil
+	}
+	return io.EOF
+}
+
+func (z *zipReader) Close() error {
+	if z.cur != nil {
+		z.cur.Close()
+	}
+	return z.f.Close()
+}
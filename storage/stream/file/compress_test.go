@@ -0,0 +1,140 @@
+package file
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"testing"
+)
+
+var testBzip2Data = []byte{66, 90, 104, 57, 49, 65, 89, 38, 83, 89, 191, 135, 64, 127, 0, 0, 3, 89, 0, 0, 16, 0, 4,
+	48, 0, 48, 0, 32, 0, 48, 192, 8, 105, 178, 136, 35, 39, 139, 185, 34, 156, 40, 72, 95, 195, 160, 63, 128}
+
+func testGzipData(t *testing.T, data []byte) []byte {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	return buf.Bytes()
+}
+
+func testZipData(t *testing.T, files map[string][]byte, names ...string) []byte {
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for _, name := range names {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write(files[name])
+	}
+	w.Close()
+	return buf.Bytes()
+}
+
+func TestCompress_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Compress
+		want bool
+	}{
+		{name: "1", c: CompressNone, want: true},
+		{name: "2", c: CompressGzip, want: true},
+		{name: "3", c: CompressBzip2, want: true},
+		{name: "4", c: CompressZip, want: true},
+		{name: "5", c: Compress("lzo"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.IsValid(); got != tt.want {
+				t.Errorf("Compress.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir := testTempDir(t)
+	tests := []struct {
+		name     string
+		filename string
+		c        Compress
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			filename: testWriteFile(t, dir, "a.csv", []byte("a,b\n1,2\n")),
+			c:        CompressNone,
+			want:     "a,b\n1,2\n",
+		},
+		{
+			name:     "2",
+			filename: testWriteFile(t, dir, "a.csv.gz", testGzipData(t, []byte("a,b\n1,2\n"))),
+			c:        CompressGzip,
+			want:     "a,b\n1,2\n",
+		},
+		{
+			name:     "3",
+			filename: testWriteFile(t, dir, "a.csv.bz2", testBzip2Data),
+			c:        CompressBzip2,
+			want:     "a,b\n1,2\n",
+		},
+		{
+			name: "4",
+			filename: testWriteFile(t, dir, "a.zip", testZipData(t, map[string][]byte{
+				"a.csv": []byte("a,b\n"),
+				"b/":    nil,
+				"c.csv": []byte("1,2\n"),
+			}, "a.csv", "b/", "c.csv")),
+			c:    CompressZip,
+			want: "a,b\n1,2\n",
+		},
+		{
+			name:     "5",
+			filename: testWriteFile(t, dir, "b.csv", []byte("a,b\n1,2\n")),
+			c:        CompressGzip,
+			wantErr:  true,
+		},
+		{
+			name:     "6",
+			filename: testWriteFile(t, dir, "c.csv", []byte("a,b\n1,2\n")),
+			c:        CompressZip,
+			wantErr:  true,
+		},
+		{
+			name:     "7",
+			filename: testWriteFile(t, dir, "d.csv", []byte("a,b\n1,2\n")),
+			c:        Compress("lzo"),
+			wantErr:  true,
+		},
+		{
+			name:     "8",
+			filename: dir + "/not_exist.csv",
+			c:        CompressNone,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, err := Open(Local, tt.filename, tt.c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Open() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer rc.Close()
+			got, err := ioutil.ReadAll(rc)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Open() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
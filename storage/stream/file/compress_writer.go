@@ -0,0 +1,85 @@
+package file
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/dsnet/compress/bzip2"
+)
+
+//Create 以压缩格式c在文件系统fs中创建文件filename，返回压缩前的数据流，关闭数据流时会关闭文件
+//appendMode为true时追加到文件结尾，gzip和bzip2会追加一个新的压缩流，zip不支持追加
+func Create(fs FileSystem, filename string, c Compress, appendMode bool) (wc io.WriteCloser, err error) {
+	if !c.IsValid() {
+		return nil, fmt.Errorf("compress %v is not supported", c)
+	}
+	if appendMode && c == CompressZip {
+		return nil, fmt.Errorf("compress %v does not support append", c)
+	}
+
+	var f io.WriteCloser
+	if f, err = fs.Create(filename, appendMode); err != nil {
+		return nil, err
+	}
+
+	var w *writeCloser
+	switch c {
+	case CompressGzip:
+		gw := gzip.NewWriter(f)
+		w = &writeCloser{Writer: gw, closers: []io.Closer{gw, f}}
+	case CompressBzip2:
+		var bw *bzip2.Writer
+		if bw, err = bzip2.NewWriter(f, nil); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("bzip2.NewWriter(%v) err: %v", filename, err)
+		}
+		w = &writeCloser{Writer: bw, closers: []io.Closer{bw, f}}
+	case CompressZip:
+		zw := zip.NewWriter(f)
+		var zf io.Writer
+		name := strings.TrimSuffix(filepath.Base(filename), ".zip")
+		if zf, err = zw.Create(name); err != nil {
+			zw.Close()
+			f.Close()
+			return nil, fmt.Errorf("create %v in zip err: %v", name, err)
+		}
+		w = &writeCloser{Writer: zf, closers: []io.Closer{zw, f}}
+	default:
+		return f, nil
+	}
+	//文件可以放弃写入时，压缩后的数据流也可以放弃写入
+	if a, ok := f.(Aborter); ok {
+		return &abortWriteCloser{writeCloser: w, aborter: a}, nil
+	}
+	return w, nil
+}
+
+type writeCloser struct {
+	io.Writer
+
+	closers []io.Closer
+}
+
+func (w *writeCloser) Close() (err error) {
+	for _, c := range w.closers {
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return
+}
+
+//abortWriteCloser 可以放弃写入的压缩数据流，放弃时压缩流中未写入的数据不再需要，只放弃写入文件
+type abortWriteCloser struct {
+	*writeCloser
+
+	aborter Aborter
+}
+
+func (w *abortWriteCloser) Abort(err error) error {
+	return w.aborter.Abort(err)
+}
@@ -0,0 +1,106 @@
+package file
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreate(t *testing.T) {
+	dir := testTempDir(t)
+	tests := []struct {
+		name       string
+		filename   string
+		c          Compress
+		appendMode bool
+		writes     []string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:     "1",
+			filename: "a.csv",
+			c:        CompressNone,
+			writes:   []string{"a,b\n", "1,2\n"},
+			want:     "1,2\n",
+		},
+		{
+			name:       "2",
+			filename:   "b.csv.gz",
+			c:          CompressGzip,
+			appendMode: true,
+			writes:     []string{"a,b\n", "1,2\n"},
+			want:       "a,b\n1,2\n",
+		},
+		{
+			name:       "3",
+			filename:   "c.csv.bz2",
+			c:          CompressBzip2,
+			appendMode: true,
+			writes:     []string{"a,b\n", "1,2\n"},
+			want:       "a,b\n1,2\n",
+		},
+		{
+			name:     "4",
+			filename: "d.csv.zip",
+			c:        CompressZip,
+			writes:   []string{"a,b\n1,2\n"},
+			want:     "a,b\n1,2\n",
+		},
+		{
+			name:       "5",
+			filename:   "e.csv.zip",
+			c:          CompressZip,
+			appendMode: true,
+			writes:     []string{"a,b\n"},
+			wantErr:    true,
+		},
+		{
+			name:     "6",
+			filename: "f.csv",
+			c:        Compress("lzo"),
+			writes:   []string{"a,b\n"},
+			wantErr:  true,
+		},
+		{
+			name:     "7",
+			filename: filepath.Join("not_exist", "g.csv"),
+			c:        CompressNone,
+			writes:   []string{"a,b\n"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(dir, tt.filename)
+			for _, v := range tt.writes {
+				wc, err := Create(Local, filename, tt.c, tt.appendMode)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil {
+					return
+				}
+				if _, err = wc.Write([]byte(v)); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+				if err = wc.Close(); err != nil {
+					t.Fatalf("Close() error = %v", err)
+				}
+			}
+
+			rc, err := Open(Local, filename, tt.c)
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer rc.Close()
+			got, err := ioutil.ReadAll(rc)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Create() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
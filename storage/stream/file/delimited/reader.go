@@ -0,0 +1,222 @@
+package delimited
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//错误
+var (
+	ErrQuote = errors.New("extraneous or missing quote in quoted field") //引号错误
+)
+
+//ParseError 解析错误
+type ParseError struct {
+	Line int   //行号，从1开始
+	Err  error //错误
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+//Options 分隔文本选项
+type Options struct {
+	Delimiter rune //字段分隔符，默认为逗号
+	Quote     rune //引号，为0时不处理引号
+	Escape    rune //转义符，为0时在引号内用两个连续的引号表示引号
+}
+
+func (o Options) delimiter() rune {
+	if o.Delimiter == 0 {
+		return ','
+	}
+	return o.Delimiter
+}
+
+//Reader 分隔文本读取器，每行为一条记录，引号内可以包含分隔符和换行符
+type Reader struct {
+	r    *bufio.Reader
+	opts Options
+	line int
+
+	field strings.Builder
+}
+
+//NewReader 通过数据流r和选项opts生成分隔文本读取器
+func NewReader(r io.Reader, opts Options) *Reader {
+	return &Reader{
+		r:    bufio.NewReader(r),
+		opts: opts,
+	}
+}
+
+//Line 当前已读到的行号
+func (r *Reader) Line() int {
+	return r.line
+}
+
+//Read 读取一条记录，会跳过空行，读到结尾时返回io.EOF
+func (r *Reader) Read() (record []string, err error) {
+	for {
+		record, err = r.readRecord()
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			return record, nil
+		}
+	}
+}
+
+//readRecord 读取一行记录，空行返回nil
+func (r *Reader) readRecord() (record []string, err error) {
+	delimiter := r.opts.delimiter()
+	quote, escape := r.opts.Quote, r.opts.Escape
+	r.line++
+	var b []byte
+	if b, err = r.r.Peek(1); err != nil {
+		return nil, err
+	}
+	switch b[0] {
+	case '\n':
+		r.r.Discard(1)
+		return nil, nil
+	case '\r':
+		if b, _ = r.r.Peek(2); string(b) == "\r\n" {
+			r.r.Discard(2)
+			return nil, nil
+		}
+	}
+
+	var c rune
+	for {
+		r.field.Reset()
+		var end bool
+		c, _, err = r.r.ReadRune()
+		if err == nil && quote != 0 && c == quote {
+			if end, err = r.readQuoted(delimiter, quote, escape); err != nil {
+				return nil, err
+			}
+		} else {
+			if err == nil {
+				r.r.UnreadRune()
+			}
+			if end, err = r.readUnquoted(delimiter, quote, escape); err != nil {
+				return nil, err
+			}
+		}
+		record = append(record, r.field.String())
+		if end {
+			return record, nil
+		}
+	}
+}
+
+//readUnquoted 读取不带引号的字段，end代表记录结束
+func (r *Reader) readUnquoted(delimiter, quote, escape rune) (end bool, err error) {
+	for {
+		var c rune
+		if c, _, err = r.r.ReadRune(); err != nil {
+			if err == io.EOF {
+				return true, nil
+			}
+			return
+		}
+		switch {
+		case c == delimiter:
+			return false, nil
+		case c == '\n':
+			r.trimCR()
+			return true, nil
+		case escape != 0 && escape != quote && c == escape:
+			if c, _, err = r.r.ReadRune(); err != nil {
+				if err == io.EOF {
+					r.field.WriteRune(escape)
+					return true, nil
+				}
+				return
+			}
+			if c == '\n' {
+				r.line++
+			}
+			r.field.WriteRune(c)
+		default:
+			r.field.WriteRune(c)
+		}
+	}
+}
+
+//readQuoted 读取带引号的字段，end代表记录结束
+func (r *Reader) readQuoted(delimiter, quote, escape rune) (end bool, err error) {
+	line := r.line
+	for {
+		var c rune
+		if c, _, err = r.r.ReadRune(); err != nil {
+			if err == io.EOF {
+				return false, &ParseError{Line: line, Err: ErrQuote}
+			}
+			return
+		}
+		switch {
+		case escape != 0 && escape != quote && c == escape:
+			if c, _, err = r.r.ReadRune(); err != nil {
+				if err == io.EOF {
+					return false, &ParseError{Line: line, Err: ErrQuote}
+				}
+				return
+			}
+			if c == '\n' {
+				r.line++
+			}
+			r.field.WriteRune(c)
+		case c == quote:
+			if c, _, err = r.r.ReadRune(); err != nil {
+				if err == io.EOF {
+					return true, nil
+				}
+				return
+			}
+			switch c {
+			case quote:
+				r.field.WriteRune(quote)
+			case delimiter:
+				return false, nil
+			case '\n':
+				r.trimCR()
+				return true, nil
+			case '\r':
+				if c, _, err = r.r.ReadRune(); err == io.EOF || (err == nil && c == '\n') {
+					return true, nil
+				}
+				if err != nil {
+					return
+				}
+				return false, &ParseError{Line: r.line, Err: ErrQuote}
+			default:
+				return false, &ParseError{Line: r.line, Err: ErrQuote}
+			}
+		default:
+			if c == '\n' {
+				r.line++
+			}
+			r.field.WriteRune(c)
+		}
+	}
+}
+
+//trimCR 去除字段尾部的回车符
+func (r *Reader) trimCR() {
+	s := r.field.String()
+	if strings.HasSuffix(s, "\r") {
+		r.field.Reset()
+		r.field.WriteString(s[:len(s)-1])
+	}
+}
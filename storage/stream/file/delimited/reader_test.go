@@ -0,0 +1,98 @@
+package delimited
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReader_Read(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		want     [][]string
+		wantLine int
+		wantErr  error
+	}{
+		{
+			name:     "1",
+			input:    "a,b,c\n1,2,3\n",
+			want:     [][]string{{"a", "b", "c"}, {"1", "2", "3"}},
+			wantLine: 2,
+		},
+		{
+			name:     "2",
+			input:    "a|b|c\r\n\r\n\n1||3",
+			opts:     Options{Delimiter: '|'},
+			want:     [][]string{{"a", "b", "c"}, {"1", "", "3"}},
+			wantLine: 4,
+		},
+		{
+			name:     "3",
+			input:    "\"a,1\",\"b\"\"2\",\"c\n3\"\nx,,\n",
+			opts:     Options{Quote: '"'},
+			want:     [][]string{{"a,1", "b\"2", "c\n3"}, {"x", "", ""}},
+			wantLine: 3,
+		},
+		{
+			name:     "4",
+			input:    "'a\\'1',b\\,2\n",
+			opts:     Options{Quote: '\'', Escape: '\\'},
+			want:     [][]string{{"a'1", "b,2"}},
+			wantLine: 1,
+		},
+		{
+			name:     "5",
+			input:    "\"a\" ,b\n",
+			opts:     Options{Quote: '"'},
+			wantLine: 1,
+			wantErr:  ErrQuote,
+		},
+		{
+			name:     "6",
+			input:    "a,\"b\n",
+			opts:     Options{Quote: '"'},
+			wantLine: 2,
+			wantErr:  ErrQuote,
+		},
+		{
+			name:     "7",
+			input:    "中文\t测试\n",
+			opts:     Options{Delimiter: '\t', Quote: '"', Escape: '"'},
+			want:     [][]string{{"中文", "测试"}},
+			wantLine: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReader(strings.NewReader(tt.input), tt.opts)
+			var got [][]string
+			var err error
+			for {
+				var record []string
+				if record, err = r.Read(); err != nil {
+					break
+				}
+				got = append(got, record)
+			}
+			if tt.wantErr == nil && err != io.EOF {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() = %q, want %q", got, tt.want)
+			}
+			if r.Line() < tt.wantLine {
+				t.Errorf("Line() = %v, want >= %v", r.Line(), tt.wantLine)
+			}
+		})
+	}
+}
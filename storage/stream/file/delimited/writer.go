@@ -0,0 +1,88 @@
+package delimited
+
+import (
+	"io"
+	"strings"
+)
+
+//Writer 分隔文本写入器，每条记录写为一行，
+//字段中包含分隔符，引号，转义符或者换行符时会用引号包裹，没有引号时会用转义符转义
+type Writer struct {
+	w    io.Writer
+	opts Options
+
+	line strings.Builder
+}
+
+//NewWriter 通过数据流w和选项opts生成分隔文本写入器，
+//每条记录只调用一次w.Write，需要缓存时由调用方包装w
+func NewWriter(w io.Writer, opts Options) *Writer {
+	return &Writer{
+		w:    w,
+		opts: opts,
+	}
+}
+
+//Write 写入一条记录record，返回写入的字节数
+func (w *Writer) Write(record []string) (n int, err error) {
+	delimiter := w.opts.delimiter()
+	w.line.Reset()
+	for i, field := range record {
+		if i > 0 {
+			w.line.WriteRune(delimiter)
+		}
+		w.writeField(field, delimiter)
+	}
+	w.line.WriteByte('\n')
+	return io.WriteString(w.w, w.line.String())
+}
+
+//writeField 将字段field写入行缓存
+func (w *Writer) writeField(field string, delimiter rune) {
+	quote, escape := w.opts.Quote, w.opts.Escape
+	if !w.needEscape(field, delimiter) {
+		w.line.WriteString(field)
+		return
+	}
+
+	if quote == 0 {
+		for _, c := range field {
+			if escape != 0 && (c == delimiter || c == escape || c == '\n' || c == '\r') {
+				w.line.WriteRune(escape)
+			}
+			w.line.WriteRune(c)
+		}
+		return
+	}
+
+	w.line.WriteRune(quote)
+	for _, c := range field {
+		switch {
+		case c == quote:
+			if escape != 0 && escape != quote {
+				w.line.WriteRune(escape)
+			} else {
+				w.line.WriteRune(quote)
+			}
+		case escape != 0 && escape != quote && c == escape:
+			w.line.WriteRune(escape)
+		}
+		w.line.WriteRune(c)
+	}
+	w.line.WriteRune(quote)
+}
+
+//needEscape 字段field是否需要引号包裹或者转义
+func (w *Writer) needEscape(field string, delimiter rune) bool {
+	for _, c := range field {
+		switch {
+		case c == delimiter, c == '\n', c == '\r':
+			return true
+		case w.opts.Quote != 0 && c == w.opts.Quote:
+			return true
+		case w.opts.Escape != 0 && c == w.opts.Escape:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package delimited
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestWriter_Write(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		records [][]string
+		want    string
+	}{
+		{
+			name:    "1",
+			opts:    Options{},
+			records: [][]string{{"a", "b"}, {"", "c"}},
+			want:    "a,b\n,c\n",
+		},
+		{
+			name:    "2",
+			opts:    Options{Delimiter: '|', Quote: '"'},
+			records: [][]string{{"a|b", `c"d`, "e\nf", "g,h"}},
+			want:    "\"a|b\"|\"c\"\"d\"|\"e\nf\"|g,h\n",
+		},
+		{
+			name:    "3",
+			opts:    Options{Quote: '"', Escape: '\\'},
+			records: [][]string{{`a"b`, `c\d`, "e"}},
+			want:    `"a\"b","c\\d",e` + "\n",
+		},
+		{
+			name:    "4",
+			opts:    Options{Escape: '\\'},
+			records: [][]string{{"a,b", `c\d`, "e\nf"}},
+			want:    `a\,b,c\\d,e\` + "\nf\n",
+		},
+		{
+			name:    "5",
+			opts:    Options{Delimiter: '\t'},
+			records: [][]string{{"a\tb", "c"}},
+			want:    "a\tb\tc\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			w := NewWriter(buf, tt.opts)
+			n := 0
+			for _, v := range tt.records {
+				m, err := w.Write(v)
+				if err != nil {
+					t.Fatalf("Writer.Write() error = %v", err)
+				}
+				n += m
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Writer.Write() = %q, want %q", buf.String(), tt.want)
+			}
+			if n != len(tt.want) {
+				t.Errorf("Writer.Write() n = %v, want %v", n, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	records := [][]string{
+		{"a,b", `c"d`, "e\nf", `g\h`, ""},
+		{"中文", "x", "y", "z", "w"},
+	}
+	for i, opts := range []Options{
+		{Quote: '"'},
+		{Quote: '"', Escape: '\\'},
+		{Escape: '\\'},
+		{Delimiter: ';', Quote: '\''},
+	} {
+		buf := &bytes.Buffer{}
+		w := NewWriter(buf, opts)
+		for _, v := range records {
+			if _, err := w.Write(v); err != nil {
+				t.Fatalf("%v Writer.Write() error = %v", i, err)
+			}
+		}
+		r := NewReader(buf, opts)
+		var got [][]string
+		for {
+			record, err := r.Read()
+			if err != nil {
+				break
+			}
+			got = append(got, record)
+		}
+		if !reflect.DeepEqual(got, records) {
+			t.Errorf("%v RoundTrip = %q, want %q", i, got, records)
+		}
+	}
+}
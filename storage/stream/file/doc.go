@@ -0,0 +1,28 @@
+// Package file 对本地文件和s3兼容对象存储中文件的读写进行封装，提供文件路径的通配符匹配，
+// 文件压缩格式(gzip，bzip2，zip)的压缩和解压以及文件字符编码(utf-8，gbk)的转换
+//
+// 文件通过FileSystem读写，NewFileSystem生成的文件系统会按照路径的前缀选择实际的文件系统，
+// s3://<bucket>/<key>形式的路径使用对象存储，其他路径使用本地文件系统Local
+//
+// 读取压缩文件并转化为utf-8编码，例如
+//
+//	fs := file.NewFileSystem(&file.FileSystemConfig{})
+//	rc, err := file.Open(fs, "s3://bucket/data/a.csv.gz", file.CompressGzip)
+//	if err != nil {
+//		fmt.Println(err)
+//		return
+//	}
+//	defer rc.Close()
+//	r, err := file.NewDecodeReader(rc, "gbk")
+//	if err != nil {
+//		fmt.Println(err)
+//		return
+//	}
+//
+// 写入时通过Create和NewEncodeWriter以相反的顺序进行压缩和编码转换，
+// 分隔文本的解析和生成见子包delimited，parquet文件的读写见子包parquet，
+// avro对象容器文件的读写见子包avro
+//
+// 未压缩的大文件可以通过NewRangeReader按照字节范围切分成多份读取，
+// 每份都从完整的行开始，并以完整的行结束
+package file
@@ -0,0 +1,48 @@
+package file
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/transform"
+)
+
+//NewDecodeReader 将字符编码为encoding的数据流r转化为utf-8编码的数据流
+//encoding为空时默认为utf-8，当编码不支持时会报错
+func NewDecodeReader(r io.Reader, encoding string) (io.Reader, error) {
+	switch strings.ToLower(encoding) {
+	case "", "utf-8", "utf8":
+		return r, nil
+	case "gbk":
+		return simplifiedchinese.GBK.NewDecoder().Reader(r), nil
+	}
+	return nil, fmt.Errorf("encoding %v is not supported", encoding)
+}
+
+//NewEncodeWriter 将utf-8编码的数据流转化为字符编码为encoding的数据流写入w
+//encoding为空时默认为utf-8，当编码不支持时会报错，关闭时会刷新缓存但不会关闭w
+func NewEncodeWriter(w io.Writer, encoding string) (io.WriteCloser, error) {
+	switch strings.ToLower(encoding) {
+	case "", "utf-8", "utf8":
+		return &nopWriteCloser{Writer: w}, nil
+	case "gbk":
+		return transform.NewWriter(w, simplifiedchinese.GBK.NewEncoder()), nil
+	}
+	return nil, fmt.Errorf("encoding %v is not supported", encoding)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (n *nopWriteCloser) Close() error {
+	return nil
+}
+
+//IsValidEncoding 是否为支持的字符编码
+func IsValidEncoding(encoding string) bool {
+	_, err := NewDecodeReader(nil, encoding)
+	return err == nil
+}
@@ -0,0 +1,110 @@
+package file
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestNewDecodeReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		encoding string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "1",
+			data: []byte("中文"),
+			want: "中文",
+		},
+		{
+			name:     "2",
+			data:     []byte("中文"),
+			encoding: "UTF-8",
+			want:     "中文",
+		},
+		{
+			name:     "3",
+			data:     []byte{0xd6, 0xd0, 0xce, 0xc4},
+			encoding: "gbk",
+			want:     "中文",
+		},
+		{
+			name:     "4",
+			encoding: "big5",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewDecodeReader(bytes.NewReader(tt.data), tt.encoding)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewDecodeReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, _ := ioutil.ReadAll(r)
+			if string(got) != tt.want {
+				t.Errorf("NewDecodeReader() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidEncoding(t *testing.T) {
+	if !IsValidEncoding("gbk") {
+		t.Errorf("IsValidEncoding(gbk) = false, want true")
+	}
+	if IsValidEncoding("big5") {
+		t.Errorf("IsValidEncoding(big5) = true, want false")
+	}
+}
+
+func TestNewEncodeWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		encoding string
+		want     []byte
+		wantErr  bool
+	}{
+		{
+			name: "1",
+			s:    "中文",
+			want: []byte("中文"),
+		},
+		{
+			name:     "2",
+			s:        "中文",
+			encoding: "gbk",
+			want:     []byte{0xd6, 0xd0, 0xce, 0xc4},
+		},
+		{
+			name:     "3",
+			encoding: "big5",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			w, err := NewEncodeWriter(buf, tt.encoding)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewEncodeWriter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			w.Write([]byte(tt.s))
+			if err = w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.want) {
+				t.Errorf("NewEncodeWriter() = %v, want %v", buf.Bytes(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package file
+
+import (
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+//File 用于读取的文件，支持随机读取
+type File interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+}
+
+//FileSystem 文件系统，同一份文件格式的读写代码可以同时用于本地文件和对象存储
+type FileSystem interface {
+	//打开文件name用于读取
+	Open(name string) (File, error)
+	//创建文件name用于写入，appendMode为true时追加到文件结尾
+	Create(name string, appendMode bool) (io.WriteCloser, error)
+	//获取文件name的信息，文件不存在时返回的错误满足os.IsNotExist
+	Stat(name string) (os.FileInfo, error)
+	//获取通配符pattern匹配的所有文件，不包括目录
+	Glob(pattern string) ([]string, error)
+	//删除文件name
+	Remove(name string) error
+	//创建目录dir以及不存在的上级目录
+	MkdirAll(dir string) error
+}
+
+//Aborter 可以放弃写入的数据流，对象存储放弃后不会生成对象
+type Aborter interface {
+	//因为错误err放弃已写入的数据并释放资源
+	Abort(err error) error
+}
+
+//Local 本地文件系统
+var Local FileSystem = localFileSystem{}
+
+type localFileSystem struct{}
+
+func (localFileSystem) Open(name string) (File, error) {
+	return os.Open(name)
+}
+
+func (localFileSystem) Create(name string, appendMode bool) (io.WriteCloser, error) {
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if appendMode {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	return os.OpenFile(name, flag, 0644)
+}
+
+func (localFileSystem) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (localFileSystem) Glob(pattern string) (filenames []string, err error) {
+	var matches []string
+	if matches, err = filepath.Glob(pattern); err != nil {
+		return nil, err
+	}
+	for _, v := range matches {
+		var fi os.FileInfo
+		if fi, err = os.Stat(v); err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			filenames = append(filenames, v)
+		}
+	}
+	return
+}
+
+func (localFileSystem) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (localFileSystem) MkdirAll(dir string) error {
+	return os.MkdirAll(dir, 0755)
+}
+
+//SchemeS3 s3兼容对象存储的路径前缀，路径格式为s3://<bucket>/<key>
+const SchemeS3 = "s3://"
+
+//FileSystemConfig 文件系统配置，可以内嵌在文件读取器和写入器的参数配置中
+type FileSystemConfig struct {
+	S3 *S3Config `json:"s3"` //s3兼容对象存储的配置，为空时从环境变量中获取
+}
+
+//Validate 校验配置
+func (c *FileSystemConfig) Validate() (err error) {
+	if c.S3 != nil {
+		return c.S3.Validate()
+	}
+	return
+}
+
+//NewFileSystem 通过配置conf生成文件系统，conf可以为空，文件系统按照路径的前缀选择实际的文件系统，
+//s3://开头的路径使用s3兼容对象存储，其他路径使用本地文件系统
+func NewFileSystem(conf *FileSystemConfig) FileSystem {
+	return &fileSystem{
+		conf: conf,
+	}
+}
+
+//fileSystem 按照路径的前缀选择实际文件系统的文件系统，对象存储的客户端在第一次使用时生成
+type fileSystem struct {
+	conf *FileSystemConfig
+
+	once sync.Once
+	s3   FileSystem
+	err  error
+}
+
+func (f *fileSystem) get(name string) (FileSystem, error) {
+	if !strings.HasPrefix(name, SchemeS3) {
+		return Local, nil
+	}
+	f.once.Do(func() {
+		var conf *S3Config
+		if f.conf != nil {
+			conf = f.conf.S3
+		}
+		if conf == nil {
+			conf = &S3Config{}
+		}
+		f.s3, f.err = NewS3FileSystem(conf)
+	})
+	return f.s3, f.err
+}
+
+func (f *fileSystem) Open(name string) (File, error) {
+	fs, err := f.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Open(name)
+}
+
+func (f *fileSystem) Create(name string, appendMode bool) (io.WriteCloser, error) {
+	fs, err := f.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Create(name, appendMode)
+}
+
+func (f *fileSystem) Stat(name string) (os.FileInfo, error) {
+	fs, err := f.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Stat(name)
+}
+
+func (f *fileSystem) Glob(pattern string) ([]string, error) {
+	fs, err := f.get(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Glob(pattern)
+}
+
+func (f *fileSystem) Remove(name string) error {
+	fs, err := f.get(name)
+	if err != nil {
+		return err
+	}
+	return fs.Remove(name)
+}
+
+func (f *fileSystem) MkdirAll(dir string) error {
+	fs, err := f.get(dir)
+	if err != nil {
+		return err
+	}
+	return fs.MkdirAll(dir)
+}
+
+//Join 将目录dir和文件名name连接成路径，对象存储的路径使用/连接
+func Join(dir, name string) string {
+	if i := strings.Index(dir, "://"); i >= 0 {
+		return dir[:i+3] + path.Join(dir[i+3:], name)
+	}
+	return filepath.Join(dir, name)
+}
@@ -0,0 +1,207 @@
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLocalFileSystem(t *testing.T) {
+	dir := testTempDir(t)
+	filename := filepath.Join(dir, "a", "b.txt")
+	if err := Local.MkdirAll(filepath.Dir(filename)); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []struct {
+		data       string
+		appendMode bool
+	}{{"x", false}, {"yz", true}} {
+		w, err := Local.Create(filename, v.appendMode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(v.data))
+		w.Close()
+	}
+
+	f, err := Local.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := ioutil.ReadAll(f)
+	f.Close()
+	if string(got) != "xyz" {
+		t.Errorf("localFileSystem.Open() = %q, want xyz", got)
+	}
+
+	if matches, err := Local.Glob(filepath.Join(dir, "*")); err != nil || len(matches) != 0 {
+		t.Errorf("localFileSystem.Glob() = %v, %v, want empty", matches, err)
+	}
+	if matches, err := Local.Glob(filepath.Join(dir, "*", "*")); err != nil || !reflect.DeepEqual(matches, []string{filename}) {
+		t.Errorf("localFileSystem.Glob() = %v, %v, want %v", matches, err, filename)
+	}
+	if err = Local.Remove(filename); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Local.Stat(filename); !os.IsNotExist(err) {
+		t.Errorf("localFileSystem.Stat() error = %v, want not exist", err)
+	}
+}
+
+func TestFileSystemConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *FileSystemConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: &FileSystemConfig{},
+		},
+		{
+			name: "2",
+			conf: &FileSystemConfig{S3: &S3Config{AccessKey: "ak", SecretKey: "sk"}},
+		},
+		{
+			name:    "3",
+			conf:    &FileSystemConfig{S3: &S3Config{SecretKey: "sk"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.conf.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("FileSystemConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewFileSystem(t *testing.T) {
+	ts := newTestS3Server(t)
+	fs := NewFileSystem(&FileSystemConfig{S3: &S3Config{
+		Endpoint:  ts.URL,
+		AccessKey: "ak",
+		SecretKey: "sk",
+		PathStyle: true,
+	}})
+	dir := testTempDir(t)
+
+	for _, d := range []string{filepath.Join(dir, "x"), "s3://b/x"} {
+		name := Join(d, "a.csv.gz")
+		if err := fs.MkdirAll(d); err != nil {
+			t.Fatal(err)
+		}
+		w, err := Create(fs, name, CompressGzip, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte("a,b\n1,2\n"))
+		if err = w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = fs.Stat(name); err != nil {
+			t.Fatal(err)
+		}
+
+		matches, err := fs.Glob(Join(d, "*.gz"))
+		if err != nil || !reflect.DeepEqual(matches, []string{name}) {
+			t.Errorf("fileSystem.Glob() = %v, %v, want %v", matches, err, name)
+		}
+
+		rc, err := Open(fs, name, CompressGzip)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _ := ioutil.ReadAll(rc)
+		rc.Close()
+		if string(got) != "a,b\n1,2\n" {
+			t.Errorf("Open(%v) = %q, want a,b\\n1,2\\n", name, got)
+		}
+		if err = fs.Remove(name); err != nil {
+			t.Errorf("fileSystem.Remove() error = %v", err)
+		}
+	}
+
+	ts.objects["b/a.zip"] = testZipData(t, map[string][]byte{"a.csv": []byte("a,b\n")}, "a.csv")
+	rc, err := Open(fs, "s3://b/a.zip", CompressZip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := ioutil.ReadAll(rc)
+	rc.Close()
+	if string(got) != "a,b\n" {
+		t.Errorf("Open() = %q, want a,b\\n", got)
+	}
+
+	ts.objects["b/a.txt"] = []byte("1\n22\n333\n")
+	rr, err := NewRangeReader(fs, "s3://b/a.txt", Range{Start: 1, End: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ = ioutil.ReadAll(rr)
+	rr.Close()
+	if string(got) != "22\n" {
+		t.Errorf("NewRangeReader() = %q, want 22\\n", got)
+	}
+
+	invalid := NewFileSystem(&FileSystemConfig{S3: &S3Config{AccessKey: "ak"}})
+	if _, err = invalid.Open("s3://b/a.txt"); err == nil {
+		t.Errorf("fileSystem.Open() error = nil, wantErr true")
+	}
+	if _, err = invalid.Create("s3://b/a.txt", false); err == nil {
+		t.Errorf("fileSystem.Create() error = nil, wantErr true")
+	}
+	if _, err = invalid.Stat("s3://b/a.txt"); err == nil {
+		t.Errorf("fileSystem.Stat() error = nil, wantErr true")
+	}
+	if _, err = invalid.Glob("s3://b/*"); err == nil {
+		t.Errorf("fileSystem.Glob() error = nil, wantErr true")
+	}
+	if err = invalid.Remove("s3://b/a.txt"); err == nil {
+		t.Errorf("fileSystem.Remove() error = nil, wantErr true")
+	}
+	if err = invalid.MkdirAll("s3://b"); err == nil {
+		t.Errorf("fileSystem.MkdirAll() error = nil, wantErr true")
+	}
+	if _, err = NewFileSystem(nil).Stat(dir); err != nil {
+		t.Errorf("fileSystem.Stat() error = %v", err)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  string
+		file string
+		want string
+	}{
+		{
+			name: "1",
+			dir:  "s3://bucket/data/",
+			file: "a.csv",
+			want: "s3://bucket/data/a.csv",
+		},
+		{
+			name: "2",
+			dir:  "s3://bucket",
+			file: "a.csv",
+			want: "s3://bucket/a.csv",
+		},
+		{
+			name: "3",
+			dir:  filepath.Join("data", "x"),
+			file: "a.csv",
+			want: filepath.Join("data", "x", "a.csv"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Join(tt.dir, tt.file); got != tt.want {
+				t.Errorf("Join() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
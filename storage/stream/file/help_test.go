@@ -0,0 +1,167 @@
+package file
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func testTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+func testWriteFile(t *testing.T, dir, name string, data []byte) string {
+	filename := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(filename, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+//testS3Server s3兼容对象存储的本地替身，只支持路径形式的访问地址，
+//支持对象的读写删除，分段上传以及分页列出对象，桶名为deny时拒绝所有请求
+type testS3Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	objects  map[string][]byte         //对象，键为<bucket>/<key>
+	uploads  map[string]map[int][]byte //分段上传中的段
+	parts    int                       //完成的分段上传的段数
+	pageSize int                       //列出对象时每页的对象数
+}
+
+func newTestS3Server(t *testing.T) *testS3Server {
+	s := &testS3Server{
+		objects:  make(map[string][]byte),
+		uploads:  make(map[string]map[int][]byte),
+		pageSize: 2,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+//FileSystem 生成访问替身的文件系统
+func (s *testS3Server) FileSystem(t *testing.T) FileSystem {
+	fs, err := NewS3FileSystem(&S3Config{
+		Endpoint:  s.URL,
+		AccessKey: "ak",
+		SecretKey: "sk",
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
+func (s *testS3Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	bucket := strings.SplitN(name, "/", 2)[0]
+	q := r.URL.Query()
+	body, _ := ioutil.ReadAll(r.Body)
+	switch {
+	case bucket == "deny":
+		s.error(w, http.StatusForbidden, "AccessDenied")
+	case r.Method == http.MethodGet && bucket == name:
+		s.list(w, bucket, q.Get("prefix"), q.Get("continuation-token"))
+	case r.Method == http.MethodHead, r.Method == http.MethodGet:
+		data, ok := s.objects[name]
+		if !ok {
+			s.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Last-Modified", "Sat, 02 Jan 2021 03:04:05 GMT")
+		if r.Method == http.MethodHead {
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			return
+		}
+		var start, end int
+		end = len(data) - 1
+		fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end)
+		if end >= len(data) {
+			end = len(data) - 1
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(end-start+1))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(data[start : end+1])
+	case r.Method == http.MethodPost && q.Get("uploadId") == "":
+		id := strconv.Itoa(len(s.uploads) + 1)
+		s.uploads[id] = make(map[int][]byte)
+		fmt.Fprintf(w, `<InitiateMultipartUploadResult><Bucket>%v</Bucket><Key>%v</Key><UploadId>%v</UploadId></InitiateMultipartUploadResult>`,
+			bucket, strings.TrimPrefix(name, bucket+"/"), id)
+	case r.Method == http.MethodPut && q.Get("uploadId") != "":
+		n, _ := strconv.Atoi(q.Get("partNumber"))
+		s.uploads[q.Get("uploadId")][n] = body
+		w.Header().Set("ETag", `"`+strconv.Itoa(n)+`"`)
+	case r.Method == http.MethodPost && q.Get("uploadId") != "":
+		parts := s.uploads[q.Get("uploadId")]
+		var data []byte
+		for i := 1; i <= len(parts); i++ {
+			data = append(data, parts[i]...)
+		}
+		s.objects[name] = data
+		s.parts += len(parts)
+		delete(s.uploads, q.Get("uploadId"))
+		fmt.Fprintf(w, `<CompleteMultipartUploadResult><ETag>"x"</ETag></CompleteMultipartUploadResult>`)
+	case r.Method == http.MethodPut:
+		s.objects[name] = body
+		w.Header().Set("ETag", `"x"`)
+	case r.Method == http.MethodDelete && q.Get("uploadId") != "":
+		delete(s.uploads, q.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		delete(s.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.error(w, http.StatusBadRequest, "InvalidRequest")
+	}
+}
+
+//list 按照对象键的顺序分页列出桶bucket中前缀为prefix的对象，分页标记为上一页最后一个对象键
+func (s *testS3Server) list(w http.ResponseWriter, bucket, prefix, token string) {
+	var keys []string
+	for k := range s.objects {
+		key := strings.TrimPrefix(k, bucket+"/")
+		if strings.HasPrefix(k, bucket+"/") && strings.HasPrefix(key, prefix) && key > token {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	truncated := len(keys) > s.pageSize
+	if truncated {
+		keys = keys[:s.pageSize]
+	}
+	fmt.Fprintf(w, `<ListBucketResult><Name>%v</Name><Prefix>%v</Prefix><KeyCount>%v</KeyCount><IsTruncated>%v</IsTruncated>`,
+		bucket, prefix, len(keys), truncated)
+	if truncated {
+		fmt.Fprintf(w, `<NextContinuationToken>%v</NextContinuationToken>`, keys[len(keys)-1])
+	}
+	for _, k := range keys {
+		fmt.Fprintf(w, `<Contents><Key>%v</Key><Size>%v</Size></Contents>`, k, len(s.objects[bucket+"/"+k]))
+	}
+	fmt.Fprint(w, `</ListBucketResult>`)
+}
+
+func (s *testS3Server) error(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<Error><Code>%v</Code><Message>%v</Message></Error>`, code, code)
+}
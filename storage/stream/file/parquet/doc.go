@@ -0,0 +1,20 @@
+// Package parquet 对parquet文件的读写进行封装，将parquet的物理类型以及逻辑类型
+// 与element中的列值互相转化
+//
+// 读取时按照行组(row group)读取，便于按照行组切分成多个任务，例如
+//
+//	r, err := parquet.NewReader(file.Local, "/data/a.parquet", nil)
+//	if err != nil {
+//		fmt.Println(err)
+//		return
+//	}
+//	defer r.Close()
+//	for i := 0; i < r.NumRowGroups(); i++ {
+//		err = r.ReadRowGroup(i, func(columns []element.Column) error {
+//			fmt.Println(columns)
+//			return nil
+//		})
+//	}
+//
+// 写入时可以声明列定义，没有声明列定义时通过写入的第一条记录推断
+package parquet
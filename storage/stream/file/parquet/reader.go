@@ -0,0 +1,187 @@
+package parquet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+)
+
+//batchSize 每次从每列读取的行数
+const batchSize = 1024
+
+//readColumn 读取的列
+type readColumn struct {
+	name  string    //列名，嵌套的列以.连接
+	path  string    //parquet内部路径
+	value valueFunc //转化函数
+}
+
+//Reader parquet文件读取器，只支持不重复(repeated)的列
+type Reader struct {
+	filename string
+	pf       source.ParquetFile
+	pr       *reader.ParquetReader
+	columns  []*readColumn
+}
+
+//NewReader 打开文件系统fs中的parquet文件filename，读取列名为names的列，names为空时读取所有列，
+//嵌套的列名以.连接，如a.b
+func NewReader(fs file.FileSystem, filename string, names []string) (r *Reader, err error) {
+	r = &Reader{
+		filename: filename,
+	}
+	if r.pf, err = openSource(fs, filename); err != nil {
+		return nil, err
+	}
+	if r.pr, err = reader.NewParquetColumnReader(r.pf, 1); err != nil {
+		r.pf.Close()
+		return nil, fmt.Errorf("parquet file(%v) err: %v", filename, err)
+	}
+	if err = r.initColumns(names); err != nil {
+		r.Close()
+		return nil, fmt.Errorf("parquet file(%v) err: %v", filename, err)
+	}
+	return
+}
+
+//initColumns 初始化列名为names的列
+func (r *Reader) initColumns(names []string) (err error) {
+	sh := r.pr.SchemaHandler
+	all := make(map[string]*readColumn)
+	var ordered []*readColumn
+	for _, path := range sh.ValueColumns {
+		exPath := common.StrToPath(sh.InPathToExPath[path])
+		c := &readColumn{
+			name: strings.Join(exPath[1:], "."),
+			path: path,
+		}
+
+		var level int32
+		if level, err = sh.MaxRepetitionLevel(common.StrToPath(path)); err != nil {
+			return
+		}
+		if level > 0 {
+			if len(names) == 0 {
+				return fmt.Errorf("column(%v) is repeated", c.name)
+			}
+			all[c.name] = nil
+			continue
+		}
+
+		if c.value, err = newValueFunc(sh.SchemaElements[sh.MapIndex[path]]); err != nil {
+			return fmt.Errorf("column(%v) err: %v", c.name, err)
+		}
+		all[c.name] = c
+		ordered = append(ordered, c)
+	}
+
+	if len(names) == 0 {
+		r.columns = ordered
+		return
+	}
+
+	for _, v := range names {
+		c, ok := all[v]
+		if !ok {
+			return fmt.Errorf("column(%v) does not exist", v)
+		}
+		if c == nil {
+			return fmt.Errorf("column(%v) is repeated", v)
+		}
+		r.columns = append(r.columns, c)
+	}
+	return
+}
+
+//Columns 读取的列名
+func (r *Reader) Columns() (names []string) {
+	for _, v := range r.columns {
+		names = append(names, v.name)
+	}
+	return
+}
+
+//NumRowGroups 行组数
+func (r *Reader) NumRowGroups() int {
+	return len(r.pr.Footer.GetRowGroups())
+}
+
+//ReadRowGroup 读取第index个行组，每读取一行调用一次handle，
+//handle返回错误时停止读取并返回该错误
+func (r *Reader) ReadRowGroup(index int, handle func(columns []element.Column) error) (err error) {
+	rowGroups := r.pr.Footer.GetRowGroups()
+	if index < 0 || index >= len(rowGroups) {
+		return fmt.Errorf("parquet file(%v) row group(%v) is out of range [0, %v)", r.filename, index, len(rowGroups))
+	}
+
+	var skip int64
+	for _, v := range rowGroups[:index] {
+		skip += v.GetNumRows()
+	}
+	r.pr.ReadStop()
+	r.pr.ColumnBuffers = make(map[string]*reader.ColumnBufferType)
+	for _, c := range r.columns {
+		if err = r.pr.SkipRowsByPath(c.path, skip); err != nil {
+			return fmt.Errorf("parquet file(%v) column(%v) err: %v", r.filename, c.name, err)
+		}
+	}
+
+	values := make([][]interface{}, len(r.columns))
+	for rest := rowGroups[index].GetNumRows(); rest > 0; {
+		n := rest
+		if n > batchSize {
+			n = batchSize
+		}
+		for i, c := range r.columns {
+			if values[i], _, _, err = r.pr.ReadColumnByPath(c.path, n); err != nil {
+				return fmt.Errorf("parquet file(%v) column(%v) err: %v", r.filename, c.name, err)
+			}
+			if int64(len(values[i])) != n {
+				return fmt.Errorf("parquet file(%v) column(%v) has %v values, want %v",
+					r.filename, c.name, len(values[i]), n)
+			}
+		}
+
+		for j := int64(0); j < n; j++ {
+			columns := make([]element.Column, len(r.columns))
+			for i, c := range r.columns {
+				var cv element.ColumnValue
+				if cv, err = c.value(values[i][j]); err != nil {
+					return fmt.Errorf("parquet file(%v) column(%v) err: %v", r.filename, c.name, err)
+				}
+				columns[i] = element.NewDefaultColumn(cv, c.name, byteSize(values[i][j]))
+			}
+			if err = handle(columns); err != nil {
+				return
+			}
+		}
+		rest -= n
+	}
+	return
+}
+
+//Close 关闭文件
+func (r *Reader) Close() error {
+	r.pr.ReadStop()
+	return r.pf.Close()
+}
+
+//byteSize parquet值v的字节数
+func byteSize(v interface{}) int {
+	switch v := v.(type) {
+	case bool:
+		return 1
+	case int32, float32:
+		return 4
+	case int64, float64:
+		return 8
+	case string:
+		return len(v)
+	}
+	return 0
+}
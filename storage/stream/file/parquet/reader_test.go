@@ -0,0 +1,148 @@
+package parquet
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+//testNested 嵌套和重复列的parquet文件
+type testNested struct {
+	ID   int64    `parquet:"name=id, type=INT64"`
+	Tags []string `parquet:"name=tags, type=MAP, convertedtype=LIST, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	User struct {
+		Name *string `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	} `parquet:"name=user"`
+}
+
+func testWriteNested(t *testing.T, filename string) {
+	fw, err := local.NewLocalFileWriter(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.Close()
+	pw, err := writer.NewParquetWriter(fw, new(testNested), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "a"
+	v := testNested{ID: 1, Tags: []string{"x"}}
+	v.User.Name = &name
+	if err = pw.Write(v); err != nil {
+		t.Fatal(err)
+	}
+	if err = pw.Write(testNested{ID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err = pw.WriteStop(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReader(t *testing.T) {
+	dir := testTempDir(t)
+	nested := filepath.Join(dir, "nested.parquet")
+	testWriteNested(t, nested)
+	filename := testWrite(t, dir, "a.parquet", nil, Options{}, testRecord(testColumns()...))
+
+	tests := []struct {
+		name     string
+		filename string
+		names    []string
+		want     [][]string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			filename: filename,
+			names:    []string{"decimal", "id"},
+			want:     [][]string{{"decimal:decimal:-12345678901234567890.123456789", "id:bigInt:1"}},
+		},
+		{
+			name:     "2",
+			filename: nested,
+			names:    []string{"user.name", "id"},
+			want:     [][]string{{"user.name:string:a", "id:bigInt:1"}, {"user.name:string:<nil>", "id:bigInt:2"}},
+		},
+		{
+			name:     "3",
+			filename: nested,
+			wantErr:  true,
+		},
+		{
+			name:     "4",
+			filename: nested,
+			names:    []string{"tags.list.element"},
+			wantErr:  true,
+		},
+		{
+			name:     "5",
+			filename: filename,
+			names:    []string{"none"},
+			wantErr:  true,
+		},
+		{
+			name:     "6",
+			filename: filepath.Join(dir, "none.parquet"),
+			wantErr:  true,
+		},
+		{
+			name:     "7",
+			filename: testWrite(t, dir, "b.parquet", nil, Options{}),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReader(file.Local, tt.filename, tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			r.Close()
+			if got := testRead(t, tt.filename, tt.names); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reader = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReader_Columns(t *testing.T) {
+	dir := testTempDir(t)
+	filename := testWrite(t, dir, "a.parquet", nil, Options{}, testRecord(testColumns()[:2]...))
+	r, err := NewReader(file.Local, filename, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if got := r.Columns(); !reflect.DeepEqual(got, []string{"id", "name"}) {
+		t.Errorf("Reader.Columns() = %v, want %v", got, []string{"id", "name"})
+	}
+}
+
+func TestReader_ReadRowGroupErr(t *testing.T) {
+	dir := testTempDir(t)
+	filename := testWrite(t, dir, "a.parquet", nil, Options{}, testRecord(testColumns()...))
+	r, err := NewReader(file.Local, filename, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if err = r.ReadRowGroup(1, nil); err == nil {
+		t.Errorf("Reader.ReadRowGroup() error = nil, wantErr true")
+	}
+	errMock := errors.New("mock error")
+	if err = r.ReadRowGroup(0, func(columns []element.Column) error {
+		return errMock
+	}); err != errMock {
+		t.Errorf("Reader.ReadRowGroup() error = %v, want %v", err, errMock)
+	}
+}
@@ -0,0 +1,178 @@
+package parquet
+
+import (
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/xitongsys/parquet-go/parquet"
+)
+
+//Type 写入的列类型
+type Type string
+
+//列类型枚举
+const (
+	TypeBoolean         Type = "BOOLEAN"          //布尔值
+	TypeInt32           Type = "INT32"            //32位整数
+	TypeInt64           Type = "INT64"            //64位整数
+	TypeFloat           Type = "FLOAT"            //单精度浮点数
+	TypeDouble          Type = "DOUBLE"           //双精度浮点数
+	TypeByteArray       Type = "BYTE_ARRAY"       //字节流
+	TypeUTF8            Type = "UTF8"             //字符串
+	TypeDecimal         Type = "DECIMAL"          //高精度实数，以BYTE_ARRAY存储
+	TypeDate            Type = "DATE"             //日期
+	TypeTimestampMillis Type = "TIMESTAMP_MILLIS" //毫秒精度的时间戳
+	TypeTimestampMicros Type = "TIMESTAMP_MICROS" //微秒精度的时间戳
+)
+
+//高精度实数的默认精度和标度
+const (
+	DefaultPrecision = 38
+	DefaultScale     = 18
+)
+
+//Column 写入的列定义，所有列都是可为空的
+type Column struct {
+	Name      string `json:"name"`      //列名
+	Type      Type   `json:"type"`      //列类型
+	Precision int    `json:"precision"` //高精度实数的精度，默认为38
+	Scale     int    `json:"scale"`     //高精度实数的标度，默认为18
+}
+
+//InferColumn 通过列c推断列定义，整数推断为INT64，高精度实数推断为DECIMAL(38,18)，
+//时间推断为TIMESTAMP_MICROS
+func InferColumn(c element.Column) (col Column, err error) {
+	col.Name = c.Name()
+	switch c.Type() {
+	case element.TypeBool:
+		col.Type = TypeBoolean
+	case element.TypeBigInt:
+		col.Type = TypeInt64
+	case element.TypeDecimal:
+		col.Type = TypeDecimal
+	case element.TypeString:
+		col.Type = TypeUTF8
+	case element.TypeBytes:
+		col.Type = TypeByteArray
+	case element.TypeTime:
+		col.Type = TypeTimestampMicros
+	default:
+		return col, fmt.Errorf("column(%v) type(%v) can not be inferred", c.Name(), c.Type())
+	}
+	return
+}
+
+//Validate 校验列定义
+func (c *Column) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("column name is empty")
+	}
+	switch c.Type {
+	case TypeBoolean, TypeInt32, TypeInt64, TypeFloat, TypeDouble,
+		TypeByteArray, TypeUTF8, TypeDate, TypeTimestampMillis, TypeTimestampMicros:
+	case TypeDecimal:
+		if p := c.precision(); p <= 0 || p > DefaultPrecision {
+			return fmt.Errorf("column(%v) precision(%v) is out of range [1, %v]", c.Name, p, DefaultPrecision)
+		}
+		if s := c.scale(); s < 0 || s > c.precision() {
+			return fmt.Errorf("column(%v) scale(%v) is out of range [0, %v]", c.Name, s, c.precision())
+		}
+	default:
+		return fmt.Errorf("column(%v) type(%v) is not supported", c.Name, c.Type)
+	}
+	return nil
+}
+
+//precision 高精度实数的精度，默认为38
+func (c *Column) precision() int {
+	if c.Precision == 0 {
+		return DefaultPrecision
+	}
+	return c.Precision
+}
+
+//scale 高精度实数的标度，精度和标度都未设置时默认为18
+func (c *Column) scale() int {
+	if c.Precision == 0 && c.Scale == 0 {
+		return DefaultScale
+	}
+	return c.Scale
+}
+
+//schemaElement 生成列定义对应的parquet模式元素
+func (c *Column) schemaElement() *parquet.SchemaElement {
+	se := parquet.NewSchemaElement()
+	se.Name = c.Name
+	se.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL)
+
+	physical := func(t parquet.Type) {
+		se.Type = parquet.TypePtr(t)
+	}
+	converted := func(t parquet.ConvertedType) {
+		se.ConvertedType = parquet.ConvertedTypePtr(t)
+	}
+	switch c.Type {
+	case TypeBoolean:
+		physical(parquet.Type_BOOLEAN)
+	case TypeInt32:
+		physical(parquet.Type_INT32)
+	case TypeInt64:
+		physical(parquet.Type_INT64)
+	case TypeFloat:
+		physical(parquet.Type_FLOAT)
+	case TypeDouble:
+		physical(parquet.Type_DOUBLE)
+	case TypeByteArray:
+		physical(parquet.Type_BYTE_ARRAY)
+	case TypeUTF8:
+		physical(parquet.Type_BYTE_ARRAY)
+		converted(parquet.ConvertedType_UTF8)
+	case TypeDecimal:
+		physical(parquet.Type_BYTE_ARRAY)
+		converted(parquet.ConvertedType_DECIMAL)
+		precision, scale := int32(c.precision()), int32(c.scale())
+		se.Precision, se.Scale = &precision, &scale
+	case TypeDate:
+		physical(parquet.Type_INT32)
+		converted(parquet.ConvertedType_DATE)
+	case TypeTimestampMillis:
+		physical(parquet.Type_INT64)
+		converted(parquet.ConvertedType_TIMESTAMP_MILLIS)
+	case TypeTimestampMicros:
+		physical(parquet.Type_INT64)
+		converted(parquet.ConvertedType_TIMESTAMP_MICROS)
+	}
+	return se
+}
+
+//Codec 写入的压缩格式
+type Codec string
+
+//压缩格式枚举
+const (
+	CodecNone   Codec = "none"   //不压缩
+	CodecSnappy Codec = "snappy" //snappy压缩，默认的压缩格式
+	CodecGzip   Codec = "gzip"   //gzip压缩
+	CodecZstd   Codec = "zstd"   //zstd压缩
+)
+
+//IsValid 是否为支持的压缩格式
+func (c Codec) IsValid() bool {
+	_, ok := c.compressionCodec()
+	return ok
+}
+
+//compressionCodec 获取对应的parquet压缩格式，默认为snappy
+func (c Codec) compressionCodec() (parquet.CompressionCodec, bool) {
+	switch c {
+	case CodecNone:
+		return parquet.CompressionCodec_UNCOMPRESSED, true
+	case "", CodecSnappy:
+		return parquet.CompressionCodec_SNAPPY, true
+	case CodecGzip:
+		return parquet.CompressionCodec_GZIP, true
+	case CodecZstd:
+		return parquet.CompressionCodec_ZSTD, true
+	}
+	return 0, false
+}
@@ -0,0 +1,153 @@
+package parquet
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockColumnValue struct {
+	element.ColumnValue
+}
+
+func (m *mockColumnValue) Type() element.ColumnType {
+	return "mock"
+}
+
+func TestInferColumn(t *testing.T) {
+	columns := testColumns()
+	want := []Type{TypeInt64, TypeUTF8, TypeUTF8, TypeTimestampMicros, TypeBoolean, TypeByteArray, TypeDecimal}
+	for i, c := range columns {
+		got, err := InferColumn(c)
+		if err != nil {
+			t.Fatalf("InferColumn() error = %v", err)
+		}
+		if got.Name != c.Name() || got.Type != want[i] {
+			t.Errorf("InferColumn() = %v, want %v %v", got, c.Name(), want[i])
+		}
+	}
+
+	if _, err := InferColumn(element.NewDefaultColumn(&mockColumnValue{
+		ColumnValue: element.NewNilStringColumnValue(),
+	}, "mock", 0)); err == nil {
+		t.Errorf("InferColumn() error = nil, wantErr true")
+	}
+}
+
+func TestColumn_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       Column
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    Column{Name: "a", Type: TypeTimestampMillis},
+		},
+		{
+			name: "2",
+			c:    Column{Name: "a", Type: TypeDecimal, Precision: 10, Scale: 2},
+		},
+		{
+			name:    "3",
+			c:       Column{Type: TypeInt32},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			c:       Column{Name: "a", Type: "INT128"},
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			c:       Column{Name: "a", Type: TypeDecimal, Precision: 39},
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			c:       Column{Name: "a", Type: TypeDecimal, Precision: 10, Scale: 11},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Column.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestColumn_precisionScale(t *testing.T) {
+	tests := []struct {
+		name          string
+		c             Column
+		wantPrecision int
+		wantScale     int
+	}{
+		{
+			name:          "1",
+			c:             Column{},
+			wantPrecision: DefaultPrecision,
+			wantScale:     DefaultScale,
+		},
+		{
+			name:          "2",
+			c:             Column{Precision: 10},
+			wantPrecision: 10,
+			wantScale:     0,
+		},
+		{
+			name:          "3",
+			c:             Column{Scale: 2},
+			wantPrecision: DefaultPrecision,
+			wantScale:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.precision(); got != tt.wantPrecision {
+				t.Errorf("Column.precision() = %v, want %v", got, tt.wantPrecision)
+			}
+			if got := tt.c.scale(); got != tt.wantScale {
+				t.Errorf("Column.scale() = %v, want %v", got, tt.wantScale)
+			}
+		})
+	}
+}
+
+func TestCodec_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Codec
+		want bool
+	}{
+		{
+			name: "1",
+			c:    "",
+			want: true,
+		},
+		{
+			name: "2",
+			c:    CodecNone,
+			want: true,
+		},
+		{
+			name: "3",
+			c:    CodecZstd,
+			want: true,
+		},
+		{
+			name: "4",
+			c:    "brotli",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.IsValid(); got != tt.want {
+				t.Errorf("Codec.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package parquet
+
+import (
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/xitongsys/parquet-go/source"
+)
+
+//fileSource 将文件系统中的文件适配成parquet的文件，读取时parquet会通过Open多次打开同一个文件
+type fileSource struct {
+	file.File
+
+	fs   file.FileSystem
+	name string
+}
+
+//openSource 打开文件系统fs中的文件name
+func openSource(fs file.FileSystem, name string) (source.ParquetFile, error) {
+	f, err := fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSource{
+		File: f,
+		fs:   fs,
+		name: name,
+	}, nil
+}
+
+//Open 打开文件name，name为空时打开同一个文件
+func (s *fileSource) Open(name string) (source.ParquetFile, error) {
+	if name == "" {
+		name = s.name
+	}
+	return openSource(s.fs, name)
+}
+
+//Create 不支持
+func (s *fileSource) Create(name string) (source.ParquetFile, error) {
+	return nil, fmt.Errorf("parquet file(%v) is read only", s.name)
+}
+
+//Read 读满p或者读到文件结尾
+func (s *fileSource) Read(p []byte) (n int, err error) {
+	var cnt int
+	for n < len(p) {
+		cnt, err = s.File.Read(p[n:])
+		n += cnt
+		if err != nil {
+			break
+		}
+	}
+	return
+}
+
+//Write 不支持
+func (s *fileSource) Write(p []byte) (n int, err error) {
+	return 0, fmt.Errorf("parquet file(%v) is read only", s.name)
+}
@@ -0,0 +1,49 @@
+package parquet
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+func TestFileSource(t *testing.T) {
+	dir := testTempDir(t)
+	filename := filepath.Join(dir, "a.txt")
+	if err := ioutil.WriteFile(filename, []byte("0123456789"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := openSource(file.Local, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	other, err := s.Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	p := make([]byte, 4)
+	if n, err := s.Read(p); err != nil || string(p[:n]) != "0123" {
+		t.Errorf("fileSource.Read() = %q, %v, want 0123", p[:n], err)
+	}
+	if _, err = other.Seek(8, 0); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := other.Read(p); n != 2 || string(p[:n]) != "89" || err == nil {
+		t.Errorf("fileSource.Read() = %q, %v, want 89 and EOF", p[:n], err)
+	}
+
+	if _, err = s.Open(filepath.Join(dir, "none.txt")); err == nil {
+		t.Errorf("fileSource.Open() error = nil, wantErr true")
+	}
+	if _, err = s.Create(filename); err == nil {
+		t.Errorf("fileSource.Create() error = nil, wantErr true")
+	}
+	if _, err = s.Write(p); err == nil {
+		t.Errorf("fileSource.Write() error = nil, wantErr true")
+	}
+}
@@ -0,0 +1,295 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/shopspring/decimal"
+	"github.com/xitongsys/parquet-go/parquet"
+)
+
+const (
+	secondsPerDay    = 24 * 60 * 60
+	julianDayOfEpoch = 2440588 //1970-01-01的儒略日
+)
+
+//value 将列col转化为列定义对应的parquet值，空值转化为nil
+func (c *Column) value(col element.Column) (v interface{}, err error) {
+	if col.IsNil() {
+		return nil, nil
+	}
+
+	switch c.Type {
+	case TypeBoolean:
+		return col.AsBool()
+	case TypeInt32, TypeInt64:
+		var bi *big.Int
+		if bi, err = col.AsBigInt(); err != nil {
+			return
+		}
+		if !bi.IsInt64() {
+			return nil, fmt.Errorf("%v overflows %v", bi, c.Type)
+		}
+		i := bi.Int64()
+		if c.Type == TypeInt64 {
+			return i, nil
+		}
+		if i < math.MinInt32 || i > math.MaxInt32 {
+			return nil, fmt.Errorf("%v overflows %v", bi, c.Type)
+		}
+		return int32(i), nil
+	case TypeFloat, TypeDouble:
+		var d decimal.Decimal
+		if d, err = col.AsDecimal(); err != nil {
+			return
+		}
+		f, _ := d.Float64()
+		if c.Type == TypeDouble {
+			return f, nil
+		}
+		return float32(f), nil
+	case TypeByteArray:
+		var b []byte
+		if b, err = col.AsBytes(); err != nil {
+			return
+		}
+		return string(b), nil
+	case TypeUTF8:
+		return col.AsString()
+	case TypeDecimal:
+		var d decimal.Decimal
+		if d, err = col.AsDecimal(); err != nil {
+			return
+		}
+		unscaled := d.Shift(int32(c.scale())).Round(0).BigInt()
+		if len(new(big.Int).Abs(unscaled).String()) > c.precision() {
+			return nil, fmt.Errorf("%v overflows %v(%v,%v)", d, c.Type, c.precision(), c.scale())
+		}
+		return string(toTwosComplement(unscaled)), nil
+	case TypeDate, TypeTimestampMillis, TypeTimestampMicros:
+		var t time.Time
+		if t, err = col.AsTime(); err != nil {
+			return
+		}
+		switch c.Type {
+		case TypeDate:
+			y, m, d := t.Date()
+			return int32(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / secondsPerDay), nil
+		case TypeTimestampMillis:
+			return t.Unix()*1e3 + int64(t.Nanosecond())/1e6, nil
+		}
+		return t.Unix()*1e6 + int64(t.Nanosecond())/1e3, nil
+	}
+	return nil, fmt.Errorf("type(%v) is not supported", c.Type)
+}
+
+//valueFunc 将parquet值转化为列值的函数
+type valueFunc func(v interface{}) (element.ColumnValue, error)
+
+//newValueFunc 根据parquet模式元素se的逻辑类型以及物理类型生成转化函数，
+//整数转化为整数列值，浮点数和DECIMAL转化为高精度实数列值，
+//DATE，TIMESTAMP和INT96转化为时间列值，UTF8，ENUM和JSON转化为字符串列值，
+//其余字节数组转化为字节流列值
+func newValueFunc(se *parquet.SchemaElement) (f valueFunc, err error) {
+	lt, ct := se.GetLogicalType(), se.GetConvertedType()
+	isConverted := func(types ...parquet.ConvertedType) bool {
+		if !se.IsSetConvertedType() {
+			return false
+		}
+		for _, v := range types {
+			if ct == v {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case lt != nil && lt.IsSetDECIMAL():
+		return withNil(element.NewNilDecimalColumnValue, decimalValue(lt.DECIMAL.Scale)), nil
+	case isConverted(parquet.ConvertedType_DECIMAL):
+		return withNil(element.NewNilDecimalColumnValue, decimalValue(se.GetScale())), nil
+	case lt != nil && lt.IsSetDATE(), isConverted(parquet.ConvertedType_DATE):
+		return withNil(element.NewNilTimeColumnValue, dateValue), nil
+	case lt != nil && lt.IsSetTIMESTAMP():
+		unit := lt.TIMESTAMP.Unit
+		switch {
+		case unit.IsSetMILLIS():
+			return withNil(element.NewNilTimeColumnValue, timestampValue(time.Millisecond)), nil
+		case unit.IsSetMICROS():
+			return withNil(element.NewNilTimeColumnValue, timestampValue(time.Microsecond)), nil
+		}
+		return withNil(element.NewNilTimeColumnValue, timestampValue(time.Nanosecond)), nil
+	case isConverted(parquet.ConvertedType_TIMESTAMP_MILLIS):
+		return withNil(element.NewNilTimeColumnValue, timestampValue(time.Millisecond)), nil
+	case isConverted(parquet.ConvertedType_TIMESTAMP_MICROS):
+		return withNil(element.NewNilTimeColumnValue, timestampValue(time.Microsecond)), nil
+	case lt != nil && (lt.IsSetSTRING() || lt.IsSetENUM() || lt.IsSetJSON()),
+		isConverted(parquet.ConvertedType_UTF8, parquet.ConvertedType_ENUM, parquet.ConvertedType_JSON):
+		return withNil(element.NewNilStringColumnValue, stringValue), nil
+	case lt != nil && lt.IsSetINTEGER() && !lt.INTEGER.IsSigned,
+		isConverted(parquet.ConvertedType_UINT_8, parquet.ConvertedType_UINT_16,
+			parquet.ConvertedType_UINT_32, parquet.ConvertedType_UINT_64):
+		return withNil(element.NewNilBigIntColumnValue, unsignedValue), nil
+	}
+
+	switch se.GetType() {
+	case parquet.Type_BOOLEAN:
+		return withNil(element.NewNilBoolColumnValue, boolValue), nil
+	case parquet.Type_INT32, parquet.Type_INT64:
+		return withNil(element.NewNilBigIntColumnValue, intValue), nil
+	case parquet.Type_INT96:
+		return withNil(element.NewNilTimeColumnValue, int96Value), nil
+	case parquet.Type_FLOAT, parquet.Type_DOUBLE:
+		return withNil(element.NewNilDecimalColumnValue, floatValue), nil
+	case parquet.Type_BYTE_ARRAY, parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		return withNil(element.NewNilBytesColumnValue, bytesValue), nil
+	}
+	return nil, fmt.Errorf("type(%v) is not supported", se.GetType())
+}
+
+//withNil 在转化函数f的基础上将nil转化为newNil生成的空值
+func withNil(newNil func() element.ColumnValue, f valueFunc) valueFunc {
+	return func(v interface{}) (element.ColumnValue, error) {
+		if v == nil {
+			return newNil(), nil
+		}
+		return f(v)
+	}
+}
+
+func boolValue(v interface{}) (element.ColumnValue, error) {
+	if b, ok := v.(bool); ok {
+		return element.NewBoolColumnValue(b), nil
+	}
+	return nil, fmt.Errorf("%v(%T) is not bool", v, v)
+}
+
+func intValue(v interface{}) (element.ColumnValue, error) {
+	i, err := toInt64(v)
+	if err != nil {
+		return nil, err
+	}
+	return element.NewBigIntColumnValueFromInt64(i), nil
+}
+
+func unsignedValue(v interface{}) (element.ColumnValue, error) {
+	switch i := v.(type) {
+	case int32:
+		return element.NewBigIntColumnValueFromInt64(int64(uint32(i))), nil
+	case int64:
+		return element.NewBigIntColumnValue(new(big.Int).SetUint64(uint64(i))), nil
+	}
+	return nil, fmt.Errorf("%v(%T) is not integer", v, v)
+}
+
+func floatValue(v interface{}) (element.ColumnValue, error) {
+	switch f := v.(type) {
+	case float32:
+		return element.NewDecimalColumnValue(decimal.NewFromFloat32(f)), nil
+	case float64:
+		return element.NewDecimalColumnValueFromFloat(f), nil
+	}
+	return nil, fmt.Errorf("%v(%T) is not float", v, v)
+}
+
+func stringValue(v interface{}) (element.ColumnValue, error) {
+	if s, ok := v.(string); ok {
+		return element.NewStringColumnValue(s), nil
+	}
+	return nil, fmt.Errorf("%v(%T) is not byte array", v, v)
+}
+
+func bytesValue(v interface{}) (element.ColumnValue, error) {
+	if s, ok := v.(string); ok {
+		return element.NewBytesColumnValue([]byte(s)), nil
+	}
+	return nil, fmt.Errorf("%v(%T) is not byte array", v, v)
+}
+
+func dateValue(v interface{}) (element.ColumnValue, error) {
+	i, err := toInt64(v)
+	if err != nil {
+		return nil, err
+	}
+	return element.NewTimeColumnValue(time.Unix(i*secondsPerDay, 0).UTC()), nil
+}
+
+//int96Value 将INT96转化为时间，前8个字节为小端序的当天纳秒数，后4个字节为小端序的儒略日
+func int96Value(v interface{}) (element.ColumnValue, error) {
+	s, ok := v.(string)
+	if !ok || len(s) != 12 {
+		return nil, fmt.Errorf("%v(%T) is not int96", v, v)
+	}
+	nanos := int64(binary.LittleEndian.Uint64([]byte(s[:8])))
+	days := int64(binary.LittleEndian.Uint32([]byte(s[8:])))
+	return element.NewTimeColumnValue(time.Unix((days-julianDayOfEpoch)*secondsPerDay, nanos).UTC()), nil
+}
+
+//timestampValue 以unit为单位将时间戳转化为UTC时间
+func timestampValue(unit time.Duration) valueFunc {
+	return func(v interface{}) (element.ColumnValue, error) {
+		i, err := toInt64(v)
+		if err != nil {
+			return nil, err
+		}
+		perSecond := int64(time.Second / unit)
+		sec, frac := i/perSecond, i%perSecond
+		if frac < 0 {
+			sec, frac = sec-1, frac+perSecond
+		}
+		return element.NewTimeColumnValue(time.Unix(sec, frac*int64(unit)).UTC()), nil
+	}
+}
+
+//decimalValue 将标度为scale的未标度整数转化为高精度实数，
+//整数以INT32或者INT64存储，字节数组以大端序的二进制补码存储
+func decimalValue(scale int32) valueFunc {
+	return func(v interface{}) (element.ColumnValue, error) {
+		var unscaled *big.Int
+		switch i := v.(type) {
+		case int32:
+			unscaled = big.NewInt(int64(i))
+		case int64:
+			unscaled = big.NewInt(i)
+		case string:
+			unscaled = fromTwosComplement([]byte(i))
+		default:
+			return nil, fmt.Errorf("%v(%T) is not decimal", v, v)
+		}
+		return element.NewDecimalColumnValue(decimal.NewFromBigInt(unscaled, -scale)), nil
+	}
+}
+
+func toInt64(v interface{}) (int64, error) {
+	switch i := v.(type) {
+	case int32:
+		return int64(i), nil
+	case int64:
+		return i, nil
+	}
+	return 0, fmt.Errorf("%v(%T) is not integer", v, v)
+}
+
+//toTwosComplement 将整数i转化为大端序的二进制补码
+func toTwosComplement(i *big.Int) []byte {
+	n := i.BitLen()/8 + 1
+	if i.Sign() >= 0 {
+		return i.FillBytes(make([]byte, n))
+	}
+	c := new(big.Int).Lsh(big.NewInt(1), uint(n*8))
+	return c.Add(c, i).FillBytes(make([]byte, n))
+}
+
+//fromTwosComplement 将大端序的二进制补码b转化为整数
+func fromTwosComplement(b []byte) *big.Int {
+	i := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		i.Sub(i, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	return i
+}
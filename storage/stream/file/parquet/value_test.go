@@ -0,0 +1,207 @@
+package parquet
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/xitongsys/parquet-go/parquet"
+)
+
+func TestTwosComplement(t *testing.T) {
+	values := []string{"0", "1", "-1", "127", "128", "-128", "-129", "255", "-256",
+		"123456789012345678901234567890", "-123456789012345678901234567890"}
+	for _, v := range values {
+		i, _ := new(big.Int).SetString(v, 10)
+		if got := fromTwosComplement(toTwosComplement(i)); got.Cmp(i) != 0 {
+			t.Errorf("fromTwosComplement(toTwosComplement(%v)) = %v", v, got)
+		}
+	}
+	if got := toTwosComplement(big.NewInt(-2)); string(got) != "\xfe" {
+		t.Errorf("toTwosComplement(-2) = %x, want fe", got)
+	}
+}
+
+func TestColumn_valueErr(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Column
+		col  element.Column
+	}{
+		{
+			name: "1",
+			c:    Column{Name: "a", Type: TypeInt32},
+			col:  element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1<<31), "a", 0),
+		},
+		{
+			name: "2",
+			c:    Column{Name: "a", Type: TypeInt64},
+			col:  element.NewDefaultColumn(testBigInt("123456789012345678901234567890"), "a", 0),
+		},
+		{
+			name: "3",
+			c:    Column{Name: "a", Type: TypeDecimal, Precision: 3, Scale: 1},
+			col:  element.NewDefaultColumn(testDecimal("100"), "a", 0),
+		},
+		{
+			name: "4",
+			c:    Column{Name: "a", Type: TypeBoolean},
+			col:  element.NewDefaultColumn(element.NewStringColumnValue("abc"), "a", 0),
+		},
+		{
+			name: "5",
+			c:    Column{Name: "a", Type: TypeTimestampMicros},
+			col:  element.NewDefaultColumn(element.NewBoolColumnValue(true), "a", 0),
+		},
+		{
+			name: "6",
+			c:    Column{Name: "a", Type: "mock"},
+			col:  element.NewDefaultColumn(element.NewBoolColumnValue(true), "a", 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.c.value(tt.col); err == nil {
+				t.Errorf("Column.value() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func TestNewValueFunc(t *testing.T) {
+	physical := func(t parquet.Type) *parquet.SchemaElement {
+		se := parquet.NewSchemaElement()
+		se.Type = parquet.TypePtr(t)
+		return se
+	}
+	converted := func(t parquet.Type, c parquet.ConvertedType) *parquet.SchemaElement {
+		se := physical(t)
+		se.ConvertedType = parquet.ConvertedTypePtr(c)
+		return se
+	}
+	logical := func(t parquet.Type, l *parquet.LogicalType) *parquet.SchemaElement {
+		se := physical(t)
+		se.LogicalType = l
+		return se
+	}
+	tm := time.Date(2021, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	tests := []struct {
+		name     string
+		se       *parquet.SchemaElement
+		v        interface{}
+		wantType element.ColumnType
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			se:       logical(parquet.Type_INT64, &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{Unit: &parquet.TimeUnit{NANOS: &parquet.NanoSeconds{}}}}),
+			v:        tm.UnixNano(),
+			wantType: element.TypeTime,
+			want:     "2021-01-02T03:04:05.123456789Z",
+		},
+		{
+			name:     "2",
+			se:       logical(parquet.Type_INT64, &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{Unit: &parquet.TimeUnit{MILLIS: &parquet.MilliSeconds{}}}}),
+			v:        int64(-1),
+			wantType: element.TypeTime,
+			want:     "1969-12-31T23:59:59.999Z",
+		},
+		{
+			name:     "3",
+			se:       logical(parquet.Type_INT32, &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Scale: 2, Precision: 9}}),
+			v:        int32(-12345),
+			wantType: element.TypeDecimal,
+			want:     "-123.45",
+		},
+		{
+			name:     "4",
+			se:       converted(parquet.Type_INT64, parquet.ConvertedType_UINT_64),
+			v:        int64(-1),
+			wantType: element.TypeBigInt,
+			want:     "18446744073709551615",
+		},
+		{
+			name:     "5",
+			se:       logical(parquet.Type_INT32, &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 32}}),
+			v:        int32(-1),
+			wantType: element.TypeBigInt,
+			want:     "4294967295",
+		},
+		{
+			name:     "6",
+			se:       physical(parquet.Type_INT96),
+			v:        "\x15\xff\xa8\xa4\x0b\x0a\x00\x00\x51\x86\x25\x00",
+			wantType: element.TypeTime,
+			want:     "2021-01-02T03:04:05.123456789Z",
+		},
+		{
+			name:     "7",
+			se:       physical(parquet.Type_FLOAT),
+			v:        float32(1.5),
+			wantType: element.TypeDecimal,
+			want:     "1.5",
+		},
+		{
+			name:     "8",
+			se:       logical(parquet.Type_BYTE_ARRAY, &parquet.LogicalType{JSON: &parquet.JsonType{}}),
+			v:        `{"a":1}`,
+			wantType: element.TypeString,
+			want:     `{"a":1}`,
+		},
+		{
+			name:     "9",
+			se:       physical(parquet.Type_FIXED_LEN_BYTE_ARRAY),
+			v:        nil,
+			wantType: element.TypeBytes,
+			want:     "<nil>",
+		},
+		{
+			name:     "10",
+			se:       converted(parquet.Type_INT32, parquet.ConvertedType_DATE),
+			v:        int32(-1),
+			wantType: element.TypeTime,
+			want:     "1969-12-31T00:00:00Z",
+		},
+		{
+			name:    "11",
+			se:      physical(parquet.Type_BOOLEAN),
+			v:       int32(1),
+			wantErr: true,
+		},
+		{
+			name:    "12",
+			se:      physical(parquet.Type_INT96),
+			v:       "abc",
+			wantErr: true,
+		},
+		{
+			name:    "13",
+			se:      converted(parquet.Type_BYTE_ARRAY, parquet.ConvertedType_DECIMAL),
+			v:       1.5,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newValueFunc(tt.se)
+			if err != nil {
+				t.Fatalf("newValueFunc() error = %v", err)
+			}
+			got, err := f(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("valueFunc() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Type() != tt.wantType {
+				t.Errorf("valueFunc() type = %v, want %v", got.Type(), tt.wantType)
+			}
+			if got.String() != tt.want {
+				t.Errorf("valueFunc() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/xitongsys/parquet-go/marshal"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+//DefaultRowGroupSize 默认的行组大小，单位字节
+const DefaultRowGroupSize = 128 * 1024 * 1024
+
+//Options 写入选项
+type Options struct {
+	RowGroupSize int64 //行组大小，单位字节，小于等于0时为默认的128MB
+	Codec        Codec //压缩格式，默认为snappy
+}
+
+//Writer parquet文件写入器
+type Writer struct {
+	w       io.Writer
+	columns []Column
+	opts    Options
+
+	pw     *writer.ParquetWriter
+	size   int64 //已写入记录按照PLAIN编码的字节数
+	closed bool
+}
+
+//NewWriter 生成向w写入，列定义为columns，写入选项为opts的parquet文件写入器，
+//columns为空时通过写入的第一条记录推断列定义
+func NewWriter(w io.Writer, columns []Column, opts Options) (*Writer, error) {
+	for i := range columns {
+		if err := columns[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if !opts.Codec.IsValid() {
+		return nil, fmt.Errorf("codec(%v) is not supported", opts.Codec)
+	}
+	return &Writer{
+		w:       w,
+		columns: columns,
+		opts:    opts,
+	}, nil
+}
+
+//Write 写入记录record，记录中的列按照顺序与列定义对应
+func (w *Writer) Write(record element.Record) (err error) {
+	if w.closed {
+		return fmt.Errorf("writer is closed")
+	}
+	if w.pw == nil {
+		if len(w.columns) == 0 {
+			if w.columns, err = inferColumns(record); err != nil {
+				return
+			}
+		}
+		if err = w.open(); err != nil {
+			return
+		}
+	}
+
+	if record.ColumnNumber() != len(w.columns) {
+		return fmt.Errorf("record has %v columns, want %v", record.ColumnNumber(), len(w.columns))
+	}
+
+	row := make([]interface{}, len(w.columns))
+	for i := range w.columns {
+		var c element.Column
+		if c, err = record.GetByIndex(i); err != nil {
+			return
+		}
+		if row[i], err = w.columns[i].value(c); err != nil {
+			return fmt.Errorf("column(%v) err: %v", w.columns[i].Name, err)
+		}
+	}
+	if err = w.pw.Write(row); err != nil {
+		return
+	}
+	for _, v := range row {
+		w.size += plainSize(v)
+	}
+	return
+}
+
+//EncodedSize 已写入记录按照PLAIN编码且未压缩的字节数，由于数据按照行组写入，
+//可以作为已写入数据大小的估计
+func (w *Writer) EncodedSize() int64 {
+	return w.size
+}
+
+//open 按照列定义开始写入
+func (w *Writer) open() (err error) {
+	root := parquet.NewSchemaElement()
+	root.Name = "parquet_go_root"
+	root.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED)
+	numChildren := int32(len(w.columns))
+	root.NumChildren = &numChildren
+	schema := []*parquet.SchemaElement{root}
+	for i := range w.columns {
+		schema = append(schema, w.columns[i].schemaElement())
+	}
+
+	if w.pw, err = writer.NewParquetWriterFromWriter(w.w, schema, 1); err != nil {
+		return
+	}
+	w.pw.MarshalFunc = marshal.MarshalCSV
+	w.pw.RowGroupSize = DefaultRowGroupSize
+	if w.opts.RowGroupSize > 0 {
+		w.pw.RowGroupSize = w.opts.RowGroupSize
+	}
+	w.pw.CompressionType, _ = w.opts.Codec.compressionCodec()
+	return
+}
+
+//Close 写入剩余的行组和文件尾，在声明了列定义时没有写入过记录也会生成只有列定义的文件，
+//不会关闭w，重复调用时不做处理
+func (w *Writer) Close() (err error) {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.pw == nil {
+		if len(w.columns) == 0 {
+			return nil
+		}
+		if err = w.open(); err != nil {
+			return
+		}
+	}
+	return w.pw.WriteStop()
+}
+
+//plainSize parquet值v按照PLAIN编码的字节数，字节数组带有4字节的长度，空值不占用字节
+func plainSize(v interface{}) int64 {
+	switch v := v.(type) {
+	case bool:
+		return 1
+	case int32, float32:
+		return 4
+	case int64, float64:
+		return 8
+	case string:
+		return 4 + int64(len(v))
+	}
+	return 0
+}
+
+//inferColumns 通过记录record推断列定义
+func inferColumns(record element.Record) (columns []Column, err error) {
+	for i := 0; i < record.ColumnNumber(); i++ {
+		var c element.Column
+		if c, err = record.GetByIndex(i); err != nil {
+			return
+		}
+		var col Column
+		if col, err = InferColumn(c); err != nil {
+			return
+		}
+		columns = append(columns, col)
+	}
+	return
+}
@@ -0,0 +1,188 @@
+package parquet
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+func TestWriter(t *testing.T) {
+	dir := testTempDir(t)
+	declared := []Column{
+		{Name: "id", Type: TypeInt32},
+		{Name: "name", Type: TypeUTF8},
+		{Name: "nil", Type: TypeByteArray},
+		{Name: "time", Type: TypeTimestampMillis},
+		{Name: "bool", Type: TypeBoolean},
+		{Name: "bytes", Type: TypeUTF8},
+		{Name: "decimal", Type: TypeDecimal, Precision: 25, Scale: 2},
+	}
+	tests := []struct {
+		name    string
+		columns []Column
+		opts    Options
+		records []element.Record
+		want    [][]string
+	}{
+		{
+			name:    "1",
+			records: []element.Record{testRecord(testColumns()...)},
+			want: [][]string{{"id:bigInt:1", "name:string:a", "nil:string:<nil>",
+				"time:time:2021-01-02T03:04:05.123456Z", "bool:bool:true", "bytes:bytes:xyz",
+				"decimal:decimal:-12345678901234567890.123456789"}},
+		},
+		{
+			name:    "2",
+			columns: declared,
+			opts:    Options{Codec: CodecGzip},
+			records: []element.Record{testRecord(testColumns()...)},
+			want: [][]string{{"id:bigInt:1", "name:string:a", "nil:bytes:<nil>",
+				"time:time:2021-01-02T03:04:05.123Z", "bool:bool:true", "bytes:string:xyz",
+				"decimal:decimal:-12345678901234567890.12"}},
+		},
+		{
+			name:    "3",
+			columns: declared,
+			opts:    Options{Codec: CodecZstd},
+		},
+		{
+			name: "4",
+			columns: []Column{
+				{Name: "float", Type: TypeFloat},
+				{Name: "double", Type: TypeDouble},
+				{Name: "date", Type: TypeDate},
+				{Name: "int64", Type: TypeInt64},
+			},
+			opts: Options{Codec: CodecNone},
+			records: []element.Record{testRecord(
+				element.NewDefaultColumn(testDecimal("1.5"), "float", 0),
+				element.NewDefaultColumn(element.NewStringColumnValue("2.25"), "double", 0),
+				testColumns()[3],
+				element.NewDefaultColumn(testBigInt("-9223372036854775808"), "int64", 0),
+			)},
+			want: [][]string{{"float:decimal:1.5", "double:decimal:2.25",
+				"date:time:2021-01-02T00:00:00Z", "int64:bigInt:-9223372036854775808"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := testWrite(t, dir, tt.name+".parquet", tt.columns, tt.opts, tt.records...)
+			if got := testRead(t, filename, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Writer = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter_RowGroup(t *testing.T) {
+	dir := testTempDir(t)
+	var records []element.Record
+	var want [][]string
+	for i := 0; i < 3000; i++ {
+		s := strings.Repeat("a", i%100)
+		records = append(records, testRecord(element.NewDefaultColumn(element.NewStringColumnValue(s), "s", 0)))
+		want = append(want, []string{"s:string:" + s})
+	}
+	filename := testWrite(t, dir, "a.parquet", nil, Options{RowGroupSize: 1}, records...)
+
+	r, err := NewReader(file.Local, filename, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if r.NumRowGroups() < 2 {
+		t.Errorf("Reader.NumRowGroups() = %v, want at least 2", r.NumRowGroups())
+	}
+	if got := testRead(t, filename, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("Writer = %v, want %v", len(got), len(want))
+	}
+}
+
+func TestWriter_EncodedSize(t *testing.T) {
+	w, err := NewWriter(&bytes.Buffer{}, []Column{
+		{Name: "id", Type: TypeInt64},
+		{Name: "name", Type: TypeUTF8},
+		{Name: "ok", Type: TypeBoolean},
+		{Name: "nil", Type: TypeUTF8},
+	}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := testRecord(
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue("abc"), "name", 0),
+		element.NewDefaultColumn(element.NewBoolColumnValue(true), "ok", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+	)
+	for i := 1; i <= 2; i++ {
+		if err = w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+		if got := w.EncodedSize(); got != int64(i*(8+4+3+1)) {
+			t.Errorf("Writer.EncodedSize() = %v, want %v", got, i*(8+4+3+1))
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, []Column{{Name: "a"}}, Options{}); err == nil {
+		t.Errorf("NewWriter() error = nil, wantErr true")
+	}
+	if _, err := NewWriter(&bytes.Buffer{}, nil, Options{Codec: "lzo"}); err == nil {
+		t.Errorf("NewWriter() error = nil, wantErr true")
+	}
+}
+
+func TestWriter_WriteErr(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		record  element.Record
+	}{
+		{
+			name:    "1",
+			columns: []Column{{Name: "a", Type: TypeInt32}},
+			record:  testRecord(testColumns()[:2]...),
+		},
+		{
+			name:    "2",
+			columns: []Column{{Name: "a", Type: TypeInt32}},
+			record:  testRecord(testColumns()[1]),
+		},
+		{
+			name: "3",
+			record: testRecord(element.NewDefaultColumn(&mockColumnValue{
+				ColumnValue: element.NewNilStringColumnValue(),
+			}, "mock", 0)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewWriter(&bytes.Buffer{}, tt.columns, Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = w.Write(tt.record); err == nil {
+				t.Errorf("Writer.Write() error = nil, wantErr true")
+			}
+		})
+	}
+
+	w, _ := NewWriter(&bytes.Buffer{}, nil, Options{})
+	if err := w.Close(); err != nil {
+		t.Errorf("Writer.Close() error = %v", err)
+	}
+	if err := w.Write(testRecord(testColumns()...)); err == nil {
+		t.Errorf("Writer.Write() error = nil, wantErr true")
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Writer.Close() error = %v", err)
+	}
+}
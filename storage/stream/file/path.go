@@ -0,0 +1,30 @@
+package file
+
+import (
+	"fmt"
+	"sort"
+)
+
+//Glob 获取文件系统fs中通配符路径patterns匹配的所有文件，忽略目录，结果去重并排序
+//当通配符格式错误或者没有匹配到任何文件时会报错
+func Glob(fs FileSystem, patterns []string) (filenames []string, err error) {
+	exists := make(map[string]bool)
+	for _, pattern := range patterns {
+		var matches []string
+		if matches, err = fs.Glob(pattern); err != nil {
+			return nil, fmt.Errorf("Glob(%v) err: %v", pattern, err)
+		}
+		for _, v := range matches {
+			if exists[v] {
+				continue
+			}
+			exists[v] = true
+			filenames = append(filenames, v)
+		}
+	}
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("no file matches %v", patterns)
+	}
+	sort.Strings(filenames)
+	return
+}
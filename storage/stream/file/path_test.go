@@ -0,0 +1,56 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGlob(t *testing.T) {
+	dir := testTempDir(t)
+	a := testWriteFile(t, dir, "a.csv", nil)
+	b := testWriteFile(t, dir, "b.csv", nil)
+	c := testWriteFile(t, dir, "c.txt", nil)
+	if err := os.Mkdir(filepath.Join(dir, "d.csv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			patterns: []string{filepath.Join(dir, "*.csv")},
+			want:     []string{a, b},
+		},
+		{
+			name:     "2",
+			patterns: []string{c, filepath.Join(dir, "*")},
+			want:     []string{a, b, c},
+		},
+		{
+			name:     "3",
+			patterns: []string{filepath.Join(dir, "*.json")},
+			wantErr:  true,
+		},
+		{
+			name:     "4",
+			patterns: []string{filepath.Join(dir, "[")},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Glob(Local, tt.patterns)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Glob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Glob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package file
+
+import (
+	"bufio"
+	"io"
+)
+
+//Range 文件的字节范围[Start, End)，End小于等于0时代表到文件结尾
+type Range struct {
+	Start int64 `json:"start"` //起始位置
+	End   int64 `json:"end"`   //结束位置
+}
+
+//SplitRanges 将大小为size的文件按照每份splitSize字节切分成多个字节范围
+//当splitSize小于等于0或者文件不大于splitSize时，只返回整个文件
+func SplitRanges(size, splitSize int64) (ranges []Range) {
+	if splitSize <= 0 || size <= splitSize {
+		return []Range{{}}
+	}
+	for start := int64(0); start < size; start += splitSize {
+		end := start + splitSize
+		if end >= size {
+			end = 0
+		}
+		ranges = append(ranges, Range{Start: start, End: end})
+	}
+	return
+}
+
+//RangeReader 按行对齐的字节范围读取器
+//起始行为Start所在行的下一个完整行(Start为0或者Start前一个字节为换行符时为Start所在行)，
+//结束行为End前一个字节所在的行，这样相邻的字节范围读取的行不会重复也不会遗漏
+type RangeReader struct {
+	f      File
+	r      *bufio.Reader
+	remain int64  //字节范围内剩余的字节数，为-1时代表到文件结尾，为0时需要读取到行尾
+	tail   []byte //结束行中已读取但还没有返回的数据
+	last   bool   //结束行已经读取完毕，tail返回后结束
+	done   bool
+}
+
+//NewRangeReader 打开文件系统fs中的文件filename，并生成字节范围rng的读取器
+func NewRangeReader(fs FileSystem, filename string, rng Range) (rr *RangeReader, err error) {
+	rr = &RangeReader{
+		remain: -1,
+	}
+	if rr.f, err = fs.Open(filename); err != nil {
+		return nil, err
+	}
+	if rng.End > 0 {
+		rr.remain = rng.End - rng.Start
+	}
+	defer func() {
+		if err != nil {
+			rr.f.Close()
+			rr = nil
+		}
+	}()
+
+	if rng.Start > 0 {
+		if _, err = rr.f.Seek(rng.Start-1, io.SeekStart); err != nil {
+			return
+		}
+		rr.r = bufio.NewReader(rr.f)
+		var b byte
+		if b, err = rr.r.ReadByte(); err != nil {
+			if err == io.EOF {
+				rr.done, err = true, nil
+			}
+			return
+		}
+		if b != '\n' {
+			var line []byte
+			line, err = rr.r.ReadBytes('\n')
+			if err == io.EOF {
+				rr.done, err = true, nil
+			}
+			if err != nil {
+				return
+			}
+			if rng.End > 0 {
+				if rr.remain -= int64(len(line)); rr.remain <= 0 {
+					rr.done = true
+				}
+			}
+		}
+		return
+	}
+	rr.r = bufio.NewReader(rr.f)
+	return
+}
+
+//Read 读取数据，字节范围内的数据整块读取，超出字节范围后读取到结束行的行尾为止
+func (rr *RangeReader) Read(p []byte) (n int, err error) {
+	for n < len(p) && !rr.done {
+		if len(rr.tail) > 0 {
+			m := copy(p[n:], rr.tail)
+			rr.tail = rr.tail[m:]
+			n += m
+			continue
+		}
+		if rr.last {
+			rr.done = true
+			break
+		}
+
+		buf := p[n:]
+		if rr.remain != 0 {
+			if rr.remain > 0 && int64(len(buf)) > rr.remain {
+				buf = buf[:rr.remain]
+			}
+			var m int
+			m, err = rr.r.Read(buf)
+			n += m
+			if rr.remain > 0 {
+				rr.remain -= int64(m)
+				//字节范围恰好以换行符结束
+				if rr.remain == 0 && m > 0 && buf[m-1] == '\n' {
+					rr.last = true
+				}
+			}
+		} else {
+			var line []byte
+			line, err = rr.r.ReadSlice('\n')
+			switch err {
+			case nil:
+				rr.last = true
+			case bufio.ErrBufferFull:
+				err = nil
+			}
+			m := copy(buf, line)
+			n += m
+			rr.tail = append(rr.tail[:0], line[m:]...)
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				rr.last, err = true, nil
+				continue
+			}
+			return
+		}
+	}
+	if n == 0 && rr.done {
+		return 0, io.EOF
+	}
+	return
+}
+
+//Close 关闭文件
+func (rr *RangeReader) Close() error {
+	return rr.f.Close()
+}
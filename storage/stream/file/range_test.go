@@ -0,0 +1,119 @@
+package file
+
+import (
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSplitRanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int64
+		splitSize int64
+		want      []Range
+	}{
+		{
+			name:      "1",
+			size:      100,
+			splitSize: 0,
+			want:      []Range{{}},
+		},
+		{
+			name:      "2",
+			size:      100,
+			splitSize: 100,
+			want:      []Range{{}},
+		},
+		{
+			name:      "3",
+			size:      100,
+			splitSize: 40,
+			want:      []Range{{0, 40}, {40, 80}, {80, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitRanges(tt.size, tt.splitSize); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitRanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeReader(t *testing.T) {
+	dir := testTempDir(t)
+	data := "aaa\nbbbb\ncc\n\nddddd\ne"
+	filename := testWriteFile(t, dir, "a.txt", []byte(data))
+	for splitSize := int64(1); splitSize <= int64(len(data))+1; splitSize++ {
+		var got []string
+		for _, rng := range SplitRanges(int64(len(data)), splitSize) {
+			rr, err := NewRangeReader(Local, filename, rng)
+			if err != nil {
+				t.Fatalf("NewRangeReader(%v) error = %v", rng, err)
+			}
+			b, err := ioutil.ReadAll(rr)
+			rr.Close()
+			if err != nil {
+				t.Fatalf("ReadAll(%v) error = %v", rng, err)
+			}
+			got = append(got, string(b))
+		}
+		if strings.Join(got, "") != data {
+			t.Errorf("splitSize: %v RangeReader = %q, want %q", splitSize, got, data)
+		}
+		for _, v := range got {
+			if v != "" && strings.Index(v, "\n") != len(v)-1 && strings.Count(v, "\n") == 0 && !strings.HasSuffix(data, v) {
+				t.Errorf("splitSize: %v RangeReader part %q is not whole lines", splitSize, v)
+			}
+		}
+	}
+}
+
+func TestNewRangeReader(t *testing.T) {
+	if _, err := NewRangeReader(Local, "not_exist.txt", Range{}); err == nil {
+		t.Errorf("NewRangeReader() error = nil, wantErr true")
+	}
+}
+
+func TestRangeReader_LongLine(t *testing.T) {
+	dir := testTempDir(t)
+	var lines []string
+	for i := 0; i < 5; i++ {
+		lines = append(lines, strings.Repeat(string(rune('a'+i)), 3000*(i+1)))
+	}
+	data := strings.Join(lines, "\n") + "\n"
+	filename := testWriteFile(t, dir, "a.txt", []byte(data))
+	for _, splitSize := range []int64{1, 100, 4096, 5000, 9001} {
+		for _, oneByte := range []bool{false, true} {
+			var got []string
+			for _, rng := range SplitRanges(int64(len(data)), splitSize) {
+				rr, err := NewRangeReader(Local, filename, rng)
+				if err != nil {
+					t.Fatalf("NewRangeReader(%v) error = %v", rng, err)
+				}
+				var r io.Reader = rr
+				if oneByte {
+					r = iotest.OneByteReader(rr)
+				}
+				b, err := ioutil.ReadAll(r)
+				rr.Close()
+				if err != nil {
+					t.Fatalf("ReadAll(%v) error = %v", rng, err)
+				}
+				got = append(got, string(b))
+			}
+			if strings.Join(got, "") != data {
+				t.Errorf("splitSize: %v oneByte: %v RangeReader got wrong data", splitSize, oneByte)
+			}
+			for _, v := range got {
+				if v != "" && !strings.HasSuffix(v, "\n") {
+					t.Errorf("splitSize: %v oneByte: %v RangeReader part is not whole lines", splitSize, oneByte)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package file
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+//defaultS3Region 没有配置区域时使用的默认区域
+const defaultS3Region = "us-east-1"
+
+//S3Config s3兼容对象存储的配置，accessKey为空时从环境变量AWS_ACCESS_KEY_ID，
+//AWS_SECRET_ACCESS_KEY以及共享凭证文件中获取凭证，region为空时从环境变量AWS_REGION中获取
+type S3Config struct {
+	Endpoint     string `json:"endpoint"`     //服务地址，如http://127.0.0.1:9000，为空时使用aws s3
+	Region       string `json:"region"`       //区域，默认为us-east-1
+	AccessKey    string `json:"accessKey"`    //访问密钥ID
+	SecretKey    string `json:"secretKey"`    //访问密钥
+	SessionToken string `json:"sessionToken"` //临时凭证的会话令牌
+	PathStyle    bool   `json:"pathStyle"`    //是否使用路径形式的访问地址，minio等兼容服务一般需要设置为true
+	PartSize     int64  `json:"partSize"`     //分段上传时每段的字节数，默认为5MB
+}
+
+//Validate 校验配置
+func (c *S3Config) Validate() error {
+	if (c.AccessKey == "") != (c.SecretKey == "") {
+		return fmt.Errorf("accessKey and secretKey should be set together")
+	}
+	if c.PartSize != 0 && c.PartSize < s3manager.MinUploadPartSize {
+		return fmt.Errorf("partSize(%v) is less than %v", c.PartSize, s3manager.MinUploadPartSize)
+	}
+	return nil
+}
+
+//s3FileSystem s3兼容对象存储的文件系统，对象存储没有目录，
+//写入时通过分段上传流式写入，不支持追加写入
+type s3FileSystem struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+//NewS3FileSystem 通过配置conf生成s3兼容对象存储的文件系统
+func NewS3FileSystem(conf *S3Config) (FileSystem, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	cfg := aws.NewConfig().WithS3ForcePathStyle(conf.PathStyle)
+	if conf.Endpoint != "" {
+		cfg = cfg.WithEndpoint(conf.Endpoint)
+	}
+	if conf.Region != "" {
+		cfg = cfg.WithRegion(conf.Region)
+	} else if os.Getenv("AWS_REGION") == "" {
+		cfg = cfg.WithRegion(defaultS3Region)
+	}
+	if conf.AccessKey != "" {
+		cfg = cfg.WithCredentials(credentials.NewStaticCredentials(conf.AccessKey, conf.SecretKey, conf.SessionToken))
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("s3 session err: %v", err)
+	}
+	f := &s3FileSystem{
+		client: s3.New(sess),
+	}
+	f.uploader = s3manager.NewUploaderWithClient(f.client, func(u *s3manager.Uploader) {
+		if conf.PartSize > 0 {
+			u.PartSize = conf.PartSize
+		}
+	})
+	return f, nil
+}
+
+//splitS3Path 将路径name拆分成桶和对象键
+func splitS3Path(name string) (bucket, key string, err error) {
+	if !strings.HasPrefix(name, SchemeS3) {
+		return "", "", fmt.Errorf("%v is not a s3 path", name)
+	}
+	s := strings.SplitN(strings.TrimPrefix(name, SchemeS3), "/", 2)
+	if s[0] == "" {
+		return "", "", fmt.Errorf("%v has no bucket", name)
+	}
+	if len(s) == 2 {
+		key = s[1]
+	}
+	return s[0], key, nil
+}
+
+//splitS3Key 将路径name拆分成桶和对象键，对象键为空时会报错
+func splitS3Key(name string) (bucket, key string, err error) {
+	if bucket, key, err = splitS3Path(name); err != nil {
+		return
+	}
+	if key == "" {
+		return "", "", fmt.Errorf("%v has no key", name)
+	}
+	return
+}
+
+//s3Error 将对象不存在的错误转化为满足os.IsNotExist的错误
+func s3Error(op, name string, err error) error {
+	if e, ok := err.(awserr.RequestFailure); ok && e.StatusCode() == http.StatusNotFound {
+		return &os.PathError{Op: op, Path: name, Err: os.ErrNotExist}
+	}
+	return err
+}
+
+func (f *s3FileSystem) Open(name string) (File, error) {
+	fi, err := f.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	bucket, key, _ := splitS3Key(name)
+	return &s3File{
+		client: f.client,
+		name:   name,
+		bucket: bucket,
+		key:    key,
+		size:   fi.Size(),
+	}, nil
+}
+
+func (f *s3FileSystem) Create(name string, appendMode bool) (io.WriteCloser, error) {
+	if appendMode {
+		return nil, fmt.Errorf("s3 does not support append")
+	}
+	bucket, key, err := splitS3Key(name)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	w := &s3Writer{
+		pw:   pw,
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(w.done)
+		_, w.err = f.uploader.Upload(&s3manager.UploadInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Body:   pr,
+		})
+		pr.CloseWithError(w.err)
+	}()
+	return w, nil
+}
+
+func (f *s3FileSystem) Stat(name string) (os.FileInfo, error) {
+	bucket, key, err := splitS3Key(name)
+	if err != nil {
+		return nil, err
+	}
+	out, err := f.client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, s3Error("stat", name, err)
+	}
+	return &s3FileInfo{
+		name:    path.Base(key),
+		size:    aws.Int64Value(out.ContentLength),
+		modTime: aws.TimeValue(out.LastModified),
+	}, nil
+}
+
+//Glob 列出通配符前的前缀下的所有对象，并逐个匹配，通配符的规则与path.Match相同
+func (f *s3FileSystem) Glob(pattern string) (filenames []string, err error) {
+	var bucket, keyPattern string
+	if bucket, keyPattern, err = splitS3Key(pattern); err != nil {
+		return
+	}
+	if _, err = path.Match(keyPattern, ""); err != nil {
+		return
+	}
+
+	prefix := keyPattern
+	if i := strings.IndexAny(keyPattern, `*?[\`); i >= 0 {
+		prefix = keyPattern[:i]
+	}
+	err = f.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}, func(out *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, v := range out.Contents {
+			key := aws.StringValue(v.Key)
+			if strings.HasSuffix(key, "/") {
+				continue
+			}
+			if ok, _ := path.Match(keyPattern, key); ok {
+				filenames = append(filenames, SchemeS3+bucket+"/"+key)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list bucket(%v) err: %v", bucket, err)
+	}
+	sort.Strings(filenames)
+	return
+}
+
+func (f *s3FileSystem) Remove(name string) error {
+	bucket, key, err := splitS3Key(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	return s3Error("remove", name, err)
+}
+
+//MkdirAll 对象存储没有目录，只检查路径中的桶
+func (f *s3FileSystem) MkdirAll(dir string) error {
+	_, _, err := splitS3Path(dir)
+	return err
+}
+
+//s3File 对象存储中的文件，顺序读取时通过一次范围请求读取到文件结尾，
+//随机读取时每次通过一次范围请求读取
+type s3File struct {
+	client *s3.S3
+	name   string
+	bucket string
+	key    string
+	size   int64
+
+	offset int64
+	body   io.ReadCloser
+}
+
+//get 获取从off开始长度为n的数据，n小于等于0时读取到文件结尾
+func (f *s3File) get(off, n int64) (io.ReadCloser, error) {
+	rng := fmt.Sprintf("bytes=%v-", off)
+	if n > 0 {
+		rng += fmt.Sprint(off + n - 1)
+	}
+	out, err := f.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(f.bucket),
+		Key:    aws.String(f.key),
+		Range:  aws.String(rng),
+	})
+	if err != nil {
+		return nil, s3Error("read", f.name, err)
+	}
+	return out.Body, nil
+}
+
+func (f *s3File) Read(p []byte) (n int, err error) {
+	if f.offset >= f.size {
+		return 0, io.EOF
+	}
+	if f.body == nil {
+		if f.body, err = f.get(f.offset, 0); err != nil {
+			return
+		}
+	}
+	n, err = f.body.Read(p)
+	f.offset += int64(n)
+	if err == io.EOF && f.offset < f.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return
+}
+
+func (f *s3File) ReadAt(p []byte, off int64) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if off >= f.size {
+		return 0, io.EOF
+	}
+	want := int64(len(p))
+	if off+want > f.size {
+		want = f.size - off
+	}
+	var body io.ReadCloser
+	if body, err = f.get(off, want); err != nil {
+		return
+	}
+	defer body.Close()
+	if n, err = io.ReadFull(body, p[:want]); err != nil {
+		return
+	}
+	if n < len(p) {
+		err = io.EOF
+	}
+	return
+}
+
+func (f *s3File) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	default:
+		return 0, fmt.Errorf("seek whence(%v) is invalid", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("seek offset(%v) is negative", offset)
+	}
+	if offset != f.offset && f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *s3File) Close() (err error) {
+	if f.body != nil {
+		err = f.body.Close()
+		f.body = nil
+	}
+	return
+}
+
+//s3Writer 流式写入对象，写入的数据通过管道交给分段上传，关闭时等待上传完成
+type s3Writer struct {
+	pw   *io.PipeWriter
+	done chan struct{}
+	err  error
+}
+
+func (w *s3Writer) Write(p []byte) (int, error) {
+	return w.pw.Write(p)
+}
+
+func (w *s3Writer) Close() error {
+	w.pw.Close()
+	<-w.done
+	return w.err
+}
+
+//Abort 通过错误err中断管道，分段上传读取失败后会放弃上传，不会生成不完整的对象
+func (w *s3Writer) Abort(err error) error {
+	w.pw.CloseWithError(err)
+	<-w.done
+	return nil
+}
+
+type s3FileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (fi *s3FileInfo) Name() string       { return fi.name }
+func (fi *s3FileInfo) Size() int64        { return fi.size }
+func (fi *s3FileInfo) Mode() os.FileMode  { return 0644 }
+func (fi *s3FileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *s3FileInfo) IsDir() bool        { return false }
+func (fi *s3FileInfo) Sys() interface{}   { return nil }
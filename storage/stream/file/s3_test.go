@@ -0,0 +1,352 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestS3Config_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *S3Config
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: &S3Config{},
+		},
+		{
+			name: "2",
+			conf: &S3Config{AccessKey: "ak", SecretKey: "sk", PartSize: 5 * 1024 * 1024},
+		},
+		{
+			name:    "3",
+			conf:    &S3Config{AccessKey: "ak"},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    &S3Config{PartSize: 1024},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.conf.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("S3Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, err := NewS3FileSystem(tt.conf); (err != nil) != tt.wantErr {
+				t.Errorf("NewS3FileSystem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitS3Path(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantBucket string
+		wantKey    string
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			path:       "s3://bucket/a/b.csv",
+			wantBucket: "bucket",
+			wantKey:    "a/b.csv",
+		},
+		{
+			name:       "2",
+			path:       "s3://bucket",
+			wantBucket: "bucket",
+		},
+		{
+			name:    "3",
+			path:    "s3:///a.csv",
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			path:    "/data/a.csv",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket, key, err := splitS3Path(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitS3Path() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if bucket != tt.wantBucket || key != tt.wantKey {
+				t.Errorf("splitS3Path() = %v %v, want %v %v", bucket, key, tt.wantBucket, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestS3FileSystem_Glob(t *testing.T) {
+	ts := newTestS3Server(t)
+	for _, k := range []string{"b/data/a.csv", "b/data/b.csv", "b/data/c.txt", "b/data/d/e.csv", "b/data/f/", "b/other.csv", "c/data/a.csv"} {
+		ts.objects[k] = []byte("x")
+	}
+	fs := ts.FileSystem(t)
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			pattern: "s3://b/data/*.csv",
+			want:    []string{"s3://b/data/a.csv", "s3://b/data/b.csv"},
+		},
+		{
+			name:    "2",
+			pattern: "s3://b/data/*/*",
+			want:    []string{"s3://b/data/d/e.csv"},
+		},
+		{
+			name:    "3",
+			pattern: "s3://b/other.csv",
+			want:    []string{"s3://b/other.csv"},
+		},
+		{
+			name:    "4",
+			pattern: "s3://b/none/*",
+		},
+		{
+			name:    "5",
+			pattern: "s3://b/[",
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			pattern: "s3://b",
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			pattern: "s3://deny/*",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fs.Glob(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("s3FileSystem.Glob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("s3FileSystem.Glob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestS3FileSystem_Open(t *testing.T) {
+	ts := newTestS3Server(t)
+	ts.objects["b/a.txt"] = []byte("0123456789")
+	fs := ts.FileSystem(t)
+
+	f, err := fs.Open("s3://b/a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	p := make([]byte, 4)
+	if n, err := f.Read(p); err != nil || string(p[:n]) != "0123" {
+		t.Errorf("s3File.Read() = %q, %v, want 0123", p[:n], err)
+	}
+	if n, err := f.ReadAt(p, 8); err != io.EOF || string(p[:n]) != "89" {
+		t.Errorf("s3File.ReadAt() = %q, %v, want 89 and EOF", p[:n], err)
+	}
+	if n, err := f.ReadAt(p, 10); err != io.EOF || n != 0 {
+		t.Errorf("s3File.ReadAt() = %v, %v, want 0 and EOF", n, err)
+	}
+	if off, err := f.Seek(-3, io.SeekEnd); err != nil || off != 7 {
+		t.Errorf("s3File.Seek() = %v, %v, want 7", off, err)
+	}
+	if got, err := ioutil.ReadAll(f); err != nil || string(got) != "789" {
+		t.Errorf("s3File.Read() = %q, %v, want 789", got, err)
+	}
+	if off, err := f.Seek(-8, io.SeekCurrent); err != nil || off != 2 {
+		t.Errorf("s3File.Seek() = %v, %v, want 2", off, err)
+	}
+	if got, err := ioutil.ReadAll(f); err != nil || string(got) != "23456789" {
+		t.Errorf("s3File.Read() = %q, %v, want 23456789", got, err)
+	}
+	if _, err := f.Seek(-1, io.SeekStart); err == nil {
+		t.Errorf("s3File.Seek() error = nil, wantErr true")
+	}
+	if _, err := f.Seek(0, 3); err == nil {
+		t.Errorf("s3File.Seek() error = nil, wantErr true")
+	}
+
+	if _, err := fs.Open("s3://b/none.txt"); !os.IsNotExist(err) {
+		t.Errorf("s3FileSystem.Open() error = %v, want not exist", err)
+	}
+	if _, err := fs.Open("s3://deny/a.txt"); err == nil || os.IsNotExist(err) {
+		t.Errorf("s3FileSystem.Open() error = %v, want access denied", err)
+	}
+}
+
+func TestS3FileSystem_Create(t *testing.T) {
+	ts := newTestS3Server(t)
+	fs := ts.FileSystem(t)
+
+	large := bytes.Repeat([]byte("0123456789"), 600*1024)
+	tests := []struct {
+		name      string
+		filename  string
+		data      []byte
+		wantParts int
+	}{
+		{
+			name:     "1",
+			filename: "s3://b/a.txt",
+			data:     []byte("hello"),
+		},
+		{
+			name:      "2",
+			filename:  "s3://b/large.txt",
+			data:      large,
+			wantParts: 2,
+		},
+		{
+			name:     "3",
+			filename: "s3://b/empty.txt",
+			data:     []byte{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts.parts = 0
+			w, err := fs.Create(tt.filename, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for p := tt.data; len(p) > 0; p = p[len(p)/2+1:] {
+				if _, err = w.Write(p[:len(p)/2+1]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err = w.Close(); err != nil {
+				t.Fatalf("s3Writer.Close() error = %v", err)
+			}
+			if !bytes.Equal(ts.objects[tt.filename[len(SchemeS3):]], tt.data) {
+				t.Errorf("s3FileSystem.Create() data length = %v, want %v", len(ts.objects[tt.filename[len(SchemeS3):]]), len(tt.data))
+			}
+			if ts.parts != tt.wantParts {
+				t.Errorf("s3FileSystem.Create() parts = %v, want %v", ts.parts, tt.wantParts)
+			}
+			fi, err := fs.Stat(tt.filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fi.Size() != int64(len(tt.data)) || fi.IsDir() {
+				t.Errorf("s3FileSystem.Stat() size = %v, want %v", fi.Size(), len(tt.data))
+			}
+		})
+	}
+
+	if _, err := fs.Create("s3://b/a.txt", true); err == nil {
+		t.Errorf("s3FileSystem.Create() error = nil, wantErr true")
+	}
+	if _, err := fs.Create("s3://b", false); err == nil {
+		t.Errorf("s3FileSystem.Create() error = nil, wantErr true")
+	}
+	w, err := fs.Create("s3://deny/a.txt", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("hello"))
+	if err = w.Close(); err == nil {
+		t.Errorf("s3Writer.Close() error = nil, wantErr true")
+	}
+}
+
+func TestS3Writer_Abort(t *testing.T) {
+	ts := newTestS3Server(t)
+	fs := ts.FileSystem(t)
+
+	tests := []struct {
+		name     string
+		filename string
+		c        Compress
+		data     []byte
+	}{
+		{
+			name:     "1",
+			filename: "s3://b/a.txt",
+			c:        CompressNone,
+			data:     []byte("hello"),
+		},
+		{
+			name:     "2",
+			filename: "s3://b/b.txt",
+			c:        CompressNone,
+			data:     bytes.Repeat([]byte("0123456789"), 600*1024),
+		},
+		{
+			name:     "3",
+			filename: "s3://b/c.txt.gz",
+			c:        CompressGzip,
+			data:     []byte("hello"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := Create(fs, tt.filename, tt.c, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = w.Write(tt.data); err != nil {
+				t.Fatal(err)
+			}
+			a, ok := w.(Aborter)
+			if !ok {
+				t.Fatalf("Create() = %T, want Aborter", w)
+			}
+			if err = a.Abort(errors.New("mock error")); err != nil {
+				t.Fatalf("Aborter.Abort() error = %v", err)
+			}
+			if _, err = fs.Stat(tt.filename); !os.IsNotExist(err) {
+				t.Errorf("s3FileSystem.Stat() error = %v, want not exist", err)
+			}
+			if len(ts.uploads) != 0 {
+				t.Errorf("s3FileSystem.Create() uploads = %v, want 0", len(ts.uploads))
+			}
+		})
+	}
+}
+
+func TestS3FileSystem_Remove(t *testing.T) {
+	ts := newTestS3Server(t)
+	ts.objects["b/a.txt"] = []byte("x")
+	fs := ts.FileSystem(t)
+
+	if err := fs.Remove("s3://b/a.txt"); err != nil {
+		t.Errorf("s3FileSystem.Remove() error = %v", err)
+	}
+	if _, err := fs.Stat("s3://b/a.txt"); !os.IsNotExist(err) {
+		t.Errorf("s3FileSystem.Stat() error = %v, want not exist", err)
+	}
+	if err := fs.Remove("s3://b"); err == nil {
+		t.Errorf("s3FileSystem.Remove() error = nil, wantErr true")
+	}
+	if err := fs.MkdirAll("s3://b/dir"); err != nil {
+		t.Errorf("s3FileSystem.MkdirAll() error = %v", err)
+	}
+	if err := fs.MkdirAll("/dir"); err == nil {
+		t.Errorf("s3FileSystem.MkdirAll() error = nil, wantErr true")
+	}
+}
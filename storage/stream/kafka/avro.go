@@ -0,0 +1,40 @@
+package kafka
+
+import (
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file/avro"
+)
+
+//avroCodec 不含模式的avro二进制编解码器，所有列都是与null的联合
+type avroCodec struct {
+	columns []Column
+	datum   *avro.DatumCodec
+}
+
+func newAvroCodec(columns []Column, opts Options) (c *avroCodec, err error) {
+	c = &avroCodec{
+		columns: columns,
+	}
+	avroColumns := make([]avro.Column, len(columns))
+	for i := range columns {
+		avroColumns[i] = columns[i].avroColumn()
+	}
+	if c.datum, err = avro.NewDatumCodec(opts.Name, avroColumns); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//Encode 将记录record中按列名选择的列编码成avro二进制
+func (c *avroCodec) Encode(record element.Record) (value []byte, err error) {
+	var cols []element.Column
+	if cols, err = selectColumns(c.columns, record); err != nil {
+		return
+	}
+	return c.datum.Encode(cols)
+}
+
+//Decode 将avro二进制value解码为列
+func (c *avroCodec) Decode(value []byte) ([]element.Column, error) {
+	return c.datum.Decode(value)
+}
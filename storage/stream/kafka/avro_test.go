@@ -0,0 +1,44 @@
+package kafka
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestAvroCodec(t *testing.T) {
+	columns := []Column{
+		{Name: "bool", Type: element.TypeBool},
+		{Name: "id", Type: element.TypeDecimal, Precision: 30, Scale: 0},
+		{Name: "time", Type: element.TypeTime},
+		{Name: "nil"},
+	}
+	c, err := newAvroCodec(columns, Options{Name: "User"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := c.Encode(testRecord(testColumns()...))
+	if err != nil {
+		t.Fatalf("avroCodec.Encode() error = %v", err)
+	}
+	got, err := c.Decode(value)
+	if err != nil {
+		t.Fatalf("avroCodec.Decode() error = %v", err)
+	}
+	want := []string{"bool:bool:true", "id:decimal:123456789012345678901234567890",
+		"time:time:2021-01-02T03:04:05Z", "nil:string:<nil>"}
+	if !reflect.DeepEqual(testStrings(got), want) {
+		t.Errorf("avroCodec.Decode() = %v, want %v", testStrings(got), want)
+	}
+
+	if _, err = c.Encode(testRecord(testColumns()[:1]...)); err == nil {
+		t.Errorf("avroCodec.Encode() error = nil, wantErr true")
+	}
+	if _, err = c.Decode(value[:1]); err == nil {
+		t.Errorf("avroCodec.Decode() error = nil, wantErr true")
+	}
+	if _, err = newAvroCodec([]Column{{Name: "a", LogicalType: "date"}}, Options{}); err == nil {
+		t.Errorf("newAvroCodec() error = nil, wantErr true")
+	}
+}
@@ -0,0 +1,134 @@
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file/avro"
+)
+
+//Format 消息值的格式
+type Format string
+
+//消息值的格式枚举
+const (
+	FormatJSON Format = "json" //JSON对象，默认的格式
+	FormatCSV  Format = "csv"  //CSV行
+	FormatAvro Format = "avro" //不含模式的avro二进制
+)
+
+//IsValid 是否为支持的格式
+func (f Format) IsValid() bool {
+	switch f {
+	case "", FormatJSON, FormatCSV, FormatAvro:
+		return true
+	}
+	return false
+}
+
+//Column 列定义
+type Column struct {
+	Name        string             `json:"name"`        //列名，JSON格式中为对象中的路径，如a.b
+	Type        element.ColumnType `json:"type"`        //列类型，默认为字符串
+	Format      string             `json:"format"`      //JSON和CSV格式的时间格式，使用go的时间格式，默认为time.RFC3339Nano
+	LogicalType string             `json:"logicalType"` //avro格式的逻辑类型
+	Precision   int                `json:"precision"`   //avro格式高精度实数的精度
+	Scale       int                `json:"scale"`       //avro格式高精度实数的标度
+}
+
+//columnType 获取列类型，默认为字符串
+func (c *Column) columnType() element.ColumnType {
+	if c.Type == "" {
+		return element.TypeString
+	}
+	return c.Type
+}
+
+//layout 获取时间格式，默认为time.RFC3339Nano
+func (c *Column) layout() string {
+	if c.Format == "" {
+		return time.RFC3339Nano
+	}
+	return c.Format
+}
+
+//avroColumn 转化为avro的列定义
+func (c *Column) avroColumn() avro.Column {
+	return avro.Column{
+		Name:        c.Name,
+		Type:        c.columnType(),
+		LogicalType: c.LogicalType,
+		Precision:   c.Precision,
+		Scale:       c.Scale,
+	}
+}
+
+//Options 编解码选项
+type Options struct {
+	Delimiter  rune   //CSV格式的字段分隔符，默认为逗号
+	NullFormat string //CSV格式中空值的文本，默认为空字符串
+	Name       string //avro格式的记录名，默认为Record
+}
+
+//Codec 消息值编解码器
+type Codec interface {
+	Encode(record element.Record) ([]byte, error)  //将记录编码为消息值
+	Decode(value []byte) ([]element.Column, error) //将消息值解码为列
+}
+
+//NewCodec 生成格式为format，列定义为columns的编解码器，format为空时为JSON格式，
+//编码时按列名从记录中选择列，columns为空时编码记录中的所有列，
+//解码时按照列定义生成列，因此解码和avro格式必须设置列定义
+func NewCodec(format Format, columns []Column, opts Options) (Codec, error) {
+	for i, v := range columns {
+		if v.Name == "" {
+			return nil, fmt.Errorf("column(%v) name is empty", i)
+		}
+		switch v.columnType() {
+		case element.TypeBool, element.TypeBigInt, element.TypeDecimal,
+			element.TypeString, element.TypeBytes, element.TypeTime:
+		default:
+			return nil, fmt.Errorf("column(%v) type(%v) is not supported", v.Name, v.Type)
+		}
+	}
+
+	switch format {
+	case "", FormatJSON:
+		return newJSONCodec(columns), nil
+	case FormatCSV:
+		return newCSVCodec(columns, opts), nil
+	case FormatAvro:
+		return newAvroCodec(columns, opts)
+	}
+	return nil, fmt.Errorf("format(%v) is not supported", format)
+}
+
+//selectColumns 按照列定义columns的列名从记录record中选择列，columns为空时选择所有列
+func selectColumns(columns []Column, record element.Record) (cols []element.Column, err error) {
+	if len(columns) == 0 {
+		cols = make([]element.Column, record.ColumnNumber())
+		for i := range cols {
+			if cols[i], err = record.GetByIndex(i); err != nil {
+				return nil, err
+			}
+		}
+		return
+	}
+
+	cols = make([]element.Column, len(columns))
+	for i := range columns {
+		if cols[i], err = record.GetByName(columns[i].Name); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+//timeLayout 获取列定义columns中第i列的时间格式，columns为空时为time.RFC3339Nano
+func timeLayout(columns []Column, i int) string {
+	if len(columns) == 0 {
+		return time.RFC3339Nano
+	}
+	return columns[i].layout()
+}
@@ -0,0 +1,94 @@
+package kafka
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestNewCodec(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  Format
+		columns []Column
+		want    Codec
+		wantErr bool
+	}{
+		{
+			name: "1",
+			want: &jsonCodec{},
+		},
+		{
+			name:   "2",
+			format: FormatCSV,
+			want:   &csvCodec{},
+		},
+		{
+			name:    "3",
+			format:  FormatAvro,
+			columns: []Column{{Name: "a"}},
+			want:    &avroCodec{},
+		},
+		{
+			name:    "4",
+			format:  FormatAvro,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			format:  "xml",
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			columns: []Column{{Type: element.TypeString}},
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			columns: []Column{{Name: "a", Type: "none"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCodec(tt.format, tt.columns, Options{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewCodec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && reflect.TypeOf(got) != reflect.TypeOf(tt.want) {
+				t.Errorf("NewCodec() = %T, want %T", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat_IsValid(t *testing.T) {
+	for _, f := range []Format{"", FormatJSON, FormatCSV, FormatAvro} {
+		if !f.IsValid() {
+			t.Errorf("Format(%v).IsValid() = false, want true", f)
+		}
+	}
+	if Format("xml").IsValid() {
+		t.Errorf("Format(xml).IsValid() = true, want false")
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	record := testRecord(testColumns()...)
+	cols, err := selectColumns(nil, record)
+	if err != nil || len(cols) != record.ColumnNumber() {
+		t.Errorf("selectColumns() = %v, %v", len(cols), err)
+	}
+	cols, err = selectColumns([]Column{{Name: "bool"}, {Name: "id"}}, record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := testStrings(cols); !reflect.DeepEqual(got, []string{"bool:bool:true", "id:bigInt:123456789012345678901234567890"}) {
+		t.Errorf("selectColumns() = %v", got)
+	}
+	if _, err = selectColumns([]Column{{Name: "none"}}, record); err == nil {
+		t.Errorf("selectColumns() error = nil, wantErr true")
+	}
+}
@@ -0,0 +1,78 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+)
+
+//DefaultVersion 默认的kafka版本
+const DefaultVersion = "1.0.0"
+
+//Config kafka连接配置
+type Config struct {
+	Brokers  []string   `json:"brokers"`  //broker地址，如127.0.0.1:9092
+	Version  string     `json:"version"`  //kafka版本，如2.0.0，默认为1.0.0，按时间查询偏移量需要0.10.1.0及以上
+	ClientID string     `json:"clientID"` //客户端ID，默认为go-etl
+	SASL     SASLConfig `json:"sasl"`     //SASL认证配置
+	TLS      bool       `json:"tls"`      //是否使用TLS连接
+}
+
+//SASLConfig SASL认证配置，目前只支持PLAIN
+type SASLConfig struct {
+	User     string `json:"user"`     //用户名，为空时不认证
+	Password string `json:"password"` //密码
+}
+
+//Validate 校验连接配置
+func (c *Config) Validate() (err error) {
+	if len(c.Brokers) == 0 {
+		return fmt.Errorf("brokers are empty")
+	}
+	for i, v := range c.Brokers {
+		if v == "" {
+			return fmt.Errorf("broker(%v) is empty", i)
+		}
+	}
+	if _, err = c.version(); err != nil {
+		return
+	}
+	return
+}
+
+//SaramaConfig 生成sarama的配置
+func (c *Config) SaramaConfig() (conf *sarama.Config, err error) {
+	conf = sarama.NewConfig()
+	if conf.Version, err = c.version(); err != nil {
+		return nil, err
+	}
+	conf.ClientID = "go-etl"
+	if c.ClientID != "" {
+		conf.ClientID = c.ClientID
+	}
+	if c.SASL.User != "" {
+		conf.Net.SASL.Enable = true
+		conf.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		conf.Net.SASL.User = c.SASL.User
+		conf.Net.SASL.Password = c.SASL.Password
+	}
+	if c.TLS {
+		conf.Net.TLS.Enable = true
+		conf.Net.TLS.Config = &tls.Config{}
+	}
+	return
+}
+
+//version 解析kafka版本，默认为1.0.0
+func (c *Config) version() (sarama.KafkaVersion, error) {
+	v := c.Version
+	if v == "" {
+		v = DefaultVersion
+	}
+	version, err := sarama.ParseKafkaVersion(v)
+	if err != nil {
+		return version, fmt.Errorf("version(%v) err: %v", c.Version, err)
+	}
+	return version, nil
+}
@@ -0,0 +1,75 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *Config
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    &Config{Brokers: []string{"127.0.0.1:9092"}, Version: "2.0.0"},
+		},
+		{
+			name:    "2",
+			c:       &Config{},
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			c:       &Config{Brokers: []string{""}},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			c:       &Config{Brokers: []string{"127.0.0.1:9092"}, Version: "x"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_SaramaConfig(t *testing.T) {
+	c := &Config{Brokers: []string{"127.0.0.1:9092"}}
+	conf, err := c.SaramaConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.Version != sarama.V1_0_0_0 || conf.ClientID != "go-etl" || conf.Net.SASL.Enable || conf.Net.TLS.Enable {
+		t.Errorf("Config.SaramaConfig() = %v %v %v %v", conf.Version, conf.ClientID, conf.Net.SASL.Enable, conf.Net.TLS.Enable)
+	}
+
+	c = &Config{
+		Brokers:  []string{"127.0.0.1:9092"},
+		Version:  "2.1.0",
+		ClientID: "etl",
+		SASL:     SASLConfig{User: "u", Password: "p"},
+		TLS:      true,
+	}
+	if conf, err = c.SaramaConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if conf.Version != sarama.V2_1_0_0 || conf.ClientID != "etl" || !conf.Net.TLS.Enable ||
+		!conf.Net.SASL.Enable || conf.Net.SASL.User != "u" || conf.Net.SASL.Password != "p" {
+		t.Errorf("Config.SaramaConfig() = %v %v %v %v", conf.Version, conf.ClientID, conf.Net.SASL, conf.Net.TLS.Enable)
+	}
+	if err = conf.Validate(); err != nil {
+		t.Errorf("Config.SaramaConfig().Validate() error = %v", err)
+	}
+
+	if _, err = (&Config{Version: "x"}).SaramaConfig(); err == nil {
+		t.Errorf("Config.SaramaConfig() error = nil, wantErr true")
+	}
+}
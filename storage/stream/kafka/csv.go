@@ -0,0 +1,137 @@
+package kafka
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file/delimited"
+)
+
+//csvCodec CSV行编解码器，字段按照顺序与列对应，消息值末尾没有换行符
+type csvCodec struct {
+	columns    []Column
+	opts       delimited.Options
+	nullFormat string
+	buf        *bytes.Buffer
+	w          *delimited.Writer
+}
+
+func newCSVCodec(columns []Column, opts Options) *csvCodec {
+	c := &csvCodec{
+		columns: columns,
+		opts: delimited.Options{
+			Delimiter: opts.Delimiter,
+			Quote:     '"',
+		},
+		nullFormat: opts.NullFormat,
+		buf:        &bytes.Buffer{},
+	}
+	c.w = delimited.NewWriter(c.buf, c.opts)
+	return c
+}
+
+//Encode 将记录record编码成一行CSV，空值转化为nullFormat，时间按照列定义中的格式格式化
+func (c *csvCodec) Encode(record element.Record) (value []byte, err error) {
+	var cols []element.Column
+	if cols, err = selectColumns(c.columns, record); err != nil {
+		return
+	}
+
+	fields := make([]string, len(cols))
+	for i, col := range cols {
+		if fields[i], err = c.toString(col, timeLayout(c.columns, i)); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", col.Name(), err)
+		}
+	}
+	c.buf.Reset()
+	if _, err = c.w.Write(fields); err != nil {
+		return
+	}
+	return append([]byte(nil), bytes.TrimSuffix(c.buf.Bytes(), []byte("\n"))...), nil
+}
+
+//toString 将列col转化为字符串，空值转化为nullFormat，时间按照layout格式化
+func (c *csvCodec) toString(col element.Column, layout string) (s string, err error) {
+	if col.IsNil() {
+		return c.nullFormat, nil
+	}
+
+	switch col.Type() {
+	case element.TypeTime:
+		var tm time.Time
+		if tm, err = col.AsTime(); err != nil {
+			return
+		}
+		return tm.Format(layout), nil
+	case element.TypeBytes:
+		var b []byte
+		if b, err = col.AsBytes(); err != nil {
+			return
+		}
+		return string(b), nil
+	}
+	return col.AsString()
+}
+
+//Decode 将一行CSV value按照列定义解码为列，缺少的字段解码为空值
+func (c *csvCodec) Decode(value []byte) (columns []element.Column, err error) {
+	var fields []string
+	if fields, err = delimited.NewReader(bytes.NewReader(value), c.opts).Read(); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(fields) > len(c.columns) {
+		return nil, fmt.Errorf("%v fields, want at most %v", len(fields), len(c.columns))
+	}
+
+	columns = make([]element.Column, len(c.columns))
+	for i := range c.columns {
+		var s string
+		isNull := true
+		if i < len(fields) {
+			s = fields[i]
+			isNull = c.nullFormat != "" && s == c.nullFormat
+		}
+		var cv element.ColumnValue
+		if cv, err = csvColumnValue(&c.columns[i], s, isNull); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", c.columns[i].Name, err)
+		}
+		columns[i] = element.NewDefaultColumn(cv, c.columns[i].Name, len(s))
+	}
+	return columns, nil
+}
+
+//csvColumnValue 将字符串s按照列定义c转化为列值，isNull为true时生成对应类型的空值，
+//非字符串类型的空字符串也会转化为空值
+func csvColumnValue(c *Column, s string, isNull bool) (element.ColumnValue, error) {
+	typ := c.columnType()
+	if isNull || (s == "" && typ != element.TypeString) {
+		return element.NewNilColumnValue(typ), nil
+	}
+
+	switch typ {
+	case element.TypeBool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		return element.NewBoolColumnValue(v), nil
+	case element.TypeBigInt:
+		return element.NewBigIntColumnValueFromString(s)
+	case element.TypeDecimal:
+		return element.NewDecimalColumnValueFromString(s)
+	case element.TypeBytes:
+		return element.NewBytesColumnValue([]byte(s)), nil
+	case element.TypeTime:
+		layout := c.layout()
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return nil, err
+		}
+		return element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(layout)), nil
+	}
+	return element.NewStringColumnValue(s), nil
+}
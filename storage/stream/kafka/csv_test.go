@@ -0,0 +1,135 @@
+package kafka
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestCSVCodec_Encode(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		opts    Options
+		record  element.Record
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "1",
+			record: testRecord(testColumns()...),
+			want:   `123456789012345678901234567890,"a""<b>,c",,2021-01-02T03:04:05Z,true,xyz,0.10000000000000000001`,
+		},
+		{
+			name:    "2",
+			columns: []Column{{Name: "time", Format: "2006-01-02"}, {Name: "nil"}},
+			opts:    Options{Delimiter: '|', NullFormat: `\N`},
+			record:  testRecord(testColumns()...),
+			want:    `2021-01-02|\N`,
+		},
+		{
+			name:    "3",
+			columns: []Column{{Name: "none"}},
+			record:  testRecord(testColumns()...),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			record:  testBadRecord(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newCSVCodec(tt.columns, tt.opts).Encode(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("csvCodec.Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("csvCodec.Encode() = %s, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVCodec_Decode(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: element.TypeBigInt},
+		{Name: "name"},
+		{Name: "ok", Type: element.TypeBool},
+		{Name: "price", Type: element.TypeDecimal},
+		{Name: "at", Type: element.TypeTime, Format: "2006-01-02"},
+		{Name: "raw", Type: element.TypeBytes},
+	}
+	tests := []struct {
+		name    string
+		columns []Column
+		opts    Options
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			columns: columns,
+			value:   `1,"a,""b""",true,1.50,2021-01-02,xyz`,
+			want: []string{"id:bigInt:1", `name:string:a,"b"`, "ok:bool:true",
+				"price:decimal:1.5", "at:time:2021-01-02T00:00:00Z", "raw:bytes:xyz"},
+		},
+		{
+			name:    "2",
+			columns: columns,
+			opts:    Options{Delimiter: '|', NullFormat: `\N`},
+			value:   `|\N|`,
+			want: []string{"id:bigInt:<nil>", "name:string:<nil>", "ok:bool:<nil>",
+				"price:decimal:<nil>", "at:time:<nil>", "raw:bytes:<nil>"},
+		},
+		{
+			name:    "3",
+			columns: columns[:2],
+			want:    []string{"id:bigInt:<nil>", "name:string:<nil>"},
+		},
+		{
+			name:    "4",
+			columns: columns[:1],
+			value:   "1,2",
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			columns: columns[:1],
+			value:   "x",
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			columns: columns[:1],
+			value:   `"1`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			columns: columns[2:3],
+			value:   "x",
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			columns: columns[4:5],
+			value:   "x",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newCSVCodec(tt.columns, tt.opts).Decode([]byte(tt.value))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("csvCodec.Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(testStrings(got), tt.want) {
+				t.Errorf("csvCodec.Decode() = %v, want %v", testStrings(got), tt.want)
+			}
+		})
+	}
+}
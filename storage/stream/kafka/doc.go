@@ -0,0 +1,14 @@
+// Package kafka 对kafka的连接配置和消息值的编解码进行封装，
+// 消息值支持JSON对象，CSV行和avro二进制三种格式，每条消息对应一条记录，例如
+//
+//	codec, err := kafka.NewCodec(kafka.FormatJSON, []kafka.Column{
+//		{Name: "id", Type: element.TypeBigInt},
+//		{Name: "user.name"},
+//	}, kafka.Options{})
+//	if err != nil {
+//		fmt.Println(err)
+//		return
+//	}
+//	columns, err := codec.Decode([]byte(`{"id":1,"user":{"name":"a"}}`))
+//	fmt.Println(columns, err)
+package kafka
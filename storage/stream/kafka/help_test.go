@@ -0,0 +1,56 @@
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+//testRecord 生成列为columns的记录
+func testRecord(columns ...element.Column) element.Record {
+	r := element.NewDefaultRecord()
+	for _, c := range columns {
+		r.Add(c)
+	}
+	return r
+}
+
+//testColumns 生成包含所有类型的列
+func testColumns() []element.Column {
+	i, _ := element.NewBigIntColumnValueFromString("123456789012345678901234567890")
+	d, _ := element.NewDecimalColumnValueFromString("0.10000000000000000001")
+	return []element.Column{
+		element.NewDefaultColumn(i, "id", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue(`a"<b>,c`), "name", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)), "time", 0),
+		element.NewDefaultColumn(element.NewBoolColumnValue(true), "bool", 0),
+		element.NewDefaultColumn(element.NewBytesColumnValue([]byte("xyz")), "bytes", 0),
+		element.NewDefaultColumn(d, "decimal", 0),
+	}
+}
+
+//testStrings 将列columns转化为name:type:value的形式
+func testStrings(columns []element.Column) (s []string) {
+	for _, c := range columns {
+		s = append(s, fmt.Sprintf("%v:%v:%v", c.Name(), c.Type(), c.String()))
+	}
+	return
+}
+
+//mockTimeValue 类型为时间但是无法转化为时间的列值
+type mockTimeValue struct {
+	element.ColumnValue
+}
+
+func (m *mockTimeValue) Type() element.ColumnType {
+	return element.TypeTime
+}
+
+//testBadRecord 生成时间列无法转化为时间的记录
+func testBadRecord() element.Record {
+	return testRecord(element.NewDefaultColumn(&mockTimeValue{
+		ColumnValue: element.NewStringColumnValue("abc"),
+	}, "time", 0))
+}
@@ -0,0 +1,194 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/tidwall/gjson"
+)
+
+//jsonCodec JSON对象编解码器，对象的键为列名
+type jsonCodec struct {
+	columns []Column
+	buf     *bytes.Buffer
+	enc     *json.Encoder
+}
+
+func newJSONCodec(columns []Column) *jsonCodec {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	return &jsonCodec{
+		columns: columns,
+		buf:     buf,
+		enc:     enc,
+	}
+}
+
+//Encode 将记录record编码成以列名为键的JSON对象，
+//整数和高精度实数按原样写入JSON数字，不会丢失精度
+func (j *jsonCodec) Encode(record element.Record) (value []byte, err error) {
+	var cols []element.Column
+	if cols, err = selectColumns(j.columns, record); err != nil {
+		return
+	}
+
+	j.buf.Reset()
+	j.buf.WriteByte('{')
+	for i, c := range cols {
+		if i > 0 {
+			j.buf.WriteByte(',')
+		}
+		if err = j.writeString(c.Name()); err != nil {
+			return
+		}
+		j.buf.WriteByte(':')
+		if err = j.writeValue(c, timeLayout(j.columns, i)); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", c.Name(), err)
+		}
+	}
+	j.buf.WriteByte('}')
+	return append([]byte(nil), j.buf.Bytes()...), nil
+}
+
+//writeValue 将列c的值以JSON格式写入缓存，时间按照layout格式化
+func (j *jsonCodec) writeValue(c element.Column, layout string) (err error) {
+	if c.IsNil() {
+		j.buf.WriteString("null")
+		return
+	}
+
+	switch c.Type() {
+	case element.TypeBool:
+		var b bool
+		if b, err = c.AsBool(); err != nil {
+			return
+		}
+		j.buf.WriteString(strconv.FormatBool(b))
+		return
+	case element.TypeBigInt:
+		var v fmt.Stringer
+		if v, err = c.AsBigInt(); err != nil {
+			return
+		}
+		j.buf.WriteString(v.String())
+		return
+	case element.TypeDecimal:
+		var v fmt.Stringer
+		if v, err = c.AsDecimal(); err != nil {
+			return
+		}
+		j.buf.WriteString(v.String())
+		return
+	case element.TypeTime:
+		var tm time.Time
+		if tm, err = c.AsTime(); err != nil {
+			return
+		}
+		return j.writeString(tm.Format(layout))
+	case element.TypeBytes:
+		var b []byte
+		if b, err = c.AsBytes(); err != nil {
+			return
+		}
+		return j.writeString(string(b))
+	}
+
+	var s string
+	if s, err = c.AsString(); err != nil {
+		return
+	}
+	return j.writeString(s)
+}
+
+//writeString 将字符串s以JSON字符串写入缓存
+func (j *jsonCodec) writeString(s string) (err error) {
+	if err = j.enc.Encode(s); err != nil {
+		return
+	}
+	//json.Encoder会在末尾添加换行符
+	j.buf.Truncate(j.buf.Len() - 1)
+	return
+}
+
+//Decode 将JSON对象value按照列定义解码为列，列名为对象中的路径，
+//空消息值解码为所有列都是空值
+func (j *jsonCodec) Decode(value []byte) (columns []element.Column, err error) {
+	if len(value) > 0 && !gjson.ValidBytes(value) {
+		return nil, fmt.Errorf("value is not valid json")
+	}
+	obj := gjson.ParseBytes(value)
+	columns = make([]element.Column, len(j.columns))
+	for i := range j.columns {
+		v := obj.Get(j.columns[i].Name)
+		var cv element.ColumnValue
+		if cv, err = jsonColumnValue(&j.columns[i], v); err != nil {
+			return nil, fmt.Errorf("column(%v) err: %v", j.columns[i].Name, err)
+		}
+		columns[i] = element.NewDefaultColumn(cv, j.columns[i].Name, len(v.Raw))
+	}
+	return
+}
+
+//jsonColumnValue 将JSON值v按照列定义c转化为列值，不存在的值和null会转化为对应类型的空值，
+//数字按照原始文本转化，整数和高精度实数不会损失精度
+func jsonColumnValue(c *Column, v gjson.Result) (element.ColumnValue, error) {
+	typ := c.columnType()
+	if !v.Exists() || v.Type == gjson.Null {
+		return element.NewNilColumnValue(typ), nil
+	}
+
+	switch typ {
+	case element.TypeBool:
+		switch v.Type {
+		case gjson.True, gjson.False:
+			return element.NewBoolColumnValue(v.Bool()), nil
+		case gjson.String:
+			b, err := strconv.ParseBool(v.Str)
+			if err != nil {
+				return nil, err
+			}
+			return element.NewBoolColumnValue(b), nil
+		}
+	case element.TypeBigInt:
+		switch v.Type {
+		case gjson.Number:
+			return element.NewBigIntColumnValueFromString(v.Raw)
+		case gjson.String:
+			return element.NewBigIntColumnValueFromString(v.Str)
+		}
+	case element.TypeDecimal:
+		switch v.Type {
+		case gjson.Number:
+			return element.NewDecimalColumnValueFromString(v.Raw)
+		case gjson.String:
+			return element.NewDecimalColumnValueFromString(v.Str)
+		}
+	case element.TypeString:
+		return element.NewStringColumnValue(text(v)), nil
+	case element.TypeBytes:
+		return element.NewBytesColumnValue([]byte(text(v))), nil
+	case element.TypeTime:
+		if v.Type == gjson.String {
+			layout := c.layout()
+			t, err := time.Parse(layout, v.Str)
+			if err != nil {
+				return nil, err
+			}
+			return element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(layout)), nil
+		}
+	}
+	return nil, fmt.Errorf("%v can not convert to %v", v.Raw, typ)
+}
+
+//text 获取JSON值v的文本，字符串为其内容，其他为原始的JSON文本
+func text(v gjson.Result) string {
+	if v.Type == gjson.String {
+		return v.Str
+	}
+	return v.Raw
+}
@@ -0,0 +1,126 @@
+package kafka
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestJSONCodec_Encode(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		record  element.Record
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "1",
+			record: testRecord(testColumns()...),
+			want: `{"id":123456789012345678901234567890,"name":"a\"<b>,c","nil":null,"time":"2021-01-02T03:04:05Z",` +
+				`"bool":true,"bytes":"xyz","decimal":0.10000000000000000001}`,
+		},
+		{
+			name:    "2",
+			columns: []Column{{Name: "time", Format: "2006-01-02"}, {Name: "bool"}},
+			record:  testRecord(testColumns()...),
+			want:    `{"time":"2021-01-02","bool":true}`,
+		},
+		{
+			name:    "3",
+			columns: []Column{{Name: "none"}},
+			record:  testRecord(testColumns()...),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			record:  testBadRecord(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newJSONCodec(tt.columns).Encode(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("jsonCodec.Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("jsonCodec.Encode() = %s, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONCodec_Decode(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: element.TypeBigInt},
+		{Name: "user.name"},
+		{Name: "ok", Type: element.TypeBool},
+		{Name: "price", Type: element.TypeDecimal},
+		{Name: "at", Type: element.TypeTime, Format: "2006-01-02"},
+		{Name: "raw", Type: element.TypeBytes},
+	}
+	tests := []struct {
+		name    string
+		columns []Column
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			columns: columns,
+			value:   `{"id":12345678901234567890,"user":{"name":"a"},"ok":"true","price":"1.50","at":"2021-01-02","raw":{"x":1}}`,
+			want: []string{"id:bigInt:12345678901234567890", "user.name:string:a", "ok:bool:true",
+				"price:decimal:1.5", "at:time:2021-01-02T00:00:00Z", `raw:bytes:{"x":1}`},
+		},
+		{
+			name:    "2",
+			columns: columns,
+			value:   `{"id":"1","user":null,"ok":false,"price":2}`,
+			want: []string{"id:bigInt:1", "user.name:string:<nil>", "ok:bool:false",
+				"price:decimal:2", "at:time:<nil>", "raw:bytes:<nil>"},
+		},
+		{
+			name:    "3",
+			columns: columns[:1],
+			want:    []string{"id:bigInt:<nil>"},
+		},
+		{
+			name:    "4",
+			columns: columns[:1],
+			value:   `{"id":`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			columns: columns[:1],
+			value:   `{"id":true}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			columns: columns[2:3],
+			value:   `{"ok":"x"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			columns: columns[4:5],
+			value:   `{"at":"x"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newJSONCodec(tt.columns).Decode([]byte(tt.value))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("jsonCodec.Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(testStrings(got), tt.want) {
+				t.Errorf("jsonCodec.Decode() = %v, want %v", testStrings(got), tt.want)
+			}
+		})
+	}
+}